@@ -0,0 +1,73 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/DIMO-Network/trips-api/internal/config"
+	"github.com/DIMO-Network/trips-api/internal/database"
+	"github.com/DIMO-Network/trips-api/internal/health"
+	"github.com/DIMO-Network/trips-api/internal/kafka"
+	"github.com/DIMO-Network/trips-api/internal/services/bundlr"
+	es_store "github.com/DIMO-Network/trips-api/internal/services/es"
+	pg_store "github.com/DIMO-Network/trips-api/internal/services/pg"
+)
+
+const (
+	defaultHealthCheckTimeout = 5 * time.Second
+	migrationsDir             = "migrations"
+)
+
+// newHealthChecker wires up the dependencies that the monitoring server reports on. Liveness
+// only covers the process itself, so that an outage elsewhere doesn't trigger restarts.
+func newHealthChecker(settings *config.Settings, pgStore *pg_store.Store, esStore *es_store.Client, bundlrClient *bundlr.Client, consumers map[string]*kafka.Consumer) *health.Checker {
+	timeout := defaultHealthCheckTimeout
+	if settings.HealthCheckTimeoutSeconds > 0 {
+		timeout = time.Duration(settings.HealthCheckTimeoutSeconds) * time.Second
+	}
+
+	checker := health.New(timeout)
+
+	for name, c := range consumers {
+		checker.AddLiveness(name+"-consumer", func(context.Context) error {
+			return c.Running()
+		})
+
+		checker.AddReadiness(name+"-consumer-lag", func(context.Context) error {
+			if lag := c.Lag(); settings.MaxConsumerLag > 0 && lag > int64(settings.MaxConsumerLag) {
+				return fmt.Errorf("lag of %d messages exceeds %d", lag, settings.MaxConsumerLag)
+			}
+			return nil
+		})
+	}
+
+	checker.AddReadiness("postgres", pgStore.Ping)
+
+	latestMigration, migrationErr := database.LatestMigrationVersion(migrationsDir)
+	checker.AddReadiness("migrations", func(ctx context.Context) error {
+		if migrationErr != nil {
+			return fmt.Errorf("couldn't read migration files: %w", migrationErr)
+		}
+
+		version, err := database.MigrationVersion(ctx, pgStore.DB.DBS().Reader.DB, "trips_api")
+		if err != nil {
+			return err
+		}
+
+		if version != latestMigration {
+			return fmt.Errorf("database at version %d, latest migration is %d", version, latestMigration)
+		}
+		return nil
+	})
+
+	if settings.DataFetchEnabled {
+		checker.AddReadiness("elasticsearch", esStore.Ping)
+	}
+
+	if settings.BundlrEnabled {
+		checker.AddReadiness("bundlr", bundlrClient.Ping)
+	}
+
+	return checker
+}
@@ -8,19 +8,31 @@ import (
 	"strings"
 	"sync"
 	"syscall"
+	"time"
+	_ "time/tzdata" // The image has no zoneinfo, and summaries take IANA zones.
 
 	"github.com/DIMO-Network/shared"
-	"github.com/DIMO-Network/shared/kafka"
 	"github.com/DIMO-Network/shared/middleware/privilegetoken"
 	"github.com/DIMO-Network/shared/privileges"
 	_ "github.com/DIMO-Network/trips-api/docs"
 	"github.com/DIMO-Network/trips-api/internal/api"
+	"github.com/DIMO-Network/trips-api/internal/attestation"
 	"github.com/DIMO-Network/trips-api/internal/config"
 	"github.com/DIMO-Network/trips-api/internal/database"
+	"github.com/DIMO-Network/trips-api/internal/health"
+	"github.com/DIMO-Network/trips-api/internal/kafka"
+	"github.com/DIMO-Network/trips-api/internal/privacy"
+	"github.com/DIMO-Network/trips-api/internal/services/anchoring"
 	"github.com/DIMO-Network/trips-api/internal/services/bundlr"
 	"github.com/DIMO-Network/trips-api/internal/services/consumer"
 	es_store "github.com/DIMO-Network/trips-api/internal/services/es"
+	"github.com/DIMO-Network/trips-api/internal/services/identity"
 	pg_store "github.com/DIMO-Network/trips-api/internal/services/pg"
+	"github.com/DIMO-Network/trips-api/internal/services/retention"
+	"github.com/DIMO-Network/trips-api/internal/services/segmenter"
+	"github.com/DIMO-Network/trips-api/internal/services/tripevents"
+	"github.com/DIMO-Network/trips-api/internal/services/webhook"
+	"github.com/DIMO-Network/trips-api/internal/tracing"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/goccy/go-json"
 	jwtware "github.com/gofiber/contrib/jwt"
@@ -34,6 +46,11 @@ import (
 
 // const userIDContextKey = "userID"
 
+const defaultShutdownTimeout = 30 * time.Second
+
+// defaultAttestationChainID is Polygon, where the Bundlr key pays for uploads.
+const defaultAttestationChainID = 137
+
 // @title			DIMO Segment API
 // @version		1.0
 // @description	segments
@@ -65,6 +82,20 @@ func main() {
 		}
 		database.MigrateDatabase(logger, &settings, command, "trips_api")
 		return
+	case "sync-vehicles":
+		syncVehicles(ctx, &settings, &logger)
+		return
+	case "retention":
+		enforceRetention(ctx, &settings, len(os.Args) > 2 && os.Args[2] == "--dry-run", &logger)
+		return
+	case "verify":
+		verifyArchives(ctx, &settings, os.Args[2:], &logger)
+		return
+	}
+
+	shutdownTracing, err := tracing.Setup(ctx, &settings)
+	if err != nil {
+		logger.Fatal().Err(err).Msg("Failed to set up tracing.")
 	}
 
 	esStore, err := es_store.New(&settings)
@@ -82,24 +113,105 @@ func main() {
 		logger.Fatal().Err(err).Msg("Failed to initialize Bundlr client")
 	}
 
-	controller := consumer.New(esStore, bundlrClient, pgStore, &logger, settings.DataFetchEnabled, settings.WorkerCount, settings.BundlrEnabled)
-	segmentChannel := make(chan *shared.CloudEvent[consumer.SegmentEvent])
-	vehicleEventChannel := make(chan *shared.CloudEvent[consumer.UserDeviceMintEvent])
-	var wg sync.WaitGroup
+	precision, err := privacy.ParsePrecision(settings.LocationPrecision)
+	if err != nil {
+		logger.Fatal().Err(err).Msg("Invalid location precision.")
+	}
+
+	// The identity service knows who owns each vehicle and what they have granted. Webhook
+	// deliveries are checked against it, and only owners see through privacy zones.
+	var sinks []tripevents.Sink
+	var dispatcher *webhook.Dispatcher
+	var owners api.Owners
+	if identityClient, err := identity.New(&settings); err != nil {
+		logger.Warn().Err(err).Msg("Without the identity service, webhooks won't be delivered and privacy zones apply to everyone.")
+	} else {
+		owners = identityClient
+		dispatcher = webhook.New(pgStore, identityClient, webhook.Config{
+			MaxAttempts:  settings.WebhookMaxAttempts,
+			DisableAfter: settings.WebhookDisableAfter,
+			// Local endpoints are reached over plain HTTP.
+			AllowPrivateNetworks: settings.WebhookAllowHTTP,
+		}, &logger)
+		sinks = append(sinks, dispatcher)
+	}
+
+	if settings.TripLifecycleTopic != "" {
+		producer, err := kafka.NewProducer(strings.Split(settings.KafkaBrokers, ","), settings.TripLifecycleTopic)
+		if err != nil {
+			logger.Fatal().Err(err).Msg("Couldn't start trip lifecycle producer.")
+		}
+		defer producer.Close() //nolint:errcheck
+		sinks = append(sinks, tripevents.KafkaSink(producer))
+	}
+	events := tripevents.New(pgStore, precision, sinks...)
+
+	// Webhook deliveries continue until the consumers have drained.
+	dispatchCtx, stopDispatching := context.WithCancel(ctx)
+	if dispatcher != nil {
+		go dispatcher.Run(dispatchCtx)
+	}
+
+	controller := consumer.New(esStore, bundlrClient, pgStore, events, &logger, settings.DataFetchEnabled, settings.WorkerCount, settings.BundlrEnabled)
+
+	// Cancelling consumeCtx stops the consumers from fetching new messages.
+	consumeCtx, stopConsuming := context.WithCancel(ctx)
+
+	if settings.SegmenterEnabled {
+		seg := segmenter.New(esStore, pgStore, controller.ProcessSegmentEvent, segmenter.Config{
+			UserDeviceIDs: strings.Split(strings.ReplaceAll(settings.SegmenterDeviceIDs, " ", ""), ","),
+			PollInterval:  time.Duration(settings.SegmenterPollIntervalSeconds) * time.Second,
+			Thresholds: segmenter.Thresholds{
+				Idle:     time.Duration(settings.SegmenterIdleTimeoutSeconds) * time.Second,
+				Gap:      time.Duration(settings.SegmenterGapTimeoutSeconds) * time.Second,
+				MinSpeed: float64(settings.SegmenterMinSpeedKph),
+			},
+		}, &logger)
+		go seg.Run(consumeCtx)
+	}
+
+	if settings.RetentionEnabled {
+		go retention.New(pgStore, retentionConfig(&settings), &logger).Run(consumeCtx)
+	}
+
+	if settings.AnchorEnabled {
+		var publisher anchoring.Publisher
+		switch settings.AnchorPublisher {
+		case "bundlr":
+			if !settings.BundlrEnabled {
+				logger.Fatal().Msg("Anchoring to Bundlr needs Bundlr uploads enabled.")
+			}
+			publisher = anchoring.NewBundlrPublisher(bundlrClient)
+		case "local":
+			publisher = anchoring.NewLocalPublisher(&logger)
+		default:
+			logger.Fatal().Str("publisher", settings.AnchorPublisher).Msg("Unknown anchor publisher.")
+		}
+		go anchoring.New(pgStore, publisher, anchoring.Config{
+			Interval:  time.Duration(settings.AnchorIntervalSeconds) * time.Second,
+			BatchSize: settings.AnchorBatchSize,
+		}, &logger).Run(consumeCtx)
+	}
 
-	if err := kafka.Consume(ctx, kafka.Config{
-		Brokers: strings.Split(settings.KafkaBrokers, ","),
-		Topic:   settings.TripEventTopic,
-		Group:   "completed-segment",
-	}, controller.ProcessSegmentEvent, &logger); err != nil {
+	segmentConsumer, err := kafka.Consume(consumeCtx, kafka.Config{
+		Brokers:         strings.Split(settings.KafkaBrokers, ","),
+		Topic:           settings.TripEventTopic,
+		Group:           "completed-segment",
+		DeadLetterTopic: settings.TripEventDeadLetterTopic,
+		MaxAttempts:     settings.ConsumerMaxAttempts,
+	}, controller.ProcessSegmentEvent, &logger)
+	if err != nil {
 		logger.Fatal().Err(err).Msg("Couldn't start completed segment consumer.")
 	}
 
-	if err := kafka.Consume(ctx, kafka.Config{
-		Brokers: strings.Split(settings.KafkaBrokers, ","),
-		Topic:   settings.EventTopic,
-		Group:   "vehicle-event",
-	}, controller.VehicleEvent, &logger); err != nil {
+	vehicleConsumer, err := kafka.Consume(consumeCtx, kafka.Config{
+		Brokers:         strings.Split(settings.KafkaBrokers, ","),
+		Topic:           settings.EventTopic,
+		Group:           "vehicle-event",
+		DeadLetterTopic: settings.EventDeadLetterTopic,
+		MaxAttempts:     settings.ConsumerMaxAttempts,
+	}, controller.VehicleEvent, &logger)
+	if err != nil {
 		logger.Fatal().Err(err).Msg("Couldn't start vehicle event consumer.")
 	}
 
@@ -111,10 +223,17 @@ func main() {
 			JSONDecoder: json.Unmarshal,
 		},
 	)
+	app.Use(api.Metrics)
+	app.Use(tracing.Middleware)
 	v1 := app.Group("/v1")
 	v1.Get("/swagger/*", swagger.HandlerDefault)
 
-	go serveMonitoring(settings.MonPort, &logger) //nolint
+	checker := newHealthChecker(&settings, pgStore, esStore, bundlrClient, map[string]*kafka.Consumer{
+		"segment": segmentConsumer,
+		"vehicle": vehicleConsumer,
+	})
+
+	go serveMonitoring(settings.MonPort, checker, &logger) //nolint
 
 	privilegeJWT := jwtware.New(jwtware.Config{
 		JWKSetURLs: []string{settings.PrivilegeJWKURL},
@@ -125,8 +244,60 @@ func main() {
 	})
 	vehicleAddr := common.HexToAddress(settings.VehicleNFTAddr)
 
-	handler := api.NewHandler(pgStore, &logger)
-	v1.Get("/vehicle/:tokenID/trips", privilegeJWT, privilege.OneOf(vehicleAddr, []privileges.Privilege{privileges.VehicleAllTimeLocation}), handler.GetVehicleTrips)
+	tripUpdates, err := pg_store.NewTripUpdateListener(&settings, *pgStore, &logger)
+	if err != nil {
+		logger.Fatal().Err(err).Msg("Couldn't listen for trip updates.")
+	}
+	listenCtx, stopListening := context.WithCancel(ctx)
+	go tripUpdates.Run(listenCtx)
+
+	handler := api.NewHandler(pgStore, owners, precision, &logger)
+	// Callers with only non-location access get the trips without positions.
+	v1.Get("/vehicle/:tokenID/trips", privilegeJWT, privilege.OneOf(vehicleAddr, []privileges.Privilege{privileges.VehicleNonLocationData, privileges.VehicleAllTimeLocation}), handler.GetVehicleTrips)
+
+	// Summaries carry no locations, so the non-location privilege is enough.
+	v1.Get("/vehicle/:tokenID/trips/summary", privilegeJWT, privilege.OneOf(vehicleAddr, []privileges.Privilege{privileges.VehicleNonLocationData, privileges.VehicleAllTimeLocation}), handler.GetVehicleTripSummary)
+
+	// Attestations and proofs carry no positions, so the non-location privilege is enough.
+	chainID := settings.AttestationChainID
+	if chainID == 0 {
+		chainID = defaultAttestationChainID
+	}
+	attestationHandler := api.NewAttestationHandler(pgStore, attestation.NewSigner(bundlrClient.Signer.PrivateKey, int64(chainID)), &logger)
+	v1.Get("/vehicle/:tokenID/trips/:tripID/attestation", privilegeJWT, privilege.OneOf(vehicleAddr, []privileges.Privilege{privileges.VehicleNonLocationData, privileges.VehicleAllTimeLocation}), attestationHandler.GetTripAttestation)
+	v1.Get("/vehicle/:tokenID/trips/:tripID/proof", privilegeJWT, privilege.OneOf(vehicleAddr, []privileges.Privilege{privileges.VehicleNonLocationData, privileges.VehicleAllTimeLocation}), handler.GetTripProof)
+
+	// Changing a trip takes the commands privilege on top of the one needed to read it.
+	v1.Patch("/vehicle/:tokenID/trips/:tripID", privilegeJWT, privilege.OneOf(vehicleAddr, []privileges.Privilege{privileges.VehicleAllTimeLocation}), privilege.OneOf(vehicleAddr, []privileges.Privilege{privileges.VehicleCommands}), handler.AnnotateTrip)
+
+	// Erasure can't be undone, so on top of the privileges for changing trips it's left to the
+	// owner, as are its receipts.
+	v1.Post("/vehicle/:tokenID/erasures", privilegeJWT, privilege.OneOf(vehicleAddr, []privileges.Privilege{privileges.VehicleAllTimeLocation}), privilege.OneOf(vehicleAddr, []privileges.Privilege{privileges.VehicleCommands}), api.OwnerOnly(owners), handler.EraseTrips)
+	v1.Get("/vehicle/:tokenID/erasures", privilegeJWT, privilege.OneOf(vehicleAddr, []privileges.Privilege{privileges.VehicleAllTimeLocation}), privilege.OneOf(vehicleAddr, []privileges.Privilege{privileges.VehicleCommands}), api.OwnerOnly(owners), handler.ListErasures)
+
+	// Lets owners see which apps have read their trips.
+	v1.Get("/vehicle/:tokenID/accesses", privilegeJWT, privilege.OneOf(vehicleAddr, []privileges.Privilege{privileges.VehicleAllTimeLocation}), privilege.OneOf(vehicleAddr, []privileges.Privilege{privileges.VehicleCommands}), api.OwnerOnly(owners), handler.ListAppAccesses)
+
+	editHandler := api.NewEditHandler(controller, &logger)
+	v1.Post("/vehicle/:tokenID/trips/merge", privilegeJWT, privilege.OneOf(vehicleAddr, []privileges.Privilege{privileges.VehicleAllTimeLocation}), privilege.OneOf(vehicleAddr, []privileges.Privilege{privileges.VehicleCommands}), editHandler.MergeTrips)
+	v1.Post("/vehicle/:tokenID/trips/:tripID/split", privilegeJWT, privilege.OneOf(vehicleAddr, []privileges.Privilege{privileges.VehicleAllTimeLocation}), privilege.OneOf(vehicleAddr, []privileges.Privilege{privileges.VehicleCommands}), editHandler.SplitTrip)
+	v1.Get("/vehicle/:tokenID/trips/mileage-log", privilegeJWT, privilege.OneOf(vehicleAddr, []privileges.Privilege{privileges.VehicleAllTimeLocation}), handler.GetMileageLog)
+
+	streamHandler := api.NewStreamHandler(pgStore, tripUpdates, owners, precision, &logger)
+	v1.Get("/vehicle/:tokenID/trips/stream", privilegeJWT, privilege.OneOf(vehicleAddr, []privileges.Privilege{privileges.VehicleAllTimeLocation}), streamHandler.StreamVehicleTrips)
+
+	// Trip events carry locations, so managing webhooks takes the same privilege as reading trips.
+	webhookHandler := api.NewWebhookHandler(pgStore, settings.WebhookAllowHTTP, &logger)
+	v1.Post("/vehicle/:tokenID/webhooks", privilegeJWT, privilege.OneOf(vehicleAddr, []privileges.Privilege{privileges.VehicleAllTimeLocation}), webhookHandler.CreateWebhook)
+	v1.Get("/vehicle/:tokenID/webhooks", privilegeJWT, privilege.OneOf(vehicleAddr, []privileges.Privilege{privileges.VehicleAllTimeLocation}), webhookHandler.ListWebhooks)
+	v1.Delete("/vehicle/:tokenID/webhooks/:webhookID", privilegeJWT, privilege.OneOf(vehicleAddr, []privileges.Privilege{privileges.VehicleAllTimeLocation}), webhookHandler.DeleteWebhook)
+
+	// Zones hide where the vehicle is kept from the apps it's shared with, so only the owner, who
+	// sees through them, may manage them.
+	zoneHandler := api.NewPrivacyZoneHandler(pgStore, &logger)
+	v1.Post("/vehicle/:tokenID/privacy-zones", privilegeJWT, privilege.OneOf(vehicleAddr, []privileges.Privilege{privileges.VehicleAllTimeLocation}), api.OwnerOnly(owners), zoneHandler.CreatePrivacyZone)
+	v1.Get("/vehicle/:tokenID/privacy-zones", privilegeJWT, privilege.OneOf(vehicleAddr, []privileges.Privilege{privileges.VehicleAllTimeLocation}), api.OwnerOnly(owners), zoneHandler.ListPrivacyZones)
+	v1.Delete("/vehicle/:tokenID/privacy-zones/:zoneID", privilegeJWT, privilege.OneOf(vehicleAddr, []privileges.Privilege{privileges.VehicleAllTimeLocation}), api.OwnerOnly(owners), zoneHandler.DeletePrivacyZone)
 
 	go func() {
 		logger.Info().Msgf("Starting API server on port %s.", settings.Port)
@@ -139,18 +310,48 @@ func main() {
 	signal.Notify(c, os.Interrupt, syscall.SIGTERM) // When an interrupt or termination signal is sent, notify the channel
 	<-c                                             // This blocks the main thread until an interrupt is received
 	logger.Info().Msg("Gracefully shutting down and running cleanup tasks...")
-	close(segmentChannel)
-	close(vehicleEventChannel)
+
+	shutdownTimeout := defaultShutdownTimeout
+	if settings.ShutdownTimeoutSeconds > 0 {
+		shutdownTimeout = time.Duration(settings.ShutdownTimeoutSeconds) * time.Second
+	}
+	shutdownCtx, cancelShutdown := context.WithTimeout(ctx, shutdownTimeout)
+	defer cancelShutdown()
+
+	stopConsuming()
+
+	var wg sync.WaitGroup
+	for name, c := range map[string]*kafka.Consumer{"segment": segmentConsumer, "vehicle": vehicleConsumer} {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := c.Shutdown(shutdownCtx); err != nil {
+				logger.Warn().Err(err).Str("consumer", name).Msg("In-flight messages abandoned at shutdown deadline.")
+			}
+		}()
+	}
 	wg.Wait()
-	_ = app.Shutdown()
+	stopDispatching()
+	// Closing the subscriptions ends open streams, which the server would otherwise wait on.
+	stopListening()
+
+	if err := app.ShutdownWithContext(shutdownCtx); err != nil {
+		logger.Err(err).Msg("Error shutting down API server.")
+	}
+
+	if err := shutdownTracing(shutdownCtx); err != nil {
+		logger.Err(err).Msg("Error flushing traces.")
+	}
 }
 
-func serveMonitoring(port string, logger *zerolog.Logger) (*fiber.App, error) {
+func serveMonitoring(port string, checker *health.Checker, logger *zerolog.Logger) (*fiber.App, error) {
 	logger.Info().Str("port", port).Msg("Starting monitoring web server.")
 
 	monApp := fiber.New(fiber.Config{DisableStartupMessage: true})
 
 	monApp.Get("/", func(c *fiber.Ctx) error { return nil })
+	monApp.Get("/health/live", checker.Live)
+	monApp.Get("/health/ready", checker.Ready)
 	monApp.Get("/metrics", adaptor.HTTPHandler(promhttp.Handler()))
 
 	go func() {
@@ -0,0 +1,39 @@
+package main
+
+import (
+	"context"
+	"time"
+
+	"github.com/DIMO-Network/trips-api/internal/config"
+	pg_store "github.com/DIMO-Network/trips-api/internal/services/pg"
+	"github.com/DIMO-Network/trips-api/internal/services/retention"
+	"github.com/rs/zerolog"
+)
+
+const day = 24 * time.Hour
+
+func retentionConfig(settings *config.Settings) retention.Config {
+	return retention.Config{
+		Interval:    time.Duration(settings.RetentionIntervalSeconds) * time.Second,
+		PositionAge: time.Duration(settings.RetentionPositionDays) * day,
+		TripAge:     time.Duration(settings.RetentionTripDays) * day,
+		Aggregate:   settings.RetentionAggregate,
+		DryRun:      settings.RetentionDryRun,
+	}
+}
+
+// enforceRetention applies the retention rules once. With dryRun, it only reports what they
+// would change.
+func enforceRetention(ctx context.Context, settings *config.Settings, dryRun bool, logger *zerolog.Logger) {
+	pgStore, err := pg_store.New(settings)
+	if err != nil {
+		logger.Fatal().Err(err).Msg("Failed to establish connection to postgres.")
+	}
+	pgStore.DB.WaitForDB(*logger)
+
+	conf := retentionConfig(settings)
+	conf.DryRun = conf.DryRun || dryRun
+	if _, err := retention.New(pgStore, conf, logger).Enforce(ctx, time.Now()); err != nil {
+		logger.Fatal().Err(err).Msg("Retention failed.")
+	}
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"context"
+	"time"
+
+	"github.com/DIMO-Network/trips-api/internal/config"
+	"github.com/DIMO-Network/trips-api/internal/services/identity"
+	pg_store "github.com/DIMO-Network/trips-api/internal/services/pg"
+	"github.com/rs/zerolog"
+)
+
+// syncVehicles pages through the identity service and brings the stored vehicle mappings in
+// line with it. This seeds new environments and recovers databases that missed mint events.
+func syncVehicles(ctx context.Context, settings *config.Settings, logger *zerolog.Logger) {
+	identityClient, err := identity.New(settings)
+	if err != nil {
+		logger.Fatal().Err(err).Msg("Failed to initialize identity client.")
+	}
+
+	pgStore, err := pg_store.New(settings)
+	if err != nil {
+		logger.Fatal().Err(err).Msg("Failed to establish connection to postgres.")
+	}
+	pgStore.DB.WaitForDB(*logger)
+
+	syncedAt := time.Now()
+	var total pg_store.VehicleSyncResult
+
+	err = identityClient.Vehicles(ctx, func(page []identity.Vehicle) error {
+		vehicles := make([]pg_store.Vehicle, len(page))
+		for i, v := range page {
+			vehicles[i] = pg_store.Vehicle{
+				TokenID:      v.TokenID,
+				UserDeviceID: v.UserDeviceID,
+				MintedAt:     v.MintedAt,
+			}
+		}
+
+		res, err := pgStore.SyncVehicles(ctx, vehicles, syncedAt)
+		if err != nil {
+			return err
+		}
+
+		total.Added += res.Added
+		total.Changed += res.Changed
+		total.Unchanged += res.Unchanged
+		logger.Debug().Int("added", total.Added).Int("changed", total.Changed).Int("unchanged", total.Unchanged).Msg("Synced page of vehicles.")
+		return nil
+	})
+	if err != nil {
+		logger.Fatal().Err(err).Int("added", total.Added).Int("changed", total.Changed).Int("unchanged", total.Unchanged).Msg("Vehicle sync failed.")
+	}
+
+	logger.Info().Int("added", total.Added).Int("changed", total.Changed).Int("unchanged", total.Unchanged).Msg("Vehicle sync complete.")
+}
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/DIMO-Network/trips-api/internal/config"
+	"github.com/DIMO-Network/trips-api/internal/services/bundlr"
+	pg_store "github.com/DIMO-Network/trips-api/internal/services/pg"
+	"github.com/DIMO-Network/trips-api/models"
+	"github.com/rs/zerolog"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+)
+
+const verifyBatchSize = 100
+
+// verifyArchives downloads the archives of the given trips, or of every trip with recorded
+// hashes if none are given, and checks them against the hashes. Trips whose keys are gone
+// only have their ciphertext checked. It exits non-zero if any archive doesn't match.
+func verifyArchives(ctx context.Context, settings *config.Settings, tripIDs []string, logger *zerolog.Logger) {
+	bundlrClient, err := bundlr.New(settings)
+	if err != nil {
+		logger.Fatal().Err(err).Msg("Failed to initialize Bundlr client")
+	}
+
+	pgStore, err := pg_store.New(settings)
+	if err != nil {
+		logger.Fatal().Err(err).Msg("Failed to establish connection to postgres.")
+	}
+	pgStore.DB.WaitForDB(*logger)
+
+	var verified, failed int
+	check := func(trp *models.Trip) {
+		log := logger.With().Str("tripId", trp.ID).Str("archiveId", trp.BundlrID.String).Logger()
+		if !trp.BundlrID.Valid || !trp.CiphertextSha256.Valid {
+			log.Warn().Msg("Trip has no hashed archive.")
+			failed++
+			return
+		}
+
+		a, err := bundlrClient.Download(ctx, trp.BundlrID.String)
+		if err == nil && trp.Compression.Valid && string(a.Compression()) != trp.Compression.String {
+			err = fmt.Errorf("archive is tagged %s, trip records %s", a.Compression(), trp.Compression.String)
+		}
+		if err == nil {
+			err = a.Verify(trp.EncryptionKey.Bytes, trp.DataSha256.Bytes, trp.CiphertextSha256.Bytes)
+		}
+		if err != nil {
+			if errors.Is(err, bundlr.ErrHashMismatch) {
+				log.Error().Err(err).Msg("Archive doesn't match its hashes.")
+			} else {
+				log.Error().Err(err).Msg("Couldn't verify archive.")
+			}
+			failed++
+			return
+		}
+
+		log.Debug().Bool("dataChecked", trp.EncryptionKey.Valid).Msg("Verified archive.")
+		verified++
+	}
+
+	if len(tripIDs) > 0 {
+		trips, err := models.Trips(models.TripWhere.ID.IN(tripIDs)).All(ctx, pgStore.DB.DBS().Reader)
+		if err != nil {
+			logger.Fatal().Err(err).Msg("Couldn't load trips.")
+		}
+		if len(trips) != len(tripIDs) {
+			logger.Error().Int("requested", len(tripIDs)).Int("found", len(trips)).Msg("Some trips don't exist.")
+			failed += len(tripIDs) - len(trips)
+		}
+		for _, trp := range trips {
+			check(trp)
+		}
+	} else {
+		after := ""
+		for {
+			trips, err := models.Trips(
+				models.TripWhere.ID.GT(after),
+				models.TripWhere.BundlrID.IsNotNull(),
+				models.TripWhere.CiphertextSha256.IsNotNull(),
+				qm.OrderBy(models.TripColumns.ID),
+				qm.Limit(verifyBatchSize),
+			).All(ctx, pgStore.DB.DBS().Reader)
+			if err != nil {
+				logger.Fatal().Err(err).Msg("Couldn't load trips.")
+			}
+			for _, trp := range trips {
+				check(trp)
+			}
+			if len(trips) < verifyBatchSize {
+				break
+			}
+			after = trips[len(trips)-1].ID
+		}
+	}
+
+	if failed > 0 {
+		logger.Fatal().Int("verified", verified).Int("failed", failed).Msg("Some archives failed verification.")
+	}
+	logger.Info().Int("verified", verified).Msg("All archives verified.")
+}
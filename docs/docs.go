@@ -15,6 +15,228 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
+        "/vehicle/{tokenId}/accesses": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Lists the apps that have read the vehicle's trips, summaries, mileage logs or trip stream, or received them by webhook, most recent first. Only the vehicle's owner may list them.",
+                "produces": [
+                    "application/json"
+                ],
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Vehicle token id",
+                        "name": "tokenId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_DIMO-Network_trips-api_internal_api_types.AppAccess"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/vehicle/{tokenId}/erasures": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Lists the receipts of the vehicle's erasures, oldest first. Only the vehicle's owner may list them.",
+                "produces": [
+                    "application/json"
+                ],
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Vehicle token id",
+                        "name": "tokenId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_DIMO-Network_trips-api_internal_api_types.Erasure"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Deletes the vehicle's trips starting in the range and the keys to their archived data, and returns a receipt. Repeating an erasure of the same range, or with the same Idempotency-Key, returns the original receipt with status 200. Requests without an end erase up to now and must send an Idempotency-Key. Segments that arrive later for the range are dropped. Requires the commands privilege as well as all-time location, and only the vehicle's owner may erase.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Vehicle token id",
+                        "name": "tokenId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Range",
+                        "name": "range",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_DIMO-Network_trips-api_internal_api_types.ErasureRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Key identifying retries of the erasure, required without an end",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_DIMO-Network_trips-api_internal_api_types.Erasure"
+                        }
+                    },
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_DIMO-Network_trips-api_internal_api_types.Erasure"
+                        }
+                    }
+                }
+            }
+        },
+        "/vehicle/{tokenId}/privacy-zones": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Lists the privacy zones of a vehicle.",
+                "produces": [
+                    "application/json"
+                ],
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Vehicle token id",
+                        "name": "tokenId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_DIMO-Network_trips-api_internal_api_types.PrivacyZone"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Adds a privacy zone. Trip positions inside it are snapped to its center or omitted for everyone but the vehicle's owner. Only the owner may manage zones.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Vehicle token id",
+                        "name": "tokenId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Zone",
+                        "name": "zone",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_DIMO-Network_trips-api_internal_api_types.PrivacyZoneRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_DIMO-Network_trips-api_internal_api_types.PrivacyZone"
+                        }
+                    }
+                }
+            }
+        },
+        "/vehicle/{tokenId}/privacy-zones/{zoneId}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Deletes a privacy zone.",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Vehicle token id",
+                        "name": "tokenId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Zone id",
+                        "name": "zoneId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    }
+                }
+            }
+        },
         "/vehicle/{tokenId}/trips": {
             "get": {
                 "security": [
@@ -22,10 +244,508 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Lists vehicle trips.",
-                "produces": [
-                    "application/json"
-                ],
+                "description": "Lists vehicle trips. Without the all-time location privilege, positions are left out.",
+                "produces": [
+                    "application/json"
+                ],
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Vehicle token id",
+                        "name": "tokenId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page of trips to retrieve. Defaults to 1.",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only list trips with this purpose: business, personal, commute, custom or the name of a custom purpose.",
+                        "name": "purpose",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Coarsen positions to full, 100m, 1km, or geohash cells with gh7, gh6 or gh5. Positions are never finer than the service allows.",
+                        "name": "precision",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_DIMO-Network_trips-api_internal_api_types.VehicleTrips"
+                        }
+                    }
+                }
+            }
+        },
+        "/vehicle/{tokenId}/trips/merge": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Merges consecutive completed trips into one, which keeps the id of the first and the segment ids of all of them. The other trips are deleted; their purposes and notes, which must agree, carry over with their history. Requires the commands privilege as well as all-time location.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Vehicle token id",
+                        "name": "tokenId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Trips to merge",
+                        "name": "trips",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_DIMO-Network_trips-api_internal_api_types.MergeTrips"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_DIMO-Network_trips-api_internal_api_types.TripDetails"
+                        }
+                    }
+                }
+            }
+        },
+        "/vehicle/{tokenId}/trips/mileage-log": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Exports completed trips starting in the range as a mileage log, with date, times, coordinates, distance, duration and purpose.",
+                "produces": [
+                    "text/csv",
+                    "application/pdf"
+                ],
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Vehicle token id",
+                        "name": "tokenId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "csv or pdf. Defaults to csv.",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Distance unit, km or mi. Defaults to km.",
+                        "name": "units",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "IANA time zone for dates and times. Defaults to UTC.",
+                        "name": "timezone",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "RFC 3339 start of the range. Defaults to a month before the end.",
+                        "name": "start",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "RFC 3339 end of the range. Defaults to now.",
+                        "name": "end",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Coarsen coordinates to full, 100m, 1km, or geohash cells with gh7, gh6 or gh5.",
+                        "name": "precision",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    }
+                }
+            }
+        },
+        "/vehicle/{tokenId}/trips/stream": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Streams trip updates as Server-Sent Events. Each event is named \"begin\" or \"complete\", has the trip as its data and an id that can be sent back as Last-Event-ID to resume, for up to a day.",
+                "produces": [
+                    "text/event-stream"
+                ],
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Vehicle token id",
+                        "name": "tokenId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Id of the last event received",
+                        "name": "Last-Event-ID",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Coarsen positions to full, 100m, 1km, or geohash cells with gh7, gh6 or gh5.",
+                        "name": "precision",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Events whose data is a trip, as in the trips listing",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/vehicle/{tokenId}/trips/summary": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Summarizes completed trips by the calendar day, week (starting Monday) or month in which they started. Periods without trips are omitted. Trips deleted by retention are counted in buckets marked aggregated, which span a UTC calendar month.",
+                "produces": [
+                    "application/json"
+                ],
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Vehicle token id",
+                        "name": "tokenId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "One of day, week or month. Defaults to day.",
+                        "name": "period",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "IANA time zone for the calendar. Defaults to UTC.",
+                        "name": "timezone",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "RFC 3339 start of the range. Defaults to 30 days, 12 weeks or 12 months before the end.",
+                        "name": "start",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "RFC 3339 end of the range. Defaults to now.",
+                        "name": "end",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_DIMO-Network_trips-api_internal_api_types.TripSummary"
+                        }
+                    }
+                }
+            }
+        },
+        "/vehicle/{tokenId}/trips/{tripId}": {
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Sets or clears the purpose and note of a trip. Omitted fields are left alone. Requires the commands privilege as well as all-time location.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Vehicle token id",
+                        "name": "tokenId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Trip id",
+                        "name": "tripId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Changes",
+                        "name": "annotation",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_DIMO-Network_trips-api_internal_api_types.TripAnnotation"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_DIMO-Network_trips-api_internal_api_types.TripDetails"
+                        }
+                    }
+                }
+            }
+        },
+        "/vehicle/{tokenId}/trips/{tripId}/attestation": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns an EIP-712 attestation of a completed trip's vehicle, times, distance, archive id and data hash, signed by the service. The typed data can be verified offline against the signer address, for example with eth_signTypedData_v4 tooling. Positions aren't attested, so the non-location privilege is enough.",
+                "produces": [
+                    "application/json"
+                ],
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Vehicle token id",
+                        "name": "tokenId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Trip id",
+                        "name": "tripId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_DIMO-Network_trips-api_internal_api_types.TripAttestation"
+                        }
+                    }
+                }
+            }
+        },
+        "/vehicle/{tokenId}/trips/{tripId}/proof": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns the proof that the trip's data hash is a leaf of a published Merkle root. The leaf is SHA-256(0x00 ‖ dataHash ‖ tripId) and nodes are SHA-256(0x01 ‖ left ‖ right), as in RFC 6962. Positions aren't included, so the non-location privilege is enough.",
+                "produces": [
+                    "application/json"
+                ],
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Vehicle token id",
+                        "name": "tokenId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Trip id",
+                        "name": "tripId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_DIMO-Network_trips-api_internal_api_types.TripProof"
+                        }
+                    }
+                }
+            }
+        },
+        "/vehicle/{tokenId}/trips/{tripId}/split": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Splits a completed trip in two at the given time. The first part keeps the trip's id; the second gets a new one. Both keep the trip's segment ids. Requires the commands privilege as well as all-time location.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Vehicle token id",
+                        "name": "tokenId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Trip id",
+                        "name": "tripId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Split time",
+                        "name": "split",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_DIMO-Network_trips-api_internal_api_types.SplitTrip"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_DIMO-Network_trips-api_internal_api_types.TripDetails"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/vehicle/{tokenId}/webhooks": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Lists the webhooks the caller registered for a vehicle.",
+                "produces": [
+                    "application/json"
+                ],
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Vehicle token id",
+                        "name": "tokenId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_DIMO-Network_trips-api_internal_api_types.Webhook"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Registers a webhook for trip events. Deliveries are signed with the returned secret.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Vehicle token id",
+                        "name": "tokenId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Endpoint and events",
+                        "name": "webhook",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_DIMO-Network_trips-api_internal_api_types.WebhookRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_DIMO-Network_trips-api_internal_api_types.NewWebhook"
+                        }
+                    }
+                }
+            }
+        },
+        "/vehicle/{tokenId}/webhooks/{webhookId}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Deletes a webhook the caller registered.",
                 "parameters": [
                     {
                         "type": "integer",
@@ -35,24 +755,98 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "type": "integer",
-                        "description": "Page of trips to retrieve. Defaults to 1.",
-                        "name": "page",
-                        "in": "query"
+                        "type": "string",
+                        "description": "Webhook id",
+                        "name": "webhookId",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_DIMO-Network_trips-api_internal_api_types.VehicleTrips"
-                        }
+                    "204": {
+                        "description": "No Content"
                     }
                 }
             }
         }
     },
     "definitions": {
+        "github_com_DIMO-Network_trips-api_internal_api_types.AppAccess": {
+            "type": "object",
+            "properties": {
+                "accessCount": {
+                    "type": "integer",
+                    "example": 42
+                },
+                "clientId": {
+                    "description": "ClientID identifies the app. It is empty for tokens that didn't name one.",
+                    "type": "string",
+                    "example": "0x4b7B7F6E5A5E8A3E4F0D3c8F2B1e9A7d6C5b4A3f"
+                },
+                "endpoints": {
+                    "description": "Endpoints are the routes the app read.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "/v1/vehicle/:tokenID/trips"
+                    ]
+                },
+                "firstAccess": {
+                    "type": "string"
+                },
+                "lastAccess": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_DIMO-Network_trips-api_internal_api_types.Erasure": {
+            "type": "object",
+            "properties": {
+                "archiveIds": {
+                    "description": "ArchiveIDs are the Bundlr transactions of the deleted trips. Their keys are gone, so they\ncan no longer be decrypted.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "O5Xp3eVwHbpJEmmRH9mEtTx0KzWOYgF2xU2cK1x3i2s"
+                    ]
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "end": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "2cZ4GjK0sbvh7vD4mdPDJhSq1Nt"
+                },
+                "start": {
+                    "type": "string"
+                },
+                "tripCount": {
+                    "description": "TripCount is the number of trips deleted.",
+                    "type": "integer",
+                    "example": 12
+                }
+            }
+        },
+        "github_com_DIMO-Network_trips-api_internal_api_types.ErasureRequest": {
+            "type": "object",
+            "properties": {
+                "end": {
+                    "type": "string",
+                    "example": "2024-03-01T00:00:00Z"
+                },
+                "start": {
+                    "type": "string",
+                    "example": "2024-01-01T00:00:00Z"
+                }
+            }
+        },
         "github_com_DIMO-Network_trips-api_internal_api_types.Location": {
             "type": "object",
             "properties": {
@@ -64,9 +858,198 @@ const docTemplate = `{
                 }
             }
         },
+        "github_com_DIMO-Network_trips-api_internal_api_types.MergeTrips": {
+            "type": "object",
+            "properties": {
+                "tripIds": {
+                    "description": "TripIDs are the consecutive trips to merge, in any order.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "2Y83IHPItgk0uHD7hybGnA776Bo",
+                        "2Y83IKzqDb8gXqFvV4JYrqZbFkM"
+                    ]
+                }
+            }
+        },
+        "github_com_DIMO-Network_trips-api_internal_api_types.NewWebhook": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "disabledAt": {
+                    "type": "string"
+                },
+                "events": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "com.dimo.trip.completed"
+                    ]
+                },
+                "failureCount": {
+                    "description": "FailureCount is the number of consecutive failed deliveries.",
+                    "type": "integer",
+                    "example": 0
+                },
+                "id": {
+                    "type": "string",
+                    "example": "2cZ4GjK0sbvh7vD4mdPDJhSq1Nt"
+                },
+                "secret": {
+                    "type": "string",
+                    "example": "5c2f0e2c1d0f4b7f9a3e6d8c1b2a4f6e5c2f0e2c1d0f4b7f9a3e6d8c1b2a4f6e"
+                },
+                "url": {
+                    "type": "string",
+                    "example": "https://example.com/trips"
+                }
+            }
+        },
+        "github_com_DIMO-Network_trips-api_internal_api_types.PrivacyZone": {
+            "type": "object",
+            "properties": {
+                "center": {
+                    "$ref": "#/definitions/github_com_DIMO-Network_trips-api_internal_api_types.Location"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "2cZ4GjK0sbvh7vD4mdPDJhSq1Nt"
+                },
+                "mask": {
+                    "type": "string",
+                    "example": "snap"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Home"
+                },
+                "radiusMeters": {
+                    "type": "integer",
+                    "example": 300
+                }
+            }
+        },
+        "github_com_DIMO-Network_trips-api_internal_api_types.PrivacyZoneRequest": {
+            "type": "object",
+            "properties": {
+                "center": {
+                    "$ref": "#/definitions/github_com_DIMO-Network_trips-api_internal_api_types.Location"
+                },
+                "mask": {
+                    "description": "Mask is snap, to move positions in the zone to its center, or omit, to drop them.\nIt defaults to snap.",
+                    "type": "string",
+                    "example": "snap"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Home"
+                },
+                "radiusMeters": {
+                    "type": "integer",
+                    "example": 300
+                }
+            }
+        },
+        "github_com_DIMO-Network_trips-api_internal_api_types.SplitTrip": {
+            "type": "object",
+            "properties": {
+                "time": {
+                    "description": "Time is where to split the trip, strictly between its start and end.",
+                    "type": "string",
+                    "example": "2024-03-01T08:10:00Z"
+                }
+            }
+        },
+        "github_com_DIMO-Network_trips-api_internal_api_types.SummaryBucket": {
+            "type": "object",
+            "properties": {
+                "aggregated": {
+                    "description": "Aggregated is set on buckets that include the totals of trips deleted by retention,\nwhich are kept by UTC calendar month. Such a bucket spans that month and its dropped\ndata count leaves those trips out.",
+                    "type": "boolean"
+                },
+                "distanceKm": {
+                    "type": "number",
+                    "example": 42.7
+                },
+                "droppedDataCount": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "durationSeconds": {
+                    "type": "number",
+                    "example": 5400
+                },
+                "end": {
+                    "type": "string"
+                },
+                "start": {
+                    "type": "string"
+                },
+                "tripCount": {
+                    "type": "integer",
+                    "example": 3
+                }
+            }
+        },
+        "github_com_DIMO-Network_trips-api_internal_api_types.TripAnnotation": {
+            "type": "object",
+            "properties": {
+                "customPurpose": {
+                    "description": "CustomPurpose names a custom purpose, and is required with one.",
+                    "type": "string",
+                    "example": "Client visits"
+                },
+                "note": {
+                    "type": "string",
+                    "example": "Picked up samples from the warehouse."
+                },
+                "purpose": {
+                    "description": "Purpose is one of business, personal, commute or custom.",
+                    "type": "string",
+                    "example": "custom"
+                }
+            }
+        },
+        "github_com_DIMO-Network_trips-api_internal_api_types.TripAttestation": {
+            "type": "object",
+            "properties": {
+                "signature": {
+                    "type": "string",
+                    "example": "0x9f3c...1b"
+                },
+                "signer": {
+                    "description": "Signer is the address of the service's key.",
+                    "type": "string",
+                    "example": "0x4b7B7F6E5A5E8A3E4F0D3c8F2B1e9A7d6C5b4A3f"
+                },
+                "typedData": {
+                    "description": "TypedData is the signed EIP-712 typed data with its domain, types and message, as taken by\neth_signTypedData_v4. Times are Unix seconds and dataHash is the SHA-256 of the trip's\nstatus data, or zero if there was none.",
+                    "type": "object"
+                }
+            }
+        },
         "github_com_DIMO-Network_trips-api_internal_api_types.TripDetails": {
             "type": "object",
             "properties": {
+                "customPurpose": {
+                    "description": "CustomPurpose names the purpose when it is custom.",
+                    "type": "string",
+                    "example": "Client visits"
+                },
+                "distanceKm": {
+                    "description": "DistanceKm is the distance travelled, once the trip has completed.",
+                    "type": "number",
+                    "example": 14.2
+                },
                 "droppedData": {
                     "type": "boolean"
                 },
@@ -77,6 +1060,24 @@ const docTemplate = `{
                     "type": "string",
                     "example": "2Y83IHPItgk0uHD7hybGnA776Bo"
                 },
+                "note": {
+                    "type": "string",
+                    "example": "Picked up samples from the warehouse."
+                },
+                "purpose": {
+                    "type": "string",
+                    "example": "business"
+                },
+                "segmentIds": {
+                    "description": "SegmentIDs are the upstream segments that make up the trip. There is one, with the same\nid as the trip, unless the trip has been merged or split.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "2Y83IHPItgk0uHD7hybGnA776Bo"
+                    ]
+                },
                 "start": {
                     "$ref": "#/definitions/github_com_DIMO-Network_trips-api_internal_api_types.TripStart"
                 }
@@ -93,6 +1094,57 @@ const docTemplate = `{
                 }
             }
         },
+        "github_com_DIMO-Network_trips-api_internal_api_types.TripProof": {
+            "type": "object",
+            "properties": {
+                "anchorId": {
+                    "type": "string",
+                    "example": "2cZ4GjK0sbvh7vD4mdPDJhSq1Nt"
+                },
+                "dataHash": {
+                    "type": "string"
+                },
+                "leaf": {
+                    "description": "Leaf is SHA-256(0x00 ‖ dataHash ‖ tripId).",
+                    "type": "string"
+                },
+                "leafIndex": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "proof": {
+                    "description": "Proof lists the sibling hashes from the leaf up to the root, as in RFC 9162.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "publishedAt": {
+                    "type": "string"
+                },
+                "publisher": {
+                    "description": "Publisher is where the root was published: bundlr or local.",
+                    "type": "string",
+                    "example": "bundlr"
+                },
+                "reference": {
+                    "description": "Reference locates the published root, such as a Bundlr transaction id.",
+                    "type": "string",
+                    "example": "O5Xp3eVwHbpJEmmRH9mEtTx0KzWOYgF2xU2cK1x3i2s"
+                },
+                "root": {
+                    "type": "string"
+                },
+                "treeSize": {
+                    "type": "integer",
+                    "example": 1000
+                },
+                "tripId": {
+                    "type": "string",
+                    "example": "2Y83IHPItgk0uHD7hybGnA776Bo"
+                }
+            }
+        },
         "github_com_DIMO-Network_trips-api_internal_api_types.TripStart": {
             "type": "object",
             "properties": {
@@ -107,6 +1159,25 @@ const docTemplate = `{
                 }
             }
         },
+        "github_com_DIMO-Network_trips-api_internal_api_types.TripSummary": {
+            "type": "object",
+            "properties": {
+                "buckets": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_DIMO-Network_trips-api_internal_api_types.SummaryBucket"
+                    }
+                },
+                "period": {
+                    "type": "string",
+                    "example": "day"
+                },
+                "timezone": {
+                    "type": "string",
+                    "example": "America/New_York"
+                }
+            }
+        },
         "github_com_DIMO-Network_trips-api_internal_api_types.VehicleTrips": {
             "type": "object",
             "properties": {
@@ -125,6 +1196,57 @@ const docTemplate = `{
                     }
                 }
             }
+        },
+        "github_com_DIMO-Network_trips-api_internal_api_types.Webhook": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "disabledAt": {
+                    "type": "string"
+                },
+                "events": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "com.dimo.trip.completed"
+                    ]
+                },
+                "failureCount": {
+                    "description": "FailureCount is the number of consecutive failed deliveries.",
+                    "type": "integer",
+                    "example": 0
+                },
+                "id": {
+                    "type": "string",
+                    "example": "2cZ4GjK0sbvh7vD4mdPDJhSq1Nt"
+                },
+                "url": {
+                    "type": "string",
+                    "example": "https://example.com/trips"
+                }
+            }
+        },
+        "github_com_DIMO-Network_trips-api_internal_api_types.WebhookRequest": {
+            "type": "object",
+            "properties": {
+                "events": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "com.dimo.trip.completed"
+                    ]
+                },
+                "url": {
+                    "type": "string",
+                    "example": "https://example.com/trips"
+                }
+            }
         }
     },
     "securityDefinitions": {
@@ -0,0 +1,61 @@
+package api
+
+import (
+	"strconv"
+
+	"github.com/DIMO-Network/trips-api/internal/api/types"
+	pg_store "github.com/DIMO-Network/trips-api/internal/services/pg"
+	"github.com/gofiber/fiber/v2"
+	"github.com/volatiletech/null/v8"
+)
+
+// recordAccess appends the read of the vehicle's trip data to the audit log. Reads that can't
+// be recorded mustn't be served, so the error is a fiber error to return.
+func recordAccess(c *fiber.Ctx, pgStore *pg_store.Store, tokenID int, from, to null.Time, tripCount null.Int) error {
+	subject, clientID := tokenIdentity(c)
+	err := pgStore.RecordAccess(c.UserContext(), pg_store.Access{
+		VehicleTokenID: tokenID,
+		Subject:        subject,
+		ClientID:       clientID,
+		Endpoint:       c.Route().Path,
+		From:           from,
+		To:             to,
+		TripCount:      tripCount,
+	})
+	if err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, "Couldn't record access.")
+	}
+	return nil
+}
+
+// ListAppAccesses summarizes which apps have read the vehicle's trip data.
+//
+//	@Description	Lists the apps that have read the vehicle's trips, summaries, mileage logs or trip stream, or received them by webhook, most recent first. Only the vehicle's owner may list them.
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			tokenId	path	int	true	"Vehicle token id"
+//	@Success		200		{array}	types.AppAccess
+//	@Router			/vehicle/{tokenId}/accesses [get]
+func (h *Handler) ListAppAccesses(c *fiber.Ctx) error {
+	tokenID, err := strconv.Atoi(c.Params("tokenID"))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Couldn't parse vehicle token id.")
+	}
+
+	accesses, err := h.pg.AppAccesses(c.UserContext(), tokenID)
+	if err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	resp := make([]types.AppAccess, len(accesses))
+	for i, a := range accesses {
+		resp[i] = types.AppAccess{
+			ClientID:    a.ClientID,
+			AccessCount: a.AccessCount,
+			FirstAccess: a.FirstAccess,
+			LastAccess:  a.LastAccess,
+			Endpoints:   a.Endpoints,
+		}
+	}
+	return c.JSON(resp)
+}
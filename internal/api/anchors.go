@@ -0,0 +1,71 @@
+package api
+
+import (
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"strconv"
+
+	"github.com/DIMO-Network/trips-api/internal/api/types"
+	"github.com/DIMO-Network/trips-api/internal/merkle"
+	pg_store "github.com/DIMO-Network/trips-api/internal/services/pg"
+	"github.com/gofiber/fiber/v2"
+	"github.com/volatiletech/null/v8"
+)
+
+// GetTripProof returns the Merkle inclusion proof of a trip in its latest published anchor.
+//
+//	@Description	Returns the proof that the trip's data hash is a leaf of a published Merkle root. The leaf is SHA-256(0x00 ‖ dataHash ‖ tripId) and nodes are SHA-256(0x01 ‖ left ‖ right), as in RFC 6962. Positions aren't included, so the non-location privilege is enough.
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			tokenId	path		int		true	"Vehicle token id"
+//	@Param			tripId	path		string	true	"Trip id"
+//	@Success		200		{object}	types.TripProof
+//	@Router			/vehicle/{tokenId}/trips/{tripId}/proof [get]
+func (h *Handler) GetTripProof(c *fiber.Ctx) error {
+	tokenID, err := strconv.Atoi(c.Params("tokenID"))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Couldn't parse vehicle token id.")
+	}
+
+	p, err := h.pg.TripProof(c.UserContext(), tokenID, c.Params("tripID"))
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return fiber.NewError(fiber.StatusNotFound, "No such trip.")
+		case errors.Is(err, pg_store.ErrNotAnchored):
+			return fiber.NewError(fiber.StatusConflict, "Trip hasn't been anchored yet.")
+		case errors.Is(err, pg_store.ErrNotPublished):
+			return fiber.NewError(fiber.StatusConflict, "Trip's anchor hasn't been published yet.")
+		}
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	if err := recordAccess(c, h.pg, tokenID, null.Time{}, null.Time{}, null.IntFrom(1)); err != nil {
+		return err
+	}
+
+	return c.JSON(tripProofToAPI(c.Params("tripID"), p))
+}
+
+func tripProofToAPI(tripID string, p *pg_store.TripProof) types.TripProof {
+	leaf := merkle.LeafHash(tripID, p.DataHash)
+	proof := make([]string, len(p.Proof))
+	for i, h := range p.Proof {
+		proof[i] = hex.EncodeToString(h[:])
+	}
+
+	return types.TripProof{
+		TripID:      tripID,
+		DataHash:    hex.EncodeToString(p.DataHash[:]),
+		Leaf:        hex.EncodeToString(leaf[:]),
+		LeafIndex:   p.LeafIndex,
+		TreeSize:    p.Anchor.LeafCount,
+		Proof:       proof,
+		Root:        hex.EncodeToString(p.Anchor.Root),
+		AnchorID:    p.Anchor.ID,
+		Publisher:   p.Anchor.Publisher,
+		Reference:   p.Anchor.Reference.String,
+		PublishedAt: p.Anchor.PublishedAt.Time,
+	}
+}
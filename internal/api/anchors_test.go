@@ -0,0 +1,65 @@
+package api
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/DIMO-Network/trips-api/internal/merkle"
+	"github.com/DIMO-Network/trips-api/internal/privacy"
+	pg_store "github.com/DIMO-Network/trips-api/internal/services/pg"
+	"github.com/DIMO-Network/trips-api/models"
+	"github.com/gofiber/fiber/v2"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/volatiletech/null/v8"
+)
+
+func TestGetTripProofValidation(t *testing.T) {
+	h := NewHandler(nil, nil, privacy.Precision{}, &zerolog.Logger{})
+	app := fiber.New()
+	app.Get("/vehicle/:tokenID/trips/:tripID/proof", h.GetTripProof)
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/vehicle/abc/trips/2Y83IHPItgk0uHD7hybGnA776Bo/proof", nil))
+	require.NoError(t, err)
+	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+}
+
+func TestTripProofToAPI(t *testing.T) {
+	ids := []string{"trip0", "trip1", "trip2"}
+	hashes := make([]merkle.Hash, len(ids))
+	leaves := make([]merkle.Hash, len(ids))
+	for i, id := range ids {
+		hashes[i] = sha256.Sum256([]byte(id))
+		leaves[i] = merkle.LeafHash(id, hashes[i])
+	}
+	root := merkle.Root(leaves)
+
+	published := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	resp := tripProofToAPI("trip2", &pg_store.TripProof{
+		Anchor: &models.Anchor{
+			ID: "anchor", Root: root[:], LeafCount: 3, Publisher: "local",
+			Reference: null.StringFrom("ref"), PublishedAt: null.TimeFrom(published),
+		},
+		LeafIndex: 2,
+		DataHash:  hashes[2],
+		Proof:     merkle.Proof(leaves, 2),
+	})
+	assert.Equal(t, hex.EncodeToString(leaves[2][:]), resp.Leaf)
+	assert.Equal(t, hex.EncodeToString(root[:]), resp.Root)
+	assert.Equal(t, "ref", resp.Reference)
+	assert.Equal(t, published, resp.PublishedAt)
+
+	// A client can check the proof from the response alone.
+	var leaf, gotRoot merkle.Hash
+	hex.Decode(leaf[:], []byte(resp.Leaf))
+	hex.Decode(gotRoot[:], []byte(resp.Root))
+	proof := make([]merkle.Hash, len(resp.Proof))
+	for i, p := range resp.Proof {
+		hex.Decode(proof[i][:], []byte(p))
+	}
+	assert.NoError(t, merkle.Verify(leaf, resp.LeafIndex, resp.TreeSize, proof, gotRoot))
+}
@@ -0,0 +1,101 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/DIMO-Network/trips-api/internal/api/types"
+	"github.com/DIMO-Network/trips-api/internal/mileage"
+	pg_store "github.com/DIMO-Network/trips-api/internal/services/pg"
+	"github.com/gofiber/fiber/v2"
+)
+
+const (
+	maxCustomPurposeLength = 64
+	maxNoteLength          = 2000
+)
+
+// AnnotateTrip sets the purpose and note of a trip, recording the change and who made it.
+//
+//	@Description	Sets or clears the purpose and note of a trip. Omitted fields are left alone. Requires the commands privilege as well as all-time location.
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			tokenId		path		int						true	"Vehicle token id"
+//	@Param			tripId		path		string					true	"Trip id"
+//	@Param			annotation	body		types.TripAnnotation	true	"Changes"
+//	@Success		200			{object}	types.TripDetails
+//	@Router			/vehicle/{tokenId}/trips/{tripId} [patch]
+func (h *Handler) AnnotateTrip(c *fiber.Ctx) error {
+	tokenID, err := strconv.Atoi(c.Params("tokenID"))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Couldn't parse vehicle token id.")
+	}
+
+	var req types.TripAnnotation
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Couldn't parse request body.")
+	}
+	if err := validateAnnotation(&req); err != nil {
+		return err
+	}
+
+	subject, clientID := tokenIdentity(c)
+	if subject == "" {
+		return fiber.NewError(fiber.StatusUnauthorized, "Token has no subject.")
+	}
+
+	trp, err := h.pg.AnnotateTrip(c.UserContext(), tokenID, c.Params("tripID"), pg_store.Annotation{
+		Purpose:       req.Purpose,
+		CustomPurpose: req.CustomPurpose,
+		Note:          req.Note,
+	}, pg_store.Editor{Subject: subject, ClientID: clientID, UserAddress: tokenAddress(c)})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fiber.NewError(fiber.StatusNotFound, "No such trip.")
+		}
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	return c.JSON(tripToAPI(trp))
+}
+
+// validateAnnotation checks the request and trims the custom purpose and note.
+func validateAnnotation(req *types.TripAnnotation) error {
+	if req.Purpose == nil && req.CustomPurpose == nil && req.Note == nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Nothing to change.")
+	}
+
+	if req.Purpose != nil && *req.Purpose != "" && !slices.Contains(mileage.Purposes, *req.Purpose) {
+		return fiber.NewError(fiber.StatusBadRequest, "Purpose must be business, personal, commute or custom.")
+	}
+
+	if req.CustomPurpose != nil {
+		*req.CustomPurpose = strings.TrimSpace(*req.CustomPurpose)
+	}
+	custom := req.Purpose != nil && *req.Purpose == mileage.PurposeCustom
+	switch {
+	case custom && (req.CustomPurpose == nil || *req.CustomPurpose == ""):
+		return fiber.NewError(fiber.StatusBadRequest, "A custom purpose needs a name.")
+	case !custom && req.CustomPurpose != nil && *req.CustomPurpose != "":
+		return fiber.NewError(fiber.StatusBadRequest, "Only a custom purpose can be named.")
+	case custom && utf8.RuneCountInString(*req.CustomPurpose) > maxCustomPurposeLength:
+		return fiber.NewError(fiber.StatusBadRequest, "Custom purpose is too long.")
+	case custom && slices.Contains(mileage.Purposes, *req.CustomPurpose):
+		// Names are used to filter trips, so they mustn't collide with the built-in purposes.
+		return fiber.NewError(fiber.StatusBadRequest, "Custom purpose can't be named after a built-in one.")
+	}
+
+	if req.Note != nil {
+		*req.Note = strings.TrimSpace(*req.Note)
+		if utf8.RuneCountInString(*req.Note) > maxNoteLength {
+			return fiber.NewError(fiber.StatusBadRequest, "Note is too long.")
+		}
+	}
+
+	return nil
+}
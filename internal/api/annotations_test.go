@@ -0,0 +1,65 @@
+package api
+
+import (
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/DIMO-Network/trips-api/internal/privacy"
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAnnotateTripValidation(t *testing.T) {
+	h := NewHandler(nil, nil, privacy.PrecisionFull, &zerolog.Logger{})
+	app := fiber.New()
+	app.Patch("/vehicle/:tokenID/trips/:tripID", h.AnnotateTrip)
+
+	for _, body := range []string{
+		`{}`,
+		`{"purpose": "holiday"}`,
+		`{"purpose": "custom"}`,
+		`{"purpose": "custom", "customPurpose": "  "}`,
+		`{"purpose": "custom", "customPurpose": "personal"}`,
+		`{"purpose": "custom", "customPurpose": "` + strings.Repeat("a", 65) + `"}`,
+		`{"purpose": "business", "customPurpose": "Client visits"}`,
+		`{"customPurpose": "Client visits"}`,
+		`{"note": "` + strings.Repeat("a", 2001) + `"}`,
+	} {
+		req := httptest.NewRequest("PATCH", "/vehicle/1/trips/2Y83IHPItgk0uHD7hybGnA776Bo", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := app.Test(req)
+		require.NoError(t, err)
+		assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode, body)
+	}
+}
+
+func TestTokenIdentity(t *testing.T) {
+	app := fiber.New()
+	app.Get("/", func(c *fiber.Ctx) error {
+		if c.Query("token") != "" {
+			c.Locals("user", &jwt.Token{Claims: jwt.MapClaims{
+				"sub":       "0xbA5738a18d83D41847dfFbDC6101d37C69c9B0cF/1",
+				"client_id": "0x0123",
+			}})
+		}
+		subject, clientID := tokenIdentity(c)
+		return c.SendString(subject + " " + clientID)
+	})
+
+	for query, expected := range map[string]string{
+		"":         " ",
+		"?token=1": "0xbA5738a18d83D41847dfFbDC6101d37C69c9B0cF/1 0x0123",
+	} {
+		resp, err := app.Test(httptest.NewRequest("GET", "/"+query, nil))
+		require.NoError(t, err)
+		body := new(strings.Builder)
+		_, err = io.Copy(body, resp.Body)
+		require.NoError(t, err)
+		assert.Equal(t, expected, body.String())
+	}
+}
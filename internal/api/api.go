@@ -2,37 +2,47 @@ package api
 
 import (
 	"math"
+	"slices"
 	"strconv"
 	"time"
 
 	"github.com/DIMO-Network/trips-api/internal/api/types"
+	"github.com/DIMO-Network/trips-api/internal/mileage"
+	"github.com/DIMO-Network/trips-api/internal/privacy"
 	pg_store "github.com/DIMO-Network/trips-api/internal/services/pg"
 	"github.com/DIMO-Network/trips-api/models"
 	"github.com/gofiber/fiber/v2"
 	"github.com/rs/zerolog"
+	"github.com/volatiletech/null/v8"
 	"github.com/volatiletech/sqlboiler/v4/queries/qm"
 	"github.com/volatiletech/sqlboiler/v4/types/pgeo"
 )
 
 type Handler struct {
-	pg     *pg_store.Store
-	logger *zerolog.Logger
+	pg *pg_store.Store
+	// owners decides who sees through privacy zones.
+	owners Owners
+	// precision is applied to the positions shown to callers with location access.
+	precision privacy.Precision
+	logger    *zerolog.Logger
 }
 
-func NewHandler(pgStore *pg_store.Store, logger *zerolog.Logger) *Handler {
-	return &Handler{pgStore, logger}
+func NewHandler(pgStore *pg_store.Store, owners Owners, precision privacy.Precision, logger *zerolog.Logger) *Handler {
+	return &Handler{pgStore, owners, precision, logger}
 }
 
 const pageSize = 100
 
 // GetVehicleTrips returns a page of the given vehicle's trips.
 //
-//	@Description	Lists vehicle trips.
+//	@Description	Lists vehicle trips. Without the all-time location privilege, positions are left out.
 //	@Produce		json
 //	@Security		BearerAuth
-//	@Param			tokenId	path		int	true	"Vehicle token id"
-//	@Param			page	query		int	false	"Page of trips to retrieve. Defaults to 1."
-//	@Success		200		{object}	types.VehicleTrips
+//	@Param			tokenId		path		int		true	"Vehicle token id"
+//	@Param			page		query		int		false	"Page of trips to retrieve. Defaults to 1."
+//	@Param			purpose		query		string	false	"Only list trips with this purpose: business, personal, commute, custom or the name of a custom purpose."
+//	@Param			precision	query		string	false	"Coarsen positions to full, 100m, 1km, or geohash cells with gh7, gh6 or gh5. Positions are never finer than the service allows."
+//	@Success		200			{object}	types.VehicleTrips
 //	@Router			/vehicle/{tokenId}/trips [get]
 func (h *Handler) GetVehicleTrips(c *fiber.Ctx) error {
 	rawTokenID := c.Params("tokenID")
@@ -47,26 +57,50 @@ func (h *Handler) GetVehicleTrips(c *fiber.Ctx) error {
 		return fiber.NewError(fiber.StatusBadRequest, "Couldn't parse query params.")
 	}
 
-	totalCount, err := models.Trips(
-		models.TripWhere.VehicleTokenID.EQ(tokenID),
-	).Count(c.Context(), h.pg.DB.DBS().Reader)
+	mask, err := tripMasker(c, h.pg, h.owners, tokenID, h.precision)
+	if err != nil {
+		return err
+	}
+
+	filter := []qm.QueryMod{models.TripWhere.VehicleTokenID.EQ(tokenID)}
+	if p.Purpose != "" {
+		if slices.Contains(mileage.Purposes, p.Purpose) {
+			filter = append(filter, models.TripWhere.Purpose.EQ(null.StringFrom(p.Purpose)))
+		} else {
+			filter = append(filter, models.TripWhere.CustomPurpose.EQ(null.StringFrom(p.Purpose)))
+		}
+	}
+
+	totalCount, err := models.Trips(filter...).Count(c.UserContext(), h.pg.DB.DBS().Reader)
 	if err != nil {
 		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
 	}
 
 	start := time.Now()
-	trips, err := models.Trips(
-		models.TripWhere.VehicleTokenID.EQ(tokenID),
+	trips, err := models.Trips(append(filter,
 		models.TripWhere.EndTime.IsNotNull(),
 		qm.OrderBy(models.TripColumns.EndTime+" DESC"),
 		qm.Limit(pageSize),
 		qm.Offset((p.Page-1)*pageSize),
-	).All(c.Context(), h.pg.DB.DBS().Reader)
+	)...).All(c.UserContext(), h.pg.DB.DBS().Reader)
 	if err != nil {
 		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
 	}
 	h.logger.Info().Int("vehicleTokenId", tokenID).Str("duration", time.Since(start).String()).Msg("Ran trips query.")
 
+	var from, to null.Time
+	for _, trp := range trips {
+		if !from.Valid || trp.StartTime.Before(from.Time) {
+			from = null.TimeFrom(trp.StartTime)
+		}
+		if !to.Valid || trp.EndTime.Time.After(to.Time) {
+			to = trp.EndTime
+		}
+	}
+	if err := recordAccess(c, h.pg, tokenID, from, to, null.IntFrom(len(trips))); err != nil {
+		return err
+	}
+
 	resp := types.VehicleTrips{
 		Trips:       make([]types.TripDetails, len(trips)),
 		CurrentPage: p.Page,
@@ -74,24 +108,34 @@ func (h *Handler) GetVehicleTrips(c *fiber.Ctx) error {
 	}
 
 	for i, trp := range trips {
-		resp.Trips[i] = types.TripDetails{
-			ID: trp.ID,
-			Start: types.TripStart{
-				Time:              trp.StartTime,
-				Location:          nullLocationToAPI(trp.StartPosition),
-				EstimatedLocation: nullLocationToAPI(trp.StartPositionEstimate),
-			},
-			End: types.TripEnd{
-				Time:     trp.EndTime.Time,
-				Location: nullLocationToAPI(trp.EndPosition),
-			},
-			Dropped: trp.DroppedData,
-		}
+		mask(trp)
+		resp.Trips[i] = tripToAPI(trp)
 	}
 
 	return c.JSON(resp)
 }
 
+func tripToAPI(trp *models.Trip) types.TripDetails {
+	return types.TripDetails{
+		ID: trp.ID,
+		Start: types.TripStart{
+			Time:              trp.StartTime,
+			Location:          nullLocationToAPI(trp.StartPosition),
+			EstimatedLocation: nullLocationToAPI(trp.StartPositionEstimate),
+		},
+		End: types.TripEnd{
+			Time:     trp.EndTime.Time,
+			Location: nullLocationToAPI(trp.EndPosition),
+		},
+		Dropped:       trp.DroppedData,
+		DistanceKm:    trp.DistanceKM.Ptr(),
+		Purpose:       trp.Purpose.String,
+		CustomPurpose: trp.CustomPurpose.String,
+		Note:          trp.Note.String,
+		SegmentIDs:    trp.SegmentIds,
+	}
+}
+
 func validateQueryParams(p *Params, c *fiber.Ctx) error {
 	err := c.QueryParser(p)
 	if err != nil {
@@ -105,7 +149,8 @@ func validateQueryParams(p *Params, c *fiber.Ctx) error {
 }
 
 type Params struct {
-	Page int `query:"page"`
+	Page    int    `query:"page"`
+	Purpose string `query:"purpose"`
 }
 
 func nullLocationToAPI(l pgeo.NullPoint) *types.Location {
@@ -0,0 +1,70 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/DIMO-Network/trips-api/internal/api/types"
+	"github.com/DIMO-Network/trips-api/internal/privacy"
+	pg_store "github.com/DIMO-Network/trips-api/internal/services/pg"
+	"github.com/DIMO-Network/trips-api/internal/test"
+	"github.com/DIMO-Network/trips-api/models"
+	"github.com/gofiber/fiber/v2"
+	"github.com/rs/zerolog"
+	"github.com/segmentio/ksuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/types/pgeo"
+)
+
+const migrationsDirRelPath = "../../migrations"
+
+// Trips are masked with the vehicle's privacy zones for everyone but its owner.
+func Test_GetVehicleTripsMasked(t *testing.T) {
+	ctx := context.Background()
+	pdb := test.StartContainerDatabase(ctx, t, migrationsDirRelPath)
+	test.CreateVehicles(ctx, t, pdb, 1)
+
+	home := pgeo.NewPoint(-73.98, 40.75)
+	zone := models.PrivacyZone{ID: ksuid.New().String(), VehicleTokenID: 1, Name: "Home", Center: home, RadiusM: 300, Mask: "snap"}
+	require.NoError(t, zone.Insert(ctx, pdb.DBS().Writer, boil.Infer()))
+
+	start := time.Date(2024, 3, 1, 8, 0, 0, 0, time.UTC)
+	trp := models.Trip{
+		ID:             ksuid.New().String(),
+		VehicleTokenID: 1,
+		StartTime:      start,
+		EndTime:        null.TimeFrom(start.Add(time.Hour)),
+		StartPosition:  pgeo.NewNullPoint(pgeo.NewPoint(-73.9805, 40.7503), true),
+		EndPosition:    pgeo.NewNullPoint(pgeo.NewPoint(-73.99821, 40.75445), true),
+	}
+	require.NoError(t, trp.Insert(ctx, pdb.DBS().Writer, boil.Infer()))
+
+	h := NewHandler(&pg_store.Store{DB: pdb}, fakeOwners{owner: "0xABC"}, privacy.PrecisionFull, &zerolog.Logger{})
+	app := fiber.New()
+	app.Get("/vehicle/:tokenID/trips", withToken, h.GetVehicleTrips)
+
+	for name, c := range map[string]struct {
+		address string
+		start   types.Location
+	}{
+		"owner":     {"0xabc", types.Location{Latitude: 40.7503, Longitude: -73.9805}},
+		"other app": {"0xdef", types.Location{Latitude: home.Y, Longitude: home.X}},
+	} {
+		resp, err := app.Test(httptest.NewRequest("GET", "/vehicle/1/trips?address="+c.address, nil))
+		require.NoError(t, err)
+		require.Equal(t, fiber.StatusOK, resp.StatusCode, name)
+
+		var body types.VehicleTrips
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+		if assert.Len(t, body.Trips, 1, name) {
+			assert.Equal(t, &c.start, body.Trips[0].Start.Location, name)
+			assert.Equal(t, &types.Location{Latitude: 40.75445, Longitude: -73.99821}, body.Trips[0].End.Location, name)
+		}
+	}
+}
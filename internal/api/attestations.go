@@ -0,0 +1,89 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"math"
+	"strconv"
+
+	"github.com/DIMO-Network/trips-api/internal/api/types"
+	"github.com/DIMO-Network/trips-api/internal/attestation"
+	pg_store "github.com/DIMO-Network/trips-api/internal/services/pg"
+	"github.com/DIMO-Network/trips-api/models"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/gofiber/fiber/v2"
+	"github.com/rs/zerolog"
+	"github.com/volatiletech/null/v8"
+)
+
+type AttestationHandler struct {
+	pg     *pg_store.Store
+	signer *attestation.Signer
+	logger *zerolog.Logger
+}
+
+func NewAttestationHandler(pgStore *pg_store.Store, signer *attestation.Signer, logger *zerolog.Logger) *AttestationHandler {
+	return &AttestationHandler{pgStore, signer, logger}
+}
+
+// GetTripAttestation returns a signed attestation of a completed trip.
+//
+//	@Description	Returns an EIP-712 attestation of a completed trip's vehicle, times, distance, archive id and data hash, signed by the service. The typed data can be verified offline against the signer address, for example with eth_signTypedData_v4 tooling. Positions aren't attested, so the non-location privilege is enough.
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			tokenId	path		int		true	"Vehicle token id"
+//	@Param			tripId	path		string	true	"Trip id"
+//	@Success		200		{object}	types.TripAttestation
+//	@Router			/vehicle/{tokenId}/trips/{tripId}/attestation [get]
+func (h *AttestationHandler) GetTripAttestation(c *fiber.Ctx) error {
+	tokenID, err := strconv.Atoi(c.Params("tokenID"))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Couldn't parse vehicle token id.")
+	}
+
+	trp, err := models.Trips(
+		models.TripWhere.ID.EQ(c.Params("tripID")),
+		models.TripWhere.VehicleTokenID.EQ(tokenID),
+	).One(c.UserContext(), h.pg.DB.DBS().Reader)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fiber.NewError(fiber.StatusNotFound, "No such trip.")
+		}
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+	if !trp.EndTime.Valid {
+		return fiber.NewError(fiber.StatusConflict, "Trip hasn't completed.")
+	}
+
+	if err := recordAccess(c, h.pg, tokenID, null.TimeFrom(trp.StartTime), trp.EndTime, null.IntFrom(1)); err != nil {
+		return err
+	}
+
+	at := attestationTrip(trp)
+	sig, err := h.signer.Sign(at)
+	if err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, "Couldn't sign attestation.")
+	}
+
+	return c.JSON(types.TripAttestation{
+		TypedData: attestation.TypedData(h.signer.ChainID(), at),
+		Signer:    h.signer.Address().Hex(),
+		Signature: hexutil.Encode(sig),
+	})
+}
+
+// attestationTrip is the attested summary of the completed trip.
+func attestationTrip(trp *models.Trip) attestation.Trip {
+	at := attestation.Trip{
+		VehicleTokenID: trp.VehicleTokenID,
+		TripID:         trp.ID,
+		Start:          trp.StartTime,
+		End:            trp.EndTime.Time,
+		ArchiveID:      trp.BundlrID.String,
+	}
+	if trp.DistanceKM.Valid && trp.DistanceKM.Float64 > 0 {
+		at.DistanceMeters = uint64(math.Round(trp.DistanceKM.Float64 * 1000))
+	}
+	copy(at.DataHash[:], trp.DataSha256.Bytes)
+	return at
+}
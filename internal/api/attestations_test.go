@@ -0,0 +1,60 @@
+package api
+
+import (
+	"crypto/sha256"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/DIMO-Network/trips-api/internal/attestation"
+	"github.com/DIMO-Network/trips-api/models"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/gofiber/fiber/v2"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/volatiletech/null/v8"
+)
+
+func TestGetTripAttestationValidation(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	h := NewAttestationHandler(nil, attestation.NewSigner(key, 137), &zerolog.Logger{})
+	app := fiber.New()
+	app.Get("/vehicle/:tokenID/trips/:tripID/attestation", h.GetTripAttestation)
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/vehicle/abc/trips/2Y83IHPItgk0uHD7hybGnA776Bo/attestation", nil))
+	require.NoError(t, err)
+	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+}
+
+func TestAttestationTrip(t *testing.T) {
+	start := time.Date(2024, 3, 1, 8, 0, 0, 0, time.UTC)
+	end := start.Add(30 * time.Minute)
+	hash := sha256.Sum256([]byte("data"))
+
+	at := attestationTrip(&models.Trip{
+		ID:             "2Y83IHPItgk0uHD7hybGnA776Bo",
+		VehicleTokenID: 42,
+		StartTime:      start,
+		EndTime:        null.TimeFrom(end),
+		DistanceKM:     null.Float64From(12.3456),
+		BundlrID:       null.StringFrom("O5Xp3eVwHbpJEmmRH9mEtTx0KzWOYgF2xU2cK1x3i2s"),
+		DataSha256:     null.BytesFrom(hash[:]),
+	})
+	assert.Equal(t, attestation.Trip{
+		VehicleTokenID: 42,
+		TripID:         "2Y83IHPItgk0uHD7hybGnA776Bo",
+		Start:          start,
+		End:            end,
+		DistanceMeters: 12346,
+		ArchiveID:      "O5Xp3eVwHbpJEmmRH9mEtTx0KzWOYgF2xU2cK1x3i2s",
+		DataHash:       hash,
+	}, at)
+
+	// Unknown distances, archives and data are attested as zero.
+	at = attestationTrip(&models.Trip{ID: "trip", VehicleTokenID: 42, StartTime: start, EndTime: null.TimeFrom(end)})
+	assert.Zero(t, at.DistanceMeters)
+	assert.Empty(t, at.ArchiveID)
+	assert.Equal(t, [32]byte{}, at.DataHash)
+}
@@ -0,0 +1,111 @@
+package api
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/DIMO-Network/trips-api/internal/api/types"
+	"github.com/DIMO-Network/trips-api/internal/services/consumer"
+	"github.com/DIMO-Network/trips-api/models"
+	"github.com/gofiber/fiber/v2"
+	"github.com/rs/zerolog"
+)
+
+// TripEditor merges and splits trips, archiving their data again.
+type TripEditor interface {
+	MergeTrips(ctx context.Context, tokenID int, tripIDs []string) (*models.Trip, error)
+	SplitTrip(ctx context.Context, tokenID int, tripID string, at time.Time) (models.TripSlice, error)
+}
+
+type EditHandler struct {
+	editor TripEditor
+	logger *zerolog.Logger
+}
+
+func NewEditHandler(editor TripEditor, logger *zerolog.Logger) *EditHandler {
+	return &EditHandler{editor, logger}
+}
+
+// MergeTrips joins consecutive trips that segmentation broke up.
+//
+//	@Description	Merges consecutive completed trips into one, which keeps the id of the first and the segment ids of all of them. The other trips are deleted; their purposes and notes, which must agree, carry over with their history. Requires the commands privilege as well as all-time location.
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			tokenId	path		int					true	"Vehicle token id"
+//	@Param			trips	body		types.MergeTrips	true	"Trips to merge"
+//	@Success		200		{object}	types.TripDetails
+//	@Router			/vehicle/{tokenId}/trips/merge [post]
+func (h *EditHandler) MergeTrips(c *fiber.Ctx) error {
+	tokenID, err := strconv.Atoi(c.Params("tokenID"))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Couldn't parse vehicle token id.")
+	}
+
+	var req types.MergeTrips
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Couldn't parse request body.")
+	}
+
+	trp, err := h.editor.MergeTrips(c.UserContext(), tokenID, req.TripIDs)
+	if err != nil {
+		return h.editError(err, tokenID)
+	}
+
+	return c.JSON(tripToAPI(trp))
+}
+
+// SplitTrip divides a trip that segmentation joined across a stop.
+//
+//	@Description	Splits a completed trip in two at the given time. The first part keeps the trip's id; the second gets a new one. Both keep the trip's segment ids. Requires the commands privilege as well as all-time location.
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			tokenId	path		int				true	"Vehicle token id"
+//	@Param			tripId	path		string			true	"Trip id"
+//	@Param			split	body		types.SplitTrip	true	"Split time"
+//	@Success		200		{array}		types.TripDetails
+//	@Router			/vehicle/{tokenId}/trips/{tripId}/split [post]
+func (h *EditHandler) SplitTrip(c *fiber.Ctx) error {
+	tokenID, err := strconv.Atoi(c.Params("tokenID"))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Couldn't parse vehicle token id.")
+	}
+
+	var req types.SplitTrip
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Couldn't parse request body.")
+	}
+	if req.Time.IsZero() {
+		return fiber.NewError(fiber.StatusBadRequest, "Split time is required.")
+	}
+
+	trips, err := h.editor.SplitTrip(c.UserContext(), tokenID, c.Params("tripID"), req.Time)
+	if err != nil {
+		return h.editError(err, tokenID)
+	}
+
+	resp := make([]types.TripDetails, len(trips))
+	for i, trp := range trips {
+		resp[i] = tripToAPI(trp)
+	}
+	return c.JSON(resp)
+}
+
+func (h *EditHandler) editError(err error, tokenID int) error {
+	var editErr *consumer.EditError
+	switch {
+	case errors.As(err, &editErr):
+		return fiber.NewError(fiber.StatusBadRequest, editErr.Reason)
+	case errors.Is(err, sql.ErrNoRows):
+		return fiber.NewError(fiber.StatusNotFound, "No such trip.")
+	case errors.Is(err, consumer.ErrTripsChanged):
+		return fiber.NewError(fiber.StatusConflict, "Trips changed while being edited. Try again.")
+	default:
+		h.logger.Err(err).Int("vehicleTokenId", tokenID).Msg("Failed to edit trips.")
+		return fiber.NewError(fiber.StatusInternalServerError, "Failed to edit trips.")
+	}
+}
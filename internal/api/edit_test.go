@@ -0,0 +1,81 @@
+package api
+
+import (
+	"context"
+	"database/sql"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DIMO-Network/trips-api/internal/services/consumer"
+	"github.com/DIMO-Network/trips-api/models"
+	"github.com/gofiber/fiber/v2"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeEditor struct {
+	err error
+}
+
+func (f fakeEditor) MergeTrips(_ context.Context, tokenID int, tripIDs []string) (*models.Trip, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &models.Trip{ID: tripIDs[0], VehicleTokenID: tokenID, SegmentIds: tripIDs}, nil
+}
+
+func (f fakeEditor) SplitTrip(_ context.Context, tokenID int, tripID string, at time.Time) (models.TripSlice, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return models.TripSlice{
+		{ID: tripID, VehicleTokenID: tokenID},
+		{ID: "2Y83IKzqDb8gXqFvV4JYrqZbFkM", VehicleTokenID: tokenID, StartTime: at},
+	}, nil
+}
+
+func TestEditErrors(t *testing.T) {
+	cases := []struct {
+		err    error
+		status int
+	}{
+		{nil, fiber.StatusOK},
+		{&consumer.EditError{Reason: "Trips aren't consecutive."}, fiber.StatusBadRequest},
+		{sql.ErrNoRows, fiber.StatusNotFound},
+		{consumer.ErrTripsChanged, fiber.StatusConflict},
+		{context.DeadlineExceeded, fiber.StatusInternalServerError},
+	}
+
+	for _, c := range cases {
+		h := NewEditHandler(fakeEditor{err: c.err}, &zerolog.Logger{})
+		app := fiber.New()
+		app.Post("/vehicle/:tokenID/trips/merge", h.MergeTrips)
+		app.Post("/vehicle/:tokenID/trips/:tripID/split", h.SplitTrip)
+
+		for path, body := range map[string]string{
+			"/vehicle/1/trips/merge":                             `{"tripIds": ["2Y83IHPItgk0uHD7hybGnA776Bo", "2Y83IKzqDb8gXqFvV4JYrqZbFkM"]}`,
+			"/vehicle/1/trips/2Y83IHPItgk0uHD7hybGnA776Bo/split": `{"time": "2024-03-01T08:10:00Z"}`,
+		} {
+			req := httptest.NewRequest("POST", path, strings.NewReader(body))
+			req.Header.Set("Content-Type", "application/json")
+			resp, err := app.Test(req)
+			require.NoError(t, err)
+			assert.Equal(t, c.status, resp.StatusCode, path)
+		}
+	}
+}
+
+func TestSplitTripNeedsTime(t *testing.T) {
+	h := NewEditHandler(fakeEditor{}, &zerolog.Logger{})
+	app := fiber.New()
+	app.Post("/vehicle/:tokenID/trips/:tripID/split", h.SplitTrip)
+
+	req := httptest.NewRequest("POST", "/vehicle/1/trips/2Y83IHPItgk0uHD7hybGnA776Bo/split", strings.NewReader(`{}`))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(req)
+	require.NoError(t, err)
+	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+}
@@ -0,0 +1,135 @@
+package api
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/DIMO-Network/trips-api/internal/api/types"
+	pg_store "github.com/DIMO-Network/trips-api/internal/services/pg"
+	"github.com/DIMO-Network/trips-api/models"
+	"github.com/gofiber/fiber/v2"
+)
+
+// IdempotencyKeyHeader names a retried erasure, so that it returns the original receipt.
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+const maxIdempotencyKeyLength = 255
+
+// EraseTrips deletes the vehicle's trips in a range, destroying their encryption keys.
+//
+//	@Description	Deletes the vehicle's trips starting in the range and the keys to their archived data, and returns a receipt. Repeating an erasure of the same range, or with the same Idempotency-Key, returns the original receipt with status 200. Requests without an end erase up to now and must send an Idempotency-Key. Segments that arrive later for the range are dropped. Requires the commands privilege as well as all-time location, and only the vehicle's owner may erase.
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			tokenId			path		int						true	"Vehicle token id"
+//	@Param			range			body		types.ErasureRequest	true	"Range"
+//	@Param			Idempotency-Key	header		string					false	"Key identifying retries of the erasure, required without an end"
+//	@Success		201				{object}	types.Erasure
+//	@Success		200				{object}	types.Erasure
+//	@Router			/vehicle/{tokenId}/erasures [post]
+func (h *Handler) EraseTrips(c *fiber.Ctx) error {
+	tokenID, err := strconv.Atoi(c.Params("tokenID"))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Couldn't parse vehicle token id.")
+	}
+
+	var req types.ErasureRequest
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Couldn't parse request body.")
+	}
+
+	// Without an end the range runs up to now, which differs on each retry, so such requests
+	// must carry a key to recognize retries by.
+	key := c.Get(IdempotencyKeyHeader)
+	if len(key) > maxIdempotencyKeyLength {
+		return fiber.NewError(fiber.StatusBadRequest, "Idempotency key is too long.")
+	}
+	if req.End == nil && key == "" {
+		return fiber.NewError(fiber.StatusBadRequest, "End is required without an Idempotency-Key header.")
+	}
+
+	now := time.Now()
+	end := now
+	if req.End != nil {
+		if req.End.After(now) {
+			return fiber.NewError(fiber.StatusBadRequest, "End may not be in the future.")
+		}
+		end = *req.End
+	}
+	if req.Start != nil && !req.Start.Before(end) {
+		return fiber.NewError(fiber.StatusBadRequest, "Start must be before end.")
+	}
+
+	subject, clientID := tokenIdentity(c)
+	if subject == "" {
+		return fiber.NewError(fiber.StatusUnauthorized, "Token has no subject.")
+	}
+
+	e, created, err := h.pg.EraseTrips(c.UserContext(), tokenID, req.Start, end, key, pg_store.Editor{Subject: subject, ClientID: clientID, UserAddress: tokenAddress(c)})
+	if err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	if !created && key != "" && !sameRange(e, req) {
+		return fiber.NewError(fiber.StatusUnprocessableEntity, "Idempotency key was already used for a different range.")
+	}
+
+	h.logger.Info().Int("vehicleTokenId", tokenID).Str("erasureId", e.ID).Int("tripCount", e.TripCount).Bool("created", created).Msg("Erased trips.")
+
+	status := fiber.StatusOK
+	if created {
+		status = fiber.StatusCreated
+	}
+	return c.Status(status).JSON(erasureToAPI(e))
+}
+
+// ListErasures returns the receipts of the vehicle's erasures.
+//
+//	@Description	Lists the receipts of the vehicle's erasures, oldest first. Only the vehicle's owner may list them.
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			tokenId	path	int	true	"Vehicle token id"
+//	@Success		200		{array}	types.Erasure
+//	@Router			/vehicle/{tokenId}/erasures [get]
+func (h *Handler) ListErasures(c *fiber.Ctx) error {
+	tokenID, err := strconv.Atoi(c.Params("tokenID"))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Couldn't parse vehicle token id.")
+	}
+
+	erasures, err := h.pg.Erasures(c.UserContext(), tokenID)
+	if err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	resp := make([]types.Erasure, len(erasures))
+	for i, e := range erasures {
+		resp[i] = erasureToAPI(e)
+	}
+	return c.JSON(resp)
+}
+
+// sameRange reports whether the request asks for the receipt's range. A request without an end
+// matches any end.
+func sameRange(e *models.Erasure, req types.ErasureRequest) bool {
+	// Postgres keeps microseconds, so compare at that precision.
+	if req.Start == nil {
+		if e.RangeStart.Valid {
+			return false
+		}
+	} else if !e.RangeStart.Valid || !req.Start.Truncate(time.Microsecond).Equal(e.RangeStart.Time) {
+		return false
+	}
+	return req.End == nil || req.End.Truncate(time.Microsecond).Equal(e.RangeEnd)
+}
+
+func erasureToAPI(e *models.Erasure) types.Erasure {
+	return types.Erasure{
+		ID:         e.ID,
+		Start:      e.RangeStart.Ptr(),
+		End:        e.RangeEnd,
+		TripCount:  e.TripCount,
+		ArchiveIDs: e.ArchiveIds,
+		CreatedAt:  e.CreatedAt,
+	}
+}
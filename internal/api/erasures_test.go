@@ -0,0 +1,92 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DIMO-Network/trips-api/internal/api/types"
+	"github.com/DIMO-Network/trips-api/internal/privacy"
+	pg_store "github.com/DIMO-Network/trips-api/internal/services/pg"
+	"github.com/DIMO-Network/trips-api/internal/test"
+	"github.com/DIMO-Network/trips-api/models"
+	"github.com/gofiber/fiber/v2"
+	"github.com/rs/zerolog"
+	"github.com/segmentio/ksuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+)
+
+func TestEraseTripsValidation(t *testing.T) {
+	h := NewHandler(nil, nil, privacy.PrecisionFull, &zerolog.Logger{})
+	app := fiber.New()
+	app.Post("/vehicle/:tokenID/erasures", h.EraseTrips)
+
+	future := time.Now().Add(time.Hour).Format(time.RFC3339)
+	for body, status := range map[string]int{
+		`[]`: fiber.StatusBadRequest,
+		// Up to now, with no key to recognize retries by.
+		`{}`:                          fiber.StatusBadRequest,
+		`{"end": "` + future + `"}`:   fiber.StatusBadRequest,
+		`{"start": "` + future + `"}`: fiber.StatusBadRequest,
+		`{"start": "2024-03-01T00:00:00Z", "end": "2024-03-01T00:00:00Z"}`: fiber.StatusBadRequest,
+		`{"start": "2024-03-02T00:00:00Z", "end": "2024-03-01T00:00:00Z"}`: fiber.StatusBadRequest,
+		// Valid, but there is no token to attribute the erasure to.
+		`{"start": "2024-03-01T00:00:00Z", "end": "2024-03-02T00:00:00Z"}`: fiber.StatusUnauthorized,
+	} {
+		req := httptest.NewRequest("POST", "/vehicle/1/erasures", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := app.Test(req)
+		require.NoError(t, err)
+		assert.Equal(t, status, resp.StatusCode, body)
+	}
+}
+
+// Erasures up to now are retried with the same key and get the original receipt.
+func Test_EraseTripsRetry(t *testing.T) {
+	ctx := context.Background()
+	pdb := test.StartContainerDatabase(ctx, t, migrationsDirRelPath)
+	test.CreateVehicles(ctx, t, pdb, 1)
+
+	start := time.Date(2024, 3, 1, 8, 0, 0, 0, time.UTC)
+	trp := models.Trip{ID: ksuid.New().String(), VehicleTokenID: 1, StartTime: start, EndTime: null.TimeFrom(start.Add(time.Hour))}
+	require.NoError(t, trp.Insert(ctx, pdb.DBS().Writer, boil.Infer()))
+
+	h := NewHandler(&pg_store.Store{DB: pdb}, nil, privacy.PrecisionFull, &zerolog.Logger{})
+	app := fiber.New()
+	app.Post("/vehicle/:tokenID/erasures", withToken, h.EraseTrips)
+
+	erase := func(body string) (int, types.Erasure) {
+		req := httptest.NewRequest("POST", "/vehicle/1/erasures?address=0xabc", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set(IdempotencyKeyHeader, "erase-everything")
+		resp, err := app.Test(req)
+		require.NoError(t, err)
+		var e types.Erasure
+		if resp.StatusCode < 300 {
+			require.NoError(t, json.NewDecoder(resp.Body).Decode(&e))
+		}
+		return resp.StatusCode, e
+	}
+
+	status, first := erase(`{}`)
+	require.Equal(t, fiber.StatusCreated, status)
+	assert.Equal(t, 1, first.TripCount)
+
+	status, again := erase(`{}`)
+	require.Equal(t, fiber.StatusOK, status)
+	assert.Equal(t, first, again)
+
+	count, err := models.Erasures().Count(ctx, pdb.DBS().Reader)
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, count)
+
+	// The key can't be reused for another range.
+	status, _ = erase(`{"start": "2024-01-01T00:00:00Z"}`)
+	assert.Equal(t, fiber.StatusUnprocessableEntity, status)
+}
@@ -0,0 +1,57 @@
+package api
+
+import (
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	HTTPRequestTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "trips_api",
+			Subsystem: "http",
+			Name:      "requests_total",
+			Help:      "The total number of API requests, by route and response status.",
+		},
+		[]string{"method", "route", "status"},
+	)
+
+	HTTPRequestDuration = promauto.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: "trips_api",
+			Subsystem: "http",
+			Name:      "request_duration_seconds",
+			Help:      "The distribution of API request durations in seconds.",
+			Buckets:   []float64{0.01, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10},
+		},
+		[]string{"method", "route"},
+	)
+)
+
+// Metrics is middleware that records request counts and durations. Routes are labeled by
+// their pattern rather than the request path, so that token ids don't explode cardinality.
+func Metrics(c *fiber.Ctx) error {
+	start := time.Now()
+	err := c.Next()
+
+	status := c.Response().StatusCode()
+	if err != nil {
+		// The error handler hasn't set the status yet.
+		status = fiber.StatusInternalServerError
+		var fe *fiber.Error
+		if errors.As(err, &fe) {
+			status = fe.Code
+		}
+	}
+
+	route := c.Route().Path
+	HTTPRequestTotal.WithLabelValues(c.Method(), route, strconv.Itoa(status)).Inc()
+	HTTPRequestDuration.WithLabelValues(c.Method(), route).Observe(time.Since(start).Seconds())
+
+	return err
+}
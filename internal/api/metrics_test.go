@@ -0,0 +1,29 @@
+package api
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMetricsLabelsByRoute(t *testing.T) {
+	app := fiber.New()
+	app.Use(Metrics)
+	app.Get("/v1/vehicle/:tokenID/trips", func(c *fiber.Ctx) error {
+		if c.Params("tokenID") == "bad" {
+			return fiber.NewError(fiber.StatusBadRequest, "Couldn't parse vehicle token id.")
+		}
+		return c.SendString("ok")
+	})
+
+	for _, path := range []string{"/v1/vehicle/1/trips", "/v1/vehicle/2/trips", "/v1/vehicle/bad/trips"} {
+		_, err := app.Test(httptest.NewRequest("GET", path, nil))
+		assert.NoError(t, err)
+	}
+
+	assert.Equal(t, 2.0, testutil.ToFloat64(HTTPRequestTotal.WithLabelValues("GET", "/v1/vehicle/:tokenID/trips", "200")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(HTTPRequestTotal.WithLabelValues("GET", "/v1/vehicle/:tokenID/trips", "400")))
+}
@@ -0,0 +1,117 @@
+package api
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/DIMO-Network/trips-api/internal/mileage"
+	"github.com/DIMO-Network/trips-api/models"
+	"github.com/gofiber/fiber/v2"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+)
+
+const (
+	maxMileageLogRange = 366 * 24 * time.Hour
+	maxMileageLogTrips = 10000
+)
+
+type MileageLogParams struct {
+	Format   string `query:"format"`
+	Units    string `query:"units"`
+	Timezone string `query:"timezone"`
+	Start    string `query:"start"`
+	End      string `query:"end"`
+}
+
+// GetMileageLog exports the vehicle's completed trips as a mileage log.
+//
+//	@Description	Exports completed trips starting in the range as a mileage log, with date, times, coordinates, distance, duration and purpose.
+//	@Produce		text/csv
+//	@Produce		application/pdf
+//	@Security		BearerAuth
+//	@Param			tokenId		path		int		true	"Vehicle token id"
+//	@Param			format		query		string	false	"csv or pdf. Defaults to csv."
+//	@Param			units		query		string	false	"Distance unit, km or mi. Defaults to km."
+//	@Param			timezone	query		string	false	"IANA time zone for dates and times. Defaults to UTC."
+//	@Param			start		query		string	false	"RFC 3339 start of the range. Defaults to a month before the end."
+//	@Param			end			query		string	false	"RFC 3339 end of the range. Defaults to now."
+//	@Param			precision	query		string	false	"Coarsen coordinates to full, 100m, 1km, or geohash cells with gh7, gh6 or gh5."
+//	@Success		200			{file}		file
+//	@Router			/vehicle/{tokenId}/trips/mileage-log [get]
+func (h *Handler) GetMileageLog(c *fiber.Ctx) error {
+	tokenID, err := strconv.Atoi(c.Params("tokenID"))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Couldn't parse vehicle token id.")
+	}
+
+	var p MileageLogParams
+	if err := c.QueryParser(&p); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Couldn't parse query params.")
+	}
+
+	if p.Format == "" {
+		p.Format = "csv"
+	}
+	if p.Format != "csv" && p.Format != "pdf" {
+		return fiber.NewError(fiber.StatusBadRequest, "Format must be csv or pdf.")
+	}
+	if p.Units == "" {
+		p.Units = mileage.UnitKilometers
+	}
+	if p.Units != mileage.UnitKilometers && p.Units != mileage.UnitMiles {
+		return fiber.NewError(fiber.StatusBadRequest, "Units must be km or mi.")
+	}
+
+	loc, err := parseTimezone(p.Timezone)
+	if err != nil {
+		return err
+	}
+	start, end, err := parseTimeRange(p.Start, p.End, func(end time.Time) time.Time { return end.AddDate(0, -1, 0) })
+	if err != nil {
+		return err
+	}
+	if end.Sub(start) > maxMileageLogRange {
+		return fiber.NewError(fiber.StatusBadRequest, "Range may not exceed a year.")
+	}
+
+	mask, err := tripMasker(c, h.pg, h.owners, tokenID, h.precision)
+	if err != nil {
+		return err
+	}
+
+	trips, err := models.Trips(
+		models.TripWhere.VehicleTokenID.EQ(tokenID),
+		models.TripWhere.EndTime.IsNotNull(),
+		models.TripWhere.StartTime.GTE(start),
+		models.TripWhere.StartTime.LT(end),
+		qm.OrderBy(models.TripColumns.StartTime),
+		qm.Limit(maxMileageLogTrips+1),
+	).All(c.UserContext(), h.pg.DB.DBS().Reader)
+	if err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+	if len(trips) > maxMileageLogTrips {
+		return fiber.NewError(fiber.StatusBadRequest, "Too many trips, narrow the range.")
+	}
+
+	for _, trp := range trips {
+		mask(trp)
+	}
+
+	if err := recordAccess(c, h.pg, tokenID, null.TimeFrom(start), null.TimeFrom(end), null.IntFrom(len(trips))); err != nil {
+		return err
+	}
+
+	log := mileage.New(tokenID, loc, p.Units, start, end, trips)
+	fileName := fmt.Sprintf("mileage-%d-%s-%s.%s", tokenID, log.From.Format(time.DateOnly), log.To.Format(time.DateOnly), p.Format)
+	c.Attachment(fileName)
+
+	if p.Format == "pdf" {
+		c.Type("pdf")
+		return mileage.WritePDF(c.Response().BodyWriter(), log)
+	}
+	c.Set(fiber.HeaderContentType, "text/csv; charset=utf-8")
+	return mileage.WriteCSV(c.Response().BodyWriter(), log)
+}
@@ -0,0 +1,30 @@
+package api
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/DIMO-Network/trips-api/internal/privacy"
+	"github.com/gofiber/fiber/v2"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetMileageLogValidation(t *testing.T) {
+	h := NewHandler(nil, nil, privacy.PrecisionFull, &zerolog.Logger{})
+	app := fiber.New()
+	app.Get("/vehicle/:tokenID/trips/mileage-log", h.GetMileageLog)
+
+	for _, query := range []string{
+		"format=xlsx",
+		"units=furlongs",
+		"timezone=Mars/Olympus_Mons",
+		"start=2024-03-02T00:00:00Z&end=2024-03-01T00:00:00Z",
+		"start=2022-01-01T00:00:00Z&end=2024-01-01T00:00:00Z",
+	} {
+		resp, err := app.Test(httptest.NewRequest("GET", "/vehicle/1/trips/mileage-log?"+query, nil))
+		require.NoError(t, err)
+		assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode, query)
+	}
+}
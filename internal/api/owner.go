@@ -0,0 +1,53 @@
+package api
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// Owners tells whether a user owns a vehicle. Privileges can be granted to any app, so
+// seeing through privacy zones and managing them is left to the owner.
+type Owners interface {
+	IsOwner(ctx context.Context, tokenID int, users ...string) (bool, error)
+}
+
+// isOwner reports whether the request's privilege token was issued to the vehicle's owner,
+// going by its ethereum_address claim. The token's subject is the vehicle itself, so a token
+// without the claim is never the owner's. Without owners to ask, nobody owns anything. Errors
+// are fiber errors.
+func isOwner(c *fiber.Ctx, owners Owners, tokenID int) (bool, error) {
+	address := tokenAddress(c)
+	if owners == nil || address == "" {
+		return false, nil
+	}
+	ok, err := owners.IsOwner(c.UserContext(), tokenID, address)
+	if err != nil {
+		return false, fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+	return ok, nil
+}
+
+// OwnerOnly lets through only requests whose privilege token was issued to the owner of the
+// vehicle in the tokenID path parameter. Tokens without an ethereum_address claim are refused,
+// as there is no way to tell whose they are.
+func OwnerOnly(owners Owners) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		tokenID, err := strconv.Atoi(c.Params("tokenID"))
+		if err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, "Couldn't parse vehicle token id.")
+		}
+		if tokenAddress(c) == "" {
+			return fiber.NewError(fiber.StatusForbidden, "Token has no ethereum_address claim, so ownership can't be checked.")
+		}
+		ok, err := isOwner(c, owners, tokenID)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return fiber.NewError(fiber.StatusForbidden, "Only the vehicle's owner may do this.")
+		}
+		return c.Next()
+	}
+}
@@ -0,0 +1,98 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/DIMO-Network/shared/middleware/privilegetoken"
+	"github.com/DIMO-Network/shared/privileges"
+	"github.com/DIMO-Network/trips-api/internal/privacy"
+	"github.com/DIMO-Network/trips-api/models"
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/volatiletech/sqlboiler/v4/types/pgeo"
+)
+
+// fakeOwners knows the owner of vehicle 1.
+type fakeOwners struct {
+	owner string
+}
+
+func (f fakeOwners) IsOwner(_ context.Context, tokenID int, users ...string) (bool, error) {
+	for _, u := range users {
+		if tokenID == 1 && u != "" && strings.EqualFold(u, f.owner) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// withToken stands in for the privilege middleware, issuing the token to the address in the
+// address query parameter with all-time location and commands. Without the parameter the
+// token has no ethereum_address claim.
+func withToken(c *fiber.Ctx) error {
+	claims := jwt.MapClaims{
+		"sub":       "0xbA5738a18d83D41847dfFbDC6101d37C69c9B0cF/1",
+		"client_id": "0x0123",
+	}
+	if address := c.Query("address"); address != "" {
+		claims["ethereum_address"] = address
+	}
+	c.Locals("user", &jwt.Token{Claims: claims})
+	c.Locals("tokenClaims", privilegetoken.CustomClaims{
+		PrivilegeIDs: []privileges.Privilege{privileges.VehicleAllTimeLocation, privileges.VehicleCommands},
+	})
+	return c.Next()
+}
+
+func TestOwnerOnly(t *testing.T) {
+	for name, c := range map[string]struct {
+		owners Owners
+		path   string
+		status int
+	}{
+		"owner":           {fakeOwners{owner: "0xABC"}, "/vehicle/1?address=0xabc", fiber.StatusOK},
+		"commands holder": {fakeOwners{owner: "0xABC"}, "/vehicle/1?address=0xdef", fiber.StatusForbidden},
+		"other vehicle":   {fakeOwners{owner: "0xABC"}, "/vehicle/2?address=0xabc", fiber.StatusForbidden},
+		"no identity":     {nil, "/vehicle/1?address=0xabc", fiber.StatusForbidden},
+		"no address":      {fakeOwners{owner: "0xABC"}, "/vehicle/1", fiber.StatusForbidden},
+		// The subject names the vehicle, not its owner.
+		"subject":          {fakeOwners{owner: "0xbA5738a18d83D41847dfFbDC6101d37C69c9B0cF/1"}, "/vehicle/1", fiber.StatusForbidden},
+		"invalid token id": {fakeOwners{owner: "0xABC"}, "/vehicle/x?address=0xabc", fiber.StatusBadRequest},
+	} {
+		app := fiber.New()
+		app.Get("/vehicle/:tokenID", withToken, OwnerOnly(c.owners), func(c *fiber.Ctx) error {
+			return c.SendStatus(fiber.StatusOK)
+		})
+
+		resp, err := app.Test(httptest.NewRequest("GET", c.path, nil))
+		require.NoError(t, err)
+		assert.Equal(t, c.status, resp.StatusCode, name)
+	}
+}
+
+func TestTripMaskerOwner(t *testing.T) {
+	// The owner's trips aren't masked, so no zones are loaded and no store is needed.
+	app := fiber.New()
+	app.Get("/vehicle/:tokenID", withToken, func(c *fiber.Ctx) error {
+		mask, err := tripMasker(c, nil, fakeOwners{owner: "0xABC"}, 1, privacy.PrecisionFull)
+		if err != nil {
+			return err
+		}
+		trp := &models.Trip{StartPosition: pgeo.NewNullPoint(pgeo.NewPoint(-73.98123, 40.75123), true)}
+		mask(trp)
+		return c.SendString(fmt.Sprint(trp.StartPosition.Valid, trp.StartPosition.X, trp.StartPosition.Y))
+	})
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/vehicle/1?address=0xabc", nil))
+	require.NoError(t, err)
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Equal(t, "true -73.98123 40.75123", string(body))
+}
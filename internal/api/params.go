@@ -0,0 +1,44 @@
+package api
+
+import (
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// parseTimezone loads the IANA time zone named by raw, defaulting to UTC.
+func parseTimezone(raw string) (*time.Location, error) {
+	if raw == "" {
+		return time.UTC, nil
+	}
+	loc, err := time.LoadLocation(raw)
+	if err != nil {
+		return nil, fiber.NewError(fiber.StatusBadRequest, "Unknown time zone.")
+	}
+	return loc, nil
+}
+
+// parseTimeRange parses optional RFC 3339 start and end query params. The end defaults to now
+// and the start to defaultStart of the end.
+func parseTimeRange(rawStart, rawEnd string, defaultStart func(end time.Time) time.Time) (time.Time, time.Time, error) {
+	var err error
+
+	end := time.Now()
+	if rawEnd != "" {
+		if end, err = time.Parse(time.RFC3339, rawEnd); err != nil {
+			return time.Time{}, time.Time{}, fiber.NewError(fiber.StatusBadRequest, "Couldn't parse end.")
+		}
+	}
+
+	start := defaultStart(end)
+	if rawStart != "" {
+		if start, err = time.Parse(time.RFC3339, rawStart); err != nil {
+			return time.Time{}, time.Time{}, fiber.NewError(fiber.StatusBadRequest, "Couldn't parse start.")
+		}
+	}
+
+	if !start.Before(end) {
+		return time.Time{}, time.Time{}, fiber.NewError(fiber.StatusBadRequest, "Start must be before end.")
+	}
+	return start, end, nil
+}
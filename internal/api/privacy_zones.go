@@ -0,0 +1,175 @@
+package api
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/DIMO-Network/trips-api/internal/api/types"
+	"github.com/DIMO-Network/trips-api/internal/privacy"
+	pg_store "github.com/DIMO-Network/trips-api/internal/services/pg"
+	"github.com/DIMO-Network/trips-api/models"
+	"github.com/gofiber/fiber/v2"
+	"github.com/rs/zerolog"
+	"github.com/segmentio/ksuid"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/types/pgeo"
+)
+
+const (
+	maxPrivacyZonesPerVehicle = 10
+	minPrivacyZoneRadius      = 50
+	maxPrivacyZoneRadius      = 5000
+	maxPrivacyZoneNameLength  = 64
+)
+
+type PrivacyZoneHandler struct {
+	pg     *pg_store.Store
+	logger *zerolog.Logger
+}
+
+func NewPrivacyZoneHandler(pgStore *pg_store.Store, logger *zerolog.Logger) *PrivacyZoneHandler {
+	return &PrivacyZoneHandler{pgStore, logger}
+}
+
+// CreatePrivacyZone adds a privacy zone to the vehicle.
+//
+//	@Description	Adds a privacy zone. Trip positions inside it are snapped to its center or omitted for everyone but the vehicle's owner. Only the owner may manage zones.
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			tokenId	path		int							true	"Vehicle token id"
+//	@Param			zone	body		types.PrivacyZoneRequest	true	"Zone"
+//	@Success		201		{object}	types.PrivacyZone
+//	@Router			/vehicle/{tokenId}/privacy-zones [post]
+func (h *PrivacyZoneHandler) CreatePrivacyZone(c *fiber.Ctx) error {
+	tokenID, err := strconv.Atoi(c.Params("tokenID"))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Couldn't parse vehicle token id.")
+	}
+
+	var req types.PrivacyZoneRequest
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Couldn't parse request body.")
+	}
+	if err := validatePrivacyZone(&req); err != nil {
+		return err
+	}
+
+	count, err := models.PrivacyZones(
+		models.PrivacyZoneWhere.VehicleTokenID.EQ(tokenID),
+	).Count(c.UserContext(), h.pg.DB.DBS().Reader)
+	if err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+	if count >= maxPrivacyZonesPerVehicle {
+		return fiber.NewError(fiber.StatusConflict, "Vehicle already has the maximum number of privacy zones.")
+	}
+
+	z := models.PrivacyZone{
+		ID:             ksuid.New().String(),
+		VehicleTokenID: tokenID,
+		Name:           req.Name,
+		Center:         pgeo.NewPoint(req.Center.Longitude, req.Center.Latitude),
+		RadiusM:        req.RadiusMeters,
+		Mask:           req.Mask,
+	}
+	if err := z.Insert(c.UserContext(), h.pg.DB.DBS().Writer, boil.Infer()); err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(privacyZoneToAPI(&z))
+}
+
+// ListPrivacyZones returns the vehicle's privacy zones.
+//
+//	@Description	Lists the privacy zones of a vehicle.
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			tokenId	path	int	true	"Vehicle token id"
+//	@Success		200		{array}	types.PrivacyZone
+//	@Router			/vehicle/{tokenId}/privacy-zones [get]
+func (h *PrivacyZoneHandler) ListPrivacyZones(c *fiber.Ctx) error {
+	tokenID, err := strconv.Atoi(c.Params("tokenID"))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Couldn't parse vehicle token id.")
+	}
+
+	zones, err := h.pg.PrivacyZones(c.UserContext(), tokenID)
+	if err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	resp := make([]types.PrivacyZone, len(zones))
+	for i, z := range zones {
+		resp[i] = privacyZoneToAPI(z)
+	}
+
+	return c.JSON(resp)
+}
+
+// DeletePrivacyZone removes one of the vehicle's privacy zones.
+//
+//	@Description	Deletes a privacy zone.
+//	@Security		BearerAuth
+//	@Param			tokenId	path	int		true	"Vehicle token id"
+//	@Param			zoneId	path	string	true	"Zone id"
+//	@Success		204
+//	@Router			/vehicle/{tokenId}/privacy-zones/{zoneId} [delete]
+func (h *PrivacyZoneHandler) DeletePrivacyZone(c *fiber.Ctx) error {
+	tokenID, err := strconv.Atoi(c.Params("tokenID"))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Couldn't parse vehicle token id.")
+	}
+
+	n, err := models.PrivacyZones(
+		models.PrivacyZoneWhere.ID.EQ(c.Params("zoneID")),
+		models.PrivacyZoneWhere.VehicleTokenID.EQ(tokenID),
+	).DeleteAll(c.UserContext(), h.pg.DB.DBS().Writer)
+	if err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+	if n == 0 {
+		return fiber.NewError(fiber.StatusNotFound, "No such privacy zone.")
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+func validatePrivacyZone(req *types.PrivacyZoneRequest) error {
+	req.Name = strings.TrimSpace(req.Name)
+	if req.Name == "" {
+		return fiber.NewError(fiber.StatusBadRequest, "Name is required.")
+	}
+	if utf8.RuneCountInString(req.Name) > maxPrivacyZoneNameLength {
+		return fiber.NewError(fiber.StatusBadRequest, "Name is too long.")
+	}
+
+	if req.Center.Latitude < -90 || req.Center.Latitude > 90 || req.Center.Longitude < -180 || req.Center.Longitude > 180 {
+		return fiber.NewError(fiber.StatusBadRequest, "Center is out of range.")
+	}
+	if req.RadiusMeters < minPrivacyZoneRadius || req.RadiusMeters > maxPrivacyZoneRadius {
+		return fiber.NewError(fiber.StatusBadRequest, "Radius must be between 50 and 5000 meters.")
+	}
+
+	if req.Mask == "" {
+		req.Mask = privacy.MaskSnap
+	}
+	if !slices.Contains(privacy.Masks, req.Mask) {
+		return fiber.NewError(fiber.StatusBadRequest, "Mask must be snap or omit.")
+	}
+
+	return nil
+}
+
+func privacyZoneToAPI(z *models.PrivacyZone) types.PrivacyZone {
+	return types.PrivacyZone{
+		ID:           z.ID,
+		Name:         z.Name,
+		Center:       types.Location{Latitude: z.Center.Y, Longitude: z.Center.X},
+		RadiusMeters: z.RadiusM,
+		Mask:         z.Mask,
+		CreatedAt:    z.CreatedAt,
+	}
+}
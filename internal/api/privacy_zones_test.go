@@ -0,0 +1,76 @@
+package api
+
+import (
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/DIMO-Network/shared/middleware/privilegetoken"
+	"github.com/DIMO-Network/shared/privileges"
+	"github.com/DIMO-Network/trips-api/internal/privacy"
+	"github.com/gofiber/fiber/v2"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreatePrivacyZoneValidation(t *testing.T) {
+	h := NewPrivacyZoneHandler(nil, &zerolog.Logger{})
+	app := fiber.New()
+	app.Post("/vehicle/:tokenID/privacy-zones", h.CreatePrivacyZone)
+
+	for _, body := range []string{
+		`{}`,
+		`{"name": " ", "center": {"latitude": 40.75, "longitude": -73.98}, "radiusMeters": 300}`,
+		`{"name": "` + strings.Repeat("a", 65) + `", "center": {"latitude": 40.75, "longitude": -73.98}, "radiusMeters": 300}`,
+		`{"name": "Home", "center": {"latitude": 91, "longitude": -73.98}, "radiusMeters": 300}`,
+		`{"name": "Home", "center": {"latitude": 40.75, "longitude": -181}, "radiusMeters": 300}`,
+		`{"name": "Home", "center": {"latitude": 40.75, "longitude": -73.98}, "radiusMeters": 49}`,
+		`{"name": "Home", "center": {"latitude": 40.75, "longitude": -73.98}, "radiusMeters": 5001}`,
+		`{"name": "Home", "center": {"latitude": 40.75, "longitude": -73.98}, "radiusMeters": 300, "mask": "blur"}`,
+	} {
+		req := httptest.NewRequest("POST", "/vehicle/1/privacy-zones", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := app.Test(req)
+		require.NoError(t, err)
+		assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode, body)
+	}
+}
+
+func TestRedactionFollowsPrivileges(t *testing.T) {
+	app := fiber.New()
+	app.Get("/", func(c *fiber.Ctx) error {
+		ids := []privileges.Privilege{privileges.VehicleNonLocationData}
+		if c.Query("location") != "" {
+			ids = append(ids, privileges.VehicleAllTimeLocation)
+		}
+		c.Locals("tokenClaims", privilegetoken.CustomClaims{PrivilegeIDs: ids})
+		r, err := redaction(c, privacy.Precision100m)
+		if err != nil {
+			return err
+		}
+		return c.JSON(r)
+	})
+
+	for query, expected := range map[string]string{
+		"":                          `{"Hidden": true, "Precision": {"Decimals": 3, "Geohash": 0}}`,
+		"?location=1":               `{"Hidden": false, "Precision": {"Decimals": 3, "Geohash": 0}}`,
+		"?location=1&precision=1km": `{"Hidden": false, "Precision": {"Decimals": 2, "Geohash": 0}}`,
+		"?location=1&precision=gh6": `{"Hidden": false, "Precision": {"Decimals": 0, "Geohash": 6}}`,
+		// Callers can't ask for more than they are allowed.
+		"?location=1&precision=full": `{"Hidden": false, "Precision": {"Decimals": 3, "Geohash": 0}}`,
+		// Cells of about 150 m are coarser than rounding to about 111 m.
+		"?location=1&precision=gh7": `{"Hidden": false, "Precision": {"Decimals": 0, "Geohash": 7}}`,
+	} {
+		resp, err := app.Test(httptest.NewRequest("GET", "/"+query, nil))
+		require.NoError(t, err)
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		assert.JSONEq(t, expected, string(body), query)
+	}
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/?precision=10m", nil))
+	require.NoError(t, err)
+	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+}
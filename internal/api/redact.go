@@ -0,0 +1,55 @@
+package api
+
+import (
+	"github.com/DIMO-Network/shared/privileges"
+	"github.com/DIMO-Network/trips-api/internal/privacy"
+	pg_store "github.com/DIMO-Network/trips-api/internal/services/pg"
+	"github.com/DIMO-Network/trips-api/models"
+	"github.com/gofiber/fiber/v2"
+)
+
+// locationPrivilege lets the holder see trip positions. Without it, they are removed.
+const locationPrivilege = privileges.VehicleAllTimeLocation
+
+// redaction works out what the caller may see of trip positions, given the precision
+// mandated for callers with location access. Callers may ask for a coarser one with the
+// precision query parameter.
+func redaction(c *fiber.Ctx, precision privacy.Precision) (privacy.Redaction, error) {
+	requested, err := privacy.ParsePrecision(c.Query("precision"))
+	if err != nil {
+		return privacy.Redaction{}, fiber.NewError(fiber.StatusBadRequest, "Precision must be full, 100m, 1km, gh7, gh6 or gh5.")
+	}
+
+	return privacy.Redaction{
+		Hidden:    !hasPrivilege(c, locationPrivilege),
+		Precision: privacy.Coarser(precision, requested),
+	}, nil
+}
+
+// tripMasker returns a function that masks the vehicle's trips with its privacy zones, unless
+// the caller owns the vehicle, and then redacts them for the caller. Errors are fiber errors.
+func tripMasker(c *fiber.Ctx, pgStore *pg_store.Store, owners Owners, tokenID int, precision privacy.Precision) (func(*models.Trip), error) {
+	r, err := redaction(c, precision)
+	if err != nil {
+		return nil, err
+	}
+
+	var zones models.PrivacyZoneSlice
+	if !r.Hidden {
+		owner, err := isOwner(c, owners, tokenID)
+		if err != nil {
+			return nil, err
+		}
+		if !owner {
+			zones, err = pgStore.PrivacyZones(c.UserContext(), tokenID)
+			if err != nil {
+				return nil, fiber.NewError(fiber.StatusInternalServerError, err.Error())
+			}
+		}
+	}
+
+	return func(trp *models.Trip) {
+		privacy.MaskTrip(trp, zones)
+		r.Trip(trp)
+	}, nil
+}
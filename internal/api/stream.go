@@ -0,0 +1,197 @@
+package api
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/DIMO-Network/trips-api/internal/privacy"
+	pg_store "github.com/DIMO-Network/trips-api/internal/services/pg"
+	"github.com/DIMO-Network/trips-api/models"
+	"github.com/goccy/go-json"
+	"github.com/gofiber/fiber/v2"
+	"github.com/rs/zerolog"
+	"github.com/valyala/fasthttp"
+	"github.com/volatiletech/null/v8"
+)
+
+const (
+	streamBatchSize    = 100
+	streamKeepAlive    = 15 * time.Second
+	streamQueryTimeout = 10 * time.Second
+	// streamRetry is how soon updates held back behind a transaction still in flight are
+	// read again.
+	streamRetry = time.Second
+)
+
+type StreamHandler struct {
+	pg        *pg_store.Store
+	updates   *pg_store.TripUpdateListener
+	owners    Owners
+	precision privacy.Precision
+	logger    *zerolog.Logger
+}
+
+func NewStreamHandler(pgStore *pg_store.Store, updates *pg_store.TripUpdateListener, owners Owners, precision privacy.Precision, logger *zerolog.Logger) *StreamHandler {
+	return &StreamHandler{pgStore, updates, owners, precision, logger}
+}
+
+// StreamVehicleTrips streams the vehicle's trips as they begin and complete.
+//
+//	@Description	Streams trip updates as Server-Sent Events. Each event is named "begin" or "complete", has the trip as its data and an id that can be sent back as Last-Event-ID to resume, for up to a day.
+//	@Produce		text/event-stream
+//	@Security		BearerAuth
+//	@Param			tokenId			path		int		true	"Vehicle token id"
+//	@Param			Last-Event-ID	header		string	false	"Id of the last event received"
+//	@Param			precision		query		string	false	"Coarsen positions to full, 100m, 1km, or geohash cells with gh7, gh6 or gh5."
+//	@Success		200				{string}	string	"Events whose data is a trip, as in the trips listing"
+//	@Router			/vehicle/{tokenId}/trips/stream [get]
+func (h *StreamHandler) StreamVehicleTrips(c *fiber.Ctx) error {
+	tokenID, err := strconv.Atoi(c.Params("tokenID"))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Couldn't parse vehicle token id.")
+	}
+
+	var lastID int64
+	resume := c.Get("Last-Event-ID")
+	if resume != "" {
+		lastID, err = strconv.ParseInt(resume, 10, 64)
+		if err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, "Couldn't parse Last-Event-ID.")
+		}
+	}
+
+	// The stream outlives the request, so it ends when the token it was opened with expires.
+	expiry, expires := tokenExpiry(c)
+	if expires && !expiry.After(time.Now()) {
+		return fiber.NewError(fiber.StatusUnauthorized, "Token has expired.")
+	}
+
+	r, err := redaction(c, h.precision)
+	if err != nil {
+		return err
+	}
+
+	// The stream carries trips as they are recorded from now on, and any missed since resume.
+	if err := recordAccess(c, h.pg, tokenID, null.TimeFrom(time.Now()), null.Time{}, null.Int{}); err != nil {
+		return err
+	}
+
+	// Zones are reloaded for each batch, so that changes apply to open streams.
+	owner, err := isOwner(c, h.owners, tokenID)
+	if err != nil {
+		return err
+	}
+	masked := !owner
+
+	// Subscribe before finding where to start so that nothing recorded in between is missed.
+	wake, unsubscribe := h.updates.Subscribe(tokenID)
+
+	var cursor pg_store.TripUpdateCursor
+	if resume == "" {
+		cursor, err = h.pg.LatestTripUpdateCursor(c.UserContext())
+	} else {
+		cursor, err = h.pg.TripUpdateCursorAt(c.UserContext(), lastID)
+	}
+	if err != nil {
+		unsubscribe()
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	c.Set(fiber.HeaderContentType, "text/event-stream")
+	c.Set(fiber.HeaderCacheControl, "no-cache")
+	c.Set(fiber.HeaderConnection, "keep-alive")
+	c.Set("X-Accel-Buffering", "no")
+
+	// The writer runs after this handler returns, so it mustn't touch c.
+	c.Context().SetBodyStreamWriter(fasthttp.StreamWriter(func(w *bufio.Writer) {
+		defer unsubscribe()
+
+		keepAlive := time.NewTicker(streamKeepAlive)
+		defer keepAlive.Stop()
+
+		var expired <-chan time.Time
+		if expires {
+			timer := time.NewTimer(time.Until(expiry))
+			defer timer.Stop()
+			expired = timer.C
+		}
+
+		for {
+			var pending bool
+			if cursor, pending, err = h.writeUpdates(w, tokenID, cursor, masked, r); err != nil {
+				h.logger.Debug().Err(err).Int("vehicleTokenId", tokenID).Msg("Trip stream ended.")
+				return
+			}
+			var retry <-chan time.Time
+			if pending {
+				retry = time.After(streamRetry)
+			}
+
+			select {
+			case _, ok := <-wake:
+				if !ok {
+					return
+				}
+			case <-retry:
+			case <-expired:
+				h.logger.Debug().Int("vehicleTokenId", tokenID).Msg("Trip stream token expired.")
+				return
+			case <-keepAlive.C:
+				if _, err := w.WriteString(": keep-alive\n\n"); err != nil {
+					return
+				}
+				if err := w.Flush(); err != nil {
+					return
+				}
+			}
+		}
+	}))
+
+	return nil
+}
+
+// writeUpdates writes every update after the cursor that is ready to be sent and returns the
+// cursor at the last one written, and whether newer updates were held back. If masked, trips
+// are masked with the vehicle's privacy zones before being redacted.
+func (h *StreamHandler) writeUpdates(w *bufio.Writer, tokenID int, cursor pg_store.TripUpdateCursor, masked bool, r privacy.Redaction) (pg_store.TripUpdateCursor, bool, error) {
+	for {
+		ctx, cancel := context.WithTimeout(context.Background(), streamQueryTimeout)
+		updates, pending, err := h.pg.TripUpdatesAfter(ctx, tokenID, cursor, streamBatchSize)
+		var zones models.PrivacyZoneSlice
+		if err == nil && masked && len(updates) != 0 {
+			zones, err = h.pg.PrivacyZones(ctx, tokenID)
+		}
+		cancel()
+		if err != nil {
+			return cursor, false, err
+		}
+
+		for _, u := range updates {
+			privacy.MaskTrip(u.R.Trip, zones)
+			r.Trip(u.R.Trip)
+			if err := writeEvent(w, u); err != nil {
+				return cursor, false, err
+			}
+			cursor = pg_store.TripUpdateCursor{TxID: u.Txid, ID: u.ID}
+		}
+		if err := w.Flush(); err != nil {
+			return cursor, false, err
+		}
+
+		if pending || len(updates) < streamBatchSize {
+			return cursor, pending, nil
+		}
+	}
+}
+
+func writeEvent(w *bufio.Writer, u *models.TripUpdate) error {
+	data, err := json.Marshal(tripToAPI(u.R.Trip))
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", u.ID, u.Kind, data)
+	return err
+}
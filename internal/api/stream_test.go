@@ -0,0 +1,52 @@
+package api
+
+import (
+	"bufio"
+	"bytes"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/DIMO-Network/trips-api/internal/privacy"
+	"github.com/DIMO-Network/trips-api/models"
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/volatiletech/null/v8"
+)
+
+func TestWriteEvent(t *testing.T) {
+	start := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	u := &models.TripUpdate{ID: 42, Kind: "complete"}
+	u.R = u.R.NewStruct()
+	u.R.Trip = &models.Trip{ID: "2cZ4GjK0sbvh7vD4mdPDJhSq1Nt", StartTime: start, EndTime: null.TimeFrom(start.Add(time.Hour))}
+
+	var buf bytes.Buffer
+	w := bufio.NewWriter(&buf)
+	require.NoError(t, writeEvent(w, u))
+	require.NoError(t, w.Flush())
+
+	assert.Equal(t, "id: 42\nevent: complete\ndata: "+
+		`{"id":"2cZ4GjK0sbvh7vD4mdPDJhSq1Nt","start":{"time":"2024-03-01T12:00:00Z"},"end":{"time":"2024-03-01T13:00:00Z"},"droppedData":false}`+
+		"\n\n", buf.String())
+}
+
+func TestStreamExpiredToken(t *testing.T) {
+	// The token is checked before the stream is opened, so no store is needed.
+	h := NewStreamHandler(nil, nil, nil, privacy.PrecisionFull, &zerolog.Logger{})
+
+	app := fiber.New()
+	app.Get("/vehicle/:tokenID/trips/stream", func(c *fiber.Ctx) error {
+		c.Locals("user", &jwt.Token{Claims: jwt.MapClaims{
+			"sub": "0xbA5738a18d83D41847dfFbDC6101d37C69c9B0cF/1",
+			"exp": float64(time.Now().Add(-time.Minute).Unix()),
+		}})
+		return c.Next()
+	}, h.StreamVehicleTrips)
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/vehicle/1/trips/stream", nil))
+	require.NoError(t, err)
+	assert.Equal(t, fiber.StatusUnauthorized, resp.StatusCode)
+}
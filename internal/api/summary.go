@@ -0,0 +1,121 @@
+package api
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/DIMO-Network/trips-api/internal/api/types"
+	pg_store "github.com/DIMO-Network/trips-api/internal/services/pg"
+	"github.com/gofiber/fiber/v2"
+	"github.com/volatiletech/null/v8"
+)
+
+const maxSummaryRange = 2 * 366 * 24 * time.Hour
+
+type SummaryParams struct {
+	Period   string `query:"period"`
+	Timezone string `query:"timezone"`
+	Start    string `query:"start"`
+	End      string `query:"end"`
+}
+
+// GetVehicleTripSummary returns the vehicle's completed trips aggregated by calendar period.
+//
+//	@Description	Summarizes completed trips by the calendar day, week (starting Monday) or month in which they started. Periods without trips are omitted. Trips deleted by retention are counted in buckets marked aggregated, which span a UTC calendar month.
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			tokenId		path		int		true	"Vehicle token id"
+//	@Param			period		query		string	false	"One of day, week or month. Defaults to day."
+//	@Param			timezone	query		string	false	"IANA time zone for the calendar. Defaults to UTC."
+//	@Param			start		query		string	false	"RFC 3339 start of the range. Defaults to 30 days, 12 weeks or 12 months before the end."
+//	@Param			end			query		string	false	"RFC 3339 end of the range. Defaults to now."
+//	@Success		200			{object}	types.TripSummary
+//	@Router			/vehicle/{tokenId}/trips/summary [get]
+func (h *Handler) GetVehicleTripSummary(c *fiber.Ctx) error {
+	tokenID, err := strconv.Atoi(c.Params("tokenID"))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Couldn't parse vehicle token id.")
+	}
+
+	var p SummaryParams
+	if err := c.QueryParser(&p); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Couldn't parse query params.")
+	}
+
+	if p.Period == "" {
+		p.Period = pg_store.PeriodDay
+	}
+	loc, err := parseTimezone(p.Timezone)
+	if err != nil {
+		return err
+	}
+
+	var defaultStart func(time.Time) time.Time
+	switch p.Period {
+	case pg_store.PeriodDay:
+		defaultStart = func(end time.Time) time.Time { return end.AddDate(0, 0, -30) }
+	case pg_store.PeriodWeek:
+		defaultStart = func(end time.Time) time.Time { return end.AddDate(0, 0, -12*7) }
+	case pg_store.PeriodMonth:
+		defaultStart = func(end time.Time) time.Time { return end.AddDate(0, -12, 0) }
+	default:
+		return fiber.NewError(fiber.StatusBadRequest, "Period must be day, week or month.")
+	}
+
+	start, end, err := parseTimeRange(p.Start, p.End, defaultStart)
+	if err != nil {
+		return err
+	}
+	if end.Sub(start) > maxSummaryRange {
+		return fiber.NewError(fiber.StatusBadRequest, "Range may not exceed two years.")
+	}
+
+	buckets, err := h.pg.SummarizeTrips(c.UserContext(), tokenID, p.Period, loc, start, end)
+	if err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	tripCount := 0
+	for _, b := range buckets {
+		tripCount += b.TripCount
+	}
+	if err := recordAccess(c, h.pg, tokenID, null.TimeFrom(start), null.TimeFrom(end), null.IntFrom(tripCount)); err != nil {
+		return err
+	}
+
+	resp := types.TripSummary{
+		Period:   p.Period,
+		Timezone: loc.String(),
+		Buckets:  make([]types.SummaryBucket, len(buckets)),
+	}
+	for i, b := range buckets {
+		end := periodEnd(b.Start, p.Period)
+		if b.Aggregated {
+			end = b.Start.UTC().AddDate(0, 1, 0).In(loc)
+		}
+		resp.Buckets[i] = types.SummaryBucket{
+			Start:            b.Start,
+			End:              end,
+			TripCount:        b.TripCount,
+			DurationSeconds:  b.DurationSeconds,
+			DistanceKm:       b.DistanceKm,
+			DroppedDataCount: b.DroppedDataCount,
+			Aggregated:       b.Aggregated,
+		}
+	}
+
+	return c.JSON(resp)
+}
+
+// periodEnd returns the start of the period after the one starting at start. Calendar
+// arithmetic in start's location keeps this right across daylight saving changes.
+func periodEnd(start time.Time, period string) time.Time {
+	switch period {
+	case pg_store.PeriodWeek:
+		return start.AddDate(0, 0, 7)
+	case pg_store.PeriodMonth:
+		return start.AddDate(0, 1, 0)
+	default:
+		return start.AddDate(0, 0, 1)
+	}
+}
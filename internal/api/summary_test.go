@@ -0,0 +1,43 @@
+package api
+
+import (
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/DIMO-Network/trips-api/internal/privacy"
+	"github.com/gofiber/fiber/v2"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetVehicleTripSummaryValidation(t *testing.T) {
+	h := NewHandler(nil, nil, privacy.PrecisionFull, &zerolog.Logger{})
+	app := fiber.New()
+	app.Get("/vehicle/:tokenID/trips/summary", h.GetVehicleTripSummary)
+
+	for _, query := range []string{
+		"period=year",
+		"timezone=Mars/Olympus_Mons",
+		"start=yesterday",
+		"start=2024-03-02T00:00:00Z&end=2024-03-01T00:00:00Z",
+		"start=2020-01-01T00:00:00Z&end=2024-01-01T00:00:00Z",
+	} {
+		resp, err := app.Test(httptest.NewRequest("GET", "/vehicle/1/trips/summary?"+query, nil))
+		require.NoError(t, err)
+		assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode, query)
+	}
+}
+
+func TestPeriodEndAcrossDaylightSaving(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+
+	// Clocks went forward on 10 March 2024, so that day was 23 hours long.
+	start := time.Date(2024, 3, 10, 0, 0, 0, 0, loc)
+	assert.Equal(t, time.Date(2024, 3, 11, 0, 0, 0, 0, loc), periodEnd(start, "day"))
+	assert.Equal(t, 23*time.Hour, periodEnd(start, "day").Sub(start))
+
+	assert.Equal(t, time.Date(2024, 4, 1, 0, 0, 0, 0, loc), periodEnd(time.Date(2024, 3, 1, 0, 0, 0, 0, loc), "month"))
+}
@@ -0,0 +1,67 @@
+package api
+
+import (
+	"slices"
+	"time"
+
+	"github.com/DIMO-Network/shared/middleware/privilegetoken"
+	"github.com/DIMO-Network/shared/privileges"
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// tokenIdentity returns the subject of the request's privilege token and the client it was
+// issued to, or empty strings if there is no token.
+func tokenIdentity(c *fiber.Ctx) (subject, clientID string) {
+	token, ok := c.Locals("user").(*jwt.Token)
+	if !ok {
+		return "", ""
+	}
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok {
+		return "", ""
+	}
+
+	subject, _ = claims.GetSubject()
+	for _, claim := range []string{"client_id", "azp"} {
+		if clientID, _ = claims[claim].(string); clientID != "" {
+			break
+		}
+	}
+	return subject, clientID
+}
+
+// hasPrivilege reports whether the request's privilege token, as checked by the privilege
+// middleware, grants the privilege.
+func hasPrivilege(c *fiber.Ctx, p privileges.Privilege) bool {
+	claims, ok := c.Locals("tokenClaims").(privilegetoken.CustomClaims)
+	return ok && slices.Contains(claims.PrivilegeIDs, p)
+}
+
+// tokenAddress returns the ethereum_address claim of the request's privilege token, the
+// address of the user it was issued to, or an empty string if it has none.
+func tokenAddress(c *fiber.Ctx) string {
+	token, ok := c.Locals("user").(*jwt.Token)
+	if !ok {
+		return ""
+	}
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok {
+		return ""
+	}
+	address, _ := claims["ethereum_address"].(string)
+	return address
+}
+
+// tokenExpiry returns the expiry of the request's privilege token, if it has one.
+func tokenExpiry(c *fiber.Ctx) (time.Time, bool) {
+	token, ok := c.Locals("user").(*jwt.Token)
+	if !ok {
+		return time.Time{}, false
+	}
+	exp, err := token.Claims.GetExpirationTime()
+	if err != nil || exp == nil {
+		return time.Time{}, false
+	}
+	return exp.Time, true
+}
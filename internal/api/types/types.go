@@ -13,6 +13,15 @@ type TripDetails struct {
 	Start   TripStart `json:"start"`
 	End     TripEnd   `json:"end"`
 	Dropped bool      `json:"droppedData"`
+	// DistanceKm is the distance travelled, once the trip has completed.
+	DistanceKm *float64 `json:"distanceKm,omitempty" example:"14.2"`
+	Purpose    string   `json:"purpose,omitempty" example:"business"`
+	// CustomPurpose names the purpose when it is custom.
+	CustomPurpose string `json:"customPurpose,omitempty" example:"Client visits"`
+	Note          string `json:"note,omitempty" example:"Picked up samples from the warehouse."`
+	// SegmentIDs are the upstream segments that make up the trip. There is one, with the same
+	// id as the trip, unless the trip has been merged or split.
+	SegmentIDs []string `json:"segmentIds,omitempty" example:"2Y83IHPItgk0uHD7hybGnA776Bo"`
 }
 
 type TripStart struct {
@@ -30,3 +39,142 @@ type Location struct {
 	Latitude  float64 `json:"latitude"`
 	Longitude float64 `json:"longitude"`
 }
+
+type WebhookRequest struct {
+	URL    string   `json:"url" example:"https://example.com/trips"`
+	Events []string `json:"events" example:"com.dimo.trip.completed"`
+}
+
+type Webhook struct {
+	ID     string   `json:"id" example:"2cZ4GjK0sbvh7vD4mdPDJhSq1Nt"`
+	URL    string   `json:"url" example:"https://example.com/trips"`
+	Events []string `json:"events" example:"com.dimo.trip.completed"`
+	// FailureCount is the number of consecutive failed deliveries.
+	FailureCount int        `json:"failureCount" example:"0"`
+	DisabledAt   *time.Time `json:"disabledAt,omitempty"`
+	CreatedAt    time.Time  `json:"createdAt"`
+}
+
+// NewWebhook is only returned on creation, as it includes the signing secret.
+type NewWebhook struct {
+	Webhook
+	Secret string `json:"secret" example:"5c2f0e2c1d0f4b7f9a3e6d8c1b2a4f6e5c2f0e2c1d0f4b7f9a3e6d8c1b2a4f6e"`
+}
+
+type TripSummary struct {
+	Period   string          `json:"period" example:"day"`
+	Timezone string          `json:"timezone" example:"America/New_York"`
+	Buckets  []SummaryBucket `json:"buckets"`
+}
+
+type SummaryBucket struct {
+	Start            time.Time `json:"start"`
+	End              time.Time `json:"end"`
+	TripCount        int       `json:"tripCount" example:"3"`
+	DurationSeconds  float64   `json:"durationSeconds" example:"5400"`
+	DistanceKm       float64   `json:"distanceKm" example:"42.7"`
+	DroppedDataCount int       `json:"droppedDataCount" example:"0"`
+	// Aggregated is set on buckets that include the totals of trips deleted by retention,
+	// which are kept by UTC calendar month. Such a bucket spans that month and its dropped
+	// data count leaves those trips out.
+	Aggregated bool `json:"aggregated,omitempty"`
+}
+
+// TripAnnotation changes the fields of a trip that users maintain. Omitted fields are left
+// alone and empty ones are cleared.
+type TripAnnotation struct {
+	// Purpose is one of business, personal, commute or custom.
+	Purpose *string `json:"purpose,omitempty" example:"custom"`
+	// CustomPurpose names a custom purpose, and is required with one.
+	CustomPurpose *string `json:"customPurpose,omitempty" example:"Client visits"`
+	Note          *string `json:"note,omitempty" example:"Picked up samples from the warehouse."`
+}
+
+type MergeTrips struct {
+	// TripIDs are the consecutive trips to merge, in any order.
+	TripIDs []string `json:"tripIds" example:"2Y83IHPItgk0uHD7hybGnA776Bo,2Y83IKzqDb8gXqFvV4JYrqZbFkM"`
+}
+
+type SplitTrip struct {
+	// Time is where to split the trip, strictly between its start and end.
+	Time time.Time `json:"time" example:"2024-03-01T08:10:00Z"`
+}
+
+type PrivacyZoneRequest struct {
+	Name         string   `json:"name" example:"Home"`
+	Center       Location `json:"center"`
+	RadiusMeters int      `json:"radiusMeters" example:"300"`
+	// Mask is snap, to move positions in the zone to its center, or omit, to drop them.
+	// It defaults to snap.
+	Mask string `json:"mask,omitempty" example:"snap"`
+}
+
+type PrivacyZone struct {
+	ID           string    `json:"id" example:"2cZ4GjK0sbvh7vD4mdPDJhSq1Nt"`
+	Name         string    `json:"name" example:"Home"`
+	Center       Location  `json:"center"`
+	RadiusMeters int       `json:"radiusMeters" example:"300"`
+	Mask         string    `json:"mask" example:"snap"`
+	CreatedAt    time.Time `json:"createdAt"`
+}
+
+// ErasureRequest selects the trips to erase by start time. Without a start, erasure goes back
+// to the first trip, and without an end it runs up to now, which needs an Idempotency-Key.
+type ErasureRequest struct {
+	Start *time.Time `json:"start,omitempty" example:"2024-01-01T00:00:00Z"`
+	End   *time.Time `json:"end,omitempty" example:"2024-03-01T00:00:00Z"`
+}
+
+// Erasure is the receipt for erased trips.
+type Erasure struct {
+	ID    string     `json:"id" example:"2cZ4GjK0sbvh7vD4mdPDJhSq1Nt"`
+	Start *time.Time `json:"start,omitempty"`
+	End   time.Time  `json:"end"`
+	// TripCount is the number of trips deleted.
+	TripCount int `json:"tripCount" example:"12"`
+	// ArchiveIDs are the Bundlr transactions of the deleted trips. Their keys are gone, so they
+	// can no longer be decrypted.
+	ArchiveIDs []string  `json:"archiveIds" example:"O5Xp3eVwHbpJEmmRH9mEtTx0KzWOYgF2xU2cK1x3i2s"`
+	CreatedAt  time.Time `json:"createdAt"`
+}
+
+// AppAccess summarizes one app's reads of the vehicle's trip data.
+type AppAccess struct {
+	// ClientID identifies the app. It is empty for tokens that didn't name one.
+	ClientID    string    `json:"clientId" example:"0x4b7B7F6E5A5E8A3E4F0D3c8F2B1e9A7d6C5b4A3f"`
+	AccessCount int       `json:"accessCount" example:"42"`
+	FirstAccess time.Time `json:"firstAccess"`
+	LastAccess  time.Time `json:"lastAccess"`
+	// Endpoints are the routes the app read.
+	Endpoints []string `json:"endpoints" example:"/v1/vehicle/:tokenID/trips"`
+}
+
+// TripAttestation is a signed EIP-712 attestation of a completed trip.
+type TripAttestation struct {
+	// TypedData is the signed EIP-712 typed data with its domain, types and message, as taken by
+	// eth_signTypedData_v4. Times are Unix seconds and dataHash is the SHA-256 of the trip's
+	// status data, or zero if there was none.
+	TypedData any `json:"typedData" swaggertype:"object"`
+	// Signer is the address of the service's key.
+	Signer    string `json:"signer" example:"0x4b7B7F6E5A5E8A3E4F0D3c8F2B1e9A7d6C5b4A3f"`
+	Signature string `json:"signature" example:"0x9f3c...1b"`
+}
+
+// TripProof shows that a trip's data hash is included in a Merkle root. Hashes are hex.
+type TripProof struct {
+	TripID   string `json:"tripId" example:"2Y83IHPItgk0uHD7hybGnA776Bo"`
+	DataHash string `json:"dataHash"`
+	// Leaf is SHA-256(0x00 ‖ dataHash ‖ tripId).
+	Leaf      string `json:"leaf"`
+	LeafIndex int    `json:"leafIndex" example:"3"`
+	TreeSize  int    `json:"treeSize" example:"1000"`
+	// Proof lists the sibling hashes from the leaf up to the root, as in RFC 9162.
+	Proof    []string `json:"proof"`
+	Root     string   `json:"root"`
+	AnchorID string   `json:"anchorId" example:"2cZ4GjK0sbvh7vD4mdPDJhSq1Nt"`
+	// Publisher is where the root was published: bundlr or local.
+	Publisher string `json:"publisher" example:"bundlr"`
+	// Reference locates the published root, such as a Bundlr transaction id.
+	Reference   string    `json:"reference" example:"O5Xp3eVwHbpJEmmRH9mEtTx0KzWOYgF2xU2cK1x3i2s"`
+	PublishedAt time.Time `json:"publishedAt"`
+}
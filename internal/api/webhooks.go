@@ -0,0 +1,227 @@
+package api
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"net/netip"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/DIMO-Network/trips-api/internal/api/types"
+	pg_store "github.com/DIMO-Network/trips-api/internal/services/pg"
+	"github.com/DIMO-Network/trips-api/internal/services/webhook"
+	"github.com/DIMO-Network/trips-api/models"
+	"github.com/gofiber/fiber/v2"
+	"github.com/rs/zerolog"
+	"github.com/segmentio/ksuid"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+)
+
+// maxActiveWebhooks is how many enabled webhooks each app may have on a vehicle.
+const maxActiveWebhooks = 10
+
+type WebhookHandler struct {
+	pg        *pg_store.Store
+	allowHTTP bool
+	logger    *zerolog.Logger
+}
+
+// NewWebhookHandler creates a handler for managing webhooks. Unless allowHTTP is set, only
+// HTTPS endpoints outside loopback and private networks may be registered.
+func NewWebhookHandler(pgStore *pg_store.Store, allowHTTP bool, logger *zerolog.Logger) *WebhookHandler {
+	return &WebhookHandler{pgStore, allowHTTP, logger}
+}
+
+// CreateWebhook registers a webhook for the vehicle's trip events.
+//
+//	@Description	Registers a webhook for trip events. Deliveries are signed with the returned secret.
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			tokenId	path		int						true	"Vehicle token id"
+//	@Param			webhook	body		types.WebhookRequest	true	"Endpoint and events"
+//	@Success		201		{object}	types.NewWebhook
+//	@Router			/vehicle/{tokenId}/webhooks [post]
+func (h *WebhookHandler) CreateWebhook(c *fiber.Ctx) error {
+	tokenID, err := strconv.Atoi(c.Params("tokenID"))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Couldn't parse vehicle token id.")
+	}
+
+	var req types.WebhookRequest
+	if err := c.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Couldn't parse request body.")
+	}
+
+	if err := h.validateURL(req.URL); err != nil {
+		return err
+	}
+
+	if len(req.Events) == 0 {
+		return fiber.NewError(fiber.StatusBadRequest, "At least one event type is required.")
+	}
+	var events []string
+	for _, e := range req.Events {
+		if !slices.Contains(webhook.EventTypes, e) {
+			return fiber.NewError(fiber.StatusBadRequest, "Unsupported event type "+e+".")
+		}
+		if !slices.Contains(events, e) {
+			events = append(events, e)
+		}
+	}
+
+	// Deliveries are checked against the grants of the app that registered the webhook. The
+	// token's subject is the vehicle, so it says nothing about who that is.
+	subject, clientID := tokenIdentity(c)
+	if subject == "" {
+		return fiber.NewError(fiber.StatusUnauthorized, "Token has no subject.")
+	}
+	if clientID == "" {
+		return fiber.NewError(fiber.StatusForbidden, "Webhooks can only be registered with a token issued to an app.")
+	}
+
+	// Only the caller's own webhooks count, so that one app can't use up the vehicle's slots.
+	count, err := models.Webhooks(
+		models.WebhookWhere.VehicleTokenID.EQ(tokenID),
+		models.WebhookWhere.DisabledAt.IsNull(),
+		ownedBy(c),
+	).Count(c.UserContext(), h.pg.DB.DBS().Reader)
+	if err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+	if count >= maxActiveWebhooks {
+		return fiber.NewError(fiber.StatusConflict, "You already have the maximum number of webhooks on this vehicle.")
+	}
+
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	w := models.Webhook{
+		ID:             ksuid.New().String(),
+		VehicleTokenID: tokenID,
+		URL:            req.URL,
+		EventTypes:     events,
+		Secret:         hex.EncodeToString(secret),
+		Subject:        subject,
+		ClientID:       null.NewString(clientID, clientID != ""),
+	}
+	if err := w.Insert(c.UserContext(), h.pg.DB.DBS().Writer, boil.Infer()); err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(types.NewWebhook{Webhook: webhookToAPI(&w), Secret: w.Secret})
+}
+
+// ListWebhooks returns the vehicle's webhooks that the caller registered.
+//
+//	@Description	Lists the webhooks the caller registered for a vehicle.
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			tokenId	path	int	true	"Vehicle token id"
+//	@Success		200		{array}	types.Webhook
+//	@Router			/vehicle/{tokenId}/webhooks [get]
+func (h *WebhookHandler) ListWebhooks(c *fiber.Ctx) error {
+	tokenID, err := strconv.Atoi(c.Params("tokenID"))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Couldn't parse vehicle token id.")
+	}
+
+	webhooks, err := models.Webhooks(
+		models.WebhookWhere.VehicleTokenID.EQ(tokenID),
+		ownedBy(c),
+		qm.OrderBy(models.WebhookColumns.CreatedAt),
+	).All(c.UserContext(), h.pg.DB.DBS().Reader)
+	if err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	resp := make([]types.Webhook, len(webhooks))
+	for i, w := range webhooks {
+		resp[i] = webhookToAPI(w)
+	}
+
+	return c.JSON(resp)
+}
+
+// DeleteWebhook removes one of the vehicle's webhooks that the caller registered.
+//
+//	@Description	Deletes a webhook the caller registered.
+//	@Security		BearerAuth
+//	@Param			tokenId		path	int		true	"Vehicle token id"
+//	@Param			webhookId	path	string	true	"Webhook id"
+//	@Success		204
+//	@Router			/vehicle/{tokenId}/webhooks/{webhookId} [delete]
+func (h *WebhookHandler) DeleteWebhook(c *fiber.Ctx) error {
+	tokenID, err := strconv.Atoi(c.Params("tokenID"))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Couldn't parse vehicle token id.")
+	}
+
+	n, err := models.Webhooks(
+		models.WebhookWhere.ID.EQ(c.Params("webhookID")),
+		models.WebhookWhere.VehicleTokenID.EQ(tokenID),
+		ownedBy(c),
+	).DeleteAll(c.UserContext(), h.pg.DB.DBS().Writer)
+	if err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+	if n == 0 {
+		return fiber.NewError(fiber.StatusNotFound, "No such webhook.")
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+func (h *WebhookHandler) validateURL(raw string) error {
+	u, err := url.Parse(raw)
+	if err != nil || u.Host == "" {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid webhook url.")
+	}
+	if u.Scheme != "https" && !(h.allowHTTP && u.Scheme == "http") {
+		return fiber.NewError(fiber.StatusBadRequest, "Webhook url must use https.")
+	}
+	if h.allowHTTP {
+		return nil
+	}
+	// The dispatcher refuses to connect to such addresses anyway; this catches the obvious
+	// cases up front.
+	host := strings.TrimSuffix(strings.ToLower(u.Hostname()), ".")
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return fiber.NewError(fiber.StatusBadRequest, "Webhook url must be on the public internet.")
+	}
+	if ip, err := netip.ParseAddr(host); err == nil && !webhook.IsPublic(ip) {
+		return fiber.NewError(fiber.StatusBadRequest, "Webhook url must be on the public internet.")
+	}
+	return nil
+}
+
+// ownedBy limits a webhook query to those registered with the same token subject and client
+// as the request's, so that apps sharing a vehicle can't see or remove each other's webhooks.
+func ownedBy(c *fiber.Ctx) qm.QueryMod {
+	subject, clientID := tokenIdentity(c)
+	clientMod := models.WebhookWhere.ClientID.IsNull()
+	if clientID != "" {
+		clientMod = models.WebhookWhere.ClientID.EQ(null.StringFrom(clientID))
+	}
+	return qm.Expr(models.WebhookWhere.Subject.EQ(subject), clientMod)
+}
+
+func webhookToAPI(w *models.Webhook) types.Webhook {
+	out := types.Webhook{
+		ID:           w.ID,
+		URL:          w.URL,
+		Events:       w.EventTypes,
+		FailureCount: w.FailureCount,
+		CreatedAt:    w.CreatedAt,
+	}
+	if w.DisabledAt.Valid {
+		out.DisabledAt = &w.DisabledAt.Time
+	}
+	return out
+}
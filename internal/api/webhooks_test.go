@@ -0,0 +1,51 @@
+package api
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateWebhookValidation(t *testing.T) {
+	cases := []struct {
+		name      string
+		allowHTTP bool
+		body      string
+		status    int
+	}{
+		{"malformed body", false, `{`, fiber.StatusBadRequest},
+		{"missing url", false, `{"events": ["com.dimo.trip.completed"]}`, fiber.StatusBadRequest},
+		{"plain http", false, `{"url": "http://localhost:8000/hook", "events": ["com.dimo.trip.completed"]}`, fiber.StatusBadRequest},
+		{"no events", true, `{"url": "http://localhost:8000/hook", "events": []}`, fiber.StatusBadRequest},
+		{"localhost", false, `{"url": "https://localhost/hook", "events": ["com.dimo.trip.completed"]}`, fiber.StatusBadRequest},
+		{"loopback address", false, `{"url": "https://127.0.0.1:8443/hook", "events": ["com.dimo.trip.completed"]}`, fiber.StatusBadRequest},
+		{"private address", false, `{"url": "https://10.0.0.7/hook", "events": ["com.dimo.trip.completed"]}`, fiber.StatusBadRequest},
+		{"metadata address", false, `{"url": "https://169.254.169.254/latest", "events": ["com.dimo.trip.completed"]}`, fiber.StatusBadRequest},
+		{"mapped ipv6 address", false, `{"url": "https://[::ffff:192.168.0.1]/hook", "events": ["com.dimo.trip.completed"]}`, fiber.StatusBadRequest},
+		{"unknown event", false, `{"url": "https://example.com/hook", "events": ["com.dimo.trip.archived"]}`, fiber.StatusBadRequest},
+		{"no client id", false, `{"url": "https://example.com/hook", "events": ["com.dimo.trip.completed"]}`, fiber.StatusForbidden},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			h := NewWebhookHandler(nil, c.allowHTTP, &zerolog.Logger{})
+			app := fiber.New()
+			app.Post("/vehicle/:tokenID/webhooks", func(c *fiber.Ctx) error {
+				c.Locals("user", &jwt.Token{Claims: jwt.MapClaims{"sub": "0xbA5738a18d83D41847dfFbDC6101d37C69c9B0cF/1"}})
+				return c.Next()
+			}, h.CreateWebhook)
+
+			req := httptest.NewRequest("POST", "/vehicle/1/webhooks", strings.NewReader(c.body))
+			req.Header.Set("Content-Type", "application/json")
+			resp, err := app.Test(req)
+			require.NoError(t, err)
+			assert.Equal(t, c.status, resp.StatusCode)
+		})
+	}
+}
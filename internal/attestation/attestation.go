@@ -0,0 +1,153 @@
+// Package attestation signs and verifies EIP-712 attestations of trips, so that third parties
+// can check a trip's summary offline against the service's address.
+package attestation
+
+import (
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+)
+
+// The EIP-712 domain of trip attestations.
+const (
+	DomainName    = "DIMO Trips"
+	DomainVersion = "1"
+)
+
+// PrimaryType is the EIP-712 type of the attested message.
+const PrimaryType = "TripAttestation"
+
+// Types are the EIP-712 types of trip attestations.
+var Types = apitypes.Types{
+	"EIP712Domain": {
+		{Name: "name", Type: "string"},
+		{Name: "version", Type: "string"},
+		{Name: "chainId", Type: "uint256"},
+	},
+	PrimaryType: {
+		{Name: "vehicleTokenId", Type: "uint256"},
+		{Name: "tripId", Type: "string"},
+		{Name: "startTime", Type: "uint256"},
+		{Name: "endTime", Type: "uint256"},
+		{Name: "distanceMeters", Type: "uint256"},
+		{Name: "archiveId", Type: "string"},
+		{Name: "dataHash", Type: "bytes32"},
+	},
+}
+
+// ErrBadSignature is returned for signatures that aren't by the expected signer.
+var ErrBadSignature = errors.New("signature doesn't match signer")
+
+// Trip is the attested summary of a completed trip. Times are attested to the second.
+type Trip struct {
+	VehicleTokenID int
+	TripID         string
+	Start, End     time.Time
+	// DistanceMeters is zero if the distance is unknown.
+	DistanceMeters uint64
+	// ArchiveID is the Bundlr transaction id of the trip's data, if archived.
+	ArchiveID string
+	// DataHash is the SHA-256 of the trip's status data, or zero if there was none.
+	DataHash [32]byte
+}
+
+// TypedData returns the EIP-712 typed data of the attestation on the given chain, in the form
+// taken by eth_signTypedData_v4.
+func TypedData(chainID int64, t Trip) apitypes.TypedData {
+	return apitypes.TypedData{
+		Types:       Types,
+		PrimaryType: PrimaryType,
+		Domain: apitypes.TypedDataDomain{
+			Name:    DomainName,
+			Version: DomainVersion,
+			ChainId: (*math.HexOrDecimal256)(big.NewInt(chainID)),
+		},
+		Message: apitypes.TypedDataMessage{
+			"vehicleTokenId": strconv.Itoa(t.VehicleTokenID),
+			"tripId":         t.TripID,
+			"startTime":      strconv.FormatInt(t.Start.Unix(), 10),
+			"endTime":        strconv.FormatInt(t.End.Unix(), 10),
+			"distanceMeters": strconv.FormatUint(t.DistanceMeters, 10),
+			"archiveId":      t.ArchiveID,
+			"dataHash":       hexutil.Encode(t.DataHash[:]),
+		},
+	}
+}
+
+// Signer signs attestations with the service's key.
+type Signer struct {
+	key     *ecdsa.PrivateKey
+	chainID int64
+}
+
+func NewSigner(key *ecdsa.PrivateKey, chainID int64) *Signer {
+	return &Signer{key, chainID}
+}
+
+// Address is the address that attestations can be verified against.
+func (s *Signer) Address() common.Address {
+	return crypto.PubkeyToAddress(s.key.PublicKey)
+}
+
+// ChainID is the chain id in the attestations' domain.
+func (s *Signer) ChainID() int64 {
+	return s.chainID
+}
+
+// Sign returns the 65-byte signature of the trip's attestation, with a recovery id of 27 or
+// 28 as Ethereum wallets produce.
+func (s *Signer) Sign(t Trip) ([]byte, error) {
+	hash, _, err := apitypes.TypedDataAndHash(TypedData(s.chainID, t))
+	if err != nil {
+		return nil, err
+	}
+
+	sig, err := crypto.Sign(hash, s.key)
+	if err != nil {
+		return nil, err
+	}
+	sig[crypto.RecoveryIDOffset] += 27
+	return sig, nil
+}
+
+// Verify checks that the signature is signer's attestation of the trip on the given chain.
+func Verify(chainID int64, t Trip, sig []byte, signer common.Address) error {
+	return VerifyTypedData(TypedData(chainID, t), sig, signer)
+}
+
+// VerifyTypedData checks that the signature is signer's signature of the typed data, as
+// returned alongside an attestation.
+func VerifyTypedData(data apitypes.TypedData, sig []byte, signer common.Address) error {
+	if len(sig) != crypto.SignatureLength {
+		return fmt.Errorf("signature is %d bytes, not %d", len(sig), crypto.SignatureLength)
+	}
+
+	hash, _, err := apitypes.TypedDataAndHash(data)
+	if err != nil {
+		return err
+	}
+
+	// Accept recovery ids of both 0/1 and 27/28.
+	sig = append([]byte(nil), sig...)
+	if sig[crypto.RecoveryIDOffset] >= 27 {
+		sig[crypto.RecoveryIDOffset] -= 27
+	}
+
+	pub, err := crypto.SigToPub(hash, sig)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrBadSignature, err)
+	}
+	if crypto.PubkeyToAddress(*pub) != signer {
+		return ErrBadSignature
+	}
+	return nil
+}
@@ -0,0 +1,69 @@
+package attestation
+
+import (
+	"crypto/sha256"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSignVerify(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	signer := NewSigner(key, 137)
+
+	trp := Trip{
+		VehicleTokenID: 42,
+		TripID:         "2Y83IHPItgk0uHD7hybGnA776Bo",
+		Start:          time.Date(2024, 3, 1, 8, 0, 0, 0, time.UTC),
+		End:            time.Date(2024, 3, 1, 8, 30, 0, 0, time.UTC),
+		DistanceMeters: 12345,
+		ArchiveID:      "O5Xp3eVwHbpJEmmRH9mEtTx0KzWOYgF2xU2cK1x3i2s",
+		DataHash:       sha256.Sum256([]byte("data")),
+	}
+
+	sig, err := signer.Sign(trp)
+	require.NoError(t, err)
+	require.Len(t, sig, 65)
+	assert.Contains(t, []byte{27, 28}, sig[64])
+
+	assert.NoError(t, Verify(137, trp, sig, signer.Address()))
+	assert.NoError(t, VerifyTypedData(TypedData(137, trp), sig, signer.Address()))
+
+	// A recovery id of 0 or 1 is accepted too.
+	raw := append([]byte(nil), sig...)
+	raw[64] -= 27
+	assert.NoError(t, Verify(137, trp, raw, signer.Address()))
+
+	other, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	assert.True(t, errors.Is(Verify(137, trp, sig, crypto.PubkeyToAddress(other.PublicKey)), ErrBadSignature))
+
+	assert.Error(t, Verify(1, trp, sig, signer.Address()), "other chain")
+
+	changed := trp
+	changed.DistanceMeters++
+	assert.Error(t, Verify(137, changed, sig, signer.Address()), "other distance")
+
+	assert.Error(t, Verify(137, trp, sig[:64], signer.Address()), "short signature")
+}
+
+func TestTypedDataMessage(t *testing.T) {
+	trp := Trip{
+		VehicleTokenID: 7,
+		TripID:         "trip",
+		Start:          time.Unix(1709280000, 500),
+		End:            time.Unix(1709281800, 0),
+	}
+
+	data := TypedData(137, trp)
+	assert.Equal(t, PrimaryType, data.PrimaryType)
+	assert.Equal(t, "7", data.Message["vehicleTokenId"])
+	assert.Equal(t, "1709280000", data.Message["startTime"])
+	assert.Equal(t, "1709281800", data.Message["endTime"])
+	assert.Equal(t, "0x0000000000000000000000000000000000000000000000000000000000000000", data.Message["dataHash"])
+}
@@ -27,12 +27,77 @@ type Settings struct {
 	BundlrNetwork    string `yaml:"BUNDLR_NETWORK"`
 	BundlrCurrency   string `yaml:"BUNDLR_CURRENCY"`
 	EventTopic       string `yaml:"EVENTS_TOPIC"`
+	// TripLifecycleTopic receives the trip started, completed and archived events. Leave
+	// empty to not publish them.
+	TripLifecycleTopic string `yaml:"TRIP_LIFECYCLE_TOPIC"`
+	// TripEventDeadLetterTopic and EventDeadLetterTopic receive the messages from
+	// TRIP_EVENT_TOPIC and EVENTS_TOPIC that couldn't be handled after ConsumerMaxAttempts
+	// tries. Leave empty to retry such messages until they succeed.
+	TripEventDeadLetterTopic string `yaml:"TRIP_EVENT_DEAD_LETTER_TOPIC"`
+	EventDeadLetterTopic     string `yaml:"EVENTS_DEAD_LETTER_TOPIC"`
+	ConsumerMaxAttempts      int    `yaml:"CONSUMER_MAX_ATTEMPTS"`
 
 	DataFetchEnabled bool `yaml:"DATA_FETCH_ENABLED"`
 	WorkerCount      int  `yaml:"WORKER_COUNT"`
 	BundlrEnabled    bool `yaml:"BUNDLR_ENABLED"`
+	// ArchiveCompression is how trip data is compressed before upload: zip, gzip or zstd, the
+	// default.
+	ArchiveCompression string `yaml:"ARCHIVE_COMPRESSION"`
+	// AttestationChainID is the chain id in the EIP-712 domain of trip attestations, which are
+	// signed with the Bundlr key.
+	AttestationChainID int `yaml:"ATTESTATION_CHAIN_ID"`
 
 	PrivilegeJWKURL string `yaml:"PRIVILEGE_JWK_URL"`
+	// LocationPrecision is full, 100m, 1km, gh7, gh6 or gh5, and applies to the trip positions
+	// shown to callers with location access.
+	LocationPrecision string `yaml:"LOCATION_PRECISION"`
+
+	// ShutdownTimeoutSeconds bounds how long in-flight messages are given to finish on shutdown.
+	ShutdownTimeoutSeconds int `yaml:"SHUTDOWN_TIMEOUT_SECONDS"`
+
+	HealthCheckTimeoutSeconds int `yaml:"HEALTH_CHECK_TIMEOUT_SECONDS"`
+	// MaxConsumerLag is the number of unprocessed messages past which the service reports
+	// itself unready. Zero disables the check.
+	MaxConsumerLag int `yaml:"MAX_CONSUMER_LAG"`
+
+	TracingEnabled bool `yaml:"TRACING_ENABLED"`
+	// OTLPEndpoint is the host and port of the OTLP/HTTP collector, e.g. localhost:4318.
+	OTLPEndpoint string `yaml:"OTLP_ENDPOINT"`
+	OTLPInsecure bool   `yaml:"OTLP_INSECURE"`
 
 	VehicleNFTAddr string `yaml:"VEHICLE_NFT_ADDR"`
+
+	IdentityAPIURL string `yaml:"IDENTITY_API_URL"`
+
+	// WebhookAllowHTTP permits plain HTTP webhook endpoints, and ones on loopback and private
+	// networks, for local development.
+	WebhookAllowHTTP    bool `yaml:"WEBHOOK_ALLOW_HTTP"`
+	WebhookMaxAttempts  int  `yaml:"WEBHOOK_MAX_ATTEMPTS"`
+	WebhookDisableAfter int  `yaml:"WEBHOOK_DISABLE_AFTER"`
+
+	// SegmenterEnabled detects trips from the status data of the devices in
+	// SegmenterDeviceIDs, a comma-separated list of user device ids.
+	SegmenterEnabled             bool   `yaml:"SEGMENTER_ENABLED"`
+	SegmenterDeviceIDs           string `yaml:"SEGMENTER_DEVICE_IDS"`
+	SegmenterPollIntervalSeconds int    `yaml:"SEGMENTER_POLL_INTERVAL_SECONDS"`
+	SegmenterIdleTimeoutSeconds  int    `yaml:"SEGMENTER_IDLE_TIMEOUT_SECONDS"`
+	SegmenterGapTimeoutSeconds   int    `yaml:"SEGMENTER_GAP_TIMEOUT_SECONDS"`
+	SegmenterMinSpeedKph         int    `yaml:"SEGMENTER_MIN_SPEED_KPH"`
+
+	// RetentionEnabled periodically drops trip positions after RetentionPositionDays and trips
+	// after RetentionTripDays. Zero days keep them. RetentionAggregate keeps monthly totals of
+	// deleted trips, and RetentionDryRun only reports what would change.
+	RetentionEnabled         bool `yaml:"RETENTION_ENABLED"`
+	RetentionIntervalSeconds int  `yaml:"RETENTION_INTERVAL_SECONDS"`
+	RetentionPositionDays    int  `yaml:"RETENTION_POSITION_DAYS"`
+	RetentionTripDays        int  `yaml:"RETENTION_TRIP_DAYS"`
+	RetentionAggregate       bool `yaml:"RETENTION_AGGREGATE"`
+	RetentionDryRun          bool `yaml:"RETENTION_DRY_RUN"`
+
+	// AnchorEnabled periodically rolls completed trips into Merkle trees and publishes their
+	// roots with AnchorPublisher, bundlr or local.
+	AnchorEnabled         bool   `yaml:"ANCHOR_ENABLED"`
+	AnchorPublisher       string `yaml:"ANCHOR_PUBLISHER"`
+	AnchorIntervalSeconds int    `yaml:"ANCHOR_INTERVAL_SECONDS"`
+	AnchorBatchSize       int    `yaml:"ANCHOR_BATCH_SIZE"`
 }
@@ -0,0 +1,60 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/pressly/goose/v3"
+)
+
+// LatestMigrationVersion returns the highest version among the migration files in dir.
+func LatestMigrationVersion(dir string) (int64, error) {
+	migrations, err := goose.CollectMigrations(dir, 0, goose.MaxVersion)
+	if err != nil {
+		return 0, err
+	}
+
+	last, err := migrations.Last()
+	if err != nil {
+		return 0, err
+	}
+
+	return last.Version, nil
+}
+
+// MigrationVersion returns the version the schema is currently migrated to, following the
+// same rules as goose: the most recent applied version that hasn't since been rolled back.
+func MigrationVersion(ctx context.Context, db *sql.DB, schemaName string) (int64, error) {
+	rows, err := db.QueryContext(ctx, fmt.Sprintf("SELECT version_id, is_applied FROM %s.migrations ORDER BY id DESC", schemaName))
+	if err != nil {
+		return 0, err
+	}
+	defer rows.Close()
+
+	rolledBack := make(map[int64]bool)
+
+	for rows.Next() {
+		var version int64
+		var applied bool
+		if err := rows.Scan(&version, &applied); err != nil {
+			return 0, err
+		}
+
+		if rolledBack[version] {
+			continue
+		}
+
+		if applied {
+			return version, nil
+		}
+
+		rolledBack[version] = true
+	}
+
+	if err := rows.Err(); err != nil {
+		return 0, err
+	}
+
+	return 0, nil
+}
@@ -0,0 +1,26 @@
+package database
+
+import (
+	"os"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLatestMigrationVersion(t *testing.T) {
+	entries, err := os.ReadDir("../../migrations")
+	assert.NoError(t, err)
+
+	var expected int64
+	for _, e := range entries {
+		v, err := strconv.ParseInt(strings.SplitN(e.Name(), "_", 2)[0], 10, 64)
+		assert.NoError(t, err)
+		expected = max(expected, v)
+	}
+
+	latest, err := LatestMigrationVersion("../../migrations")
+	assert.NoError(t, err)
+	assert.Equal(t, expected, latest)
+}
@@ -0,0 +1,43 @@
+package geo
+
+import (
+	"math"
+
+	"github.com/volatiletech/sqlboiler/v4/types/pgeo"
+)
+
+// metersPerDegree is the length of a degree of latitude, near enough.
+const metersPerDegree = 111_320
+
+// GeohashCenter returns the center of the geohash cell of the given length, in characters,
+// that contains the point.
+func GeohashCenter(p pgeo.Point, length int) pgeo.Point {
+	lon, lat := [2]float64{-180, 180}, [2]float64{-90, 90}
+	for bit := 0; bit < 5*length; bit++ {
+		// Bits alternate between longitude and latitude, starting with longitude.
+		r, v := &lon, p.X
+		if bit%2 == 1 {
+			r, v = &lat, p.Y
+		}
+		mid := (r[0] + r[1]) / 2
+		if v >= mid {
+			r[0] = mid
+		} else {
+			r[1] = mid
+		}
+	}
+	return pgeo.NewPoint((lon[0]+lon[1])/2, (lat[0]+lat[1])/2)
+}
+
+// GeohashCellMeters returns the longer side of a geohash cell of the given length at the
+// equator.
+func GeohashCellMeters(length int) float64 {
+	lonBits := (5*length + 1) / 2
+	return 360 / math.Exp2(float64(lonBits)) * metersPerDegree
+}
+
+// DecimalsCellMeters returns the distance between coordinates rounded to the given number of
+// decimal places, along a meridian.
+func DecimalsCellMeters(decimals int) float64 {
+	return metersPerDegree / math.Pow10(decimals)
+}
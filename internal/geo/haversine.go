@@ -21,3 +21,23 @@ func InterpolateTripStart(prevTripEnd, newTripStart pgeo.Point) bool {
 
 	return distMiles < InterpolationThresholdMiles
 }
+
+// DistanceKm returns the great-circle distance between two points in kilometers.
+func DistanceKm(a, b pgeo.Point) float64 {
+	_, km := haversine.Distance(
+		haversine.Coord{Lat: a.Y, Lon: a.X},
+		haversine.Coord{Lat: b.Y, Lon: b.X},
+	)
+	return km
+}
+
+// Zone is a circular area.
+type Zone struct {
+	Center   pgeo.Point
+	RadiusKm float64
+}
+
+// Contains reports whether the point lies within the zone, including on its edge.
+func (z Zone) Contains(p pgeo.Point) bool {
+	return DistanceKm(z.Center, p) <= z.RadiusKm
+}
@@ -0,0 +1,114 @@
+// Package health serves liveness and readiness reports built from named dependency checks.
+package health
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// Check reports whether a dependency is usable. It should give up when ctx is done.
+type Check func(ctx context.Context) error
+
+const (
+	StatusOK   = "ok"
+	StatusFail = "fail"
+)
+
+// Report is the body returned by the health endpoints.
+type Report struct {
+	Status string                 `json:"status"`
+	Checks map[string]CheckReport `json:"checks"`
+}
+
+// CheckReport is the outcome of a single check.
+type CheckReport struct {
+	Status   string `json:"status"`
+	Error    string `json:"error,omitempty"`
+	Duration string `json:"duration"`
+}
+
+type namedCheck struct {
+	name  string
+	check Check
+}
+
+// Checker runs liveness and readiness checks, each bounded by a timeout.
+type Checker struct {
+	timeout time.Duration
+	live    []namedCheck
+	ready   []namedCheck
+}
+
+func New(timeout time.Duration) *Checker {
+	return &Checker{timeout: timeout}
+}
+
+// AddLiveness registers a check that must pass for the process to be considered alive.
+func (h *Checker) AddLiveness(name string, check Check) {
+	h.live = append(h.live, namedCheck{name, check})
+}
+
+// AddReadiness registers a check that must pass for the process to receive work.
+func (h *Checker) AddReadiness(name string, check Check) {
+	h.ready = append(h.ready, namedCheck{name, check})
+}
+
+// Live responds with the liveness report, using status 503 if any check failed.
+func (h *Checker) Live(c *fiber.Ctx) error {
+	return h.respond(c, h.live)
+}
+
+// Ready responds with the readiness report, using status 503 if any check failed.
+func (h *Checker) Ready(c *fiber.Ctx) error {
+	return h.respond(c, h.ready)
+}
+
+func (h *Checker) respond(c *fiber.Ctx, checks []namedCheck) error {
+	report := h.run(c.Context(), checks)
+	if report.Status != StatusOK {
+		c.Status(fiber.StatusServiceUnavailable)
+	}
+	return c.JSON(report)
+}
+
+// run executes the checks concurrently.
+func (h *Checker) run(ctx context.Context, checks []namedCheck) Report {
+	report := Report{
+		Status: StatusOK,
+		Checks: make(map[string]CheckReport, len(checks)),
+	}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+
+	for _, nc := range checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			checkCtx, cancel := context.WithTimeout(ctx, h.timeout)
+			defer cancel()
+
+			start := time.Now()
+			err := nc.check(checkCtx)
+			cr := CheckReport{Status: StatusOK, Duration: time.Since(start).String()}
+			if err != nil {
+				cr.Status = StatusFail
+				cr.Error = err.Error()
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			report.Checks[nc.name] = cr
+			if err != nil {
+				report.Status = StatusFail
+			}
+		}()
+	}
+
+	wg.Wait()
+	return report
+}
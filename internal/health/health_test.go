@@ -0,0 +1,60 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/goccy/go-json"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReady(t *testing.T) {
+	assert := assert.New(t)
+
+	h := New(50 * time.Millisecond)
+	h.AddReadiness("postgres", func(context.Context) error { return nil })
+	h.AddReadiness("elasticsearch", func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	h.AddLiveness("consumer", func(context.Context) error { return nil })
+
+	app := fiber.New()
+	app.Get("/health/live", h.Live)
+	app.Get("/health/ready", h.Ready)
+
+	res, err := app.Test(httptest.NewRequest("GET", "/health/live", nil))
+	assert.NoError(err)
+	assert.Equal(fiber.StatusOK, res.StatusCode)
+
+	res, err = app.Test(httptest.NewRequest("GET", "/health/ready", nil))
+	assert.NoError(err)
+	assert.Equal(fiber.StatusServiceUnavailable, res.StatusCode)
+
+	b, err := io.ReadAll(res.Body)
+	assert.NoError(err)
+
+	var report Report
+	assert.NoError(json.Unmarshal(b, &report))
+	assert.Equal(StatusFail, report.Status)
+	assert.Equal(StatusOK, report.Checks["postgres"].Status)
+	assert.Equal(StatusFail, report.Checks["elasticsearch"].Status)
+	assert.Equal(context.DeadlineExceeded.Error(), report.Checks["elasticsearch"].Error)
+}
+
+func TestLiveFailure(t *testing.T) {
+	h := New(time.Second)
+	h.AddLiveness("consumer", func(context.Context) error { return errors.New("stopped") })
+
+	app := fiber.New()
+	app.Get("/health/live", h.Live)
+
+	res, err := app.Test(httptest.NewRequest("GET", "/health/live", nil))
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusServiceUnavailable, res.StatusCode)
+}
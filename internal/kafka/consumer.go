@@ -0,0 +1,298 @@
+// Package kafka runs message handlers over Kafka consumer groups. Unlike the shared consumer,
+// it lets in-flight messages finish on shutdown and only commits offsets for messages whose
+// handler ran to completion. Failed messages are retried, and can be set aside on a dead
+// letter topic after a number of attempts.
+package kafka
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/DIMO-Network/trips-api/internal/tracing"
+	"github.com/IBM/sarama"
+	"github.com/goccy/go-json"
+	"github.com/rs/zerolog"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type Config struct {
+	Brokers []string
+	Topic   string
+	Group   string
+	// DeadLetterTopic receives messages that couldn't be parsed, or whose handler failed
+	// MaxAttempts times in a row. Leave empty to retry failed messages until they succeed.
+	DeadLetterTopic string
+	// MaxAttempts is how many times a message is handled before it is sent to the dead letter
+	// topic. Defaults to 5.
+	MaxAttempts int
+}
+
+const (
+	defaultMaxAttempts = 5
+	retryBackoff       = time.Second
+	maxRetryBackoff    = time.Minute
+)
+
+// Consumer is a running consumer group. Cancel the context passed to Consume to stop fetching
+// new messages, then call Shutdown to wait for in-flight ones.
+type Consumer struct {
+	abandon context.CancelFunc
+	done    chan struct{}
+	lag     *lagTracker
+}
+
+type wrap[A any] struct {
+	// handlerCtx outlives the session context so that a message being handled when shutdown
+	// begins is given the chance to finish.
+	handlerCtx context.Context
+	handler    func(context.Context, A) error
+	logger     *zerolog.Logger
+	lag        *lagTracker
+
+	maxAttempts int
+	// backoff is the wait before the first retry. It doubles with each retry, up to
+	// maxRetryBackoff.
+	backoff    time.Duration
+	deadLetter deadLetter
+}
+
+// deadLetter receives the messages that can't be handled.
+type deadLetter interface {
+	Send(ctx context.Context, key string, value []byte) error
+}
+
+// lagTracker records, for each claimed partition, how many messages remain after the last one
+// handled.
+type lagTracker struct {
+	mu         sync.Mutex
+	partitions map[int32]int64
+}
+
+func newLagTracker() *lagTracker {
+	return &lagTracker{partitions: make(map[int32]int64)}
+}
+
+func (l *lagTracker) set(partition int32, lag int64) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.partitions[partition] = max(lag, 0)
+}
+
+func (l *lagTracker) release(partition int32) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	delete(l.partitions, partition)
+}
+
+func (l *lagTracker) total() int64 {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	var sum int64
+	for _, lag := range l.partitions {
+		sum += lag
+	}
+	return sum
+}
+
+func (w *wrap[A]) Setup(sarama.ConsumerGroupSession) error   { return nil }
+func (w *wrap[A]) Cleanup(sarama.ConsumerGroupSession) error { return nil }
+
+func (w *wrap[A]) ConsumeClaim(session sarama.ConsumerGroupSession, claim sarama.ConsumerGroupClaim) error {
+	defer w.lag.release(claim.Partition())
+
+	for {
+		select {
+		case msg, ok := <-claim.Messages():
+			if !ok {
+				return nil
+			}
+			// select picks at random among ready cases, so buffered messages can still come
+			// through after the session has ended. Leave them to the next claim.
+			if session.Context().Err() != nil {
+				return nil
+			}
+			if !w.handle(session, msg) {
+				// Later offsets mustn't be committed ahead of this one.
+				return nil
+			}
+			session.MarkMessage(msg, "")
+			w.lag.set(msg.Partition, claim.HighWaterMarkOffset()-msg.Offset-1)
+		case <-session.Context().Done():
+			return nil
+		}
+	}
+}
+
+// handle runs the handler on msg, retrying with backoff until it succeeds or the message is
+// set aside on the dead letter topic, and reports whether msg is finished with. It gives up
+// without finishing when the session ends or the handler is abandoned at shutdown, so that
+// the message is redelivered.
+func (w *wrap[A]) handle(session sarama.ConsumerGroupSession, msg *sarama.ConsumerMessage) bool {
+	log := w.logger.With().Str("topic", msg.Topic).Int32("partition", msg.Partition).Int64("offset", msg.Offset).Logger()
+
+	var a A
+	if err := json.Unmarshal(msg.Value, &a); err != nil {
+		// Retrying won't help.
+		log.Err(err).Msg("Failed unmarshaling message.")
+		if w.deadLetter == nil {
+			return true
+		}
+		return w.sendDeadLetter(&log, msg)
+	}
+
+	backoff := w.backoff
+	for attempt := 1; ; attempt++ {
+		ctx, span := startMessageSpan(w.handlerCtx, msg)
+		err := w.handler(ctx, a)
+		tracing.End(span, err)
+		if err == nil {
+			return true
+		}
+		if w.handlerCtx.Err() != nil {
+			// Abandoned at the shutdown deadline.
+			log.Warn().Err(err).Msg("Abandoned message during shutdown.")
+			return false
+		}
+		log.Err(err).Int("attempt", attempt).Msg("Error processing message.")
+
+		if w.deadLetter != nil && attempt >= w.maxAttempts && w.sendDeadLetter(&log, msg) {
+			return true
+		}
+
+		select {
+		case <-time.After(backoff):
+		case <-session.Context().Done():
+			return false
+		}
+		backoff = min(2*backoff, maxRetryBackoff)
+	}
+}
+
+// sendDeadLetter sets msg aside on the dead letter topic and reports whether it was sent.
+func (w *wrap[A]) sendDeadLetter(log *zerolog.Logger, msg *sarama.ConsumerMessage) bool {
+	if err := w.deadLetter.Send(w.handlerCtx, string(msg.Key), msg.Value); err != nil {
+		log.Err(err).Msg("Failed sending message to the dead letter topic.")
+		return false
+	}
+	log.Warn().Msg("Sent message to the dead letter topic.")
+	return true
+}
+
+// traceHeaders holds the CloudEvents distributed tracing extension attributes.
+type traceHeaders struct {
+	TraceParent string `json:"traceparent"`
+	TraceState  string `json:"tracestate"`
+}
+
+// startMessageSpan starts a consumer span for msg, continuing the producer's trace. The trace
+// context is taken from the CloudEvent's extension attributes, falling back to the Kafka
+// message headers.
+func startMessageSpan(ctx context.Context, msg *sarama.ConsumerMessage) (context.Context, trace.Span) {
+	var th traceHeaders
+	_ = json.Unmarshal(msg.Value, &th)
+	if th.TraceParent == "" {
+		for _, h := range msg.Headers {
+			switch string(h.Key) {
+			case "traceparent":
+				th.TraceParent = string(h.Value)
+			case "tracestate":
+				th.TraceState = string(h.Value)
+			}
+		}
+	}
+
+	ctx = tracing.ExtractCloudEvent(ctx, th.TraceParent, th.TraceState)
+	return tracing.Tracer().Start(ctx, msg.Topic+" process",
+		trace.WithSpanKind(trace.SpanKindConsumer),
+		trace.WithAttributes(
+			semconv.MessagingSystemKafka,
+			semconv.MessagingDestinationName(msg.Topic),
+			semconv.MessagingKafkaDestinationPartition(int(msg.Partition)),
+			semconv.MessagingKafkaMessageOffset(int(msg.Offset)),
+		),
+	)
+}
+
+// Consume starts consuming the configured topic in the background. Messages are fetched until
+// ctx is cancelled.
+func Consume[A any](ctx context.Context, config Config, handler func(context.Context, A) error, logger *zerolog.Logger) (*Consumer, error) {
+	kconf := sarama.NewConfig()
+	kconf.Version = sarama.V3_6_0_0
+
+	g, err := sarama.NewConsumerGroup(config.Brokers, config.Group, kconf)
+	if err != nil {
+		return nil, err
+	}
+
+	handlerCtx, abandon := context.WithCancel(context.WithoutCancel(ctx))
+	c := &Consumer{abandon: abandon, done: make(chan struct{}), lag: newLagTracker()}
+	w := wrap[A]{handlerCtx: handlerCtx, handler: handler, logger: logger, lag: c.lag, maxAttempts: config.MaxAttempts, backoff: retryBackoff}
+	if w.maxAttempts <= 0 {
+		w.maxAttempts = defaultMaxAttempts
+	}
+
+	var dlq *Producer
+	if config.DeadLetterTopic != "" {
+		if dlq, err = NewProducer(config.Brokers, config.DeadLetterTopic); err != nil {
+			_ = g.Close()
+			return nil, err
+		}
+		w.deadLetter = dlq
+	}
+
+	go func() {
+		defer close(c.done)
+		for {
+			if err := g.Consume(ctx, []string{config.Topic}, &w); err != nil && !errors.Is(err, sarama.ErrClosedConsumerGroup) {
+				logger.Err(err).Msg("Consumer group session ended with an error.")
+			}
+			if ctx.Err() != nil {
+				logger.Info().Str("topic", config.Topic).Msg("Context canceled, shutting down.")
+				// Closing commits the offsets of every message that was marked.
+				if err := g.Close(); err != nil {
+					logger.Err(err).Msg("Error closing consumer group.")
+				}
+				if dlq != nil {
+					if err := dlq.Close(); err != nil {
+						logger.Err(err).Msg("Error closing dead letter producer.")
+					}
+				}
+				return
+			}
+		}
+	}()
+
+	return c, nil
+}
+
+// Lag returns the number of messages waiting behind the last handled one, summed over the
+// partitions currently claimed by this instance.
+func (c *Consumer) Lag() int64 {
+	return c.lag.total()
+}
+
+// Running returns an error if the consumer has stopped.
+func (c *Consumer) Running() error {
+	select {
+	case <-c.done:
+		return errors.New("consumer stopped")
+	default:
+		return nil
+	}
+}
+
+// Shutdown waits for the consumer to stop after its context has been cancelled. If ctx
+// expires first, in-flight handlers are cancelled and their messages are left uncommitted.
+func (c *Consumer) Shutdown(ctx context.Context) error {
+	select {
+	case <-c.done:
+		return nil
+	case <-ctx.Done():
+		c.abandon()
+		<-c.done
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,204 @@
+package kafka
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/IBM/sarama"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeSession struct {
+	sarama.ConsumerGroupSession
+	ctx    context.Context
+	marked []int64
+}
+
+func (s *fakeSession) Context() context.Context { return s.ctx }
+
+func (s *fakeSession) MarkMessage(msg *sarama.ConsumerMessage, _ string) {
+	s.marked = append(s.marked, msg.Offset)
+}
+
+type fakeClaim struct {
+	sarama.ConsumerGroupClaim
+	msgs chan *sarama.ConsumerMessage
+}
+
+func (c *fakeClaim) Messages() <-chan *sarama.ConsumerMessage { return c.msgs }
+func (c *fakeClaim) Partition() int32                         { return 0 }
+func (c *fakeClaim) HighWaterMarkOffset() int64               { return 10 }
+
+type fakeDeadLetter struct {
+	sent []string
+}
+
+func (d *fakeDeadLetter) Send(_ context.Context, _ string, value []byte) error {
+	d.sent = append(d.sent, string(value))
+	return nil
+}
+
+func TestConsumeClaimRetriesFailedMessages(t *testing.T) {
+	msgs := make(chan *sarama.ConsumerMessage, 3)
+	msgs <- &sarama.ConsumerMessage{Offset: 1, Value: []byte(`"ok"`)}
+	msgs <- &sarama.ConsumerMessage{Offset: 2, Value: []byte(`"flaky"`)}
+	msgs <- &sarama.ConsumerMessage{Offset: 3, Value: []byte(`not json`)}
+	close(msgs)
+
+	var attempts int
+	w := wrap[string]{
+		handlerCtx: context.Background(),
+		handler: func(_ context.Context, s string) error {
+			if s == "flaky" {
+				attempts++
+				if attempts < 3 {
+					return errors.New("failed")
+				}
+			}
+			return nil
+		},
+		logger:      &zerolog.Logger{},
+		lag:         newLagTracker(),
+		maxAttempts: 5,
+		backoff:     time.Millisecond,
+	}
+
+	sess := &fakeSession{ctx: context.Background()}
+	assert.NoError(t, w.ConsumeClaim(sess, &fakeClaim{msgs: msgs}))
+
+	// Bad payloads can never succeed, so they count as finished work.
+	assert.Equal(t, 3, attempts)
+	assert.Equal(t, []int64{1, 2, 3}, sess.marked)
+}
+
+func TestConsumeClaimDeadLetters(t *testing.T) {
+	msgs := make(chan *sarama.ConsumerMessage, 3)
+	msgs <- &sarama.ConsumerMessage{Offset: 1, Value: []byte(`"fail"`)}
+	msgs <- &sarama.ConsumerMessage{Offset: 2, Value: []byte(`not json`)}
+	msgs <- &sarama.ConsumerMessage{Offset: 3, Value: []byte(`"ok"`)}
+	close(msgs)
+
+	var attempts int
+	dlq := &fakeDeadLetter{}
+	w := wrap[string]{
+		handlerCtx: context.Background(),
+		handler: func(_ context.Context, s string) error {
+			if s == "fail" {
+				attempts++
+				return errors.New("failed")
+			}
+			return nil
+		},
+		logger:      &zerolog.Logger{},
+		lag:         newLagTracker(),
+		maxAttempts: 2,
+		backoff:     time.Millisecond,
+		deadLetter:  dlq,
+	}
+
+	sess := &fakeSession{ctx: context.Background()}
+	assert.NoError(t, w.ConsumeClaim(sess, &fakeClaim{msgs: msgs}))
+
+	assert.Equal(t, 2, attempts)
+	assert.Equal(t, []string{`"fail"`, `not json`}, dlq.sent)
+	assert.Equal(t, []int64{1, 2, 3}, sess.marked)
+}
+
+func TestConsumeClaimLeavesFailedMessagesAtSessionEnd(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	msgs := make(chan *sarama.ConsumerMessage, 3)
+	msgs <- &sarama.ConsumerMessage{Offset: 1, Value: []byte(`"ok"`)}
+	msgs <- &sarama.ConsumerMessage{Offset: 2, Value: []byte(`"fail"`)}
+	msgs <- &sarama.ConsumerMessage{Offset: 3, Value: []byte(`"ok"`)}
+
+	var handled int
+	w := wrap[string]{
+		handlerCtx: context.Background(),
+		handler: func(_ context.Context, s string) error {
+			handled++
+			if s == "fail" {
+				// The session ends while the message waits to be retried.
+				cancel()
+				return errors.New("failed")
+			}
+			return nil
+		},
+		logger:      &zerolog.Logger{},
+		lag:         newLagTracker(),
+		maxAttempts: 5,
+		backoff:     time.Hour,
+	}
+
+	sess := &fakeSession{ctx: ctx}
+	assert.NoError(t, w.ConsumeClaim(sess, &fakeClaim{msgs: msgs}))
+
+	assert.Equal(t, 2, handled)
+	assert.Equal(t, []int64{1}, sess.marked)
+}
+
+func TestConsumeClaimStopsAtSessionEnd(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// Buffered messages are left alone once the session has ended.
+	msgs := make(chan *sarama.ConsumerMessage, 10)
+	for i := range 10 {
+		msgs <- &sarama.ConsumerMessage{Offset: int64(i), Value: []byte(`"ok"`)}
+	}
+
+	w := wrap[string]{
+		handlerCtx: context.Background(),
+		handler: func(context.Context, string) error {
+			t.Error("handled a message after the session ended")
+			return nil
+		},
+		logger: &zerolog.Logger{},
+		lag:    newLagTracker(),
+	}
+
+	sess := &fakeSession{ctx: ctx}
+	assert.NoError(t, w.ConsumeClaim(sess, &fakeClaim{msgs: msgs}))
+	assert.Empty(t, sess.marked)
+}
+
+func TestConsumeClaimLeavesAbandonedMessages(t *testing.T) {
+	handlerCtx, abandon := context.WithCancel(context.Background())
+
+	msgs := make(chan *sarama.ConsumerMessage, 2)
+	msgs <- &sarama.ConsumerMessage{Offset: 1, Value: []byte(`"ok"`)}
+	msgs <- &sarama.ConsumerMessage{Offset: 2, Value: []byte(`"slow"`)}
+
+	w := wrap[string]{
+		handlerCtx: handlerCtx,
+		handler: func(ctx context.Context, s string) error {
+			if s == "slow" {
+				abandon()
+				<-ctx.Done()
+				return ctx.Err()
+			}
+			return nil
+		},
+		logger: &zerolog.Logger{},
+		lag:    newLagTracker(),
+	}
+
+	sess := &fakeSession{ctx: context.Background()}
+	assert.NoError(t, w.ConsumeClaim(sess, &fakeClaim{msgs: msgs}))
+
+	assert.Equal(t, []int64{1}, sess.marked)
+}
+
+func TestLagTracker(t *testing.T) {
+	l := newLagTracker()
+	l.set(0, 5)
+	l.set(1, 3)
+	l.set(2, -1)
+	assert.Equal(t, int64(8), l.total())
+
+	l.release(0)
+	assert.Equal(t, int64(3), l.total())
+}
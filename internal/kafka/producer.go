@@ -0,0 +1,74 @@
+package kafka
+
+import (
+	"context"
+
+	"github.com/IBM/sarama"
+	"go.opentelemetry.io/otel"
+)
+
+// Producer sends messages to a single topic, waiting for each to be acknowledged.
+type Producer struct {
+	producer sarama.SyncProducer
+	topic    string
+}
+
+// NewProducer connects a producer for topic.
+func NewProducer(brokers []string, topic string) (*Producer, error) {
+	kconf := sarama.NewConfig()
+	kconf.Version = sarama.V3_6_0_0
+	kconf.Producer.Return.Successes = true
+	kconf.Producer.RequiredAcks = sarama.WaitForAll
+
+	p, err := sarama.NewSyncProducer(brokers, kconf)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Producer{producer: p, topic: topic}, nil
+}
+
+// Send publishes value under key. The trace context in ctx, if any, is passed along in the
+// message headers.
+func (p *Producer) Send(ctx context.Context, key string, value []byte) error {
+	msg := &sarama.ProducerMessage{
+		Topic: p.topic,
+		Key:   sarama.StringEncoder(key),
+		Value: sarama.ByteEncoder(value),
+	}
+	otel.GetTextMapPropagator().Inject(ctx, headerCarrier{msg})
+
+	_, _, err := p.producer.SendMessage(msg)
+	return err
+}
+
+// Close flushes and closes the producer.
+func (p *Producer) Close() error {
+	return p.producer.Close()
+}
+
+// headerCarrier adapts producer message headers for propagation.
+type headerCarrier struct {
+	msg *sarama.ProducerMessage
+}
+
+func (h headerCarrier) Get(key string) string {
+	for _, r := range h.msg.Headers {
+		if string(r.Key) == key {
+			return string(r.Value)
+		}
+	}
+	return ""
+}
+
+func (h headerCarrier) Set(key, value string) {
+	h.msg.Headers = append(h.msg.Headers, sarama.RecordHeader{Key: []byte(key), Value: []byte(value)})
+}
+
+func (h headerCarrier) Keys() []string {
+	keys := make([]string, len(h.msg.Headers))
+	for i, r := range h.msg.Headers {
+		keys[i] = string(r.Key)
+	}
+	return keys
+}
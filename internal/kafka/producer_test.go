@@ -0,0 +1,43 @@
+package kafka
+
+import (
+	"context"
+	"testing"
+
+	"github.com/IBM/sarama"
+	"github.com/IBM/sarama/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestProducerSendsTraceContext(t *testing.T) {
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
+	mp := mocks.NewSyncProducer(t, nil)
+	var got *sarama.ProducerMessage
+	mp.ExpectSendMessageWithMessageCheckerFunctionAndSucceed(func(msg *sarama.ProducerMessage) error {
+		got = msg
+		return nil
+	})
+
+	traceID, _ := trace.TraceIDFromHex("4bf92f3577b34da6a3ce929d0e0e4736")
+	spanID, _ := trace.SpanIDFromHex("00f067aa0ba902b7")
+	ctx := trace.ContextWithSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    traceID,
+		SpanID:     spanID,
+		TraceFlags: trace.FlagsSampled,
+	}))
+
+	p := &Producer{producer: mp, topic: "topic.trip.lifecycle"}
+	require.NoError(t, p.Send(ctx, "17", []byte(`{}`)))
+	require.NoError(t, p.Close())
+
+	require.NotNil(t, got)
+	assert.Equal(t, "topic.trip.lifecycle", got.Topic)
+	key, _ := got.Key.Encode()
+	assert.Equal(t, "17", string(key))
+	assert.Equal(t, "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01", headerCarrier{got}.Get("traceparent"))
+}
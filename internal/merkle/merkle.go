@@ -0,0 +1,105 @@
+// Package merkle builds Merkle trees of trips and their inclusion proofs. Trees follow
+// RFC 6962: leaves and interior nodes are hashed with SHA-256 under distinct prefixes, and a
+// tree of n leaves splits at the largest power of two below n, so trees of any size are
+// well-defined without padding.
+package merkle
+
+import (
+	"crypto/sha256"
+	"errors"
+	"math/bits"
+)
+
+type Hash = [32]byte
+
+const (
+	leafPrefix = 0x00
+	nodePrefix = 0x01
+)
+
+// ErrBadProof is returned for proofs that don't lead to the root.
+var ErrBadProof = errors.New("proof doesn't lead to root")
+
+// LeafHash is the leaf of a trip with the given id and data hash. The data hash has a fixed
+// length, so it comes first to keep the encoding unambiguous.
+func LeafHash(tripID string, dataHash Hash) Hash {
+	h := sha256.New()
+	h.Write([]byte{leafPrefix})
+	h.Write(dataHash[:])
+	h.Write([]byte(tripID))
+	var out Hash
+	h.Sum(out[:0])
+	return out
+}
+
+func nodeHash(left, right Hash) Hash {
+	h := sha256.New()
+	h.Write([]byte{nodePrefix})
+	h.Write(left[:])
+	h.Write(right[:])
+	var out Hash
+	h.Sum(out[:0])
+	return out
+}
+
+// split is the largest power of two less than n, for n > 1.
+func split(n int) int {
+	return 1 << (bits.Len(uint(n-1)) - 1)
+}
+
+// Root is the root of the tree with the given leaves. The root of an empty tree is the hash
+// of nothing.
+func Root(leaves []Hash) Hash {
+	switch len(leaves) {
+	case 0:
+		return sha256.Sum256(nil)
+	case 1:
+		return leaves[0]
+	}
+	k := split(len(leaves))
+	return nodeHash(Root(leaves[:k]), Root(leaves[k:]))
+}
+
+// Proof is the inclusion proof of the leaf at index, listing sibling hashes from the leaf up.
+func Proof(leaves []Hash, index int) []Hash {
+	if len(leaves) <= 1 {
+		return nil
+	}
+	k := split(len(leaves))
+	if index < k {
+		return append(Proof(leaves[:k], index), Root(leaves[k:]))
+	}
+	return append(Proof(leaves[k:], index-k), Root(leaves[:k]))
+}
+
+// Verify checks that the proof shows the leaf at index in a tree of size leaves with the given
+// root, as in RFC 9162 section 2.1.3.2.
+func Verify(leaf Hash, index, size int, proof []Hash, root Hash) error {
+	if index < 0 || index >= size {
+		return ErrBadProof
+	}
+
+	fn, sn := index, size-1
+	r := leaf
+	for _, p := range proof {
+		if sn == 0 {
+			return ErrBadProof
+		}
+		if fn&1 == 1 || fn == sn {
+			r = nodeHash(p, r)
+			for fn&1 == 0 && fn != 0 {
+				fn >>= 1
+				sn >>= 1
+			}
+		} else {
+			r = nodeHash(r, p)
+		}
+		fn >>= 1
+		sn >>= 1
+	}
+
+	if sn != 0 || r != root {
+		return ErrBadProof
+	}
+	return nil
+}
@@ -0,0 +1,46 @@
+package merkle
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func leaves(n int) []Hash {
+	out := make([]Hash, n)
+	for i := range out {
+		out[i] = LeafHash(fmt.Sprintf("trip%d", i), sha256.Sum256([]byte{byte(i)}))
+	}
+	return out
+}
+
+func TestProofs(t *testing.T) {
+	for n := 1; n <= 17; n++ {
+		ls := leaves(n)
+		root := Root(ls)
+		for i := range ls {
+			proof := Proof(ls, i)
+			assert.NoError(t, Verify(ls[i], i, n, proof, root), "leaf %d of %d", i, n)
+
+			if n > 1 {
+				assert.ErrorIs(t, Verify(ls[i], (i+1)%n, n, proof, root), ErrBadProof, "wrong index %d of %d", i, n)
+				assert.ErrorIs(t, Verify(ls[(i+1)%n], i, n, proof, root), ErrBadProof, "wrong leaf %d of %d", i, n)
+				assert.ErrorIs(t, Verify(ls[i], i, n, proof[:len(proof)-1], root), ErrBadProof, "short proof %d of %d", i, n)
+			}
+		}
+	}
+}
+
+func TestRoot(t *testing.T) {
+	ls := leaves(3)
+	// With three leaves, the first two pair up and the third joins at the root.
+	assert.Equal(t, nodeHash(nodeHash(ls[0], ls[1]), ls[2]), Root(ls))
+	assert.Equal(t, ls[0], Root(ls[:1]))
+	assert.Equal(t, Hash(sha256.Sum256(nil)), Root(nil))
+
+	// Leaves and nodes are hashed apart, so a node can't pass for a leaf.
+	assert.NotEqual(t, LeafHash("a", Hash{}), LeafHash("b", Hash{}))
+	assert.NotEqual(t, LeafHash("a", Hash{}), nodeHash(Hash{}, Hash{}))
+}
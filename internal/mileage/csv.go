@@ -0,0 +1,71 @@
+package mileage
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// WriteCSV writes the log with one row per trip.
+func WriteCSV(w io.Writer, l *Log) error {
+	cw := csv.NewWriter(w)
+
+	header := []string{
+		"Date", "Start Time", "End Time",
+		"Start Latitude", "Start Longitude", "End Latitude", "End Longitude",
+		"Distance (" + l.Unit + ")", "Duration (min)", "Purpose", "Trip ID",
+	}
+	if err := cw.Write(header); err != nil {
+		return err
+	}
+
+	for _, e := range l.Entries {
+		row := []string{
+			e.StartTime.Format(time.DateOnly),
+			e.StartTime.Format(time.TimeOnly),
+			e.EndTime.Format(time.TimeOnly),
+		}
+		row = append(row, coordinates(e.Start)...)
+		row = append(row, coordinates(e.End)...)
+		row = append(row,
+			formatDistance(e.Distance),
+			strconv.FormatFloat(e.EndTime.Sub(e.StartTime).Minutes(), 'f', 0, 64),
+			escapeFormula(e.PurposeName()),
+			e.TripID,
+		)
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+// escapeFormula prefixes user-supplied text that a spreadsheet would take for a formula with
+// an apostrophe, so that it's shown as text rather than evaluated.
+func escapeFormula(s string) string {
+	if s != "" && strings.ContainsRune("=+-@\t\r", rune(s[0])) {
+		return "'" + s
+	}
+	return s
+}
+
+func coordinates(l *Location) []string {
+	if l == nil {
+		return []string{"", ""}
+	}
+	return []string{
+		strconv.FormatFloat(l.Latitude, 'f', 5, 64),
+		strconv.FormatFloat(l.Longitude, 'f', 5, 64),
+	}
+}
+
+func formatDistance(d *float64) string {
+	if d == nil {
+		return ""
+	}
+	return strconv.FormatFloat(*d, 'f', 1, 64)
+}
@@ -0,0 +1,122 @@
+// Package mileage renders a vehicle's trips as a mileage log for tax and expense claims.
+package mileage
+
+import (
+	"time"
+
+	"github.com/DIMO-Network/trips-api/models"
+	"github.com/volatiletech/sqlboiler/v4/types/pgeo"
+)
+
+// Purposes that a trip can be given.
+const (
+	PurposeBusiness = "business"
+	PurposePersonal = "personal"
+	PurposeCommute  = "commute"
+	// PurposeCustom trips carry a purpose named by the user.
+	PurposeCustom = "custom"
+)
+
+// Purposes lists the valid trip purposes.
+var Purposes = []string{PurposeBusiness, PurposePersonal, PurposeCommute, PurposeCustom}
+
+// Units that distances can be reported in.
+const (
+	UnitKilometers = "km"
+	UnitMiles      = "mi"
+)
+
+const kmPerMile = 1.609344
+
+type Location struct {
+	Latitude  float64
+	Longitude float64
+}
+
+// Entry is one trip in the log. Times are in the log's location.
+type Entry struct {
+	TripID    string
+	StartTime time.Time
+	EndTime   time.Time
+	Start     *Location
+	End       *Location
+	// Distance is in the log's unit. It is nil if the trip couldn't be measured.
+	Distance      *float64
+	Purpose       string
+	CustomPurpose string
+}
+
+// PurposeName is the user's name for a custom purpose, and otherwise the purpose itself.
+func (e Entry) PurposeName() string {
+	if e.Purpose == PurposeCustom {
+		return e.CustomPurpose
+	}
+	return e.Purpose
+}
+
+// Log is a vehicle's trips over a range, ready to be written out.
+type Log struct {
+	VehicleTokenID int
+	Location       *time.Location
+	Unit           string
+	From, To       time.Time
+	Entries        []Entry
+}
+
+// New builds a log from completed trips, converting times to loc and distances to unit.
+func New(tokenID int, loc *time.Location, unit string, from, to time.Time, trips models.TripSlice) *Log {
+	l := &Log{
+		VehicleTokenID: tokenID,
+		Location:       loc,
+		Unit:           unit,
+		From:           from.In(loc),
+		To:             to.In(loc),
+		Entries:        make([]Entry, len(trips)),
+	}
+
+	for i, trp := range trips {
+		start := location(trp.StartPosition)
+		if start == nil {
+			start = location(trp.StartPositionEstimate)
+		}
+
+		e := Entry{
+			TripID:        trp.ID,
+			StartTime:     trp.StartTime.In(loc),
+			EndTime:       trp.EndTime.Time.In(loc),
+			Start:         start,
+			End:           location(trp.EndPosition),
+			Purpose:       trp.Purpose.String,
+			CustomPurpose: trp.CustomPurpose.String,
+		}
+		if trp.DistanceKM.Valid {
+			d := trp.DistanceKM.Float64
+			if unit == UnitMiles {
+				d /= kmPerMile
+			}
+			e.Distance = &d
+		}
+		l.Entries[i] = e
+	}
+
+	return l
+}
+
+// Totals sums the distance logged for each purpose, with unassigned trips under the empty
+// string.
+func (l *Log) Totals() map[string]float64 {
+	totals := make(map[string]float64)
+	for _, e := range l.Entries {
+		if e.Distance != nil {
+			totals[e.Purpose] += *e.Distance
+		}
+	}
+	return totals
+}
+
+func location(p pgeo.NullPoint) *Location {
+	if p.Valid {
+		return &Location{Latitude: p.Y, Longitude: p.X}
+	}
+	return nil
+}
@@ -0,0 +1,99 @@
+package mileage
+
+import (
+	"bytes"
+	"encoding/csv"
+	"testing"
+	"time"
+
+	"github.com/DIMO-Network/trips-api/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/types/pgeo"
+)
+
+func testLog(t *testing.T, unit string) *Log {
+	loc, err := time.LoadLocation("Europe/Berlin")
+	require.NoError(t, err)
+
+	start := time.Date(2024, 3, 1, 7, 30, 0, 0, time.UTC)
+	trips := models.TripSlice{
+		{
+			ID:             "2Y83IHPItgk0uHD7hybGnA776Bo",
+			VehicleTokenID: 1,
+			StartTime:      start,
+			EndTime:        null.TimeFrom(start.Add(25 * time.Minute)),
+			StartPosition:  pgeo.NewNullPoint(pgeo.NewPoint(13.40495, 52.52001), true),
+			EndPosition:    pgeo.NewNullPoint(pgeo.NewPoint(13.37691, 52.51628), true),
+			DistanceKM:     null.Float64From(16.09344),
+			Purpose:        null.StringFrom(PurposeBusiness),
+		},
+		{
+			ID:             "2Y83IHPItgk0uHD7hybGnA776Bp",
+			VehicleTokenID: 1,
+			StartTime:      start.Add(10 * time.Hour),
+			EndTime:        null.TimeFrom(start.Add(11 * time.Hour)),
+		},
+	}
+
+	return New(1, loc, unit, start.Add(-time.Hour), start.Add(24*time.Hour), trips)
+}
+
+func TestWriteCSV(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, WriteCSV(&buf, testLog(t, UnitKilometers)))
+
+	rows, err := csv.NewReader(&buf).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, rows, 3)
+
+	assert.Equal(t, "Distance (km)", rows[0][7])
+	assert.Equal(t, []string{
+		"2024-03-01", "08:30:00", "08:55:00",
+		"52.52001", "13.40495", "52.51628", "13.37691",
+		"16.1", "25", "business", "2Y83IHPItgk0uHD7hybGnA776Bo",
+	}, rows[1])
+	assert.Equal(t, []string{
+		"2024-03-01", "18:30:00", "19:30:00",
+		"", "", "", "",
+		"", "60", "", "2Y83IHPItgk0uHD7hybGnA776Bp",
+	}, rows[2])
+}
+
+func TestWriteCSVEscapesFormulas(t *testing.T) {
+	l := testLog(t, UnitKilometers)
+	for purpose, want := range map[string]string{
+		`=HYPERLINK("https://example.com","x")`: `'=HYPERLINK("https://example.com","x")`,
+		"+1":                                    "'+1",
+		"-1+2":                                  "'-1+2",
+		"@SUM(A1)":                              "'@SUM(A1)",
+		"\t=1":                                  "'\t=1",
+		"client visit":                          "client visit",
+	} {
+		l.Entries[0].Purpose = PurposeCustom
+		l.Entries[0].CustomPurpose = purpose
+
+		var buf bytes.Buffer
+		require.NoError(t, WriteCSV(&buf, l))
+
+		rows, err := csv.NewReader(&buf).ReadAll()
+		require.NoError(t, err)
+		assert.Equal(t, want, rows[1][9], purpose)
+	}
+}
+
+func TestMiles(t *testing.T) {
+	l := testLog(t, UnitMiles)
+
+	require.NotNil(t, l.Entries[0].Distance)
+	assert.InDelta(t, 10, *l.Entries[0].Distance, 1e-9)
+	assert.Nil(t, l.Entries[1].Distance)
+	assert.Equal(t, map[string]float64{PurposeBusiness: *l.Entries[0].Distance}, l.Totals())
+}
+
+func TestWritePDF(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, WritePDF(&buf, testLog(t, UnitKilometers)))
+	assert.True(t, bytes.HasPrefix(buf.Bytes(), []byte("%PDF-")))
+}
@@ -0,0 +1,110 @@
+package mileage
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-pdf/fpdf"
+)
+
+type column struct {
+	title string
+	width float64
+	align string
+}
+
+// WritePDF writes the log as a landscape A4 table, followed by the distance totals for each
+// purpose.
+func WritePDF(w io.Writer, l *Log) error {
+	cols := []column{
+		{"Date", 24, "L"},
+		{"Start", 16, "L"},
+		{"End", 16, "L"},
+		{"From", 52, "L"},
+		{"To", 52, "L"},
+		{"Distance (" + l.Unit + ")", 28, "R"},
+		{"Duration (min)", 28, "R"},
+		{"Purpose", 60, "L"},
+	}
+
+	pdf := fpdf.New("L", "mm", "A4", "")
+	pdf.SetTitle("Mileage log", false)
+	pdf.SetCreator("DIMO trips-api", false)
+	pdf.SetAutoPageBreak(true, 15)
+	// The core fonts are limited to code page 1252, which custom purposes may not fit in.
+	tr := pdf.UnicodeTranslatorFromDescriptor("")
+
+	header := func() {
+		pdf.SetFont("Helvetica", "B", 9)
+		pdf.SetFillColor(230, 230, 230)
+		for _, c := range cols {
+			pdf.CellFormat(c.width, 7, c.title, "1", 0, c.align, true, 0, "")
+		}
+		pdf.Ln(-1)
+		pdf.SetFont("Helvetica", "", 9)
+	}
+	pdf.SetHeaderFunc(func() {
+		if pdf.PageNo() > 1 {
+			header()
+		}
+	})
+	pdf.SetFooterFunc(func() {
+		pdf.SetY(-12)
+		pdf.SetFont("Helvetica", "I", 8)
+		pdf.CellFormat(0, 6, fmt.Sprintf("Page %d", pdf.PageNo()), "", 0, "C", false, 0, "")
+	})
+
+	pdf.AddPage()
+	pdf.SetFont("Helvetica", "B", 16)
+	pdf.CellFormat(0, 10, "Mileage log", "", 1, "L", false, 0, "")
+	pdf.SetFont("Helvetica", "", 10)
+	pdf.CellFormat(0, 6, fmt.Sprintf("Vehicle %d", l.VehicleTokenID), "", 1, "L", false, 0, "")
+	pdf.CellFormat(0, 6, fmt.Sprintf("%s to %s (%s)", l.From.Format(time.DateTime), l.To.Format(time.DateTime), l.Location), "", 1, "L", false, 0, "")
+	pdf.Ln(4)
+
+	header()
+	for _, e := range l.Entries {
+		cells := []string{
+			e.StartTime.Format(time.DateOnly),
+			e.StartTime.Format("15:04"),
+			e.EndTime.Format("15:04"),
+			strings.Join(coordinates(e.Start), ", "),
+			strings.Join(coordinates(e.End), ", "),
+			formatDistance(e.Distance),
+			strconv.FormatFloat(e.EndTime.Sub(e.StartTime).Minutes(), 'f', 0, 64),
+			tr(e.PurposeName()),
+		}
+		for i, c := range cols {
+			pdf.CellFormat(c.width, 6, cells[i], "1", 0, c.align, false, 0, "")
+		}
+		pdf.Ln(-1)
+	}
+
+	totals := l.Totals()
+	pdf.Ln(4)
+	pdf.SetFont("Helvetica", "B", 10)
+	pdf.CellFormat(0, 7, "Totals", "", 1, "L", false, 0, "")
+	pdf.SetFont("Helvetica", "", 10)
+	var all float64
+	for _, p := range []string{PurposeBusiness, PurposePersonal, PurposeCommute, PurposeCustom, ""} {
+		d, ok := totals[p]
+		if !ok {
+			continue
+		}
+		all += d
+		name := p
+		if name == "" {
+			name = "unassigned"
+		}
+		pdf.CellFormat(40, 6, name, "", 0, "L", false, 0, "")
+		pdf.CellFormat(30, 6, fmt.Sprintf("%.1f %s", d, l.Unit), "", 1, "R", false, 0, "")
+	}
+	pdf.SetFont("Helvetica", "B", 10)
+	pdf.CellFormat(40, 6, "all trips", "", 0, "L", false, 0, "")
+	pdf.CellFormat(30, 6, fmt.Sprintf("%.1f %s", all, l.Unit), "", 1, "R", false, 0, "")
+
+	return pdf.Output(w)
+}
@@ -0,0 +1,109 @@
+package privacy
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/DIMO-Network/trips-api/internal/geo"
+	"github.com/DIMO-Network/trips-api/models"
+	"github.com/volatiletech/sqlboiler/v4/types/pgeo"
+)
+
+// Precision says how coordinates are coarsened. The zero value leaves them as they are.
+type Precision struct {
+	// Decimals rounds coordinates to this many decimal places.
+	Decimals int
+	// Geohash, if set, moves coordinates to the center of the geohash cell of this length
+	// instead.
+	Geohash int
+}
+
+// Precision levels. A thousandth of a degree of latitude is about 111 m, and geohash cells of
+// length 7 and 6 are about 150 m and 1.2 km across.
+var (
+	PrecisionFull     = Precision{}
+	Precision100m     = Precision{Decimals: 3}
+	Precision1km      = Precision{Decimals: 2}
+	PrecisionGeohash7 = Precision{Geohash: 7}
+	PrecisionGeohash6 = Precision{Geohash: 6}
+	PrecisionGeohash5 = Precision{Geohash: 5}
+)
+
+var precisions = map[string]Precision{
+	"":     PrecisionFull,
+	"full": PrecisionFull,
+	"100m": Precision100m,
+	"1km":  Precision1km,
+	"gh7":  PrecisionGeohash7,
+	"gh6":  PrecisionGeohash6,
+	"gh5":  PrecisionGeohash5,
+}
+
+// ParsePrecision reads a precision level: full, 100m, 1km, or gh7, gh6 or gh5 for geohash
+// cells. The empty string is full.
+func ParsePrecision(s string) (Precision, error) {
+	p, ok := precisions[s]
+	if !ok {
+		return Precision{}, fmt.Errorf("unknown precision %q", s)
+	}
+	return p, nil
+}
+
+// Meters is roughly how far apart coarsened coordinates are, and zero at full precision.
+func (p Precision) Meters() float64 {
+	switch {
+	case p.Geohash > 0:
+		return geo.GeohashCellMeters(p.Geohash)
+	case p.Decimals > 0:
+		return geo.DecimalsCellMeters(p.Decimals)
+	default:
+		return 0
+	}
+}
+
+// Coarser returns whichever of the precisions is coarser.
+func Coarser(a, b Precision) Precision {
+	if b.Meters() > a.Meters() {
+		return b
+	}
+	return a
+}
+
+// Point coarsens the point.
+func (p Precision) Point(pt pgeo.Point) pgeo.Point {
+	switch {
+	case p.Geohash > 0:
+		return geo.GeohashCenter(pt, p.Geohash)
+	case p.Decimals > 0:
+		return pgeo.NewPoint(round(pt.X, p.Decimals), round(pt.Y, p.Decimals))
+	default:
+		return pt
+	}
+}
+
+// Redaction says how much of a trip's positions a caller may see.
+type Redaction struct {
+	// Hidden removes the positions.
+	Hidden bool
+	// Precision coarsens the positions that remain.
+	Precision Precision
+}
+
+// Trip applies the redaction to the trip's start, estimated start and end positions. It is
+// the one place positions are redacted, so that every output built from the trip agrees.
+func (r Redaction) Trip(trp *models.Trip) {
+	for _, p := range []*pgeo.NullPoint{&trp.StartPosition, &trp.StartPositionEstimate, &trp.EndPosition} {
+		switch {
+		case !p.Valid:
+		case r.Hidden:
+			*p = pgeo.NullPoint{}
+		default:
+			p.Point = r.Precision.Point(p.Point)
+		}
+	}
+}
+
+func round(x float64, decimals int) float64 {
+	scale := math.Pow10(decimals)
+	return math.Round(x*scale) / scale
+}
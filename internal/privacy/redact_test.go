@@ -0,0 +1,70 @@
+package privacy
+
+import (
+	"testing"
+
+	"github.com/DIMO-Network/trips-api/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/volatiletech/sqlboiler/v4/types/pgeo"
+)
+
+func TestRedaction(t *testing.T) {
+	trip := func() *models.Trip {
+		return &models.Trip{
+			StartPosition: pgeo.NewNullPoint(pgeo.NewPoint(-73.98567, 40.74844), true),
+			EndPosition:   pgeo.NewNullPoint(pgeo.NewPoint(-73.96541, 40.78246), true),
+		}
+	}
+
+	trp := trip()
+	Redaction{}.Trip(trp)
+	assert.Equal(t, trip(), trp)
+
+	trp = trip()
+	Redaction{Precision: Precision100m}.Trip(trp)
+	assert.InDelta(t, -73.986, trp.StartPosition.X, 1e-9)
+	assert.InDelta(t, 40.748, trp.StartPosition.Y, 1e-9)
+	assert.InDelta(t, -73.965, trp.EndPosition.X, 1e-9)
+	assert.InDelta(t, 40.782, trp.EndPosition.Y, 1e-9)
+	assert.False(t, trp.StartPositionEstimate.Valid)
+
+	trp = trip()
+	Redaction{Precision: PrecisionGeohash6}.Trip(trp)
+	// The centers of cells dr5ru6 and dr72hb.
+	assert.InDelta(t, -73.98743, trp.StartPosition.X, 1e-5)
+	assert.InDelta(t, 40.75104, trp.StartPosition.Y, 1e-5)
+	assert.InDelta(t, -73.96545, trp.EndPosition.X, 1e-5)
+	assert.InDelta(t, 40.78400, trp.EndPosition.Y, 1e-5)
+
+	trp = trip()
+	Redaction{Hidden: true, Precision: Precision1km}.Trip(trp)
+	assert.False(t, trp.StartPosition.Valid)
+	assert.False(t, trp.EndPosition.Valid)
+}
+
+func TestParsePrecision(t *testing.T) {
+	for s, expected := range map[string]Precision{
+		"":     PrecisionFull,
+		"full": PrecisionFull,
+		"100m": Precision100m,
+		"1km":  Precision1km,
+		"gh7":  PrecisionGeohash7,
+		"gh6":  PrecisionGeohash6,
+		"gh5":  PrecisionGeohash5,
+	} {
+		p, err := ParsePrecision(s)
+		require.NoError(t, err)
+		assert.Equal(t, expected, p, s)
+	}
+
+	_, err := ParsePrecision("10m")
+	assert.Error(t, err)
+}
+
+func TestCoarser(t *testing.T) {
+	assert.Equal(t, Precision1km, Coarser(PrecisionFull, Precision1km))
+	assert.Equal(t, Precision1km, Coarser(Precision1km, Precision100m))
+	assert.Equal(t, PrecisionGeohash6, Coarser(Precision1km, PrecisionGeohash6))
+	assert.Equal(t, PrecisionGeohash5, Coarser(PrecisionGeohash5, PrecisionGeohash7))
+}
@@ -0,0 +1,48 @@
+// Package privacy hides sensitive locations in trips before they are shared.
+package privacy
+
+import (
+	"github.com/DIMO-Network/trips-api/internal/geo"
+	"github.com/DIMO-Network/trips-api/models"
+	"github.com/volatiletech/sqlboiler/v4/types/pgeo"
+)
+
+// How positions inside a privacy zone are masked.
+const (
+	// MaskSnap moves positions to the center of the zone.
+	MaskSnap = "snap"
+	// MaskOmit drops positions.
+	MaskOmit = "omit"
+)
+
+// Masks lists the valid masks.
+var Masks = []string{MaskSnap, MaskOmit}
+
+// MaskTrip masks the trip's start, estimated start and end positions that fall inside any of
+// the zones. Where zones overlap, the first one containing a position masks it.
+func MaskTrip(trp *models.Trip, zones models.PrivacyZoneSlice) {
+	if len(zones) == 0 {
+		return
+	}
+
+	for _, p := range []*pgeo.NullPoint{&trp.StartPosition, &trp.StartPositionEstimate, &trp.EndPosition} {
+		*p = maskPoint(*p, zones)
+	}
+}
+
+func maskPoint(p pgeo.NullPoint, zones models.PrivacyZoneSlice) pgeo.NullPoint {
+	if !p.Valid {
+		return p
+	}
+
+	for _, z := range zones {
+		if !(geo.Zone{Center: z.Center, RadiusKm: float64(z.RadiusM) / 1000}).Contains(p.Point) {
+			continue
+		}
+		if z.Mask == MaskOmit {
+			return pgeo.NullPoint{}
+		}
+		return pgeo.NewNullPoint(z.Center, true)
+	}
+	return p
+}
@@ -0,0 +1,34 @@
+package privacy
+
+import (
+	"testing"
+
+	"github.com/DIMO-Network/trips-api/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/volatiletech/sqlboiler/v4/types/pgeo"
+)
+
+func TestMaskTrip(t *testing.T) {
+	home := pgeo.NewPoint(-73.98, 40.75)
+	work := pgeo.NewPoint(-73.97, 40.80)
+	zones := models.PrivacyZoneSlice{
+		{Name: "Home", Center: home, RadiusM: 200, Mask: MaskSnap},
+		{Name: "Work", Center: work, RadiusM: 500, Mask: MaskOmit},
+	}
+
+	// About 110 m north of home, and 330 m east of work.
+	nearHome := pgeo.NewNullPoint(pgeo.NewPoint(-73.98, 40.751), true)
+	nearWork := pgeo.NewNullPoint(pgeo.NewPoint(-73.966, 40.80), true)
+	elsewhere := pgeo.NewNullPoint(pgeo.NewPoint(-73.90, 40.70), true)
+
+	trp := &models.Trip{StartPosition: nearHome, StartPositionEstimate: elsewhere, EndPosition: nearWork}
+	MaskTrip(trp, zones)
+
+	assert.Equal(t, pgeo.NewNullPoint(home, true), trp.StartPosition)
+	assert.Equal(t, elsewhere, trp.StartPositionEstimate)
+	assert.False(t, trp.EndPosition.Valid)
+
+	trp = &models.Trip{StartPosition: nearHome}
+	MaskTrip(trp, nil)
+	assert.Equal(t, nearHome, trp.StartPosition)
+}
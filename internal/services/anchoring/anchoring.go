@@ -0,0 +1,198 @@
+// Package anchoring rolls completed trips into Merkle trees and publishes their roots, so that
+// trips can be shown unchanged without trusting the database.
+package anchoring
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/DIMO-Network/trips-api/internal/merkle"
+	"github.com/DIMO-Network/trips-api/models"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/rs/zerolog"
+)
+
+const (
+	defaultInterval  = time.Hour
+	defaultBatchSize = 1000
+)
+
+// Publisher makes an anchor's root public and returns a reference to where it went, such as a
+// transaction id.
+type Publisher interface {
+	// Name identifies the publisher in anchors and metrics.
+	Name() string
+	Publish(ctx context.Context, anchor *models.Anchor) (string, error)
+}
+
+type Config struct {
+	Interval time.Duration
+	// BatchSize caps the number of trips in a tree.
+	BatchSize int
+}
+
+type store interface {
+	CreateAnchor(ctx context.Context, limit int, publisher string) (*models.Anchor, error)
+	UnpublishedAnchors(ctx context.Context) (models.AnchorSlice, error)
+	MarkAnchorPublished(ctx context.Context, id, reference string, at time.Time) error
+}
+
+// Anchorer periodically anchors the trips completed since its last run.
+type Anchorer struct {
+	store     store
+	publisher Publisher
+	config    Config
+	logger    *zerolog.Logger
+}
+
+func New(store store, publisher Publisher, config Config, logger *zerolog.Logger) *Anchorer {
+	if config.Interval <= 0 {
+		config.Interval = defaultInterval
+	}
+	if config.BatchSize <= 0 {
+		config.BatchSize = defaultBatchSize
+	}
+	return &Anchorer{store, publisher, config, logger}
+}
+
+// Run anchors every interval until ctx is cancelled.
+func (a *Anchorer) Run(ctx context.Context) {
+	ticker := time.NewTicker(a.config.Interval)
+	defer ticker.Stop()
+
+	for {
+		if err := a.Anchor(ctx); err != nil && ctx.Err() != nil {
+			return
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Anchor publishes any anchors left over from failed runs, then batches and publishes the
+// trips not yet anchored, until a batch comes up short.
+func (a *Anchorer) Anchor(ctx context.Context) error {
+	err := a.anchor(ctx)
+	if err != nil {
+		AnchorRunsTotal.WithLabelValues("error").Inc()
+		a.logger.Error().Err(err).Msg("Anchoring failed.")
+		return err
+	}
+	AnchorRunsTotal.WithLabelValues("success").Inc()
+	AnchorLastSuccess.SetToCurrentTime()
+	return nil
+}
+
+func (a *Anchorer) anchor(ctx context.Context) error {
+	pending, err := a.store.UnpublishedAnchors(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list unpublished anchors: %w", err)
+	}
+	for _, anc := range pending {
+		if err := a.publish(ctx, anc); err != nil {
+			return err
+		}
+	}
+
+	for {
+		anc, err := a.store.CreateAnchor(ctx, a.config.BatchSize, a.publisher.Name())
+		if err != nil {
+			return fmt.Errorf("failed to create anchor: %w", err)
+		}
+		if anc == nil {
+			return nil
+		}
+		AnchoredTripsTotal.Add(float64(anc.LeafCount))
+
+		if err := a.publish(ctx, anc); err != nil {
+			return err
+		}
+		if anc.LeafCount < a.config.BatchSize {
+			return nil
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+	}
+}
+
+func (a *Anchorer) publish(ctx context.Context, anc *models.Anchor) error {
+	ref, err := a.publisher.Publish(ctx, anc)
+	if err != nil {
+		return fmt.Errorf("failed to publish anchor %s: %w", anc.ID, err)
+	}
+	if err := a.store.MarkAnchorPublished(ctx, anc.ID, ref, time.Now()); err != nil {
+		return fmt.Errorf("failed to record publishing of anchor %s: %w", anc.ID, err)
+	}
+	AnchorsPublishedTotal.WithLabelValues(a.publisher.Name()).Inc()
+
+	a.logger.Info().Str("anchorId", anc.ID).Hex("root", anc.Root).Int("leafCount", anc.LeafCount).
+		Str("publisher", a.publisher.Name()).Str("reference", ref).Msg("Published anchor.")
+	return nil
+}
+
+// LocalPublisher stands in for publishing to a chain in development. It only logs the root,
+// and references it by its hex.
+type LocalPublisher struct {
+	logger *zerolog.Logger
+}
+
+func NewLocalPublisher(logger *zerolog.Logger) *LocalPublisher {
+	return &LocalPublisher{logger}
+}
+
+func (p *LocalPublisher) Name() string {
+	return "local"
+}
+
+func (p *LocalPublisher) Publish(_ context.Context, anc *models.Anchor) (string, error) {
+	root := merkle.Hash(anc.Root)
+	p.logger.Debug().Str("anchorId", anc.ID).Hex("root", root[:]).Msg("Anchored locally.")
+	return fmt.Sprintf("local:%x", root), nil
+}
+
+var (
+	AnchoredTripsTotal = promauto.NewCounter(
+		prometheus.CounterOpts{
+			Namespace: "trips_api",
+			Subsystem: "anchoring",
+			Name:      "trips_total",
+			Help:      "The total number of trips added to anchors.",
+		},
+	)
+
+	AnchorsPublishedTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "trips_api",
+			Subsystem: "anchoring",
+			Name:      "published_total",
+			Help:      "The total number of anchors published, by publisher.",
+		},
+		[]string{"publisher"},
+	)
+
+	AnchorRunsTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "trips_api",
+			Subsystem: "anchoring",
+			Name:      "runs_total",
+			Help:      "The total number of anchoring runs, by outcome.",
+		},
+		[]string{"outcome"},
+	)
+
+	AnchorLastSuccess = promauto.NewGauge(
+		prometheus.GaugeOpts{
+			Namespace: "trips_api",
+			Subsystem: "anchoring",
+			Name:      "last_success_timestamp_seconds",
+			Help:      "The time of the last successful anchoring run.",
+		},
+	)
+)
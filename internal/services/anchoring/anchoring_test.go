@@ -0,0 +1,115 @@
+package anchoring
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/DIMO-Network/trips-api/models"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/volatiletech/null/v8"
+)
+
+// fakeStore holds the number of trips not yet anchored, and the anchors made so far.
+type fakeStore struct {
+	pending int
+	anchors models.AnchorSlice
+}
+
+func (f *fakeStore) CreateAnchor(_ context.Context, limit int, publisher string) (*models.Anchor, error) {
+	n := min(f.pending, limit)
+	if n == 0 {
+		return nil, nil
+	}
+	f.pending -= n
+	a := &models.Anchor{ID: fmt.Sprintf("anchor%d", len(f.anchors)), Root: make([]byte, 32), LeafCount: n, Publisher: publisher}
+	f.anchors = append(f.anchors, a)
+	return a, nil
+}
+
+func (f *fakeStore) UnpublishedAnchors(context.Context) (models.AnchorSlice, error) {
+	var out models.AnchorSlice
+	for _, a := range f.anchors {
+		if !a.PublishedAt.Valid {
+			out = append(out, a)
+		}
+	}
+	return out, nil
+}
+
+func (f *fakeStore) MarkAnchorPublished(_ context.Context, id, reference string, at time.Time) error {
+	for _, a := range f.anchors {
+		if a.ID == id {
+			a.Reference = null.StringFrom(reference)
+			a.PublishedAt = null.TimeFrom(at)
+		}
+	}
+	return nil
+}
+
+type fakePublisher struct {
+	published []string
+	err       error
+}
+
+func (p *fakePublisher) Name() string {
+	return "fake"
+}
+
+func (p *fakePublisher) Publish(_ context.Context, a *models.Anchor) (string, error) {
+	if p.err != nil {
+		return "", p.err
+	}
+	p.published = append(p.published, a.ID)
+	return "ref-" + a.ID, nil
+}
+
+func TestAnchorBatches(t *testing.T) {
+	store := &fakeStore{pending: 25}
+	pub := &fakePublisher{}
+	a := New(store, pub, Config{BatchSize: 10}, &zerolog.Logger{})
+
+	require.NoError(t, a.Anchor(context.Background()))
+
+	require.Len(t, store.anchors, 3)
+	assert.Equal(t, []int{10, 10, 5}, []int{store.anchors[0].LeafCount, store.anchors[1].LeafCount, store.anchors[2].LeafCount})
+	assert.Equal(t, []string{"anchor0", "anchor1", "anchor2"}, pub.published)
+	for _, anc := range store.anchors {
+		assert.Equal(t, "fake", anc.Publisher)
+		assert.Equal(t, "ref-"+anc.ID, anc.Reference.String)
+		assert.True(t, anc.PublishedAt.Valid)
+	}
+
+	// Nothing new, nothing anchored.
+	require.NoError(t, a.Anchor(context.Background()))
+	assert.Len(t, store.anchors, 3)
+}
+
+func TestAnchorRetriesPublishing(t *testing.T) {
+	store := &fakeStore{pending: 3}
+	pub := &fakePublisher{err: errors.New("unreachable")}
+	a := New(store, pub, Config{BatchSize: 10}, &zerolog.Logger{})
+
+	require.Error(t, a.Anchor(context.Background()))
+	require.Len(t, store.anchors, 1)
+	assert.False(t, store.anchors[0].PublishedAt.Valid)
+
+	// The next run publishes the leftover anchor before batching new trips.
+	pub.err = nil
+	store.pending = 2
+	require.NoError(t, a.Anchor(context.Background()))
+	assert.Equal(t, []string{"anchor0", "anchor1"}, pub.published)
+	assert.Equal(t, 2, store.anchors[1].LeafCount)
+}
+
+func TestLocalPublisher(t *testing.T) {
+	root := make([]byte, 32)
+	root[31] = 1
+	ref, err := NewLocalPublisher(&zerolog.Logger{}).Publish(context.Background(), &models.Anchor{ID: "a", Root: root})
+	require.NoError(t, err)
+	assert.Equal(t, "local:0000000000000000000000000000000000000000000000000000000000000001", ref)
+}
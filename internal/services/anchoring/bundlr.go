@@ -0,0 +1,67 @@
+package anchoring
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"github.com/DIMO-Network/trips-api/models"
+	"github.com/warp-contracts/syncer/src/utils/bundlr"
+)
+
+type uploader interface {
+	PreparePlain(data []byte, tags bundlr.Tags) (*bundlr.BundleItem, error)
+	Upload(ctx context.Context, dataItem *bundlr.BundleItem) error
+}
+
+// BundlrPublisher publishes anchors as unencrypted Bundlr uploads, and references them by
+// transaction id.
+type BundlrPublisher struct {
+	uploader uploader
+}
+
+func NewBundlrPublisher(uploader uploader) *BundlrPublisher {
+	return &BundlrPublisher{uploader}
+}
+
+func (p *BundlrPublisher) Name() string {
+	return "bundlr"
+}
+
+// bundlrAnchor is the body of an anchor upload.
+type bundlrAnchor struct {
+	AnchorID  string    `json:"anchorId"`
+	Root      string    `json:"root"`
+	LeafCount int       `json:"leafCount"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+func (p *BundlrPublisher) Publish(ctx context.Context, anc *models.Anchor) (string, error) {
+	root := hex.EncodeToString(anc.Root)
+	body, err := json.Marshal(bundlrAnchor{
+		AnchorID:  anc.ID,
+		Root:      root,
+		LeafCount: anc.LeafCount,
+		CreatedAt: anc.CreatedAt,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	dataItem, err := p.uploader.PreparePlain(body, bundlr.Tags{
+		bundlr.Tag{Name: "Content-Type", Value: "application/json"},
+		bundlr.Tag{Name: "Anchor-Id", Value: anc.ID},
+		bundlr.Tag{Name: "Merkle-Root", Value: root},
+		bundlr.Tag{Name: "Leaf-Count", Value: strconv.Itoa(anc.LeafCount)},
+	})
+	if err != nil {
+		return "", err
+	}
+
+	if err := p.uploader.Upload(ctx, dataItem); err != nil {
+		return "", err
+	}
+	return dataItem.Id.Base64(), nil
+}
@@ -0,0 +1,145 @@
+package bundlr
+
+import (
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/DIMO-Network/trips-api/internal/tracing"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// ErrHashMismatch is returned by Verify when an archive doesn't match a recorded hash.
+var ErrHashMismatch = errors.New("hash mismatch")
+
+// Archive is an upload as downloaded from Bundlr.
+type Archive struct {
+	Ciphertext []byte
+	Tags       map[string]string
+}
+
+// Download fetches the upload with the given id and its tags.
+func (c *Client) Download(ctx context.Context, id string) (_ *Archive, err error) {
+	ctx, span := tracing.Tracer().Start(ctx, "bundlr download", trace.WithSpanKind(trace.SpanKindClient))
+	defer func() { tracing.End(span, err) }()
+
+	meta, err := c.get(ctx, "tx/"+id)
+	if err != nil {
+		return nil, err
+	}
+
+	var tx struct {
+		Tags []struct {
+			Name  string `json:"name"`
+			Value string `json:"value"`
+		} `json:"tags"`
+	}
+	if err := json.Unmarshal(meta, &tx); err != nil {
+		return nil, fmt.Errorf("couldn't parse transaction: %w", err)
+	}
+
+	data, err := c.get(ctx, "tx/"+id+"/data")
+	if err != nil {
+		return nil, err
+	}
+
+	a := &Archive{Ciphertext: data, Tags: make(map[string]string, len(tx.Tags))}
+	for _, t := range tx.Tags {
+		a.Tags[t.Name] = t.Value
+	}
+	return a, nil
+}
+
+func (c *Client) get(ctx context.Context, path string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.url+path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("download failed: %w", err)
+	}
+	defer res.Body.Close()
+
+	if code := res.StatusCode; code >= 400 {
+		return nil, fmt.Errorf("status code %d on download of %s", code, path)
+	}
+
+	return io.ReadAll(res.Body)
+}
+
+// Open decrypts the archive with the key and the nonce in its tags, decompresses it as tagged,
+// and returns the data that was uploaded.
+func (a *Archive) Open(key []byte) ([]byte, error) {
+	nonce, err := hex.DecodeString(a.Tags["Nonce"])
+	if err != nil {
+		return nil, fmt.Errorf("couldn't parse nonce: %w", err)
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	aesgcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	if len(nonce) != aesgcm.NonceSize() {
+		return nil, fmt.Errorf("nonce is %d bytes, not %d", len(nonce), aesgcm.NonceSize())
+	}
+
+	compressed, err := aesgcm.Open(nil, nonce, a.Ciphertext, nil)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't decrypt: %w", err)
+	}
+
+	return unpack(a.Compression(), compressed)
+}
+
+// Compression is how the archive was compressed, per its tag.
+func (a *Archive) Compression() Compression {
+	if c, ok := a.Tags[CompressionTag]; ok {
+		return Compression(c)
+	}
+	return CompressionZip
+}
+
+// Verify checks the archive against the recorded hashes of its data and ciphertext and
+// against its own hash tags. Without a key, which is the case once the trip's data has been
+// shredded, only the ciphertext can be checked.
+func (a *Archive) Verify(key, dataHash, ciphertextHash []byte) error {
+	sum := sha256.Sum256(a.Ciphertext)
+	if err := checkHash("ciphertext", sum[:], ciphertextHash, a.Tags[CiphertextHashTag]); err != nil {
+		return err
+	}
+
+	if key == nil {
+		return nil
+	}
+
+	data, err := a.Open(key)
+	if err != nil {
+		return err
+	}
+	sum = sha256.Sum256(data)
+	return checkHash("data", sum[:], dataHash, a.Tags[DataHashTag])
+}
+
+func checkHash(what string, sum, recorded []byte, tag string) error {
+	if !bytes.Equal(sum, recorded) {
+		return fmt.Errorf("%w: %s hashes to %x, recorded %x", ErrHashMismatch, what, sum, recorded)
+	}
+	if tag != hex.EncodeToString(sum) {
+		return fmt.Errorf("%w: %s hashes to %x, tagged %s", ErrHashMismatch, what, sum, tag)
+	}
+	return nil
+}
@@ -0,0 +1,86 @@
+package bundlr
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/DIMO-Network/trips-api/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestArchiveVerify(t *testing.T) {
+	uploader, err := New(&config.Settings{
+		BundlrPrivateKey: "1234567890123456789123456789123456789123456789123456789123456789",
+	})
+	require.NoError(t, err)
+
+	key := make([]byte, 32)
+	_, err = rand.Read(key)
+	require.NoError(t, err)
+
+	data := []byte(`[{"data":{"odometer":1000}}]`)
+	start := time.Date(2024, 3, 1, 8, 0, 0, 0, time.UTC)
+	item, err := uploader.PrepareData(data, key, 42, start, start.Add(time.Hour))
+	require.NoError(t, err)
+
+	a := &Archive{Ciphertext: item.Data, Tags: map[string]string{}}
+	for _, tag := range item.Tags {
+		a.Tags[tag.Name] = tag.Value
+	}
+
+	dataHash := sha256.Sum256(data)
+	ciphertextHash := sha256.Sum256(item.Data)
+
+	opened, err := a.Open(key)
+	require.NoError(t, err)
+	assert.Equal(t, data, opened)
+
+	assert.NoError(t, a.Verify(key, dataHash[:], ciphertextHash[:]))
+	// Shredded trips only have their ciphertext checked.
+	assert.NoError(t, a.Verify(nil, nil, ciphertextHash[:]))
+
+	assert.True(t, errors.Is(a.Verify(key, ciphertextHash[:], ciphertextHash[:]), ErrHashMismatch), "wrong data hash")
+	assert.True(t, errors.Is(a.Verify(key, dataHash[:], dataHash[:]), ErrHashMismatch), "wrong ciphertext hash")
+
+	tampered := &Archive{Ciphertext: append([]byte(nil), item.Data...), Tags: a.Tags}
+	tampered.Ciphertext[0] ^= 1
+	assert.True(t, errors.Is(tampered.Verify(key, dataHash[:], ciphertextHash[:]), ErrHashMismatch), "tampered ciphertext")
+
+	otherKey := make([]byte, 32)
+	assert.Error(t, a.Verify(otherKey, dataHash[:], ciphertextHash[:]), "wrong key")
+}
+
+func TestDownload(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/tx/abc":
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"id":   "abc",
+				"tags": []map[string]string{{"name": "Nonce", "value": "00"}, {"name": DataHashTag, "value": "ff"}},
+			})
+		case "/tx/abc/data":
+			_, _ = w.Write([]byte("ciphertext"))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	c := &Client{url: srv.URL + "/"}
+
+	a, err := c.Download(context.Background(), "abc")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("ciphertext"), a.Ciphertext)
+	assert.Equal(t, map[string]string{"Nonce": "00", DataHashTag: "ff"}, a.Tags)
+
+	_, err = c.Download(context.Background(), "missing")
+	assert.Error(t, err)
+}
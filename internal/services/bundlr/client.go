@@ -3,9 +3,11 @@ package bundlr
 import (
 	"archive/zip"
 	"bytes"
+	"context"
 	"crypto/aes"
 	"crypto/cipher"
 	"crypto/rand"
+	"crypto/sha256"
 	"encoding/hex"
 	"fmt"
 	"io"
@@ -14,8 +16,11 @@ import (
 	"time"
 
 	"github.com/DIMO-Network/trips-api/internal/config"
+	"github.com/DIMO-Network/trips-api/internal/tracing"
 	"github.com/warp-contracts/syncer/src/utils/arweave"
 	"github.com/warp-contracts/syncer/src/utils/bundlr"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 type Client struct {
@@ -23,6 +28,7 @@ type Client struct {
 	url         string
 	contentType string
 	currency    string
+	compression Compression
 }
 
 func New(settings *config.Settings) (*Client, error) {
@@ -31,18 +37,37 @@ func New(settings *config.Settings) (*Client, error) {
 		return nil, err
 	}
 
+	compression, err := ParseCompression(settings.ArchiveCompression)
+	if err != nil {
+		return nil, err
+	}
+
 	return &Client{
 		Signer:      signer,
 		url:         settings.BundlrNetwork,
 		contentType: "application/octet-stream",
 		currency:    settings.BundlrCurrency,
+		compression: compression,
 	}, nil
 }
 
-// PrepareData prepares data for uploading to bundlr by compressing and encrypting input.
+// Tags holding the hex SHA-256 of an upload's data and of its ciphertext, so that archives can
+// be checked without the database.
+const (
+	DataHashTag       = "Data-SHA256"
+	CiphertextHashTag = "Ciphertext-SHA256"
+)
+
+// Compression is how PrepareData compresses data.
+func (c *Client) Compression() Compression {
+	return c.compression
+}
+
+// PrepareData prepares data for uploading to bundlr by compressing and encrypting input. The
+// item is tagged with the compression and with the hashes of the data and of the ciphertext.
 func (c *Client) PrepareData(data []byte, encryptionKey []byte, tokenId int, start, end time.Time) (*bundlr.BundleItem, error) {
-	fileName := fmt.Sprintf("%d-%d-%d.zip", tokenId, start.Unix(), end.Unix())
-	compressedData, err := c.compress(data, fileName)
+	name := fmt.Sprintf("%d-%d-%d", tokenId, start.Unix(), end.Unix())
+	compressedData, err := c.pack(data, name)
 	if err != nil {
 		return nil, err
 	}
@@ -52,6 +77,9 @@ func (c *Client) PrepareData(data []byte, encryptionKey []byte, tokenId int, sta
 		return nil, err
 	}
 
+	dataHash := sha256.Sum256(data)
+	ciphertextHash := sha256.Sum256(encryptedData)
+
 	dataItem := &bundlr.BundleItem{
 		Data: arweave.Base64String(encryptedData),
 		Tags: bundlr.Tags{
@@ -59,19 +87,44 @@ func (c *Client) PrepareData(data []byte, encryptionKey []byte, tokenId int, sta
 			bundlr.Tag{Name: "Start-Time", Value: start.Format(time.RFC3339)},
 			bundlr.Tag{Name: "End-Time", Value: end.Format(time.RFC3339)},
 			bundlr.Tag{Name: "Nonce", Value: hex.EncodeToString(nonce)},
+			bundlr.Tag{Name: CompressionTag, Value: string(c.compression)},
+			bundlr.Tag{Name: DataHashTag, Value: hex.EncodeToString(dataHash[:])},
+			bundlr.Tag{Name: CiphertextHashTag, Value: hex.EncodeToString(ciphertextHash[:])},
 		},
 	}
 
 	return dataItem, dataItem.Sign(c.Signer)
 }
 
-func (c *Client) Upload(dataItem *bundlr.BundleItem) error {
+// PreparePlain prepares data for uploading to bundlr as it is, for data meant to be public.
+func (c *Client) PreparePlain(data []byte, tags bundlr.Tags) (*bundlr.BundleItem, error) {
+	dataItem := &bundlr.BundleItem{
+		Data: arweave.Base64String(data),
+		Tags: tags,
+	}
+
+	return dataItem, dataItem.Sign(c.Signer)
+}
+
+func (c *Client) Upload(ctx context.Context, dataItem *bundlr.BundleItem) (err error) {
+	ctx, span := tracing.Tracer().Start(ctx, "bundlr upload",
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attribute.Int("trips_api.bundle_bytes", len(dataItem.Data))),
+	)
+	defer func() { tracing.End(span, err) }()
+
 	reqBody, err := dataItem.Reader()
 	if err != nil {
 		return err
 	}
 
-	res, err := http.Post(c.url+"tx/"+c.currency, c.contentType, reqBody)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.url+"tx/"+c.currency, reqBody)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", c.contentType)
+
+	res, err := http.DefaultClient.Do(req)
 	if err != nil {
 		return fmt.Errorf("upload failed: %w", err)
 	}
@@ -86,6 +139,26 @@ func (c *Client) Upload(dataItem *bundlr.BundleItem) error {
 	return nil
 }
 
+// Ping checks that the Bundlr node is reachable.
+func (c *Client) Ping(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.url+"info", nil)
+	if err != nil {
+		return err
+	}
+
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if code := res.StatusCode; code >= 400 {
+		return fmt.Errorf("status code %d from node info", code)
+	}
+
+	return nil
+}
+
 func (c *Client) compress(data []byte, fileName string) ([]byte, error) {
 	var buf bytes.Buffer
 	w := zip.NewWriter(&buf)
@@ -0,0 +1,155 @@
+package bundlr
+
+import (
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"math"
+	mathrand "math/rand"
+	"testing"
+	"time"
+
+	"github.com/DIMO-Network/trips-api/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCompression(t *testing.T) {
+	for s, want := range map[string]Compression{"": DefaultCompression, "zip": CompressionZip, "gzip": CompressionGzip, "zstd": CompressionZstd} {
+		c, err := ParseCompression(s)
+		require.NoError(t, err)
+		assert.Equal(t, want, c)
+	}
+	_, err := ParseCompression("brotli")
+	assert.Error(t, err)
+}
+
+func TestCompressionRoundTrip(t *testing.T) {
+	data := telemetry(50)
+	key := make([]byte, 32)
+	_, err := rand.Read(key)
+	require.NoError(t, err)
+	start := time.Date(2024, 3, 1, 8, 0, 0, 0, time.UTC)
+
+	for _, compression := range []Compression{CompressionZip, CompressionGzip, CompressionZstd} {
+		t.Run(string(compression), func(t *testing.T) {
+			c, err := New(&config.Settings{
+				BundlrPrivateKey:   "1234567890123456789123456789123456789123456789123456789123456789",
+				ArchiveCompression: string(compression),
+			})
+			require.NoError(t, err)
+			assert.Equal(t, compression, c.Compression())
+
+			item, err := c.PrepareData(data, key, 42, start, start.Add(time.Hour))
+			require.NoError(t, err)
+
+			a := &Archive{Ciphertext: item.Data, Tags: map[string]string{}}
+			for _, tag := range item.Tags {
+				a.Tags[tag.Name] = tag.Value
+			}
+			assert.Equal(t, compression, a.Compression())
+
+			opened, err := a.Open(key)
+			require.NoError(t, err)
+			assert.Equal(t, data, opened)
+
+			// Uploads from before the tag are zipped.
+			delete(a.Tags, CompressionTag)
+			opened, err = a.Open(key)
+			if compression == CompressionZip {
+				require.NoError(t, err)
+				assert.Equal(t, data, opened)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
+
+// telemetry returns n status documents like those FetchData returns for a drive, one every
+// ten seconds, in canonical form.
+func telemetry(n int) []byte {
+	rnd := mathrand.New(mathrand.NewSource(1))
+	start := time.Date(2024, 3, 1, 8, 0, 0, 0, time.UTC)
+	lat, lng, odometer, fuel := 40.7484, -73.9857, 48213.2, 0.62
+
+	statuses := make([]map[string]any, n)
+	for i := range statuses {
+		at := start.Add(time.Duration(i) * 10 * time.Second)
+		speed := math.Max(0, 45+20*math.Sin(float64(i)/15)+rnd.Float64()*8-4)
+		heading := math.Mod(90+float64(i)*2+rnd.Float64()*10, 360)
+		step := speed / 3600 * 10
+		lat += step / 111 * math.Cos(heading*math.Pi/180)
+		lng += step / 84 * math.Sin(heading*math.Pi/180)
+		odometer += step
+		fuel -= step * 0.0004
+
+		statuses[i] = map[string]any{
+			"id":          fmt.Sprintf("2Y83I%022d", rnd.Int63()),
+			"source":      "dimo/integration/27qftVRWQYpVDcO5DltO5Ojbjxk",
+			"specversion": "1.0",
+			"subject":     "2Y83IHPItgk0uHD7hybGnA776Bo",
+			"time":        at.Format(time.RFC3339Nano),
+			"type":        "zone.dimo.device.status",
+			"data": map[string]any{
+				"timestamp":            at.UnixMilli(),
+				"latitude":             math.Round(lat*1e6) / 1e6,
+				"longitude":            math.Round(lng*1e6) / 1e6,
+				"altitude":             math.Round((12+rnd.Float64()*3)*10) / 10,
+				"hdop":                 math.Round((0.7+rnd.Float64()*0.6)*10) / 10,
+				"nsat":                 8 + rnd.Intn(6),
+				"speed":                math.Round(speed*10) / 10,
+				"odometer":             math.Round(odometer*10) / 10,
+				"fuelPercentRemaining": math.Round(fuel*1000) / 1000,
+				"engineLoad":           math.Round(rnd.Float64()*600) / 10,
+				"rpm":                  800 + rnd.Intn(2200),
+				"coolantTemp":          88 + rnd.Intn(6),
+				"ambientTemp":          14,
+				"batteryVoltage":       math.Round((13.9+rnd.Float64()*0.4)*100) / 100,
+				"signalStrength":       -70 - rnd.Intn(20),
+				"device":               map[string]any{"rpiUptimeSecs": 3600 + i*10, "batteryVoltage": 12.6},
+				"vehicleId":            "0x90C4D6113Ec88dd4BDf12f26DB2b3998fd13A144",
+			},
+		}
+	}
+
+	data, err := json.Marshal(statuses)
+	if err != nil {
+		panic(err)
+	}
+	return data
+}
+
+// BenchmarkCompression compares the formats on the status data of an hour's drive. Run with
+// -benchmem; the ratio metric is the compressed size over the original.
+func BenchmarkCompression(b *testing.B) {
+	data := telemetry(360)
+
+	for _, compression := range []Compression{CompressionZip, CompressionGzip, CompressionZstd} {
+		c := &Client{compression: compression}
+		packed, err := c.pack(data, "bench")
+		if err != nil {
+			b.Fatal(err)
+		}
+		ratio := float64(len(packed)) / float64(len(data))
+
+		b.Run(string(compression)+"/pack", func(b *testing.B) {
+			b.SetBytes(int64(len(data)))
+			for range b.N {
+				if _, err := c.pack(data, "bench"); err != nil {
+					b.Fatal(err)
+				}
+			}
+			b.ReportMetric(ratio, "ratio")
+		})
+
+		b.Run(string(compression)+"/unpack", func(b *testing.B) {
+			b.SetBytes(int64(len(data)))
+			for range b.N {
+				if _, err := unpack(compression, packed); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
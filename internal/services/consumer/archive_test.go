@@ -0,0 +1,94 @@
+package consumer
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/DIMO-Network/trips-api/internal/config"
+	"github.com/DIMO-Network/trips-api/internal/services/bundlr"
+	es_store "github.com/DIMO-Network/trips-api/internal/services/es"
+	"github.com/DIMO-Network/trips-api/models"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/volatiletech/null/v8"
+)
+
+// fakeFetcher returns its statuses framed as es_store.Client.FetchData frames them.
+type fakeFetcher struct {
+	statuses []string
+}
+
+func (f *fakeFetcher) FetchData(context.Context, string, time.Time, time.Time) ([]byte, error) {
+	sources := make([]json.RawMessage, len(f.statuses))
+	for i, s := range f.statuses {
+		sources[i] = json.RawMessage(s)
+	}
+	return es_store.JoinStatuses(sources), nil
+}
+
+func newArchiveConsumer(t *testing.T, statuses ...string) *Consumer {
+	b, err := bundlr.New(&config.Settings{
+		BundlrPrivateKey: "1234567890123456789123456789123456789123456789123456789123456789",
+	})
+	require.NoError(t, err)
+	return &Consumer{
+		logger:           &zerolog.Logger{},
+		es:               &fakeFetcher{statuses: statuses},
+		bundlr:           b,
+		dataFetchEnabled: true,
+	}
+}
+
+func TestArchive(t *testing.T) {
+	c := newArchiveConsumer(t,
+		`{"subject": "d1", "data": {"timestamp": "2024-03-01T08:00:00Z", "odometer": 1000.5, "latitude": 40.75, "longitude": -73.98}}`,
+		`{"subject": "d1", "data": {"timestamp": "2024-03-01T08:10:00Z", "latitude": 40.8, "longitude": -73.98}}`,
+		`{"subject": "d1", "data": {"timestamp": "2024-03-01T08:20:00Z", "odometer": 1012.5, "latitude": 40.85, "longitude": -73.98}}`,
+	)
+
+	start := time.Date(2024, 3, 1, 8, 0, 0, 0, time.UTC)
+	trp := &models.Trip{ID: "trip", VehicleTokenID: 42, StartTime: start, EndTime: null.TimeFrom(start.Add(20 * time.Minute))}
+
+	data, err := c.archive(context.Background(), "d1", trp)
+	require.NoError(t, err)
+
+	canonical, err := es_store.Canonical(data)
+	require.NoError(t, err)
+	assert.Equal(t, canonical, data, "archived data is canonical")
+
+	hash := sha256.Sum256(data)
+	assert.Equal(t, hash[:], trp.DataSha256.Bytes)
+	assert.True(t, trp.CiphertextSha256.Valid)
+	assert.True(t, trp.BundlrID.Valid)
+	assert.InDelta(t, 12, trp.DistanceKM.Float64, 0.01, "distance comes from the odometer")
+}
+
+func TestArchiveNoStatuses(t *testing.T) {
+	c := newArchiveConsumer(t)
+
+	start := time.Date(2024, 3, 1, 8, 0, 0, 0, time.UTC)
+	trp := &models.Trip{ID: "trip", VehicleTokenID: 42, StartTime: start, EndTime: null.TimeFrom(start.Add(20 * time.Minute))}
+
+	data, err := c.archive(context.Background(), "d1", trp)
+	require.NoError(t, err)
+	assert.Equal(t, `[]`, string(data))
+	assert.False(t, trp.DistanceKM.Valid)
+}
+
+func TestArchiveUnreadableStatuses(t *testing.T) {
+	c := newArchiveConsumer(t,
+		`{"subject": "d1", "data": {"timestamp": "2024-03-01T08:00:00Z", "odometer": 1000.5}}`,
+		`"not a status"`,
+	)
+
+	start := time.Date(2024, 3, 1, 8, 0, 0, 0, time.UTC)
+	trp := &models.Trip{ID: "trip", VehicleTokenID: 42, StartTime: start, EndTime: null.TimeFrom(start.Add(20 * time.Minute))}
+
+	_, err := c.archive(context.Background(), "d1", trp)
+	assert.Error(t, err)
+	assert.False(t, trp.DistanceKM.Valid)
+}
@@ -3,6 +3,7 @@ package consumer
 import (
 	"context"
 	"crypto/rand"
+	"crypto/sha256"
 	"database/sql"
 	"errors"
 	"fmt"
@@ -13,19 +14,28 @@ import (
 	"github.com/DIMO-Network/trips-api/internal/services/bundlr"
 	es_store "github.com/DIMO-Network/trips-api/internal/services/es"
 	pg_store "github.com/DIMO-Network/trips-api/internal/services/pg"
+	"github.com/DIMO-Network/trips-api/internal/services/tripevents"
+	"github.com/DIMO-Network/trips-api/internal/tracing"
 	"github.com/DIMO-Network/trips-api/models"
 	"github.com/rs/zerolog"
 	"github.com/volatiletech/null/v8"
 	"github.com/volatiletech/sqlboiler/v4/boil"
 	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"github.com/volatiletech/sqlboiler/v4/types"
 	"github.com/volatiletech/sqlboiler/v4/types/pgeo"
 )
 
+// statusFetcher fetches a device's status data over a period, as es_store.Client does.
+type statusFetcher interface {
+	FetchData(ctx context.Context, userDeviceID string, start, end time.Time) ([]byte, error)
+}
+
 type Consumer struct {
 	logger           *zerolog.Logger
-	es               *es_store.Client
+	es               statusFetcher
 	pg               *pg_store.Store
 	bundlr           *bundlr.Client
+	events           *tripevents.Publisher
 	dataFetchEnabled bool
 	workerCount      int
 	bundlrEnabled    bool
@@ -49,6 +59,7 @@ type SegmentEvent struct {
 	End       Endpoint `json:"end"`
 }
 
+// UserDeviceMintEvent is the payload of the mint, burn and unpair events on the events topic.
 type UserDeviceMintEvent struct {
 	Timestamp time.Time `json:"timestamp"`
 	UserID    string    `json:"userId"`
@@ -60,34 +71,68 @@ type UserDeviceMintEvent struct {
 	} `json:"nft"`
 }
 
-const UserDeviceMintEventType = "com.dimo.zone.device.mint"
+const (
+	UserDeviceMintEventType   = "com.dimo.zone.device.mint"
+	UserDeviceBurnEventType   = "com.dimo.zone.device.burn"
+	UserDeviceUnpairEventType = "com.dimo.zone.device.unpair"
+)
 
-func New(es *es_store.Client, bundlrClient *bundlr.Client, pg *pg_store.Store, logger *zerolog.Logger, dataFetchEnabled bool, workerCount int, bundlrEnabled bool) *Consumer {
-	return &Consumer{logger, es, pg, bundlrClient, dataFetchEnabled, workerCount, bundlrEnabled}
+func New(es *es_store.Client, bundlrClient *bundlr.Client, pg *pg_store.Store, events *tripevents.Publisher, logger *zerolog.Logger, dataFetchEnabled bool, workerCount int, bundlrEnabled bool) *Consumer {
+	return &Consumer{logger, es, pg, bundlrClient, events, dataFetchEnabled, workerCount, bundlrEnabled}
 }
 
 func (c *Consumer) ProcessSegmentEvent(ctx context.Context, event shared.CloudEvent[SegmentEvent]) error {
 	if event.Data.Completed {
-		return c.CompleteSegment(ctx, event)
+		stageCtx, end := startStage(ctx, stageComplete)
+		err := c.CompleteSegment(stageCtx, event)
+		end(err)
+		return err
 	}
-	return c.BeginSegment(ctx, event)
+
+	stageCtx, end := startStage(ctx, stageBegin)
+	err := c.BeginSegment(stageCtx, event)
+	end(err)
+	return err
 }
 
 func (c *Consumer) BeginSegment(ctx context.Context, event shared.CloudEvent[SegmentEvent]) error {
+	// Resolve the token as of the segment start, so that replayed or backfilled segments
+	// are attributed to the vehicle the device was paired with at the time.
+	dbCtx, span := tracing.StartPostgres(ctx, "resolve vehicle")
+	tokenID, err := c.pg.VehicleTokenAt(dbCtx, event.Data.DeviceID, event.Data.Start.Time)
+	tracing.End(span, err)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("failed to find vehicle %s: %w", event.Subject, err)
+		}
+		return err
+	}
+
+	dbCtx, span = tracing.StartPostgres(ctx, "check erasures")
+	erased, err := c.pg.Erased(dbCtx, tokenID, event.Data.Start.Time)
+	tracing.End(span, err)
+	if err != nil {
+		return err
+	}
+	if erased {
+		c.logger.Info().Str("tripId", event.Data.ID).Int("vehicleTokenId", tokenID).Msg("Dropping segment begun in an erased range.")
+		SegmentErasedTotal.Inc()
+		return nil
+	}
+
+	dbCtx, span = tracing.StartPostgres(ctx, "load last trip")
 	veh, err := models.Vehicles(
-		models.VehicleWhere.UserDeviceID.EQ(event.Data.DeviceID),
+		models.VehicleWhere.TokenID.EQ(tokenID),
 		qm.Load(
 			models.VehicleRels.VehicleTokenTrips,
 			models.TripWhere.EndTime.IsNotNull(),
 			qm.OrderBy(models.TripColumns.EndTime+" DESC"),
 			qm.Limit(1),
 		),
-	).One(ctx, c.pg.DB.DBS().Reader)
+	).One(dbCtx, c.pg.DB.DBS().Reader)
+	tracing.End(span, err)
 	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			return fmt.Errorf("failed to find vehicle %s: %w", event.Subject, err)
-		}
-		return err
+		return fmt.Errorf("failed to load vehicle %d: %w", tokenID, err)
 	}
 
 	segment := models.Trip{
@@ -95,6 +140,7 @@ func (c *Consumer) BeginSegment(ctx context.Context, event shared.CloudEvent[Seg
 		VehicleTokenID: veh.TokenID,
 		StartTime:      event.Data.Start.Time,
 		StartPosition:  nullLocationToDB(event.Data.Start.Location),
+		SegmentIds:     types.StringArray{event.Data.ID},
 	}
 
 	if segment.StartPosition.Valid {
@@ -116,39 +162,59 @@ func (c *Consumer) BeginSegment(ctx context.Context, event shared.CloudEvent[Seg
 		segment.DroppedData = true
 	}
 
-	return segment.Insert(ctx, c.pg.DB.DBS().Writer, boil.Infer())
+	dbCtx, span = tracing.StartPostgres(ctx, "insert segment")
+	err = segment.Insert(dbCtx, c.pg.DB.DBS().Writer, boil.Infer())
+	tracing.End(span, err)
+	if err != nil {
+		return err
+	}
+
+	if segment.DroppedData {
+		SegmentDroppedDataTotal.Inc()
+	}
+
+	if err := c.events.Started(ctx, &segment); err != nil {
+		c.logger.Err(err).Str("tripId", segment.ID).Msg("Failed to publish trip started event.")
+	}
+	return nil
 }
 
 func (c *Consumer) CompleteSegment(ctx context.Context, event shared.CloudEvent[SegmentEvent]) error {
+	dbCtx, span := tracing.StartPostgres(ctx, "load segment")
 	segment, err := models.Trips(
 		models.TripWhere.ID.EQ(event.Data.ID),
-	).One(ctx, c.pg.DB.DBS().Reader)
+	).One(dbCtx, c.pg.DB.DBS().Reader)
+	tracing.End(span, err)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
+			if erased, err := c.erasedSegment(ctx, event); err != nil {
+				return err
+			} else if erased {
+				c.logger.Info().Str("tripId", event.Data.ID).Msg("Dropping completion of an erased segment.")
+				SegmentErasedTotal.Inc()
+				return nil
+			}
 			return fmt.Errorf("no segment with id  %s: %w", event.Data.ID, err)
 		}
 		return fmt.Errorf("error fetching segment %s: %w", event.Data.ID, err)
 	}
-	encryptionKey := make([]byte, 32)
-	if _, err := rand.Read(encryptionKey); err != nil {
-		return fmt.Errorf("couldn't produce random key: %w", err)
-	}
-
-	segment.EncryptionKey = null.BytesFrom(encryptionKey)
 	segment.EndTime = null.TimeFrom(event.Data.End.Time)
 	segment.EndPosition = nullLocationToDB(event.Data.End.Location)
 
 	if !segment.StartPosition.Valid && event.Data.Start.Location != nil {
 		segment.StartPositionEstimate = nullLocationToDB(event.Data.Start.Location)
-		if veh, err := models.Vehicles(
-			models.VehicleWhere.UserDeviceID.EQ(event.Data.DeviceID),
+		dbCtx, span := tracing.StartPostgres(ctx, "load last trip")
+		veh, err := models.Vehicles(
+			models.VehicleWhere.TokenID.EQ(segment.VehicleTokenID),
 			qm.Load(
 				models.VehicleRels.VehicleTokenTrips,
 				models.TripWhere.EndTime.IsNotNull(),
 				qm.OrderBy(models.TripColumns.EndTime+" DESC"),
 				qm.Limit(1),
 			),
-		).One(ctx, c.pg.DB.DBS().Reader); err != nil {
+		).One(dbCtx, c.pg.DB.DBS().Reader)
+		tracing.End(span, err)
+		if err != nil {
 			c.logger.Error().Err(err).Msg("failed to find vehicle for trip completion estimate")
 		} else if len(veh.R.VehicleTokenTrips) > 0 {
 			estLoc := nullLocationToDB(event.Data.Start.Location)
@@ -158,52 +224,194 @@ func (c *Consumer) CompleteSegment(ctx context.Context, event shared.CloudEvent[
 		}
 	}
 
-	if c.dataFetchEnabled {
-		response, err := c.es.FetchData(ctx, event.Data.DeviceID, segment.StartTime, event.Data.End.Time)
-		if err != nil {
-			return fmt.Errorf("call to Elasticsearch failed: %w", err)
-		}
-
-		dataItem, err := c.bundlr.PrepareData(response, encryptionKey, segment.VehicleTokenID, segment.StartTime, event.Data.End.Time)
-		if err != nil {
-			return fmt.Errorf("assembly for Bundlr failed: %w", err)
-		}
-
-		if c.bundlrEnabled {
-			if err := c.bundlr.Upload(dataItem); err != nil {
-				return fmt.Errorf("bundlr upload failed: %w", err)
-			}
-		}
+	if _, err := c.archive(ctx, event.Data.DeviceID, segment); err != nil {
+		return err
+	}
 
-		segment.BundlrID = null.StringFrom(dataItem.Id.Base64())
-		c.logger.Info().Msgf("https://devnet.bundlr.network/%s", segment.BundlrID.String)
+	if !segment.DistanceKM.Valid {
+		segment.DistanceKM = endpointDistanceKm(segment)
 	}
 
-	if _, err := segment.Update(ctx, c.pg.DB.DBS().Writer,
+	updateCtx, end := startStage(ctx, stageDBUpdate)
+	updated, err := segment.Update(updateCtx, c.pg.DB.DBS().Writer,
 		boil.Whitelist(
 			models.TripColumns.EncryptionKey,
 			models.TripColumns.EndTime,
 			models.TripColumns.BundlrID,
+			models.TripColumns.DataSha256,
+			models.TripColumns.CiphertextSha256,
+			models.TripColumns.Compression,
 			models.TripColumns.EndPosition,
-			models.TripColumns.StartPositionEstimate),
-	); err != nil {
+			models.TripColumns.StartPositionEstimate,
+			models.TripColumns.DistanceKM),
+	)
+	end(err)
+	if err != nil {
 		return fmt.Errorf("error updating segment %s: %w", event.Data.ID, err)
 	}
+	if updated == 0 {
+		// The segment was erased while its data was being archived, and the new key with it.
+		c.logger.Info().Str("tripId", event.Data.ID).Msg("Segment erased during completion.")
+		SegmentErasedTotal.Inc()
+		return nil
+	}
+
+	// Publishing failures are only logged: the segment has been recorded, and redelivering
+	// the message would upload its data again.
+	if err := c.events.Completed(ctx, segment); err != nil {
+		c.logger.Err(err).Str("tripId", segment.ID).Msg("Failed to publish trip completed event.")
+	}
+	if c.dataFetchEnabled && c.bundlrEnabled {
+		if err := c.events.Archived(ctx, segment); err != nil {
+			c.logger.Err(err).Str("tripId", segment.ID).Msg("Failed to publish trip archived event.")
+		}
+	}
 	return nil
 }
 
+// erasedSegment reports whether the segment of a completion event that has no trip was
+// deleted by an erasure.
+func (c *Consumer) erasedSegment(ctx context.Context, event shared.CloudEvent[SegmentEvent]) (_ bool, err error) {
+	dbCtx, span := tracing.StartPostgres(ctx, "check erasures")
+	defer func() { tracing.End(span, err) }()
+
+	tokenID, err := c.pg.VehicleTokenAt(dbCtx, event.Data.DeviceID, event.Data.Start.Time)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, nil
+		}
+		return false, err
+	}
+	return c.pg.Erased(dbCtx, tokenID, event.Data.Start.Time)
+}
+
+// archive gives the trip a new encryption key and, if data fetching is on, fetches the
+// device's status data over the trip, measures the distance travelled from it, and encrypts
+// and uploads it, recording the hashes of the canonical data and of the ciphertext. It returns
+// the canonical data. Any earlier archive of the trip is dropped, as are its hashes and
+// distance; the caller should fill in the distance if the data has neither odometer readings
+// nor locations. Data that can't be parsed is an error.
+func (c *Consumer) archive(ctx context.Context, userDeviceID string, trip *models.Trip) ([]byte, error) {
+	encryptionKey := make([]byte, 32)
+	if _, err := rand.Read(encryptionKey); err != nil {
+		return nil, fmt.Errorf("couldn't produce random key: %w", err)
+	}
+
+	trip.EncryptionKey = null.BytesFrom(encryptionKey)
+	trip.BundlrID = null.String{}
+	trip.DataSha256 = null.Bytes{}
+	trip.CiphertextSha256 = null.Bytes{}
+	trip.Compression = null.String{}
+	trip.DistanceKM = null.Float64{}
+
+	if !c.dataFetchEnabled {
+		return nil, nil
+	}
+
+	fetchCtx, end := startStage(ctx, stageFetch)
+	response, err := c.es.FetchData(fetchCtx, userDeviceID, trip.StartTime, trip.EndTime.Time)
+	end(err)
+	if err != nil {
+		return nil, fmt.Errorf("call to Elasticsearch failed: %w", err)
+	}
+
+	// Archive the data in canonical form, so that its hash can be reproduced from the archive.
+	response, err = es_store.Canonical(response)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't canonicalize status data: %w", err)
+	}
+	dataHash := sha256.Sum256(response)
+	trip.DataSha256 = null.BytesFrom(dataHash[:])
+
+	// Data that can't be read would otherwise pass for a trip without odometer or locations.
+	km, ok, err := es_store.DistanceKm(response)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't measure trip distance from status data: %w", err)
+	}
+	if ok {
+		trip.DistanceKM = null.Float64From(km)
+	}
+
+	_, end = startStage(ctx, stagePrepare)
+	dataItem, err := c.bundlr.PrepareData(response, encryptionKey, trip.VehicleTokenID, trip.StartTime, trip.EndTime.Time)
+	end(err)
+	if err != nil {
+		return nil, fmt.Errorf("assembly for Bundlr failed: %w", err)
+	}
+
+	ciphertextHash := sha256.Sum256(dataItem.Data)
+	trip.CiphertextSha256 = null.BytesFrom(ciphertextHash[:])
+	trip.Compression = null.StringFrom(string(c.bundlr.Compression()))
+
+	if c.bundlrEnabled {
+		uploadCtx, end := startStage(ctx, stageUpload)
+		err := c.bundlr.Upload(uploadCtx, dataItem)
+		end(err)
+		if err != nil {
+			return nil, fmt.Errorf("bundlr upload failed: %w", err)
+		}
+	}
+
+	trip.BundlrID = null.StringFrom(dataItem.Id.Base64())
+	c.logger.Info().Msgf("https://devnet.bundlr.network/%s", trip.BundlrID.String)
+
+	return response, nil
+}
+
 func (c *Consumer) VehicleEvent(ctx context.Context, event shared.CloudEvent[UserDeviceMintEvent]) error {
-	if event.Type == UserDeviceMintEventType {
-		if err := c.pg.StoreVehicle(ctx, event.Data.Device.ID, event.Data.NFT.TokenID); err != nil {
+	switch event.Type {
+	case UserDeviceMintEventType, UserDeviceBurnEventType, UserDeviceUnpairEventType:
+	default:
+		// The events topic carries many other types that we don't care about.
+		return nil
+	}
+
+	dbCtx, span := tracing.StartPostgres(ctx, "update vehicle mapping")
+	err := c.handleVehicleEvent(dbCtx, event)
+	tracing.End(span, err)
+	VehicleEventTotal.WithLabelValues(event.Type, outcome(err), errorClass(err)).Inc()
+	return err
+}
+
+func (c *Consumer) handleVehicleEvent(ctx context.Context, event shared.CloudEvent[UserDeviceMintEvent]) error {
+	switch event.Type {
+	case UserDeviceMintEventType:
+		if err := c.pg.StoreVehicle(ctx, event.Data.Device.ID, event.Data.NFT.TokenID, vehicleEventTime(event)); err != nil {
 			return fmt.Errorf("failed to store vehicle: %w", err)
 		}
 
 		c.logger.Debug().Int("tokenId", event.Data.NFT.TokenID).Str("userDeviceId", event.Data.Device.ID).Msg("Id mapping stored.")
-		return nil
+	case UserDeviceBurnEventType, UserDeviceUnpairEventType:
+		if err := c.pg.DeleteVehicle(ctx, event.Data.NFT.TokenID, vehicleEventTime(event)); err != nil {
+			return fmt.Errorf("failed to delete vehicle: %w", err)
+		}
+
+		c.logger.Debug().Int("tokenId", event.Data.NFT.TokenID).Str("userDeviceId", event.Data.Device.ID).Str("type", event.Type).Msg("Id mapping retired.")
 	}
 	return nil
 }
 
+// vehicleEventTime prefers the timestamp in the payload, falling back to the time on the envelope.
+func vehicleEventTime(event shared.CloudEvent[UserDeviceMintEvent]) time.Time {
+	if !event.Data.Timestamp.IsZero() {
+		return event.Data.Timestamp
+	}
+	return event.Time
+}
+
+// endpointDistanceKm is the straight-line distance from the trip's start, or its estimate, to
+// its end. It stands in for the distance travelled when there is no status data to measure.
+func endpointDistanceKm(trip *models.Trip) null.Float64 {
+	start := trip.StartPosition
+	if !start.Valid {
+		start = trip.StartPositionEstimate
+	}
+	if !start.Valid || !trip.EndPosition.Valid {
+		return null.Float64{}
+	}
+	return null.Float64From(geo.DistanceKm(start.Point, trip.EndPosition.Point))
+}
+
 func nullLocationToDB(l *Location) pgeo.NullPoint {
 	if l == nil {
 		return pgeo.NullPoint{}
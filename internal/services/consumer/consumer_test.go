@@ -2,6 +2,7 @@ package consumer
 
 import (
 	"context"
+	"database/sql"
 	"testing"
 	"time"
 
@@ -111,6 +112,341 @@ func Test_CreateVehicle(t *testing.T) {
 	assert.Equal(t, v.UserDeviceID, createDevice.Data.Device.ID)
 }
 
+// Vehicle NFT is burned
+// Mapping is kept for existing trips but new segments are rejected
+func Test_BurnVehicle(t *testing.T) {
+	ctx := context.Background()
+
+	pdb := test.StartContainerDatabase(ctx, t, migrationsDirRelPath)
+	consumer := Consumer{
+		logger: &zerolog.Logger{},
+		pg: &pg.Store{
+			DB: pdb,
+		},
+	}
+
+	if err := consumer.VehicleEvent(ctx, createDevice); err != nil {
+		t.Fatal(err)
+	}
+
+	burnDevice := createDevice
+	burnDevice.Type = UserDeviceBurnEventType
+	if err := consumer.VehicleEvent(ctx, burnDevice); err != nil {
+		t.Fatal(err)
+	}
+
+	v, err := models.Vehicles().One(ctx, pdb.DBS().Reader)
+	assert.NoError(t, err)
+	assert.Equal(t, createDevice.Data.NFT.TokenID, v.TokenID)
+	assert.True(t, v.DeletedAt.Valid)
+
+	segment := segment1
+	segment.Data.Completed = false
+	assert.Error(t, consumer.ProcessSegmentEvent(ctx, segment))
+}
+
+// User device is re-minted as a new vehicle NFT
+// New segments are attributed to the new token
+func Test_RemintVehicle(t *testing.T) {
+	ctx := context.Background()
+
+	pdb := test.StartContainerDatabase(ctx, t, migrationsDirRelPath)
+	consumer := Consumer{
+		logger: &zerolog.Logger{},
+		pg: &pg.Store{
+			DB: pdb,
+		},
+	}
+
+	if err := consumer.VehicleEvent(ctx, createDevice); err != nil {
+		t.Fatal(err)
+	}
+
+	remintDevice := createDevice
+	remintDevice.Data.NFT.TokenID = 2
+	remintDevice.Data.Timestamp = segment1.Data.End.Time
+	if err := consumer.VehicleEvent(ctx, remintDevice); err != nil {
+		t.Fatal(err)
+	}
+
+	oldVeh, err := models.FindVehicle(ctx, pdb.DBS().Reader, createDevice.Data.NFT.TokenID)
+	assert.NoError(t, err)
+	assert.True(t, oldVeh.DeletedAt.Valid)
+
+	segment := segment2
+	segment.Data.Completed = false
+	if err := consumer.ProcessSegmentEvent(ctx, segment); err != nil {
+		t.Fatal(err)
+	}
+
+	trp, err := models.FindTrip(ctx, pdb.DBS().Reader, segment.Data.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, remintDevice.Data.NFT.TokenID, trp.VehicleTokenID)
+}
+
+// Segment from before a re-mint is replayed
+// It is attributed to the token that was valid when it started
+func Test_ReplayedSegmentUsesHistoricalToken(t *testing.T) {
+	ctx := context.Background()
+
+	pdb := test.StartContainerDatabase(ctx, t, migrationsDirRelPath)
+	consumer := Consumer{
+		logger: &zerolog.Logger{},
+		pg: &pg.Store{
+			DB: pdb,
+		},
+	}
+
+	if err := consumer.VehicleEvent(ctx, createDevice); err != nil {
+		t.Fatal(err)
+	}
+
+	remintDevice := createDevice
+	remintDevice.Data.NFT.TokenID = 2
+	remintDevice.Data.Timestamp = segment1.Data.End.Time
+	if err := consumer.VehicleEvent(ctx, remintDevice); err != nil {
+		t.Fatal(err)
+	}
+
+	segment := segment1
+	segment.Data.Completed = false
+	if err := consumer.ProcessSegmentEvent(ctx, segment); err != nil {
+		t.Fatal(err)
+	}
+
+	trp, err := models.FindTrip(ctx, pdb.DBS().Reader, segment.Data.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, createDevice.Data.NFT.TokenID, trp.VehicleTokenID)
+
+	mappings, err := models.VehicleMappings(
+		models.VehicleMappingWhere.UserDeviceID.EQ(createDevice.Data.Device.ID),
+	).All(ctx, pdb.DBS().Reader)
+	assert.NoError(t, err)
+	assert.Len(t, mappings, 2)
+}
+
+// Trip beginning and completing is recorded for streaming
+func Test_TripUpdatesRecorded(t *testing.T) {
+	ctx := context.Background()
+
+	pdb := test.StartContainerDatabase(ctx, t, migrationsDirRelPath)
+	consumer := Consumer{
+		logger: &zerolog.Logger{},
+		pg: &pg.Store{
+			DB: pdb,
+		},
+	}
+
+	if err := consumer.VehicleEvent(ctx, createDevice); err != nil {
+		t.Fatal(err)
+	}
+
+	segment := segment1
+	segment.Data.Completed = false
+	if err := consumer.ProcessSegmentEvent(ctx, segment); err != nil {
+		t.Fatal(err)
+	}
+	segment.Data.Completed = true
+	if err := consumer.ProcessSegmentEvent(ctx, segment); err != nil {
+		t.Fatal(err)
+	}
+
+	updates, pending, err := consumer.pg.TripUpdatesAfter(ctx, createDevice.Data.NFT.TokenID, pg.TripUpdateCursor{}, 10)
+	assert.False(t, pending)
+	assert.NoError(t, err)
+	if assert.Len(t, updates, 2) {
+		assert.Equal(t, "begin", updates[0].Kind)
+		assert.Equal(t, "complete", updates[1].Kind)
+		assert.Equal(t, segment.Data.ID, updates[1].R.Trip.ID)
+	}
+}
+
+func Test_MergeTrips(t *testing.T) {
+	ctx := context.Background()
+
+	pdb := test.StartContainerDatabase(ctx, t, migrationsDirRelPath)
+	consumer := Consumer{
+		logger: &zerolog.Logger{},
+		pg: &pg.Store{
+			DB: pdb,
+		},
+	}
+
+	if err := consumer.VehicleEvent(ctx, createDevice); err != nil {
+		t.Fatal(err)
+	}
+	for _, segment := range []shared.CloudEvent[SegmentEvent]{segment1, segment2} {
+		segment.Data.Completed = false
+		if err := consumer.ProcessSegmentEvent(ctx, segment); err != nil {
+			t.Fatal(err)
+		}
+		segment.Data.Completed = true
+		if err := consumer.ProcessSegmentEvent(ctx, segment); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tokenID := createDevice.Data.NFT.TokenID
+
+	var editErr *EditError
+	_, err := consumer.MergeTrips(ctx, tokenID, []string{segment1.Data.ID})
+	assert.ErrorAs(t, err, &editErr)
+
+	_, err = consumer.MergeTrips(ctx, tokenID, []string{segment1.Data.ID, ksuid.New().String()})
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+
+	// Conflicting labels would lose one of them.
+	editor := pg.Editor{Subject: "0xbA5738a18d83D41847dfFbDC6101d37C69c9B0cF/1"}
+	business, personal, note := "business", "personal", "Client visit"
+	_, err = consumer.pg.AnnotateTrip(ctx, tokenID, segment1.Data.ID, pg.Annotation{Purpose: &business}, editor)
+	assert.NoError(t, err)
+	_, err = consumer.pg.AnnotateTrip(ctx, tokenID, segment2.Data.ID, pg.Annotation{Purpose: &personal, Note: &note}, editor)
+	assert.NoError(t, err)
+	_, err = consumer.MergeTrips(ctx, tokenID, []string{segment1.Data.ID, segment2.Data.ID})
+	assert.ErrorAs(t, err, &editErr)
+
+	_, err = consumer.pg.AnnotateTrip(ctx, tokenID, segment2.Data.ID, pg.Annotation{Purpose: &business}, editor)
+	assert.NoError(t, err)
+
+	merged, err := consumer.MergeTrips(ctx, tokenID, []string{segment2.Data.ID, segment1.Data.ID})
+	assert.NoError(t, err)
+	assert.Equal(t, null.StringFrom(business), merged.Purpose)
+	assert.Equal(t, null.StringFrom(note), merged.Note, "labels of later trips carry over")
+
+	// The history of the deleted trip's labels now belongs to the merged one.
+	changes, err := models.TripAnnotationChanges().All(ctx, pdb.DBS().Reader)
+	assert.NoError(t, err)
+	assert.Len(t, changes, 4)
+	for _, c := range changes {
+		assert.Equal(t, segment1.Data.ID, c.TripID)
+	}
+	assert.Equal(t, segment1.Data.ID, merged.ID)
+	assert.True(t, merged.EndTime.Time.Equal(segment2.Data.End.Time))
+	assert.Equal(t, nullLocationToDB(segment2.Data.End.Location), merged.EndPosition)
+	assert.Equal(t, []string{segment1.Data.ID, segment2.Data.ID}, []string(merged.SegmentIds))
+	// The second segment didn't start where the first ended.
+	assert.True(t, merged.DroppedData)
+
+	trips, err := models.Trips().All(ctx, pdb.DBS().Reader)
+	assert.NoError(t, err)
+	if assert.Len(t, trips, 1) {
+		assert.Equal(t, merged.DistanceKM, trips[0].DistanceKM)
+		assert.Equal(t, merged.EncryptionKey, trips[0].EncryptionKey)
+	}
+}
+
+func Test_SplitTrip(t *testing.T) {
+	ctx := context.Background()
+
+	pdb := test.StartContainerDatabase(ctx, t, migrationsDirRelPath)
+	consumer := Consumer{
+		logger: &zerolog.Logger{},
+		pg: &pg.Store{
+			DB: pdb,
+		},
+	}
+
+	if err := consumer.VehicleEvent(ctx, createDevice); err != nil {
+		t.Fatal(err)
+	}
+	segment := segment1
+	segment.Data.Completed = false
+	if err := consumer.ProcessSegmentEvent(ctx, segment); err != nil {
+		t.Fatal(err)
+	}
+
+	tokenID := createDevice.Data.NFT.TokenID
+	at := segment.Data.Start.Time.Add(time.Hour)
+
+	var editErr *EditError
+	_, err := consumer.SplitTrip(ctx, tokenID, segment.Data.ID, at)
+	assert.ErrorAs(t, err, &editErr)
+
+	segment.Data.Completed = true
+	if err := consumer.ProcessSegmentEvent(ctx, segment); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = consumer.SplitTrip(ctx, tokenID, segment.Data.ID, segment.Data.End.Time)
+	assert.ErrorAs(t, err, &editErr)
+
+	parts, err := consumer.SplitTrip(ctx, tokenID, segment.Data.ID, at)
+	assert.NoError(t, err)
+	if assert.Len(t, parts, 2) {
+		assert.Equal(t, segment.Data.ID, parts[0].ID)
+		assert.True(t, parts[0].EndTime.Time.Equal(at))
+		assert.True(t, parts[1].StartTime.Equal(at))
+		assert.True(t, parts[1].EndTime.Time.Equal(segment.Data.End.Time))
+		assert.Equal(t, []string{segment.Data.ID}, []string(parts[1].SegmentIds))
+		// Without status data there is nowhere to place the boundary.
+		assert.False(t, parts[1].StartPosition.Valid)
+		assert.True(t, parts[1].DroppedData)
+	}
+
+	count, err := models.Trips(models.TripWhere.EndTime.IsNotNull()).Count(ctx, pdb.DBS().Reader)
+	assert.NoError(t, err)
+	assert.EqualValues(t, 2, count)
+}
+
+func Test_EraseTrips(t *testing.T) {
+	ctx := context.Background()
+
+	pdb := test.StartContainerDatabase(ctx, t, migrationsDirRelPath)
+	consumer := Consumer{
+		logger: &zerolog.Logger{},
+		pg: &pg.Store{
+			DB: pdb,
+		},
+	}
+
+	if err := consumer.VehicleEvent(ctx, createDevice); err != nil {
+		t.Fatal(err)
+	}
+	segment := segment1
+	segment.Data.Completed = false
+	if err := consumer.ProcessSegmentEvent(ctx, segment); err != nil {
+		t.Fatal(err)
+	}
+
+	tokenID := createDevice.Data.NFT.TokenID
+	editor := pg.Editor{Subject: "0xbA5738a18d83D41847dfFbDC6101d37C69c9B0cF/1", UserAddress: "0x4b7B7F6E5A5E8A3E4F0D3c8F2B1e9A7d6C5b4A3f"}
+	end := segment2.Data.Start.Time
+
+	erasure, created, err := consumer.pg.EraseTrips(ctx, tokenID, nil, end, "", editor)
+	assert.NoError(t, err)
+	assert.True(t, created)
+	assert.Equal(t, 1, erasure.TripCount)
+	assert.Equal(t, editor.UserAddress, erasure.UserAddress.String)
+
+	again, created, err := consumer.pg.EraseTrips(ctx, tokenID, nil, end, "", editor)
+	assert.NoError(t, err)
+	assert.False(t, created)
+	assert.Equal(t, erasure.ID, again.ID)
+
+	// Late events for the erased range are dropped.
+	segment.Data.Completed = true
+	assert.NoError(t, consumer.ProcessSegmentEvent(ctx, segment))
+	segment.Data.Completed = false
+	assert.NoError(t, consumer.ProcessSegmentEvent(ctx, segment))
+
+	count, err := models.Trips().Count(ctx, pdb.DBS().Reader)
+	assert.NoError(t, err)
+	assert.Zero(t, count)
+
+	// Later segments are kept.
+	segment = segment2
+	segment.Data.Completed = false
+	assert.NoError(t, consumer.ProcessSegmentEvent(ctx, segment))
+
+	count, err = models.Trips().Count(ctx, pdb.DBS().Reader)
+	assert.NoError(t, err)
+	assert.EqualValues(t, 1, count)
+
+	erasures, err := consumer.pg.Erasures(ctx, tokenID)
+	assert.NoError(t, err)
+	assert.Len(t, erasures, 1)
+}
+
 // First trip a user takes
 // Includes geo data
 func Test_TripWithGeos(t *testing.T) {
@@ -0,0 +1,392 @@
+package consumer
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	es_store "github.com/DIMO-Network/trips-api/internal/services/es"
+	"github.com/DIMO-Network/trips-api/internal/tracing"
+	"github.com/DIMO-Network/trips-api/models"
+	"github.com/segmentio/ksuid"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"github.com/volatiletech/sqlboiler/v4/types/pgeo"
+)
+
+// MaxMergedTrips is the most trips that can be merged at once.
+const MaxMergedTrips = 20
+
+// EditError is returned when trips can't be merged or split as asked. The reason is meant for
+// users.
+type EditError struct {
+	Reason string
+}
+
+func (e *EditError) Error() string {
+	return "invalid trip edit: " + e.Reason
+}
+
+func invalidEdit(reason string) error {
+	return &EditError{Reason: reason}
+}
+
+// editColumns are the trip columns that merging and splitting recompute.
+var editColumns = boil.Whitelist(
+	models.TripColumns.EndTime,
+	models.TripColumns.EndPosition,
+	models.TripColumns.DroppedData,
+	models.TripColumns.EncryptionKey,
+	models.TripColumns.BundlrID,
+	models.TripColumns.DataSha256,
+	models.TripColumns.CiphertextSha256,
+	models.TripColumns.Compression,
+	models.TripColumns.DistanceKM,
+	models.TripColumns.SegmentIds,
+)
+
+// ErrTripsChanged is returned when trips change while they are being merged or split, so that
+// the edit would be based on stale data.
+var ErrTripsChanged = errors.New("trips changed during the edit")
+
+// MergeTrips joins consecutive completed trips of the vehicle into one, for drives that
+// segmentation broke up at a short stop. The first trip is extended to the end of the last and
+// the rest are deleted, with the merged trip keeping all of their segment ids, labels and label
+// history. Trips with different purposes or notes can't be merged. Its data is archived again
+// under a new key. It returns sql.ErrNoRows if the vehicle is missing any of the trips, and
+// ErrTripsChanged if they change before the merge is saved.
+func (c *Consumer) MergeTrips(ctx context.Context, tokenID int, tripIDs []string) (*models.Trip, error) {
+	if len(tripIDs) < 2 {
+		return nil, invalidEdit("At least two trips are needed.")
+	}
+	if len(tripIDs) > MaxMergedTrips {
+		return nil, invalidEdit(fmt.Sprintf("At most %d trips can be merged.", MaxMergedTrips))
+	}
+
+	// Fetching and uploading the data takes a while, so it happens before the trips are
+	// locked. They are checked again under the lock.
+	trips, err := c.mergeableTrips(ctx, c.pg.DB.DBS().Writer, tokenID, tripIDs, false)
+	if err != nil {
+		return nil, err
+	}
+	first, last := trips[0], trips[len(trips)-1]
+
+	userDeviceID, err := c.userDeviceFor(ctx, tokenID, first.StartTime, last.StartTime)
+	if err != nil {
+		return nil, err
+	}
+
+	// Labels on any of the trips carry over, as long as they agree.
+	purpose, customPurpose, note, err := mergeLabels(trips)
+	if err != nil {
+		return nil, err
+	}
+
+	m := *first
+	merged := &m
+	merged.Purpose, merged.CustomPurpose, merged.Note = purpose, customPurpose, note
+	merged.SegmentIds = slices.Clone(first.SegmentIds)
+	for _, trp := range trips[1:] {
+		merged.DroppedData = merged.DroppedData || trp.DroppedData
+		merged.SegmentIds = append(merged.SegmentIds, trp.SegmentIds...)
+	}
+	merged.EndTime = last.EndTime
+	merged.EndPosition = last.EndPosition
+
+	if _, err := c.archive(ctx, userDeviceID, merged); err != nil {
+		return nil, err
+	}
+	if !merged.DistanceKM.Valid {
+		merged.DistanceKM = endpointDistanceKm(merged)
+	}
+
+	tx, err := c.pg.DB.DBS().Writer.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback() //nolint
+
+	locked, err := c.mergeableTrips(ctx, tx, tokenID, tripIDs, true)
+	if err != nil {
+		return nil, err
+	}
+	for i := range trips {
+		if !unchanged(trips[i], locked[i]) {
+			return nil, ErrTripsChanged
+		}
+	}
+
+	dbCtx, span := tracing.StartPostgres(ctx, "merge trips")
+	err = func() error {
+		if _, err := merged.Update(dbCtx, tx, boil.Whitelist(append(editColumns.Cols, labelColumns...)...)); err != nil {
+			return err
+		}
+		// The merged trip takes over the label history of the others, which deleting them
+		// would otherwise cascade away.
+		var others []string
+		for _, trp := range trips[1:] {
+			others = append(others, trp.ID)
+		}
+		if _, err := models.TripAnnotationChanges(
+			models.TripAnnotationChangeWhere.TripID.IN(others),
+		).UpdateAll(dbCtx, tx, models.M{models.TripAnnotationChangeColumns.TripID: merged.ID}); err != nil {
+			return err
+		}
+		_, err := locked[1:].DeleteAll(dbCtx, tx)
+		return err
+	}()
+	tracing.End(span, err)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return merged, nil
+}
+
+// mergeableTrips loads the vehicle's trips, ordered by start time, and checks that they can be
+// merged: they are all there, completed, and have no other trips between them. If lock is set,
+// the trips are locked for update.
+func (c *Consumer) mergeableTrips(ctx context.Context, exec boil.ContextExecutor, tokenID int, tripIDs []string, lock bool) (models.TripSlice, error) {
+	mods := []qm.QueryMod{
+		models.TripWhere.ID.IN(tripIDs),
+		models.TripWhere.VehicleTokenID.EQ(tokenID),
+		qm.OrderBy(models.TripColumns.StartTime),
+	}
+	if lock {
+		mods = append(mods, qm.For("UPDATE"))
+	}
+
+	dbCtx, span := tracing.StartPostgres(ctx, "load trips")
+	trips, err := models.Trips(mods...).All(dbCtx, exec)
+	tracing.End(span, err)
+	if err != nil {
+		return nil, err
+	}
+	if len(trips) != len(tripIDs) {
+		if len(slices.Compact(slices.Sorted(slices.Values(tripIDs)))) != len(tripIDs) {
+			return nil, invalidEdit("Trips are repeated.")
+		}
+		return nil, sql.ErrNoRows
+	}
+
+	first, last := trips[0], trips[len(trips)-1]
+	for _, trp := range trips {
+		if !trp.EndTime.Valid {
+			return nil, invalidEdit("Only completed trips can be merged.")
+		}
+	}
+
+	// Any other trip starting within the span would end up inside the merged trip.
+	dbCtx, span = tracing.StartPostgres(ctx, "check trips consecutive")
+	between, err := models.Trips(
+		models.TripWhere.VehicleTokenID.EQ(tokenID),
+		models.TripWhere.ID.NIN(tripIDs),
+		models.TripWhere.StartTime.GTE(first.StartTime),
+		models.TripWhere.StartTime.LTE(last.EndTime.Time),
+	).Exists(dbCtx, exec)
+	tracing.End(span, err)
+	if err != nil {
+		return nil, err
+	}
+	if between {
+		return nil, invalidEdit("Trips aren't consecutive.")
+	}
+
+	return trips, nil
+}
+
+// SplitTrip divides a completed trip of the vehicle in two at the given time, for drives that
+// segmentation joined across a stop. The trip is cut short to become the first part, and the
+// second part is a new trip with the same segment ids, purpose and note. The boundary is
+// placed at the last location reported before the split. Both parts are archived again under
+// new keys. It returns sql.ErrNoRows if the vehicle has no such trip, and ErrTripsChanged if
+// the trip changes before the split is saved.
+func (c *Consumer) SplitTrip(ctx context.Context, tokenID int, tripID string, at time.Time) (models.TripSlice, error) {
+	// As with merging, the data is archived before the trip is locked and checked again.
+	orig, err := c.splittableTrip(ctx, c.pg.DB.DBS().Writer, tokenID, tripID, at, false)
+	if err != nil {
+		return nil, err
+	}
+
+	userDeviceID, err := c.userDeviceFor(ctx, tokenID, orig.StartTime, orig.StartTime)
+	if err != nil {
+		return nil, err
+	}
+
+	second := &models.Trip{
+		ID:             ksuid.New().String(),
+		VehicleTokenID: orig.VehicleTokenID,
+		StartTime:      at,
+		EndTime:        orig.EndTime,
+		EndPosition:    orig.EndPosition,
+		SegmentIds:     slices.Clone(orig.SegmentIds),
+		Purpose:        orig.Purpose,
+		CustomPurpose:  orig.CustomPurpose,
+		Note:           orig.Note,
+	}
+	f := *orig
+	first := &f
+	first.EndTime = null.TimeFrom(at)
+	first.EndPosition = pgeo.NullPoint{}
+
+	data, err := c.archive(ctx, userDeviceID, first)
+	if err != nil {
+		return nil, err
+	}
+	if data != nil {
+		p, ok, err := es_store.LastLocation(data)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't find split location in status data: %w", err)
+		}
+		if ok {
+			first.EndPosition = pgeo.NewNullPoint(p, true)
+			second.StartPosition = first.EndPosition
+		}
+	}
+	// As when a segment begins, a second part with no known start counts as having dropped data.
+	second.DroppedData = !second.StartPosition.Valid
+
+	if _, err := c.archive(ctx, userDeviceID, second); err != nil {
+		return nil, err
+	}
+	for _, trp := range []*models.Trip{first, second} {
+		if !trp.DistanceKM.Valid {
+			trp.DistanceKM = endpointDistanceKm(trp)
+		}
+	}
+
+	tx, err := c.pg.DB.DBS().Writer.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback() //nolint
+
+	locked, err := c.splittableTrip(ctx, tx, tokenID, tripID, at, true)
+	if err != nil {
+		return nil, err
+	}
+	if !unchanged(orig, locked) {
+		return nil, ErrTripsChanged
+	}
+
+	dbCtx, span := tracing.StartPostgres(ctx, "split trip")
+	err = func() error {
+		if _, err := first.Update(dbCtx, tx, editColumns); err != nil {
+			return err
+		}
+		return second.Insert(dbCtx, tx, boil.Infer())
+	}()
+	tracing.End(span, err)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return models.TripSlice{first, second}, nil
+}
+
+// splittableTrip loads the vehicle's trip and checks that it can be split at the given time.
+// If lock is set, the trip is locked for update.
+func (c *Consumer) splittableTrip(ctx context.Context, exec boil.ContextExecutor, tokenID int, tripID string, at time.Time, lock bool) (*models.Trip, error) {
+	mods := []qm.QueryMod{
+		models.TripWhere.ID.EQ(tripID),
+		models.TripWhere.VehicleTokenID.EQ(tokenID),
+	}
+	if lock {
+		mods = append(mods, qm.For("UPDATE"))
+	}
+
+	dbCtx, span := tracing.StartPostgres(ctx, "load trip")
+	trp, err := models.Trips(mods...).One(dbCtx, exec)
+	tracing.End(span, err)
+	if err != nil {
+		return nil, err
+	}
+
+	if !trp.EndTime.Valid {
+		return nil, invalidEdit("Only completed trips can be split.")
+	}
+	if !at.After(trp.StartTime) || !at.Before(trp.EndTime.Time) {
+		return nil, invalidEdit("Split time must be within the trip.")
+	}
+	return trp, nil
+}
+
+// unchanged reports whether the trip is as it was when an edit began, as far as the edit is
+// concerned: its span, positions, segments, labels and archive.
+func unchanged(before, after *models.Trip) bool {
+	return before.ID == after.ID &&
+		before.StartTime.Equal(after.StartTime) &&
+		before.EndTime.Valid == after.EndTime.Valid && before.EndTime.Time.Equal(after.EndTime.Time) &&
+		before.StartPosition == after.StartPosition &&
+		before.EndPosition == after.EndPosition &&
+		before.DroppedData == after.DroppedData &&
+		slices.Equal(before.SegmentIds, after.SegmentIds) &&
+		before.Purpose == after.Purpose &&
+		before.CustomPurpose == after.CustomPurpose &&
+		before.Note == after.Note &&
+		before.BundlrID == after.BundlrID
+}
+
+// labelColumns are the trip columns that merging also sets, from the labels of the merged trips.
+var labelColumns = []string{
+	models.TripColumns.Purpose,
+	models.TripColumns.CustomPurpose,
+	models.TripColumns.Note,
+}
+
+// mergeLabels returns the purpose and note shared by those of the trips that have them.
+// Trips with different purposes or notes aren't merged, since one of them would be lost.
+func mergeLabels(trips models.TripSlice) (purpose, customPurpose, note null.String, err error) {
+	for _, trp := range trips {
+		if trp.Purpose.Valid {
+			if purpose.Valid && (trp.Purpose != purpose || trp.CustomPurpose != customPurpose) {
+				return purpose, customPurpose, note, invalidEdit("Trips have different purposes.")
+			}
+			purpose, customPurpose = trp.Purpose, trp.CustomPurpose
+		}
+		if trp.Note.Valid {
+			if note.Valid && trp.Note != note {
+				return purpose, customPurpose, note, invalidEdit("Trips have different notes.")
+			}
+			note = trp.Note
+		}
+	}
+	return purpose, customPurpose, note, nil
+}
+
+// userDeviceFor returns the device paired with the vehicle from start through to end, whose
+// data the trips in between are archived from. There is no need for one if data fetching is
+// off.
+func (c *Consumer) userDeviceFor(ctx context.Context, tokenID int, start, end time.Time) (_ string, err error) {
+	if !c.dataFetchEnabled {
+		return "", nil
+	}
+
+	dbCtx, span := tracing.StartPostgres(ctx, "resolve device")
+	defer func() { tracing.End(span, err) }()
+
+	var userDeviceIDs [2]string
+	for i, at := range []time.Time{start, end} {
+		userDeviceIDs[i], err = c.pg.UserDeviceAt(dbCtx, tokenID, at)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return "", invalidEdit("No device was paired with the vehicle at the time.")
+			}
+			return "", fmt.Errorf("failed to find device for vehicle %d: %w", tokenID, err)
+		}
+	}
+	if userDeviceIDs[0] != userDeviceIDs[1] {
+		return "", invalidEdit("Trips were recorded by different devices.")
+	}
+	return userDeviceIDs[0], nil
+}
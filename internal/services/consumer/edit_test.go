@@ -0,0 +1,40 @@
+package consumer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/DIMO-Network/trips-api/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/types"
+)
+
+func TestUnchanged(t *testing.T) {
+	start := time.Date(2024, 3, 1, 8, 0, 0, 0, time.UTC)
+	before := &models.Trip{
+		ID:         "2Y83IHPItgk0uHD7hybGnA776Bo",
+		StartTime:  start,
+		EndTime:    null.TimeFrom(start.Add(20 * time.Minute)),
+		SegmentIds: types.StringArray{"2Y83IHPItgk0uHD7hybGnA776Bo"},
+		BundlrID:   null.StringFrom("iAm7bHyA8M2Ug2ZtvVtGq1DuDp5Iw3FhSpqp2cDz6Vs"),
+	}
+
+	same := *before
+	same.SegmentIds = types.StringArray{"2Y83IHPItgk0uHD7hybGnA776Bo"}
+	same.EndTime = null.TimeFrom(start.Add(20 * time.Minute).In(time.FixedZone("", 3600)))
+	assert.True(t, unchanged(before, &same))
+
+	for name, change := range map[string]func(*models.Trip){
+		"extended":     func(trp *models.Trip) { trp.EndTime = null.TimeFrom(start.Add(time.Hour)) },
+		"merged":       func(trp *models.Trip) { trp.SegmentIds = append(trp.SegmentIds, "2Y83IKzqDb8gXqFvV4JYrqZbFkM") },
+		"labelled":     func(trp *models.Trip) { trp.Note = null.StringFrom("Client visit") },
+		"rearchived":   func(trp *models.Trip) { trp.BundlrID = null.StringFrom("other") },
+		"dropped data": func(trp *models.Trip) { trp.DroppedData = true },
+	} {
+		after := *before
+		after.SegmentIds = types.StringArray{"2Y83IHPItgk0uHD7hybGnA776Bo"}
+		change(&after)
+		assert.False(t, unchanged(before, &after), name)
+	}
+}
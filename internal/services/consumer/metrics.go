@@ -0,0 +1,118 @@
+package consumer
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net"
+	"time"
+
+	"github.com/DIMO-Network/trips-api/internal/tracing"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// Stages of segment processing, used as metric labels.
+const (
+	stageBegin    = "begin"
+	stageComplete = "complete"
+	stageFetch    = "es_fetch"
+	stagePrepare  = "prepare"
+	stageUpload   = "upload"
+	stageDBUpdate = "db_update"
+)
+
+var (
+	SegmentStageTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "trips_api",
+			Subsystem: "segment",
+			Name:      "stage_total",
+			Help:      "The total number of segment processing stages run, by outcome and error class.",
+		},
+		[]string{"stage", "outcome", "error_class"},
+	)
+
+	SegmentStageDuration = promauto.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: "trips_api",
+			Subsystem: "segment",
+			Name:      "stage_duration_seconds",
+			Help:      "The distribution of segment processing stage durations in seconds.",
+			Buckets:   []float64{0.01, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60},
+		},
+		[]string{"stage"},
+	)
+
+	SegmentDroppedDataTotal = promauto.NewCounter(
+		prometheus.CounterOpts{
+			Namespace: "trips_api",
+			Subsystem: "segment",
+			Name:      "dropped_data_total",
+			Help:      "The total number of segments begun with dropped data.",
+		},
+	)
+
+	SegmentErasedTotal = promauto.NewCounter(
+		prometheus.CounterOpts{
+			Namespace: "trips_api",
+			Subsystem: "segment",
+			Name:      "erased_total",
+			Help:      "The total number of segment events dropped because their trips were erased.",
+		},
+	)
+
+	VehicleEventTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "trips_api",
+			Subsystem: "vehicle_event",
+			Name:      "processed_total",
+			Help:      "The total number of vehicle events processed, by event type and outcome.",
+		},
+		[]string{"type", "outcome", "error_class"},
+	)
+)
+
+// observeStage records the duration and outcome of a processing stage that began at start.
+func observeStage(stage string, start time.Time, err error) {
+	SegmentStageDuration.WithLabelValues(stage).Observe(time.Since(start).Seconds())
+	SegmentStageTotal.WithLabelValues(stage, outcome(err), errorClass(err)).Inc()
+}
+
+// startStage starts a span for a processing stage. Call the returned function with the stage's
+// result to end the span and record its metrics.
+func startStage(ctx context.Context, stage string) (context.Context, func(error)) {
+	start := time.Now()
+	ctx, span := tracing.Tracer().Start(ctx, "segment "+stage)
+	return ctx, func(err error) {
+		observeStage(stage, start, err)
+		tracing.End(span, err)
+	}
+}
+
+func outcome(err error) string {
+	if err != nil {
+		return "error"
+	}
+	return "success"
+}
+
+// errorClass buckets errors coarsely enough to keep label cardinality low.
+func errorClass(err error) string {
+	var netErr net.Error
+
+	switch {
+	case err == nil:
+		return "none"
+	case errors.Is(err, context.Canceled):
+		return "canceled"
+	case errors.Is(err, context.DeadlineExceeded):
+		return "timeout"
+	case errors.Is(err, sql.ErrNoRows):
+		return "not_found"
+	case errors.As(err, &netErr):
+		return "network"
+	default:
+		return "other"
+	}
+}
@@ -0,0 +1,19 @@
+package consumer
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestErrorClass(t *testing.T) {
+	assert.Equal(t, "none", errorClass(nil))
+	assert.Equal(t, "canceled", errorClass(fmt.Errorf("bundlr upload failed: %w", context.Canceled)))
+	assert.Equal(t, "timeout", errorClass(fmt.Errorf("call to Elasticsearch failed: %w", context.DeadlineExceeded)))
+	assert.Equal(t, "not_found", errorClass(fmt.Errorf("no segment with id  %s: %w", "x", sql.ErrNoRows)))
+	assert.Equal(t, "other", errorClass(errors.New("boom")))
+}
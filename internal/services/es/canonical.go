@@ -0,0 +1,33 @@
+package es
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+)
+
+// Canonical rewrites the statuses returned by FetchData in a canonical JSON form, so that
+// their hash doesn't depend on how Elasticsearch happened to serialize them: object keys are
+// sorted, insignificant whitespace is dropped, and strings are escaped minimally. Numbers are
+// kept as written.
+func Canonical(data []byte) ([]byte, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		return nil, err
+	}
+	if dec.More() {
+		return nil, errors.New("trailing data after statuses")
+	}
+
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(v); err != nil {
+		return nil, err
+	}
+	// Encode terminates the value with a newline.
+	return bytes.TrimSuffix(buf.Bytes(), []byte{'\n'}), nil
+}
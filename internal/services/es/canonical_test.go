@@ -0,0 +1,40 @@
+package es
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCanonical(t *testing.T) {
+	a, err := Canonical([]byte(`[{"subject": "d1", "data": {"timestamp": "2024-03-01T08:00:00Z", "odometer": 1000.50, "note": "<a&b>"}}]`))
+	require.NoError(t, err)
+	assert.Equal(t, `[{"data":{"note":"<a&b>","odometer":1000.50,"timestamp":"2024-03-01T08:00:00Z"},"subject":"d1"}]`, string(a))
+
+	b, err := Canonical([]byte("[\n  {\"data\":{\"timestamp\":\"2024-03-01T08:00:00Z\",\"note\":\"\\u003ca\\u0026b\\u003e\",\"odometer\":1000.50},\"subject\":\"d1\"}\n]"))
+	require.NoError(t, err)
+	assert.Equal(t, a, b)
+
+	empty, err := Canonical([]byte(`[]`))
+	require.NoError(t, err)
+	assert.Equal(t, `[]`, string(empty))
+
+	_, err = Canonical([]byte(`[{"subject": "d1"`))
+	assert.Error(t, err)
+	_, err = Canonical([]byte(`[] []`))
+	assert.Error(t, err)
+}
+
+func TestCanonicalFetchedStatuses(t *testing.T) {
+	assert.Equal(t, `[]`, string(JoinStatuses(nil)))
+
+	data := JoinStatuses([]json.RawMessage{
+		json.RawMessage(`{"subject": "d1", "data": {"speed": 20}}`),
+		json.RawMessage(`{"subject": "d1", "data": {"speed": 30}}`),
+	})
+	c, err := Canonical(data)
+	require.NoError(t, err)
+	assert.Equal(t, `[{"data":{"speed":20},"subject":"d1"},{"data":{"speed":30},"subject":"d1"}]`, string(c))
+}
@@ -0,0 +1,70 @@
+package es
+
+import (
+	"github.com/DIMO-Network/trips-api/internal/geo"
+	"github.com/goccy/go-json"
+	"github.com/volatiletech/sqlboiler/v4/types/pgeo"
+)
+
+// status is the part of a device status document used to measure distance and find locations.
+type status struct {
+	Data struct {
+		Latitude  *float64 `json:"latitude"`
+		Longitude *float64 `json:"longitude"`
+		Odometer  *float64 `json:"odometer"`
+	} `json:"data"`
+}
+
+// DistanceKm estimates how far the vehicle travelled over the statuses returned by FetchData,
+// which are in time order. The change in odometer reading is preferred, otherwise the
+// distances between successive locations are summed. It returns false if the statuses have
+// neither.
+func DistanceKm(data []byte) (float64, bool, error) {
+	var statuses []status
+	if err := json.Unmarshal(data, &statuses); err != nil {
+		return 0, false, err
+	}
+
+	var firstOdometer, lastOdometer *float64
+	var path float64
+	var last *pgeo.Point
+	for _, s := range statuses {
+		if o := s.Data.Odometer; o != nil {
+			if firstOdometer == nil {
+				firstOdometer = o
+			}
+			lastOdometer = o
+		}
+		if s.Data.Latitude != nil && s.Data.Longitude != nil {
+			p := pgeo.NewPoint(*s.Data.Longitude, *s.Data.Latitude)
+			if last != nil {
+				path += geo.DistanceKm(*last, p)
+			}
+			last = &p
+		}
+	}
+
+	if firstOdometer != nil && lastOdometer != firstOdometer && *lastOdometer >= *firstOdometer {
+		return *lastOdometer - *firstOdometer, true, nil
+	}
+	if path > 0 {
+		return path, true, nil
+	}
+	return 0, false, nil
+}
+
+// LastLocation returns the last location among the statuses returned by FetchData. It returns
+// false if none of them has one.
+func LastLocation(data []byte) (pgeo.Point, bool, error) {
+	var statuses []status
+	if err := json.Unmarshal(data, &statuses); err != nil {
+		return pgeo.Point{}, false, err
+	}
+
+	for i := len(statuses) - 1; i >= 0; i-- {
+		if d := statuses[i].Data; d.Latitude != nil && d.Longitude != nil {
+			return pgeo.NewPoint(*d.Longitude, *d.Latitude), true, nil
+		}
+	}
+	return pgeo.Point{}, false, nil
+}
@@ -0,0 +1,59 @@
+package es
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDistanceKm(t *testing.T) {
+	cases := []struct {
+		name string
+		data string
+		km   float64
+		ok   bool
+	}{
+		{
+			name: "odometer",
+			data: `[{"data":{"odometer":1000.5,"latitude":40.75,"longitude":-73.98}},{"data":{"latitude":40.8,"longitude":-73.98}},{"data":{"odometer":1012.5,"latitude":40.85,"longitude":-73.98}}]`,
+			km:   12,
+			ok:   true,
+		},
+		{
+			name: "path",
+			data: `[{"data":{"latitude":40.75,"longitude":-73.98}},{"data":{"latitude":40.8,"longitude":-73.98}},{"data":{}},{"data":{"latitude":40.75,"longitude":-73.98}}]`,
+			km:   11.1,
+			ok:   true,
+		},
+		{
+			name: "single odometer reading",
+			data: `[{"data":{"odometer":1000}},{"data":{"speed":20}}]`,
+		},
+		{
+			name: "empty",
+			data: `[]`,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			km, ok, err := DistanceKm([]byte(c.data))
+			require.NoError(t, err)
+			assert.Equal(t, c.ok, ok)
+			assert.InDelta(t, c.km, km, 0.1)
+		})
+	}
+}
+
+func TestLastLocation(t *testing.T) {
+	p, ok, err := LastLocation([]byte(`[{"data":{"latitude":40.75,"longitude":-73.98}},{"data":{"latitude":40.8,"longitude":-73.97}},{"data":{"speed":20}}]`))
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, 40.8, p.Y)
+	assert.Equal(t, -73.97, p.X)
+
+	_, ok, err = LastLocation([]byte(`[{"data":{"speed":20}}]`))
+	require.NoError(t, err)
+	assert.False(t, ok)
+}
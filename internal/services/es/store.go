@@ -3,9 +3,12 @@ package es
 import (
 	"bytes"
 	"context"
+	"encoding/json"
+	"errors"
 	"time"
 
 	"github.com/DIMO-Network/trips-api/internal/config"
+	"github.com/DIMO-Network/trips-api/internal/tracing"
 	"github.com/elastic/go-elasticsearch/v8"
 	"github.com/elastic/go-elasticsearch/v8/typedapi/core/search"
 	"github.com/elastic/go-elasticsearch/v8/typedapi/some"
@@ -13,6 +16,9 @@ import (
 	"github.com/elastic/go-elasticsearch/v8/typedapi/types/enums/sortorder"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+	"go.opentelemetry.io/otel/trace"
 )
 
 type Client struct {
@@ -35,14 +41,30 @@ func New(settings *config.Settings) (*Client, error) {
 
 const pageSize = 1000
 
-func (s *Client) FetchData(ctx context.Context, userDeviceID string, start, end time.Time) ([]byte, error) {
+// Ping checks that the cluster is reachable.
+func (s *Client) Ping(ctx context.Context) error {
+	ok, err := s.typedClient.Ping().IsSuccess(ctx)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return errors.New("elasticsearch ping unsuccessful")
+	}
+	return nil
+}
+
+func (s *Client) FetchData(ctx context.Context, userDeviceID string, start, end time.Time) (_ []byte, err error) {
+	ctx, span := tracing.Tracer().Start(ctx, "elasticsearch fetch",
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(semconv.DBSystemElasticsearch),
+	)
+	defer func() { tracing.End(span, err) }()
+
 	ElasticSearchRequestTotal.Inc()
 	timer := prometheus.NewTimer(ElasticSearchRequestDuration)
 	defer timer.ObserveDuration()
 
-	var buf bytes.Buffer
-
-	buf.WriteByte('[')
+	var sources []json.RawMessage
 
 	req := &search.Request{
 		Query: &types.Query{
@@ -72,32 +94,46 @@ func (s *Client) FetchData(ctx context.Context, userDeviceID string, start, end
 		},
 	}
 
-	needComma := false
-
-	for {
-		resp, err := s.typedClient.Search().Request(req).Do(ctx)
+	for page := 0; ; page++ {
+		pageCtx, pageSpan := tracing.Tracer().Start(ctx, "elasticsearch search page",
+			trace.WithSpanKind(trace.SpanKindClient),
+			trace.WithAttributes(semconv.DBSystemElasticsearch, attribute.Int("trips_api.page", page)),
+		)
+		resp, err := s.typedClient.Search().Request(req).Do(pageCtx)
 		if err != nil {
+			tracing.End(pageSpan, err)
 			return nil, err
 		}
 
 		hitCount := len(resp.Hits.Hits)
+		pageSpan.SetAttributes(attribute.Int("trips_api.hits", hitCount))
+		pageSpan.End()
 		if hitCount == 0 {
 			break
 		}
 
 		for _, h := range resp.Hits.Hits {
-			if needComma {
-				buf.WriteByte(',')
-			} else {
-				needComma = true
-			}
-			buf.Write(h.Source_)
+			sources = append(sources, h.Source_)
 		}
 
 		req.SearchAfter = resp.Hits.Hits[hitCount-1].Sort
 	}
 
-	return buf.Bytes(), nil
+	return JoinStatuses(sources), nil
+}
+
+// JoinStatuses frames status documents as the JSON array that FetchData returns.
+func JoinStatuses(sources []json.RawMessage) []byte {
+	var buf bytes.Buffer
+	buf.WriteByte('[')
+	for i, src := range sources {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		buf.Write(src)
+	}
+	buf.WriteByte(']')
+	return buf.Bytes()
 }
 
 var (
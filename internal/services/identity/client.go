@@ -0,0 +1,293 @@
+package identity
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/DIMO-Network/shared/privileges"
+	"github.com/DIMO-Network/trips-api/internal/config"
+	"github.com/goccy/go-json"
+)
+
+// Client pages through the vehicles known to the identity service and looks up who controls
+// each one. The endpoint is expected to speak GraphQL and expose each vehicle's token id, mint
+// time, user device id, owner and privilege grants.
+type Client struct {
+	url        string
+	httpClient *http.Client
+}
+
+// Vehicle is the identity service's view of a vehicle NFT.
+type Vehicle struct {
+	TokenID      int       `json:"tokenId"`
+	UserDeviceID string    `json:"userDeviceId"`
+	MintedAt     time.Time `json:"mintedAt"`
+}
+
+const pageSize = 100
+
+const vehiclesQuery = `query Vehicles($first: Int!, $after: String) {
+  vehicles(first: $first, after: $after) {
+    nodes {
+      tokenId
+      userDeviceId
+      mintedAt
+    }
+    pageInfo {
+      hasNextPage
+      endCursor
+    }
+  }
+}`
+
+type graphQLRequest struct {
+	Query     string         `json:"query"`
+	Variables map[string]any `json:"variables"`
+}
+
+type graphQLError struct {
+	Message string `json:"message"`
+}
+
+type vehiclesPage struct {
+	Nodes    []Vehicle `json:"nodes"`
+	PageInfo struct {
+		HasNextPage bool   `json:"hasNextPage"`
+		EndCursor   string `json:"endCursor"`
+	} `json:"pageInfo"`
+}
+
+type graphQLResponse[T any] struct {
+	Data   T              `json:"data"`
+	Errors []graphQLError `json:"errors"`
+}
+
+type vehiclesData struct {
+	Vehicles vehiclesPage `json:"vehicles"`
+}
+
+// ErrVehicleNotFound is returned when the identity service doesn't know the vehicle.
+var ErrVehicleNotFound = errors.New("vehicle not found")
+
+// Access is who controls a vehicle: its owner, and the privileges the owner has granted.
+type Access struct {
+	Owner  string
+	Grants []Grant
+}
+
+// Grant is a privilege on a vehicle granted to another address.
+type Grant struct {
+	Privilege privileges.Privilege
+	User      string
+	ExpiresAt time.Time
+}
+
+// Allows reports whether any of users owns the vehicle or holds a grant of the privilege that
+// is unexpired at now. Owners hold every privilege.
+func (a *Access) Allows(privilege privileges.Privilege, now time.Time, users ...string) bool {
+	for _, u := range users {
+		if u == "" {
+			continue
+		}
+		if strings.EqualFold(u, a.Owner) {
+			return true
+		}
+		for _, g := range a.Grants {
+			if g.Privilege == privilege && strings.EqualFold(u, g.User) && now.Before(g.ExpiresAt) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+const accessQuery = `query Access($tokenId: Int!, $first: Int!, $after: String) {
+  vehicle(tokenId: $tokenId) {
+    owner
+    privileges(first: $first, after: $after) {
+      nodes {
+        id
+        user
+        expiresAt
+      }
+      pageInfo {
+        hasNextPage
+        endCursor
+      }
+    }
+  }
+}`
+
+type accessData struct {
+	Vehicle *struct {
+		Owner      string `json:"owner"`
+		Privileges struct {
+			Nodes []struct {
+				ID        int64     `json:"id"`
+				User      string    `json:"user"`
+				ExpiresAt time.Time `json:"expiresAt"`
+			} `json:"nodes"`
+			PageInfo struct {
+				HasNextPage bool   `json:"hasNextPage"`
+				EndCursor   string `json:"endCursor"`
+			} `json:"pageInfo"`
+		} `json:"privileges"`
+	} `json:"vehicle"`
+}
+
+func New(settings *config.Settings) (*Client, error) {
+	if settings.IdentityAPIURL == "" {
+		return nil, errors.New("identity API URL not configured")
+	}
+
+	return &Client{
+		url:        settings.IdentityAPIURL,
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+	}, nil
+}
+
+// Vehicles calls fn with each page of vehicles until the identity service has no more, or
+// until fn returns an error.
+func (c *Client) Vehicles(ctx context.Context, fn func([]Vehicle) error) error {
+	var after *string
+
+	for {
+		page, err := c.fetchPage(ctx, after)
+		if err != nil {
+			return err
+		}
+
+		if len(page.Nodes) > 0 {
+			if err := fn(page.Nodes); err != nil {
+				return err
+			}
+		}
+
+		if !page.PageInfo.HasNextPage {
+			return nil
+		}
+
+		cursor := page.PageInfo.EndCursor
+		after = &cursor
+	}
+}
+
+func (c *Client) fetchPage(ctx context.Context, after *string) (*vehiclesPage, error) {
+	data, err := query[vehiclesData](ctx, c, vehiclesQuery, map[string]any{"first": pageSize, "after": after})
+	if err != nil {
+		return nil, err
+	}
+	return &data.Vehicles, nil
+}
+
+// Access returns the address that owns the vehicle, and the grants the owner has made on it.
+// Grants are paged through until the identity service has no more.
+func (c *Client) Access(ctx context.Context, tokenID int) (*Access, error) {
+	var access *Access
+	var after *string
+
+	for {
+		data, err := query[accessData](ctx, c, accessQuery, map[string]any{"tokenId": tokenID, "first": pageSize, "after": after})
+		if err != nil {
+			return nil, err
+		}
+		if data.Vehicle == nil {
+			return nil, ErrVehicleNotFound
+		}
+
+		v := data.Vehicle
+		if access == nil {
+			access = &Access{Owner: v.Owner}
+		} else if !strings.EqualFold(v.Owner, access.Owner) {
+			return nil, errors.New("vehicle changed owner while its grants were listed")
+		}
+		for _, n := range v.Privileges.Nodes {
+			access.Grants = append(access.Grants, Grant{Privilege: privileges.Privilege(n.ID), User: n.User, ExpiresAt: n.ExpiresAt})
+		}
+
+		if !v.Privileges.PageInfo.HasNextPage {
+			return access, nil
+		}
+
+		cursor := v.Privileges.PageInfo.EndCursor
+		after = &cursor
+	}
+}
+
+// HasPrivilege reports whether any of users owns the vehicle or holds an unexpired grant of
+// the privilege on it. Addresses are compared without regard to case.
+func (c *Client) HasPrivilege(ctx context.Context, tokenID int, privilege privileges.Privilege, users ...string) (bool, error) {
+	access, err := c.Access(ctx, tokenID)
+	if errors.Is(err, ErrVehicleNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return access.Allows(privilege, time.Now(), users...), nil
+}
+
+// query posts the GraphQL query and returns the response's data. A response with errors
+// fails.
+func query[T any](ctx context.Context, c *Client, query string, variables map[string]any) (*T, error) {
+	reqBody, err := json.Marshal(graphQLRequest{Query: query, Variables: variables})
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.url, bytes.NewReader(reqBody))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	res, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("identity request failed: %w", err)
+	}
+	defer res.Body.Close()
+
+	resBody, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if code := res.StatusCode; code >= 400 {
+		return nil, fmt.Errorf("status code %d from identity service, response body %s", code, string(resBody))
+	}
+
+	var resp graphQLResponse[T]
+	if err := json.Unmarshal(resBody, &resp); err != nil {
+		return nil, fmt.Errorf("couldn't parse identity response: %w", err)
+	}
+
+	if len(resp.Errors) > 0 {
+		return nil, fmt.Errorf("identity service returned an error: %s", resp.Errors[0].Message)
+	}
+
+	return &resp.Data, nil
+}
+
+// IsOwner reports whether any of users owns the vehicle. Addresses are compared without regard
+// to case.
+func (c *Client) IsOwner(ctx context.Context, tokenID int, users ...string) (bool, error) {
+	access, err := c.Access(ctx, tokenID)
+	if errors.Is(err, ErrVehicleNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	for _, u := range users {
+		if u != "" && strings.EqualFold(u, access.Owner) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
@@ -0,0 +1,153 @@
+package identity
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/DIMO-Network/shared/privileges"
+	"github.com/DIMO-Network/trips-api/internal/config"
+	"github.com/goccy/go-json"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVehiclesPaging(t *testing.T) {
+	assert := assert.New(t)
+
+	pages := []string{
+		`{"data":{"vehicles":{"nodes":[{"tokenId":1,"userDeviceId":"2Y83IHPItgk0uHD7hybGnA776Bo","mintedAt":"2023-08-16T12:15:02Z"},{"tokenId":2,"userDeviceId":"2Y83IHPItgk0uHD7hybGnA776Bp","mintedAt":"2023-08-17T12:15:02Z"}],"pageInfo":{"hasNextPage":true,"endCursor":"Mg=="}}}}`,
+		`{"data":{"vehicles":{"nodes":[{"tokenId":3,"userDeviceId":"2Y83IHPItgk0uHD7hybGnA776Bq","mintedAt":"2023-08-18T12:15:02Z"}],"pageInfo":{"hasNextPage":false,"endCursor":"Mw=="}}}}`,
+	}
+
+	var cursors []any
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, err := io.ReadAll(r.Body)
+		assert.NoError(err)
+
+		var req graphQLRequest
+		assert.NoError(json.Unmarshal(b, &req))
+		cursors = append(cursors, req.Variables["after"])
+
+		_, _ = w.Write([]byte(pages[len(cursors)-1]))
+	}))
+	defer srv.Close()
+
+	client, err := New(&config.Settings{IdentityAPIURL: srv.URL})
+	assert.NoError(err)
+
+	var tokenIDs []int
+	err = client.Vehicles(context.Background(), func(vehicles []Vehicle) error {
+		for _, v := range vehicles {
+			tokenIDs = append(tokenIDs, v.TokenID)
+		}
+		return nil
+	})
+	assert.NoError(err)
+
+	assert.Equal([]int{1, 2, 3}, tokenIDs)
+	assert.Equal([]any{nil, "Mg=="}, cursors)
+}
+
+func TestVehiclesGraphQLError(t *testing.T) {
+	assert := assert.New(t)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"errors":[{"message":"boom"}]}`))
+	}))
+	defer srv.Close()
+
+	client, err := New(&config.Settings{IdentityAPIURL: srv.URL})
+	assert.NoError(err)
+
+	err = client.Vehicles(context.Background(), func([]Vehicle) error { return nil })
+	assert.ErrorContains(err, "boom")
+}
+
+func TestHasPrivilege(t *testing.T) {
+	assert := assert.New(t)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, err := io.ReadAll(r.Body)
+		assert.NoError(err)
+
+		var req graphQLRequest
+		assert.NoError(json.Unmarshal(b, &req))
+		if req.Variables["tokenId"] != float64(7) {
+			_, _ = w.Write([]byte(`{"data":{"vehicle":null}}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"data":{"vehicle":{"owner":"0xAbC0000000000000000000000000000000000001","privileges":{"nodes":[
+			{"id":4,"user":"0x0000000000000000000000000000000000000002","expiresAt":"2999-01-01T00:00:00Z"},
+			{"id":4,"user":"0x0000000000000000000000000000000000000003","expiresAt":"2020-01-01T00:00:00Z"},
+			{"id":1,"user":"0x0000000000000000000000000000000000000004","expiresAt":"2999-01-01T00:00:00Z"}
+		]}}}}`))
+	}))
+	defer srv.Close()
+
+	client, err := New(&config.Settings{IdentityAPIURL: srv.URL})
+	assert.NoError(err)
+
+	cases := []struct {
+		name    string
+		tokenID int
+		users   []string
+		allowed bool
+	}{
+		{"owner, any case", 7, []string{"0xabc0000000000000000000000000000000000001"}, true},
+		{"granted client", 7, []string{"0xnobody", "0x0000000000000000000000000000000000000002"}, true},
+		{"expired grant", 7, []string{"0x0000000000000000000000000000000000000003"}, false},
+		{"other privilege", 7, []string{"0x0000000000000000000000000000000000000004"}, false},
+		{"no users", 7, []string{"", ""}, false},
+		{"unknown vehicle", 8, []string{"0xabc0000000000000000000000000000000000001"}, false},
+	}
+	for _, c := range cases {
+		ok, err := client.HasPrivilege(context.Background(), c.tokenID, privileges.VehicleAllTimeLocation, c.users...)
+		assert.NoError(err, c.name)
+		assert.Equal(c.allowed, ok, c.name)
+	}
+
+	// Grants don't make their holders owners.
+	owner, err := client.IsOwner(context.Background(), 7, "0x0000000000000000000000000000000000000002", "0xABC0000000000000000000000000000000000001")
+	assert.NoError(err)
+	assert.True(owner)
+	owner, err = client.IsOwner(context.Background(), 7, "0x0000000000000000000000000000000000000002")
+	assert.NoError(err)
+	assert.False(owner)
+}
+
+func TestAccessPaging(t *testing.T) {
+	assert := assert.New(t)
+
+	pages := []string{
+		`{"data":{"vehicle":{"owner":"0xAbC0000000000000000000000000000000000001","privileges":{"nodes":[
+			{"id":1,"user":"0x0000000000000000000000000000000000000002","expiresAt":"2999-01-01T00:00:00Z"}
+		],"pageInfo":{"hasNextPage":true,"endCursor":"MQ=="}}}}}`,
+		`{"data":{"vehicle":{"owner":"0xAbC0000000000000000000000000000000000001","privileges":{"nodes":[
+			{"id":4,"user":"0x0000000000000000000000000000000000000003","expiresAt":"2999-01-01T00:00:00Z"}
+		],"pageInfo":{"hasNextPage":false,"endCursor":"Mg=="}}}}}`,
+	}
+
+	var cursors []any
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, err := io.ReadAll(r.Body)
+		assert.NoError(err)
+
+		var req graphQLRequest
+		assert.NoError(json.Unmarshal(b, &req))
+		cursors = append(cursors, req.Variables["after"])
+
+		_, _ = w.Write([]byte(pages[len(cursors)-1]))
+	}))
+	defer srv.Close()
+
+	client, err := New(&config.Settings{IdentityAPIURL: srv.URL})
+	assert.NoError(err)
+
+	// The grant on the second page is found.
+	ok, err := client.HasPrivilege(context.Background(), 7, privileges.VehicleAllTimeLocation, "0x0000000000000000000000000000000000000003")
+	assert.NoError(err)
+	assert.True(ok)
+	assert.Equal([]any{nil, "MQ=="}, cursors)
+}
@@ -0,0 +1,68 @@
+package pg
+
+import (
+	"context"
+	"time"
+
+	"github.com/DIMO-Network/trips-api/models"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/sqlboiler/v4/types"
+)
+
+// Access is a read of a vehicle's trip data, for the audit log.
+type Access struct {
+	VehicleTokenID int
+	// Subject is the privilege token's subject.
+	Subject  string
+	ClientID string
+	// Endpoint is the route that was read.
+	Endpoint string
+	// From and To bound the trips returned, if known.
+	From, To null.Time
+	// TripCount is the number of trips returned, if known.
+	TripCount null.Int
+}
+
+// RecordAccess appends the access to the audit log.
+func (s Store) RecordAccess(ctx context.Context, a Access) error {
+	rec := models.TripAccess{
+		VehicleTokenID: a.VehicleTokenID,
+		Subject:        a.Subject,
+		ClientID:       null.NewString(a.ClientID, a.ClientID != ""),
+		Endpoint:       a.Endpoint,
+		RangeStart:     a.From,
+		RangeEnd:       a.To,
+		TripCount:      a.TripCount,
+	}
+	return rec.Insert(ctx, s.DB.DBS().Writer, boil.Infer())
+}
+
+// AppAccess summarizes one app's reads of a vehicle's trip data.
+type AppAccess struct {
+	// ClientID is empty for tokens that didn't name a client.
+	ClientID    string            `boil:"client_id"`
+	AccessCount int               `boil:"access_count"`
+	FirstAccess time.Time         `boil:"first_access"`
+	LastAccess  time.Time         `boil:"last_access"`
+	Endpoints   types.StringArray `boil:"endpoints"`
+}
+
+// AppAccesses summarizes the reads of the vehicle's trip data by app, most recent first.
+func (s Store) AppAccesses(ctx context.Context, tokenID int) ([]AppAccess, error) {
+	var accesses []AppAccess
+	err := queries.Raw(`
+		SELECT COALESCE(client_id, '') AS client_id,
+			count(*) AS access_count,
+			min(created_at) AS first_access,
+			max(created_at) AS last_access,
+			array_agg(DISTINCT endpoint ORDER BY endpoint) AS endpoints
+		FROM `+models.TableNames.TripAccesses+`
+		WHERE vehicle_token_id = $1
+		GROUP BY 1
+		ORDER BY last_access DESC`,
+		tokenID,
+	).Bind(ctx, s.DB.DBS().Reader, &accesses)
+	return accesses, err
+}
@@ -0,0 +1,40 @@
+package pg
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DIMO-Network/trips-api/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/volatiletech/null/v8"
+)
+
+func Test_TripAccesses(t *testing.T) {
+	ctx := context.Background()
+	store := setupStore(ctx, t)
+
+	for _, a := range []Access{
+		{VehicleTokenID: 1, ClientID: "0x01", Endpoint: "/v1/vehicle/:tokenID/trips"},
+		{VehicleTokenID: 1, ClientID: "0x01", Endpoint: "/v1/vehicle/:tokenID/trips/summary"},
+		{VehicleTokenID: 1, ClientID: "0x01", Endpoint: "/v1/vehicle/:tokenID/trips"},
+		{VehicleTokenID: 1, Endpoint: "/v1/vehicle/:tokenID/trips"},
+		{VehicleTokenID: 2, ClientID: "0x02", Endpoint: "/v1/vehicle/:tokenID/trips"},
+	} {
+		a.Subject = "0xbA5738a18d83D41847dfFbDC6101d37C69c9B0cF/1"
+		a.TripCount = null.IntFrom(3)
+		assert.NoError(t, store.RecordAccess(ctx, a))
+	}
+
+	accesses, err := store.AppAccesses(ctx, 1)
+	assert.NoError(t, err)
+	if assert.Len(t, accesses, 2) {
+		assert.Equal(t, "", accesses[0].ClientID)
+		assert.Equal(t, "0x01", accesses[1].ClientID)
+		assert.Equal(t, 3, accesses[1].AccessCount)
+		assert.Equal(t, []string{"/v1/vehicle/:tokenID/trips", "/v1/vehicle/:tokenID/trips/summary"}, []string(accesses[1].Endpoints))
+	}
+
+	// The log is append-only.
+	_, err = models.TripAccesses().DeleteAll(ctx, store.DB.DBS().Writer)
+	assert.Error(t, err)
+}
@@ -0,0 +1,184 @@
+package pg
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/DIMO-Network/trips-api/internal/merkle"
+	"github.com/DIMO-Network/trips-api/models"
+	"github.com/segmentio/ksuid"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+)
+
+// ErrNotAnchored is returned for trips whose current data hash isn't in any anchor yet.
+var ErrNotAnchored = errors.New("trip not anchored")
+
+// ErrNotPublished is returned for trips whose current data hash is only in anchors that
+// haven't been published yet.
+var ErrNotPublished = errors.New("trip anchor not published")
+
+// CreateAnchor rolls up to limit completed trips that haven't been anchored with their current
+// data hash into a Merkle tree, oldest first, and records its root and leaves for publishing
+// by publisher. It returns nil if there are no such trips. Trips whose data is archived again
+// after merging or splitting are anchored again with their new hash.
+func (s Store) CreateAnchor(ctx context.Context, limit int, publisher string) (*models.Anchor, error) {
+	tx, err := s.DB.DBS().Writer.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback() //nolint
+
+	// Serialize batching across replicas, so that no trip lands in two batches at once.
+	if _, err := tx.ExecContext(ctx, "SELECT pg_advisory_xact_lock(hashtext('anchor'))"); err != nil {
+		return nil, err
+	}
+
+	rows, err := tx.QueryContext(ctx, `
+		SELECT t.id, t.data_sha256 FROM `+models.TableNames.Trips+` t
+		WHERE t.end_time IS NOT NULL AND t.data_sha256 IS NOT NULL
+		AND NOT EXISTS (
+			SELECT 1 FROM `+models.TableNames.AnchorEntries+` l
+			WHERE l.trip_id = t.id AND l.data_sha256 = t.data_sha256
+		)
+		ORDER BY t.end_time, t.id
+		LIMIT $1`,
+		limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var leaves models.AnchorEntrySlice
+	var hashes []merkle.Hash
+	for rows.Next() {
+		var l models.AnchorEntry
+		if err := rows.Scan(&l.TripID, &l.DataSha256); err != nil {
+			return nil, err
+		}
+		l.LeafIndex = len(leaves)
+		leaves = append(leaves, &l)
+		hashes = append(hashes, merkle.LeafHash(l.TripID, merkle.Hash(l.DataSha256)))
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if len(leaves) == 0 {
+		return nil, nil
+	}
+
+	root := merkle.Root(hashes)
+	a := &models.Anchor{
+		ID:        ksuid.New().String(),
+		Root:      root[:],
+		LeafCount: len(leaves),
+		Publisher: publisher,
+	}
+	if err := a.Insert(ctx, tx, boil.Infer()); err != nil {
+		return nil, err
+	}
+	for _, l := range leaves {
+		l.AnchorID = a.ID
+		if err := l.Insert(ctx, tx, boil.Infer()); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+// UnpublishedAnchors returns the anchors that haven't been published yet, oldest first.
+func (s Store) UnpublishedAnchors(ctx context.Context) (models.AnchorSlice, error) {
+	return models.Anchors(
+		models.AnchorWhere.PublishedAt.IsNull(),
+		qm.OrderBy(models.AnchorColumns.CreatedAt),
+	).All(ctx, s.DB.DBS().Reader)
+}
+
+// MarkAnchorPublished records where the anchor was published.
+func (s Store) MarkAnchorPublished(ctx context.Context, id, reference string, at time.Time) error {
+	_, err := models.Anchors(models.AnchorWhere.ID.EQ(id)).UpdateAll(ctx, s.DB.DBS().Writer, models.M{
+		models.AnchorColumns.Reference:   null.StringFrom(reference),
+		models.AnchorColumns.PublishedAt: null.TimeFrom(at),
+	})
+	return err
+}
+
+// TripProof shows that a trip's current data hash is a leaf of an anchor.
+type TripProof struct {
+	Anchor    *models.Anchor
+	LeafIndex int
+	DataHash  merkle.Hash
+	// Proof lists the sibling hashes from the leaf up to the root.
+	Proof []merkle.Hash
+}
+
+// TripProof returns the inclusion proof of the vehicle's trip in its latest published anchor.
+// It returns sql.ErrNoRows if there's no such trip, ErrNotAnchored if the trip's current data
+// hash hasn't been anchored, and ErrNotPublished if none of its anchors has been published,
+// as a root that was never published proves nothing.
+func (s Store) TripProof(ctx context.Context, tokenID int, tripID string) (*TripProof, error) {
+	trp, err := models.Trips(
+		models.TripWhere.ID.EQ(tripID),
+		models.TripWhere.VehicleTokenID.EQ(tokenID),
+	).One(ctx, s.DB.DBS().Reader)
+	if err != nil {
+		return nil, err
+	}
+	if !trp.DataSha256.Valid {
+		return nil, ErrNotAnchored
+	}
+
+	leaf, err := models.AnchorEntries(
+		qm.Select(models.TableNames.AnchorEntries+".*"),
+		qm.InnerJoin(models.TableNames.Anchors+" ON "+models.AnchorTableColumns.ID+" = "+models.AnchorEntryTableColumns.AnchorID),
+		models.AnchorEntryWhere.TripID.EQ(trp.ID),
+		models.AnchorEntryWhere.DataSha256.EQ(trp.DataSha256.Bytes),
+		qm.Where(models.AnchorTableColumns.PublishedAt+" IS NOT NULL"),
+		qm.OrderBy(models.AnchorTableColumns.CreatedAt+" DESC"),
+		qm.Load(models.AnchorEntryRels.Anchor),
+	).One(ctx, s.DB.DBS().Reader)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			anchored, err := models.AnchorEntries(
+				models.AnchorEntryWhere.TripID.EQ(trp.ID),
+				models.AnchorEntryWhere.DataSha256.EQ(trp.DataSha256.Bytes),
+			).Exists(ctx, s.DB.DBS().Reader)
+			if err != nil {
+				return nil, err
+			}
+			if anchored {
+				return nil, ErrNotPublished
+			}
+			return nil, ErrNotAnchored
+		}
+		return nil, err
+	}
+
+	leaves, err := models.AnchorEntries(
+		models.AnchorEntryWhere.AnchorID.EQ(leaf.AnchorID),
+		qm.OrderBy(models.AnchorEntryColumns.LeafIndex),
+	).All(ctx, s.DB.DBS().Reader)
+	if err != nil {
+		return nil, err
+	}
+
+	hashes := make([]merkle.Hash, len(leaves))
+	for i, l := range leaves {
+		hashes[i] = merkle.LeafHash(l.TripID, merkle.Hash(l.DataSha256))
+	}
+
+	return &TripProof{
+		Anchor:    leaf.R.Anchor,
+		LeafIndex: leaf.LeafIndex,
+		DataHash:  merkle.Hash(leaf.DataSha256),
+		Proof:     merkle.Proof(hashes, leaf.LeafIndex),
+	}, nil
+}
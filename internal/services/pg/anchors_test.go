@@ -0,0 +1,103 @@
+package pg
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/DIMO-Network/trips-api/internal/merkle"
+	"github.com/DIMO-Network/trips-api/models"
+	"github.com/segmentio/ksuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+)
+
+func Test_Anchors(t *testing.T) {
+	ctx := context.Background()
+	store := setupStore(ctx, t)
+	tokenID := 1
+
+	start := time.Date(2024, 3, 1, 8, 0, 0, 0, time.UTC)
+	var trips []*models.Trip
+	for i := range 4 {
+		hash := sha256.Sum256([]byte{byte(i)})
+		trp := &models.Trip{
+			ID:             ksuid.New().String(),
+			VehicleTokenID: tokenID,
+			StartTime:      start.Add(time.Duration(i) * time.Hour),
+			EndTime:        null.TimeFrom(start.Add(time.Duration(i)*time.Hour + 30*time.Minute)),
+			DataSha256:     null.BytesFrom(hash[:]),
+		}
+		if i == 3 {
+			// Ongoing trips aren't anchored.
+			trp.EndTime = null.Time{}
+		}
+		if err := trp.Insert(ctx, store.DB.DBS().Writer, boil.Infer()); err != nil {
+			t.Fatal(err)
+		}
+		trips = append(trips, trp)
+	}
+
+	_, err := store.TripProof(ctx, tokenID, trips[0].ID)
+	assert.ErrorIs(t, err, ErrNotAnchored)
+
+	a1, err := store.CreateAnchor(ctx, 2, "local")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, a1.LeafCount)
+	a2, err := store.CreateAnchor(ctx, 2, "local")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, a2.LeafCount)
+	a3, err := store.CreateAnchor(ctx, 2, "local")
+	assert.NoError(t, err)
+	assert.Nil(t, a3)
+
+	unpublished, err := store.UnpublishedAnchors(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, unpublished, 2)
+	_, err = store.TripProof(ctx, tokenID, trips[0].ID)
+	assert.ErrorIs(t, err, ErrNotPublished)
+
+	assert.NoError(t, store.MarkAnchorPublished(ctx, a1.ID, "ref", time.Now()))
+	unpublished, err = store.UnpublishedAnchors(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, unpublished, 1)
+	_, err = store.TripProof(ctx, tokenID, trips[2].ID)
+	assert.ErrorIs(t, err, ErrNotPublished)
+	assert.NoError(t, store.MarkAnchorPublished(ctx, a2.ID, "ref", time.Now()))
+
+	for i, trp := range trips[:3] {
+		p, err := store.TripProof(ctx, tokenID, trp.ID)
+		if !assert.NoError(t, err, i) {
+			continue
+		}
+		leaf := merkle.LeafHash(trp.ID, merkle.Hash(trp.DataSha256.Bytes))
+		assert.NoError(t, merkle.Verify(leaf, p.LeafIndex, p.Anchor.LeafCount, p.Proof, merkle.Hash(p.Anchor.Root)), i)
+	}
+
+	_, err = store.TripProof(ctx, tokenID+1, trips[0].ID)
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+	_, err = store.TripProof(ctx, tokenID, trips[3].ID)
+	assert.ErrorIs(t, err, ErrNotAnchored)
+
+	// Trips archived again are anchored again with their new hash.
+	hash := sha256.Sum256([]byte("edited"))
+	trips[0].DataSha256 = null.BytesFrom(hash[:])
+	if _, err := trips[0].Update(ctx, store.DB.DBS().Writer, boil.Whitelist(models.TripColumns.DataSha256)); err != nil {
+		t.Fatal(err)
+	}
+	_, err = store.TripProof(ctx, tokenID, trips[0].ID)
+	assert.ErrorIs(t, err, ErrNotAnchored)
+
+	a4, err := store.CreateAnchor(ctx, 2, "local")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, a4.LeafCount)
+	_, err = store.TripProof(ctx, tokenID, trips[0].ID)
+	assert.ErrorIs(t, err, ErrNotPublished)
+	assert.NoError(t, store.MarkAnchorPublished(ctx, a4.ID, "ref", time.Now()))
+	p, err := store.TripProof(ctx, tokenID, trips[0].ID)
+	assert.NoError(t, err)
+	assert.Equal(t, a4.ID, p.Anchor.ID)
+}
@@ -0,0 +1,96 @@
+package pg
+
+import (
+	"context"
+
+	"github.com/DIMO-Network/trips-api/models"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+)
+
+// Annotation changes the fields of a trip that users maintain. Nil fields are left alone and
+// empty ones are cleared. Setting the purpose also sets the custom purpose, which only custom
+// purposes have.
+type Annotation struct {
+	Purpose       *string
+	CustomPurpose *string
+	Note          *string
+}
+
+// Editor identifies who made a change, for the audit trail.
+type Editor struct {
+	// Subject is the privilege token's subject.
+	Subject  string
+	ClientID string
+	// UserAddress is the ethereum_address of the user the token was issued to, if it names one.
+	UserAddress string
+}
+
+// AnnotateTrip applies the annotation to the vehicle's trip, recording each field it changes
+// in trip_annotation_changes. It returns sql.ErrNoRows if the vehicle has no such trip.
+func (s Store) AnnotateTrip(ctx context.Context, tokenID int, tripID string, a Annotation, editor Editor) (*models.Trip, error) {
+	tx, err := s.DB.DBS().Writer.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback() //nolint
+
+	trp, err := models.Trips(
+		models.TripWhere.ID.EQ(tripID),
+		models.TripWhere.VehicleTokenID.EQ(tokenID),
+		qm.For("UPDATE"),
+	).One(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	var changes models.TripAnnotationChangeSlice
+	set := func(field string, current *null.String, value *string) {
+		next := null.NewString(*value, *value != "")
+		if next == *current {
+			return
+		}
+		changes = append(changes, &models.TripAnnotationChange{
+			TripID:         trp.ID,
+			VehicleTokenID: trp.VehicleTokenID,
+			Subject:        editor.Subject,
+			ClientID:       null.NewString(editor.ClientID, editor.ClientID != ""),
+			UserAddress:    null.NewString(editor.UserAddress, editor.UserAddress != ""),
+			Field:          field,
+			OldValue:       *current,
+			NewValue:       next,
+		})
+		*current = next
+	}
+
+	if a.Purpose != nil {
+		set(models.TripColumns.Purpose, &trp.Purpose, a.Purpose)
+		customPurpose := ""
+		if a.CustomPurpose != nil {
+			customPurpose = *a.CustomPurpose
+		}
+		set(models.TripColumns.CustomPurpose, &trp.CustomPurpose, &customPurpose)
+	}
+	if a.Note != nil {
+		set(models.TripColumns.Note, &trp.Note, a.Note)
+	}
+
+	if len(changes) == 0 {
+		return trp, nil
+	}
+
+	if _, err := trp.Update(ctx, tx, boil.Whitelist(models.TripColumns.Purpose, models.TripColumns.CustomPurpose, models.TripColumns.Note)); err != nil {
+		return nil, err
+	}
+	for _, ch := range changes {
+		if err := ch.Insert(ctx, tx, boil.Infer()); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return trp, nil
+}
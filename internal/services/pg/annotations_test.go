@@ -0,0 +1,50 @@
+package pg
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/DIMO-Network/trips-api/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+)
+
+func Test_AnnotateTrip(t *testing.T) {
+	ctx := context.Background()
+	store := setupStore(ctx, t)
+
+	start := time.Date(2023, 8, 16, 12, 15, 2, 0, time.UTC)
+	trp := insertTrip(ctx, t, store, start, start.Add(time.Hour))
+
+	editor := Editor{Subject: "0xbA5738a18d83D41847dfFbDC6101d37C69c9B0cF/1", ClientID: "0x0123", UserAddress: "0x4b7B7F6E5A5E8A3E4F0D3c8F2B1e9A7d6C5b4A3f"}
+	custom, name, note := "custom", "Client visits", "Dropped off samples."
+
+	annotated, err := store.AnnotateTrip(ctx, 1, trp.ID, Annotation{Purpose: &custom, CustomPurpose: &name, Note: &note}, editor)
+	assert.NoError(t, err)
+	assert.Equal(t, name, annotated.CustomPurpose.String)
+
+	// Switching to a built-in purpose drops the name, and an unchanged note isn't recorded.
+	business := "business"
+	annotated, err = store.AnnotateTrip(ctx, 1, trp.ID, Annotation{Purpose: &business, Note: &note}, editor)
+	assert.NoError(t, err)
+	assert.Equal(t, business, annotated.Purpose.String)
+	assert.False(t, annotated.CustomPurpose.Valid)
+	assert.Equal(t, note, annotated.Note.String)
+
+	changes, err := models.TripAnnotationChanges(qm.OrderBy(models.TripAnnotationChangeColumns.ID)).All(ctx, store.DB.DBS().Reader)
+	assert.NoError(t, err)
+	if assert.Len(t, changes, 5) {
+		assert.Equal(t, models.TripColumns.Purpose, changes[3].Field)
+		assert.Equal(t, custom, changes[3].OldValue.String)
+		assert.Equal(t, business, changes[3].NewValue.String)
+		assert.Equal(t, models.TripColumns.CustomPurpose, changes[4].Field)
+		assert.False(t, changes[4].NewValue.Valid)
+		assert.Equal(t, editor.Subject, changes[4].Subject)
+		assert.Equal(t, editor.UserAddress, changes[4].UserAddress.String)
+	}
+
+	_, err = store.AnnotateTrip(ctx, 2, trp.ID, Annotation{Note: &note}, editor)
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+}
@@ -0,0 +1,125 @@
+package pg
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/DIMO-Network/trips-api/models"
+	"github.com/segmentio/ksuid"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"github.com/volatiletech/sqlboiler/v4/types"
+)
+
+// EraseTrips deletes the vehicle's trips that start in the range, along with their encryption
+// keys, which leaves their archived data unreadable. A nil start means from the first trip.
+// It records and returns a receipt. Erasing a range that has already been erased, or repeating
+// a non-empty idempotency key, returns the earlier receipt with created false.
+func (s Store) EraseTrips(ctx context.Context, tokenID int, start *time.Time, end time.Time, key string, editor Editor) (_ *models.Erasure, created bool, err error) {
+	tx, err := s.DB.DBS().Writer.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, false, err
+	}
+	defer tx.Rollback() //nolint
+
+	// Serialize erasures of the vehicle, so that concurrent retries find each other's receipts.
+	if _, err := tx.ExecContext(ctx, "SELECT pg_advisory_xact_lock(hashtext($1))", "erasure:"+strconv.Itoa(tokenID)); err != nil {
+		return nil, false, err
+	}
+
+	if key != "" {
+		prev, err := models.Erasures(
+			models.ErasureWhere.VehicleTokenID.EQ(tokenID),
+			models.ErasureWhere.IdempotencyKey.EQ(null.StringFrom(key)),
+		).One(ctx, tx)
+		if err == nil {
+			return prev, false, nil
+		} else if !errors.Is(err, sql.ErrNoRows) {
+			return nil, false, err
+		}
+	}
+
+	// Postgres keeps microseconds, so compare at that precision.
+	end = end.Truncate(time.Microsecond)
+	rangeStart := null.TimeFromPtr(start)
+	if start != nil {
+		rangeStart.Time = start.Truncate(time.Microsecond)
+	}
+	prev, err := models.Erasures(
+		models.ErasureWhere.VehicleTokenID.EQ(tokenID),
+		qm.Where(models.ErasureColumns.RangeStart+" IS NOT DISTINCT FROM ?", rangeStart),
+		models.ErasureWhere.RangeEnd.EQ(end),
+	).One(ctx, tx)
+	if err == nil {
+		return prev, false, nil
+	} else if !errors.Is(err, sql.ErrNoRows) {
+		return nil, false, err
+	}
+
+	filter := []qm.QueryMod{
+		models.TripWhere.VehicleTokenID.EQ(tokenID),
+		models.TripWhere.StartTime.LT(end),
+	}
+	if start != nil {
+		filter = append(filter, models.TripWhere.StartTime.GTE(rangeStart.Time))
+	}
+
+	trips, err := models.Trips(append(filter, qm.For("UPDATE"))...).All(ctx, tx)
+	if err != nil {
+		return nil, false, err
+	}
+
+	archiveIDs := types.StringArray{}
+	for _, trp := range trips {
+		if trp.BundlrID.Valid {
+			archiveIDs = append(archiveIDs, trp.BundlrID.String)
+		}
+	}
+
+	// Deleting the rows destroys the keys. Updates and annotation history go with them.
+	if _, err := trips.DeleteAll(ctx, tx); err != nil {
+		return nil, false, err
+	}
+
+	e := &models.Erasure{
+		ID:             ksuid.New().String(),
+		VehicleTokenID: tokenID,
+		RangeStart:     rangeStart,
+		RangeEnd:       end,
+		Subject:        editor.Subject,
+		ClientID:       null.NewString(editor.ClientID, editor.ClientID != ""),
+		UserAddress:    null.NewString(editor.UserAddress, editor.UserAddress != ""),
+		TripCount:      len(trips),
+		ArchiveIds:     archiveIDs,
+		IdempotencyKey: null.NewString(key, key != ""),
+	}
+	if err := e.Insert(ctx, tx, boil.Infer()); err != nil {
+		return nil, false, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, false, err
+	}
+	return e, true, nil
+}
+
+// Erased reports whether trips of the vehicle starting at t have been erased.
+func (s Store) Erased(ctx context.Context, tokenID int, t time.Time) (bool, error) {
+	return models.Erasures(
+		models.ErasureWhere.VehicleTokenID.EQ(tokenID),
+		qm.Where("("+models.ErasureColumns.RangeStart+" IS NULL OR "+models.ErasureColumns.RangeStart+" <= ?)", t),
+		models.ErasureWhere.RangeEnd.GT(t),
+	).Exists(ctx, s.DB.DBS().Reader)
+}
+
+// Erasures returns the receipts of the vehicle's erasures, oldest first.
+func (s Store) Erasures(ctx context.Context, tokenID int) (models.ErasureSlice, error) {
+	return models.Erasures(
+		models.ErasureWhere.VehicleTokenID.EQ(tokenID),
+		qm.OrderBy(models.ErasureColumns.CreatedAt),
+	).All(ctx, s.DB.DBS().Reader)
+}
@@ -0,0 +1,16 @@
+package pg
+
+import (
+	"context"
+
+	"github.com/DIMO-Network/trips-api/models"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+)
+
+// PrivacyZones returns the vehicle's privacy zones, oldest first.
+func (s Store) PrivacyZones(ctx context.Context, tokenID int) (models.PrivacyZoneSlice, error) {
+	return models.PrivacyZones(
+		models.PrivacyZoneWhere.VehicleTokenID.EQ(tokenID),
+		qm.OrderBy(models.PrivacyZoneColumns.CreatedAt),
+	).All(ctx, s.DB.DBS().Reader)
+}
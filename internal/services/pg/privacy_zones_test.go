@@ -0,0 +1,39 @@
+package pg
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DIMO-Network/trips-api/models"
+	"github.com/segmentio/ksuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/types/pgeo"
+)
+
+func Test_PrivacyZones(t *testing.T) {
+	ctx := context.Background()
+	store := setupStore(ctx, t)
+
+	for i, tokenID := range []int{1, 1, 2} {
+		z := models.PrivacyZone{
+			ID:             ksuid.New().String(),
+			VehicleTokenID: tokenID,
+			Name:           "Home",
+			Center:         pgeo.NewPoint(-73.98, 40.75),
+			RadiusM:        300,
+			Mask:           "snap",
+			CreatedAt:      time.Date(2024, 3, 1, 8, i, 0, 0, time.UTC),
+		}
+		assert.NoError(t, z.Insert(ctx, store.DB.DBS().Writer, boil.Infer()))
+	}
+
+	zones, err := store.PrivacyZones(ctx, 1)
+	assert.NoError(t, err)
+	assert.Len(t, zones, 2)
+	assert.True(t, zones[0].CreatedAt.Before(zones[1].CreatedAt))
+
+	z := models.PrivacyZone{ID: ksuid.New().String(), VehicleTokenID: 1, Name: "Tiny", RadiusM: 10, Mask: "snap"}
+	assert.Error(t, z.Insert(ctx, store.DB.DBS().Writer, boil.Infer()))
+}
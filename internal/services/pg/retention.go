@@ -0,0 +1,94 @@
+package pg
+
+import (
+	"context"
+	"time"
+
+	"github.com/DIMO-Network/trips-api/models"
+)
+
+// The trips that retention acts on, aged by their start. Trips in progress are left alone, so
+// that their completion still finds them and they are aggregated with their duration.
+const (
+	positionedTripsBefore = `start_time < $1 AND end_time IS NOT NULL AND (start_position IS NOT NULL
+		OR start_position_estimate IS NOT NULL OR end_position IS NOT NULL OR encryption_key IS NOT NULL)`
+	tripsBefore = `start_time < $1 AND end_time IS NOT NULL`
+)
+
+// CountPositionedTrips counts the completed trips that started in [since, before) and still
+// have positions or an encryption key.
+func (s Store) CountPositionedTrips(ctx context.Context, since, before time.Time) (int64, error) {
+	var n int64
+	err := s.DB.DBS().Reader.QueryRowContext(ctx,
+		`SELECT count(*) FROM `+models.TableNames.Trips+` WHERE `+positionedTripsBefore+` AND start_time >= $2`, before, since,
+	).Scan(&n)
+	return n, err
+}
+
+// ClearTripPositions removes the positions and encryption keys of up to limit completed trips
+// that started before the given time, and returns how many it changed. The archived data holds
+// positions too, so its key goes with them. Trips locked by others are skipped.
+func (s Store) ClearTripPositions(ctx context.Context, before time.Time, limit int) (int64, error) {
+	res, err := s.DB.DBS().Writer.ExecContext(ctx, `
+		UPDATE `+models.TableNames.Trips+`
+		SET start_position = NULL, start_position_estimate = NULL, end_position = NULL, encryption_key = NULL
+		WHERE id IN (
+			SELECT id FROM `+models.TableNames.Trips+`
+			WHERE `+positionedTripsBefore+`
+			LIMIT $2
+			FOR UPDATE SKIP LOCKED
+		)`,
+		before, limit,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
+
+// CountTripsBefore counts the completed trips that started before the given time.
+func (s Store) CountTripsBefore(ctx context.Context, before time.Time) (int64, error) {
+	var n int64
+	err := s.DB.DBS().Reader.QueryRowContext(ctx,
+		`SELECT count(*) FROM `+models.TableNames.Trips+` WHERE `+tripsBefore, before,
+	).Scan(&n)
+	return n, err
+}
+
+// DeleteTripsBefore deletes up to limit completed trips that started before the given time, and returns
+// how many it deleted. If aggregate is set, their counts, durations and distances are first
+// added to the vehicles' monthly totals in trip_aggregates. Trips locked by others are skipped.
+func (s Store) DeleteTripsBefore(ctx context.Context, before time.Time, limit int, aggregate bool) (int64, error) {
+	// Data-modifying statements in WITH run whether or not they are referenced.
+	query := `
+		WITH deleted AS (
+			DELETE FROM ` + models.TableNames.Trips + `
+			WHERE id IN (
+				SELECT id FROM ` + models.TableNames.Trips + `
+				WHERE ` + tripsBefore + `
+				LIMIT $2
+				FOR UPDATE SKIP LOCKED
+			)
+			RETURNING vehicle_token_id, start_time, end_time, distance_km
+		)`
+	if aggregate {
+		query += `, aggregated AS (
+			INSERT INTO ` + models.TableNames.TripAggregates + ` AS a (vehicle_token_id, month, trip_count, duration_seconds, distance_km)
+			SELECT vehicle_token_id, date_trunc('month', start_time AT TIME ZONE 'UTC')::date, count(*),
+				COALESCE(sum(extract(epoch FROM end_time - start_time)), 0)::double precision,
+				COALESCE(sum(distance_km), 0)
+			FROM deleted
+			GROUP BY 1, 2
+			ON CONFLICT (vehicle_token_id, month) DO UPDATE SET
+				trip_count = a.trip_count + EXCLUDED.trip_count,
+				duration_seconds = a.duration_seconds + EXCLUDED.duration_seconds,
+				distance_km = a.distance_km + EXCLUDED.distance_km,
+				updated_at = now()
+		)`
+	}
+	query += ` SELECT count(*) FROM deleted`
+
+	var n int64
+	err := s.DB.DBS().Writer.QueryRowContext(ctx, query, before, limit).Scan(&n)
+	return n, err
+}
@@ -0,0 +1,82 @@
+package pg
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DIMO-Network/trips-api/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+)
+
+func Test_Retention(t *testing.T) {
+	ctx := context.Background()
+	store := setupStore(ctx, t)
+
+	start1, end1 := time.Date(2023, 8, 16, 12, 15, 2, 0, time.UTC), time.Date(2023, 8, 18, 8, 15, 2, 0, time.UTC)
+	start2, end2 := time.Date(2023, 8, 18, 8, 18, 2, 0, time.UTC), time.Date(2023, 8, 18, 8, 25, 2, 0, time.UTC)
+	insertTrip(ctx, t, store, start1, end1)
+	second := insertTrip(ctx, t, store, start2, end2)
+	// A trip still in progress is left alone, however old.
+	inProgress := insertTrip(ctx, t, store, start1.Add(-24*time.Hour), time.Time{})
+
+	// Between the two trips' starts.
+	cutoff := start2
+
+	n, err := store.CountPositionedTrips(ctx, time.Time{}, time.Now())
+	assert.NoError(t, err)
+	assert.EqualValues(t, 2, n)
+
+	n, err = store.ClearTripPositions(ctx, time.Now(), 1)
+	assert.NoError(t, err)
+	assert.EqualValues(t, 1, n)
+	n, err = store.CountPositionedTrips(ctx, time.Time{}, time.Now())
+	assert.NoError(t, err)
+	assert.EqualValues(t, 1, n)
+
+	n, err = store.CountTripsBefore(ctx, cutoff)
+	assert.NoError(t, err)
+	assert.EqualValues(t, 1, n)
+
+	n, err = store.DeleteTripsBefore(ctx, cutoff, 100, true)
+	assert.NoError(t, err)
+	assert.EqualValues(t, 1, n)
+
+	trips, err := models.Trips(qm.OrderBy(models.TripColumns.StartTime)).All(ctx, store.DB.DBS().Reader)
+	assert.NoError(t, err)
+	if assert.Len(t, trips, 2) {
+		assert.Equal(t, inProgress.ID, trips[0].ID)
+		assert.True(t, trips[0].StartPosition.Valid)
+		assert.Equal(t, second.ID, trips[1].ID)
+	}
+
+	aggs, err := models.TripAggregates().All(ctx, store.DB.DBS().Reader)
+	assert.NoError(t, err)
+	if assert.Len(t, aggs, 1) {
+		assert.Equal(t, 1, aggs[0].TripCount)
+		assert.Equal(t, time.August, aggs[0].Month.Month())
+		assert.InDelta(t, end1.Sub(start1).Seconds(), aggs[0].DurationSeconds, 1e-6)
+	}
+
+	// Summaries count the deleted trip with the one that remains: in the month's bucket in
+	// UTC, and in a bucket of its own otherwise.
+	buckets, err := store.SummarizeTrips(ctx, 1, PeriodMonth, time.UTC,
+		time.Date(2023, 8, 10, 0, 0, 0, 0, time.UTC), time.Date(2023, 9, 1, 0, 0, 0, 0, time.UTC))
+	assert.NoError(t, err)
+	if assert.Len(t, buckets, 1) {
+		assert.True(t, buckets[0].Aggregated)
+		assert.Equal(t, 2, buckets[0].TripCount)
+	}
+
+	buckets, err = store.SummarizeTrips(ctx, 1, PeriodDay, time.UTC,
+		time.Date(2023, 8, 10, 0, 0, 0, 0, time.UTC), time.Date(2023, 9, 1, 0, 0, 0, 0, time.UTC))
+	assert.NoError(t, err)
+	if assert.Len(t, buckets, 2) {
+		assert.True(t, buckets[0].Aggregated)
+		assert.True(t, buckets[0].Start.Equal(time.Date(2023, 8, 1, 0, 0, 0, 0, time.UTC)))
+		assert.Equal(t, 1, buckets[0].TripCount)
+		assert.False(t, buckets[1].Aggregated)
+		assert.True(t, buckets[1].Start.Equal(time.Date(2023, 8, 18, 0, 0, 0, 0, time.UTC)))
+	}
+}
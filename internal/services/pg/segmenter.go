@@ -0,0 +1,57 @@
+package pg
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/DIMO-Network/trips-api/models"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/types"
+)
+
+// SegmenterState returns the segmenter's saved state for the user device, or nil if it has
+// never watched the device.
+func (s Store) SegmenterState(ctx context.Context, userDeviceID string) ([]byte, error) {
+	st, err := models.FindSegmenterState(ctx, s.DB.DBS().Reader, userDeviceID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return st.State, nil
+}
+
+// SaveSegmenterState records the segmenter's state for the user device.
+func (s Store) SaveSegmenterState(ctx context.Context, userDeviceID string, state []byte, at time.Time) error {
+	st := models.SegmenterState{
+		UserDeviceID: userDeviceID,
+		State:        types.JSON(state),
+		UpdatedAt:    at,
+	}
+	return st.Upsert(ctx, s.DB.DBS().Writer, true, []string{models.SegmenterStateColumns.UserDeviceID},
+		boil.Whitelist(models.SegmenterStateColumns.State, models.SegmenterStateColumns.UpdatedAt), boil.Infer())
+}
+
+// LockSegmenterDevice takes a lock on the user device for the segmenter, so that only one
+// replica segments it at a time. It returns false if another replica holds the lock. The
+// lock is held by a transaction, which unlock ends.
+func (s Store) LockSegmenterDevice(ctx context.Context, userDeviceID string) (unlock func(), locked bool, err error) {
+	tx, err := s.DB.DBS().Writer.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if err := tx.QueryRowContext(ctx, "SELECT pg_try_advisory_xact_lock(hashtext($1))", "segmenter:"+userDeviceID).Scan(&locked); err != nil {
+		_ = tx.Rollback()
+		return nil, false, err
+	}
+	if !locked {
+		_ = tx.Rollback()
+		return nil, false, nil
+	}
+
+	return func() { _ = tx.Rollback() }, true, nil
+}
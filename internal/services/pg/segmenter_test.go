@@ -0,0 +1,41 @@
+package pg
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/segmentio/ksuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_SegmenterState(t *testing.T) {
+	ctx := context.Background()
+	store := setupStore(ctx, t)
+	userDeviceID := ksuid.New().String()
+
+	state, err := store.SegmenterState(ctx, userDeviceID)
+	assert.NoError(t, err)
+	assert.Nil(t, state)
+
+	for _, s := range []string{`{"until": "2024-03-01T08:00:00Z"}`, `{"until": "2024-03-01T09:00:00Z"}`} {
+		assert.NoError(t, store.SaveSegmenterState(ctx, userDeviceID, []byte(s), time.Now()))
+	}
+	state, err = store.SegmenterState(ctx, userDeviceID)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"until": "2024-03-01T09:00:00Z"}`, string(state))
+
+	unlock, locked, err := store.LockSegmenterDevice(ctx, userDeviceID)
+	assert.NoError(t, err)
+	assert.True(t, locked)
+
+	_, locked, err = store.LockSegmenterDevice(ctx, userDeviceID)
+	assert.NoError(t, err)
+	assert.False(t, locked)
+
+	unlock()
+	unlock, locked, err = store.LockSegmenterDevice(ctx, userDeviceID)
+	assert.NoError(t, err)
+	assert.True(t, locked)
+	unlock()
+}
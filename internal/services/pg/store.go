@@ -2,11 +2,14 @@ package pg
 
 import (
 	"context"
+	"time"
 
 	"github.com/DIMO-Network/shared/db"
 	"github.com/DIMO-Network/trips-api/internal/config"
 	"github.com/DIMO-Network/trips-api/models"
+	"github.com/volatiletech/null/v8"
 	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
 )
 
 // Store connected to postgres db containing trip information and validates user
@@ -22,11 +25,217 @@ func New(settings *config.Settings) (*Store, error) {
 	}, nil
 }
 
-func (s Store) StoreVehicle(ctx context.Context, userDeviceID string, tokenID int) error {
+// Ping checks that the writer connection is usable.
+func (s Store) Ping(ctx context.Context) error {
+	return s.DB.DBS().Writer.PingContext(ctx)
+}
+
+// Vehicle is a vehicle token and the user device it is paired with.
+type Vehicle struct {
+	TokenID      int
+	UserDeviceID string
+	MintedAt     time.Time
+}
+
+// VehicleSyncResult counts how the vehicles passed to SyncVehicles compared with the stored
+// mappings.
+type VehicleSyncResult struct {
+	Added     int
+	Changed   int
+	Unchanged int
+}
+
+// StoreVehicle records that the user device is paired with the given token from mintedAt
+// onward. If the device was previously paired with a different token, that mapping is
+// retired as of mintedAt.
+func (s Store) StoreVehicle(ctx context.Context, userDeviceID string, tokenID int, mintedAt time.Time) error {
+	tx, err := s.DB.DBS().Writer.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback() //nolint
+
+	if err := storeVehicle(ctx, tx, userDeviceID, tokenID, mintedAt, false); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// SyncVehicles makes the stored mappings agree with the given vehicles, which are taken to be
+// authoritative. New tokens are valid from their mint time; tokens whose device changed, or
+// that had been retired, are remapped as of syncedAt.
+func (s Store) SyncVehicles(ctx context.Context, vehicles []Vehicle, syncedAt time.Time) (VehicleSyncResult, error) {
+	var res VehicleSyncResult
+
+	tokenIDs := make([]int, len(vehicles))
+	for i, v := range vehicles {
+		tokenIDs[i] = v.TokenID
+	}
+
+	tx, err := s.DB.DBS().Writer.BeginTx(ctx, nil)
+	if err != nil {
+		return res, err
+	}
+	defer tx.Rollback() //nolint
+
+	existing, err := models.Vehicles(models.VehicleWhere.TokenID.IN(tokenIDs)).All(ctx, tx)
+	if err != nil {
+		return res, err
+	}
+
+	byToken := make(map[int]*models.Vehicle, len(existing))
+	for _, v := range existing {
+		byToken[v.TokenID] = v
+	}
+
+	for _, v := range vehicles {
+		cur, ok := byToken[v.TokenID]
+		switch {
+		case !ok:
+			validFrom := v.MintedAt
+			if validFrom.IsZero() {
+				validFrom = syncedAt
+			}
+			if err := storeVehicle(ctx, tx, v.UserDeviceID, v.TokenID, validFrom, true); err != nil {
+				return res, err
+			}
+			res.Added++
+		case cur.UserDeviceID == v.UserDeviceID && !cur.DeletedAt.Valid:
+			res.Unchanged++
+		default:
+			if err := storeVehicle(ctx, tx, v.UserDeviceID, v.TokenID, syncedAt, true); err != nil {
+				return res, err
+			}
+			res.Changed++
+		}
+	}
+
+	return res, tx.Commit()
+}
+
+// storeVehicle pairs the user device with the token as of validFrom, retiring any other
+// active mapping for either of them. If overwrite is set, an existing row for the token is
+// repointed at the device and reactivated; otherwise it is left alone.
+func storeVehicle(ctx context.Context, exec boil.ContextExecutor, userDeviceID string, tokenID int, validFrom time.Time, overwrite bool) error {
+	if _, err := models.Vehicles(
+		models.VehicleWhere.UserDeviceID.EQ(userDeviceID),
+		models.VehicleWhere.TokenID.NEQ(tokenID),
+		models.VehicleWhere.DeletedAt.IsNull(),
+	).UpdateAll(ctx, exec, models.M{models.VehicleColumns.DeletedAt: validFrom}); err != nil {
+		return err
+	}
+
 	v := models.Vehicle{
 		UserDeviceID: userDeviceID,
 		TokenID:      tokenID,
 	}
 
-	return v.Upsert(ctx, s.DB.DBS().Writer, false, []string{models.VehicleColumns.TokenID}, boil.None(), boil.Infer())
+	updateColumns := boil.None()
+	if overwrite {
+		updateColumns = boil.Whitelist(models.VehicleColumns.UserDeviceID, models.VehicleColumns.DeletedAt)
+	}
+
+	if err := v.Upsert(ctx, exec, overwrite, []string{models.VehicleColumns.TokenID}, updateColumns, boil.Infer()); err != nil {
+		return err
+	}
+
+	// Close any open interval that pairs the device or the token with something else.
+	if _, err := models.VehicleMappings(
+		models.VehicleMappingWhere.ValidTo.IsNull(),
+		qm.Expr(
+			qm.Expr(
+				models.VehicleMappingWhere.UserDeviceID.EQ(userDeviceID),
+				models.VehicleMappingWhere.TokenID.NEQ(tokenID),
+			),
+			qm.Or2(qm.Expr(
+				models.VehicleMappingWhere.TokenID.EQ(tokenID),
+				models.VehicleMappingWhere.UserDeviceID.NEQ(userDeviceID),
+			)),
+		),
+	).UpdateAll(ctx, exec, models.M{models.VehicleMappingColumns.ValidTo: validFrom}); err != nil {
+		return err
+	}
+
+	open, err := models.VehicleMappings(
+		models.VehicleMappingWhere.UserDeviceID.EQ(userDeviceID),
+		models.VehicleMappingWhere.TokenID.EQ(tokenID),
+		models.VehicleMappingWhere.ValidTo.IsNull(),
+	).Exists(ctx, exec)
+	if err != nil {
+		return err
+	}
+
+	if open {
+		return nil
+	}
+
+	vm := models.VehicleMapping{
+		UserDeviceID: userDeviceID,
+		TokenID:      tokenID,
+		ValidFrom:    validFrom,
+	}
+	return vm.Insert(ctx, exec, boil.Infer())
+}
+
+// DeleteVehicle retires the token's mapping as of deletedAt. The row is kept so that the
+// vehicle's existing trips continue to resolve.
+func (s Store) DeleteVehicle(ctx context.Context, tokenID int, deletedAt time.Time) error {
+	tx, err := s.DB.DBS().Writer.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback() //nolint
+
+	if _, err := models.Vehicles(
+		models.VehicleWhere.TokenID.EQ(tokenID),
+		models.VehicleWhere.DeletedAt.IsNull(),
+	).UpdateAll(ctx, tx, models.M{models.VehicleColumns.DeletedAt: deletedAt}); err != nil {
+		return err
+	}
+
+	if _, err := models.VehicleMappings(
+		models.VehicleMappingWhere.TokenID.EQ(tokenID),
+		models.VehicleMappingWhere.ValidTo.IsNull(),
+	).UpdateAll(ctx, tx, models.M{models.VehicleMappingColumns.ValidTo: deletedAt}); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// VehicleTokenAt returns the token that the user device was paired with at the given time.
+// It returns sql.ErrNoRows if the device had no mapping then.
+func (s Store) VehicleTokenAt(ctx context.Context, userDeviceID string, at time.Time) (int, error) {
+	vm, err := models.VehicleMappings(
+		models.VehicleMappingWhere.UserDeviceID.EQ(userDeviceID),
+		models.VehicleMappingWhere.ValidFrom.LTE(at),
+		qm.Expr(
+			models.VehicleMappingWhere.ValidTo.IsNull(),
+			qm.Or2(models.VehicleMappingWhere.ValidTo.GT(null.TimeFrom(at))),
+		),
+		qm.OrderBy(models.VehicleMappingColumns.ValidFrom+" DESC"),
+	).One(ctx, s.DB.DBS().Reader)
+	if err != nil {
+		return 0, err
+	}
+	return vm.TokenID, nil
+}
+
+// UserDeviceAt returns the user device that the token was paired with at the given time. It
+// returns sql.ErrNoRows if the token had no mapping then.
+func (s Store) UserDeviceAt(ctx context.Context, tokenID int, at time.Time) (string, error) {
+	vm, err := models.VehicleMappings(
+		models.VehicleMappingWhere.TokenID.EQ(tokenID),
+		models.VehicleMappingWhere.ValidFrom.LTE(at),
+		qm.Expr(
+			models.VehicleMappingWhere.ValidTo.IsNull(),
+			qm.Or2(models.VehicleMappingWhere.ValidTo.GT(null.TimeFrom(at))),
+		),
+		qm.OrderBy(models.VehicleMappingColumns.ValidFrom+" DESC"),
+	).One(ctx, s.DB.DBS().Reader)
+	if err != nil {
+		return "", err
+	}
+	return vm.UserDeviceID, nil
 }
@@ -0,0 +1,43 @@
+package pg
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DIMO-Network/trips-api/internal/test"
+	"github.com/DIMO-Network/trips-api/models"
+	"github.com/segmentio/ksuid"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/types/pgeo"
+)
+
+const migrationsDirRelPath = "../../../migrations"
+
+// setupStore starts a migrated database with vehicles 1 and 2.
+func setupStore(ctx context.Context, t *testing.T) Store {
+	pdb := test.StartContainerDatabase(ctx, t, migrationsDirRelPath)
+	test.CreateVehicles(ctx, t, pdb, 1, 2)
+	return Store{DB: pdb}
+}
+
+// insertTrip records a trip of vehicle 1 over [start, end), positioned in Manhattan. A zero
+// end leaves the trip in progress.
+func insertTrip(ctx context.Context, t *testing.T, s Store, start, end time.Time) *models.Trip {
+	trp := &models.Trip{
+		ID:             ksuid.New().String(),
+		VehicleTokenID: 1,
+		StartTime:      start,
+		StartPosition:  pgeo.NewNullPoint(pgeo.NewPoint(-73.98043334522801, 40.744331740800455), true),
+	}
+	if !end.IsZero() {
+		trp.EndTime = null.TimeFrom(end)
+		trp.EndPosition = pgeo.NewNullPoint(pgeo.NewPoint(-73.99821832237583, 40.7544585026455), true)
+		trp.DistanceKM = null.Float64From(2.1)
+	}
+	if err := trp.Insert(ctx, s.DB.DBS().Writer, boil.Infer()); err != nil {
+		t.Fatal(err)
+	}
+	return trp
+}
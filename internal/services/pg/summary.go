@@ -0,0 +1,86 @@
+package pg
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/DIMO-Network/trips-api/models"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+)
+
+// Periods that trips can be summarized over. Weeks start on Monday.
+const (
+	PeriodDay   = "day"
+	PeriodWeek  = "week"
+	PeriodMonth = "month"
+)
+
+// SummaryBucket aggregates the completed trips that started within one period.
+type SummaryBucket struct {
+	Start            time.Time `boil:"bucket"`
+	TripCount        int       `boil:"trip_count"`
+	DurationSeconds  float64   `boil:"duration_seconds"`
+	DistanceKm       float64   `boil:"distance_km"`
+	DroppedDataCount int       `boil:"dropped_data_count"`
+	// Aggregated buckets include the monthly totals of trips deleted by retention. They span
+	// a UTC calendar month, whatever the period, and don't count dropped data.
+	Aggregated bool `boil:"-"`
+}
+
+// SummarizeTrips groups the vehicle's completed trips starting in [from, to) into calendar
+// periods in loc. Periods without trips are omitted. period must be one of PeriodDay,
+// PeriodWeek or PeriodMonth.
+//
+// Trips deleted by retention are only kept as monthly totals, which are added for the months
+// overlapping the range. Where a month bucket starts at the same instant, as it does in UTC,
+// the totals are added to it; otherwise each month gets an aggregated bucket of its own.
+func (s Store) SummarizeTrips(ctx context.Context, tokenID int, period string, loc *time.Location, from, to time.Time) ([]SummaryBucket, error) {
+	// date_trunc on a timestamp without time zone truncates to local calendar boundaries,
+	// and AT TIME ZONE then converts the local boundary back to an instant.
+	var buckets []SummaryBucket
+	err := queries.Raw(`
+		SELECT date_trunc($2, start_time AT TIME ZONE $3) AT TIME ZONE $3 AS bucket,
+			count(*) AS trip_count,
+			COALESCE(sum(extract(epoch FROM end_time - start_time)), 0)::double precision AS duration_seconds,
+			COALESCE(sum(distance_km), 0) AS distance_km,
+			count(*) FILTER (WHERE dropped_data) AS dropped_data_count
+		FROM `+models.TableNames.Trips+`
+		WHERE vehicle_token_id = $1 AND end_time IS NOT NULL AND start_time >= $4 AND start_time < $5
+		GROUP BY bucket
+		ORDER BY bucket`,
+		tokenID, period, loc.String(), from, to,
+	).Bind(ctx, s.DB.DBS().Reader, &buckets)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range buckets {
+		buckets[i].Start = buckets[i].Start.In(loc)
+	}
+
+	aggregates, err := models.TripAggregates(
+		models.TripAggregateWhere.VehicleTokenID.EQ(tokenID),
+		models.TripAggregateWhere.Month.GT(from.UTC().AddDate(0, -1, 0)),
+		models.TripAggregateWhere.Month.LT(to),
+	).All(ctx, s.DB.DBS().Reader)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, a := range aggregates {
+		month := time.Date(a.Month.Year(), a.Month.Month(), 1, 0, 0, 0, 0, time.UTC)
+		i := slices.IndexFunc(buckets, func(b SummaryBucket) bool { return period == PeriodMonth && b.Start.Equal(month) })
+		if i < 0 {
+			buckets = append(buckets, SummaryBucket{Start: month.In(loc)})
+			i = len(buckets) - 1
+		}
+		buckets[i].TripCount += a.TripCount
+		buckets[i].DurationSeconds += a.DurationSeconds
+		buckets[i].DistanceKm += a.DistanceKM
+		buckets[i].Aggregated = true
+	}
+	slices.SortStableFunc(buckets, func(a, b SummaryBucket) int { return a.Start.Compare(b.Start) })
+
+	return buckets, nil
+}
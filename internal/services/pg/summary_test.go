@@ -0,0 +1,35 @@
+package pg
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Completed trips are summarized by local day
+func Test_SummarizeTrips(t *testing.T) {
+	ctx := context.Background()
+	store := setupStore(ctx, t)
+
+	start := time.Date(2023, 8, 16, 12, 15, 2, 0, time.UTC)
+	end := time.Date(2023, 8, 18, 8, 15, 2, 0, time.UTC)
+	trp := insertTrip(ctx, t, store, start, end)
+	// Trips in progress aren't counted.
+	insertTrip(ctx, t, store, end.Add(time.Hour), time.Time{})
+
+	loc, err := time.LoadLocation("America/Los_Angeles")
+	assert.NoError(t, err)
+
+	buckets, err := store.SummarizeTrips(ctx, 1, PeriodDay, loc,
+		time.Date(2023, 8, 1, 0, 0, 0, 0, loc), time.Date(2023, 9, 1, 0, 0, 0, 0, loc))
+	assert.NoError(t, err)
+	if assert.Len(t, buckets, 1) {
+		assert.True(t, buckets[0].Start.Equal(time.Date(2023, 8, 16, 0, 0, 0, 0, loc)))
+		assert.Equal(t, 1, buckets[0].TripCount)
+		assert.Equal(t, end.Sub(start).Seconds(), buckets[0].DurationSeconds)
+		assert.Equal(t, trp.DistanceKM.Float64, buckets[0].DistanceKm)
+		assert.Equal(t, 0, buckets[0].DroppedDataCount)
+	}
+}
@@ -0,0 +1,241 @@
+package pg
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/DIMO-Network/trips-api/internal/config"
+	"github.com/DIMO-Network/trips-api/models"
+	"github.com/lib/pq"
+	"github.com/rs/zerolog"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+)
+
+// TripUpdatesChannel is notified with the vehicle token id whenever one of its trips begins
+// or completes.
+const TripUpdatesChannel = "trip_updates"
+
+// TripUpdateRetention is how long trip updates are kept for clients resuming a stream.
+const TripUpdateRetention = 24 * time.Hour
+
+const pruneInterval = time.Hour
+
+// TripUpdateCursor is a position in a vehicle's trip updates. Updates are ordered by the
+// transaction that recorded them and then by id, because ids are taken when an update is
+// inserted rather than when it commits.
+type TripUpdateCursor struct {
+	TxID int64
+	ID   int64
+}
+
+// TripUpdatesAfter returns up to limit of the vehicle's trip updates after the cursor, oldest
+// first, with their trips loaded. Only updates from transactions older than every transaction
+// still in flight are returned, so that no update can later commit before one already
+// returned. pending reports whether newer updates were held back for that reason; they
+// should be read again shortly, as their notification may already have been sent.
+func (s Store) TripUpdatesAfter(ctx context.Context, tokenID int, after TripUpdateCursor, limit int) (updates models.TripUpdateSlice, pending bool, err error) {
+	db := s.DB.DBS().Reader
+
+	// Taken before reading the updates, so every transaction below it has finished and its
+	// updates are visible to the read.
+	horizon, err := oldestRunningTxID(ctx, db)
+	if err != nil {
+		return nil, false, err
+	}
+
+	updates, err = models.TripUpdates(
+		models.TripUpdateWhere.VehicleTokenID.EQ(tokenID),
+		qm.Where("("+models.TripUpdateColumns.Txid+", "+models.TripUpdateColumns.ID+") > (?, ?)", after.TxID, after.ID),
+		qm.Load(models.TripUpdateRels.Trip),
+		qm.OrderBy(models.TripUpdateColumns.Txid+", "+models.TripUpdateColumns.ID),
+		qm.Limit(limit),
+	).All(ctx, db)
+	if err != nil {
+		return nil, false, err
+	}
+
+	for i, u := range updates {
+		if u.Txid >= horizon {
+			return updates[:i], true, nil
+		}
+	}
+	return updates, false, nil
+}
+
+// LatestTripUpdateCursor returns a cursor after every trip update already committed. Updates
+// from transactions still in flight come after it.
+func (s Store) LatestTripUpdateCursor(ctx context.Context) (TripUpdateCursor, error) {
+	horizon, err := oldestRunningTxID(ctx, s.DB.DBS().Reader)
+	if err != nil {
+		return TripUpdateCursor{}, err
+	}
+	return TripUpdateCursor{TxID: horizon}, nil
+}
+
+// TripUpdateCursorAt returns the cursor at the trip update with the given id. If the update
+// no longer exists, the cursor is before every update still kept.
+func (s Store) TripUpdateCursorAt(ctx context.Context, id int64) (TripUpdateCursor, error) {
+	update, err := models.TripUpdates(
+		qm.Select(models.TripUpdateColumns.ID, models.TripUpdateColumns.Txid),
+		models.TripUpdateWhere.ID.EQ(id),
+	).One(ctx, s.DB.DBS().Reader)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return TripUpdateCursor{}, nil
+		}
+		return TripUpdateCursor{}, err
+	}
+	return TripUpdateCursor{TxID: update.Txid, ID: update.ID}, nil
+}
+
+// oldestRunningTxID returns the id of the oldest transaction still in flight, or of the next
+// transaction if there are none.
+func oldestRunningTxID(ctx context.Context, db boil.ContextExecutor) (int64, error) {
+	var horizon int64
+	err := db.QueryRowContext(ctx, "SELECT pg_snapshot_xmin(pg_current_snapshot())::text::bigint").Scan(&horizon)
+	return horizon, err
+}
+
+// PruneTripUpdates deletes trip updates recorded before the given time.
+func (s Store) PruneTripUpdates(ctx context.Context, before time.Time) (int64, error) {
+	return models.TripUpdates(
+		models.TripUpdateWhere.CreatedAt.LT(before),
+	).DeleteAll(ctx, s.DB.DBS().Writer)
+}
+
+// TripUpdateListener listens for trip update notifications and wakes the streams subscribed
+// to the notified vehicle. Subscribers are only woken, never told what changed, so they
+// should read the updates they haven't yet seen from the store.
+type TripUpdateListener struct {
+	store    Store
+	listener *pq.Listener
+	logger   *zerolog.Logger
+
+	mu   sync.Mutex
+	subs map[int]map[chan struct{}]struct{}
+}
+
+func NewTripUpdateListener(settings *config.Settings, store Store, logger *zerolog.Logger) (*TripUpdateListener, error) {
+	listener := pq.NewListener(settings.DB.BuildConnectionString(true), time.Second, time.Minute, func(ev pq.ListenerEventType, err error) {
+		if err != nil {
+			logger.Err(err).Msg("Trip update listener connection problem.")
+		}
+	})
+	if err := listener.Listen(TripUpdatesChannel); err != nil {
+		_ = listener.Close()
+		return nil, err
+	}
+
+	return &TripUpdateListener{
+		store:    store,
+		listener: listener,
+		logger:   logger,
+		subs:     make(map[int]map[chan struct{}]struct{}),
+	}, nil
+}
+
+// Subscribe returns a channel that receives a value whenever the vehicle's trips may have
+// changed. It is closed when the listener stops. Call the returned function to unsubscribe.
+func (l *TripUpdateListener) Subscribe(tokenID int) (<-chan struct{}, func()) {
+	ch := make(chan struct{}, 1)
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.subs == nil {
+		close(ch)
+		return ch, func() {}
+	}
+	if l.subs[tokenID] == nil {
+		l.subs[tokenID] = make(map[chan struct{}]struct{})
+	}
+	l.subs[tokenID][ch] = struct{}{}
+
+	return ch, func() {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		if _, ok := l.subs[tokenID][ch]; ok {
+			delete(l.subs[tokenID], ch)
+			if len(l.subs[tokenID]) == 0 {
+				delete(l.subs, tokenID)
+			}
+			close(ch)
+		}
+	}
+}
+
+// Run dispatches notifications until ctx is cancelled, then closes every subscription. It
+// also prunes trip updates older than TripUpdateRetention.
+func (l *TripUpdateListener) Run(ctx context.Context) {
+	defer l.close()
+
+	prune := time.NewTicker(pruneInterval)
+	defer prune.Stop()
+
+	for {
+		select {
+		case n := <-l.listener.NotificationChannel():
+			if n == nil {
+				// The connection was re-established and notifications may have been missed.
+				l.wakeAll()
+				continue
+			}
+			tokenID, err := strconv.Atoi(n.Extra)
+			if err != nil {
+				l.logger.Warn().Str("payload", n.Extra).Msg("Unexpected trip update notification.")
+				continue
+			}
+			l.wake(tokenID)
+		case <-prune.C:
+			if _, err := l.store.PruneTripUpdates(ctx, time.Now().Add(-TripUpdateRetention)); err != nil {
+				l.logger.Err(err).Msg("Failed to prune trip updates.")
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (l *TripUpdateListener) wake(tokenID int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for ch := range l.subs[tokenID] {
+		notify(ch)
+	}
+}
+
+func (l *TripUpdateListener) wakeAll() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for _, subs := range l.subs {
+		for ch := range subs {
+			notify(ch)
+		}
+	}
+}
+
+func (l *TripUpdateListener) close() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for _, subs := range l.subs {
+		for ch := range subs {
+			close(ch)
+		}
+	}
+	l.subs = nil
+	if err := l.listener.Close(); err != nil {
+		l.logger.Err(err).Msg("Error closing trip update listener.")
+	}
+}
+
+// notify wakes a subscriber without blocking. A wake-up already pending covers this one.
+func notify(ch chan struct{}) {
+	select {
+	case ch <- struct{}{}:
+	default:
+	}
+}
@@ -0,0 +1,96 @@
+package pg
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DIMO-Network/trips-api/models"
+	"github.com/segmentio/ksuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+)
+
+func TestTripUpdateSubscriptions(t *testing.T) {
+	l := &TripUpdateListener{subs: make(map[int]map[chan struct{}]struct{})}
+
+	a, unsubscribeA := l.Subscribe(1)
+	b, unsubscribeB := l.Subscribe(2)
+
+	// Repeated wake-ups coalesce.
+	l.wake(1)
+	l.wake(1)
+	assert.Len(t, a, 1)
+	assert.Empty(t, b)
+	<-a
+
+	l.wakeAll()
+	assert.Len(t, a, 1)
+	assert.Len(t, b, 1)
+
+	unsubscribeA()
+	unsubscribeA()
+	_, ok := <-a
+	assert.True(t, ok, "pending wake-up is still delivered")
+	_, ok = <-a
+	assert.False(t, ok)
+	assert.NotContains(t, l.subs, 1)
+
+	unsubscribeB()
+	assert.Empty(t, l.subs)
+}
+
+func Test_TripUpdatesAfter(t *testing.T) {
+	ctx := context.Background()
+	store := setupStore(ctx, t)
+
+	start := time.Date(2024, 3, 1, 8, 0, 0, 0, time.UTC)
+	first := insertTrip(ctx, t, store, start, start.Add(time.Hour))
+	other := &models.Trip{ID: ksuid.New().String(), VehicleTokenID: 2, StartTime: start}
+	assert.NoError(t, other.Insert(ctx, store.DB.DBS().Writer, boil.Infer()))
+
+	updates, pending, err := store.TripUpdatesAfter(ctx, 1, TripUpdateCursor{}, 10)
+	assert.NoError(t, err)
+	assert.False(t, pending)
+	if !assert.Len(t, updates, 2) {
+		return
+	}
+	assert.Equal(t, "begin", updates[0].Kind)
+	assert.Equal(t, "complete", updates[1].Kind)
+	assert.Equal(t, first.ID, updates[1].R.Trip.ID)
+	cursor := TripUpdateCursor{TxID: updates[1].Txid, ID: updates[1].ID}
+
+	// An update committing after a later one is held back until it commits, then sent first.
+	tx, err := store.DB.DBS().Writer.BeginTx(ctx, nil)
+	assert.NoError(t, err)
+	_, err = tx.ExecContext(ctx, "SELECT pg_current_xact_id()")
+	assert.NoError(t, err)
+	slow := &models.Trip{ID: ksuid.New().String(), VehicleTokenID: 1, StartTime: start.Add(2 * time.Hour)}
+	fast := &models.Trip{ID: ksuid.New().String(), VehicleTokenID: 1, StartTime: start.Add(3 * time.Hour)}
+	assert.NoError(t, fast.Insert(ctx, store.DB.DBS().Writer, boil.Infer()))
+	assert.NoError(t, slow.Insert(ctx, tx, boil.Infer()))
+
+	updates, pending, err = store.TripUpdatesAfter(ctx, 1, cursor, 10)
+	assert.NoError(t, err)
+	assert.True(t, pending)
+	assert.Empty(t, updates)
+
+	assert.NoError(t, tx.Commit())
+	updates, pending, err = store.TripUpdatesAfter(ctx, 1, cursor, 10)
+	assert.NoError(t, err)
+	assert.False(t, pending)
+	if assert.Len(t, updates, 2) {
+		assert.Equal(t, slow.ID, updates[0].TripID)
+		assert.Equal(t, fast.ID, updates[1].TripID)
+	}
+
+	latest, err := store.LatestTripUpdateCursor(ctx)
+	assert.NoError(t, err)
+	updates, _, err = store.TripUpdatesAfter(ctx, 1, latest, 10)
+	assert.NoError(t, err)
+	assert.Empty(t, updates)
+
+	resumed, err := store.TripUpdateCursorAt(ctx, cursor.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, cursor, resumed)
+}
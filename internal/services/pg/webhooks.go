@@ -0,0 +1,150 @@
+package pg
+
+import (
+	"context"
+	"time"
+
+	"github.com/DIMO-Network/trips-api/models"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+)
+
+// ActiveWebhooks returns the vehicle's enabled webhooks that subscribe to eventType.
+func (s Store) ActiveWebhooks(ctx context.Context, tokenID int, eventType string) (models.WebhookSlice, error) {
+	return models.Webhooks(
+		models.WebhookWhere.VehicleTokenID.EQ(tokenID),
+		models.WebhookWhere.DisabledAt.IsNull(),
+		qm.Where("? = ANY("+models.WebhookColumns.EventTypes+")", eventType),
+	).All(ctx, s.DB.DBS().Reader)
+}
+
+// QueueWebhookDeliveries records a pending delivery of the event body to each of the webhooks,
+// due straight away.
+func (s Store) QueueWebhookDeliveries(ctx context.Context, webhookIDs []string, eventID string, body []byte) error {
+	tx, err := s.DB.DBS().Writer.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback() //nolint
+
+	for _, id := range webhookIDs {
+		dl := models.WebhookDelivery{WebhookID: id, EventID: eventID, Body: body}
+		if err := dl.Insert(ctx, tx, boil.Infer()); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// ClaimWebhookDeliveries claims up to limit deliveries that are due, to enabled webhooks,
+// oldest first, and returns them with their webhooks loaded. Each claimed delivery counts an
+// attempt and isn't due again until lease has passed, so that another replica retries it if
+// this one stops before finishing the attempt. Deliveries claimed elsewhere are skipped.
+func (s Store) ClaimWebhookDeliveries(ctx context.Context, limit int, lease time.Duration) (models.WebhookDeliverySlice, error) {
+	var deliveries []*models.WebhookDelivery
+	err := queries.Raw(`
+		UPDATE `+models.TableNames.WebhookDeliveries+`
+		SET next_attempt_at = now() + make_interval(secs => $2), attempts = attempts + 1
+		WHERE id IN (
+			SELECT d.id FROM `+models.TableNames.WebhookDeliveries+` d
+			JOIN `+models.TableNames.Webhooks+` w ON w.id = d.webhook_id
+			WHERE d.next_attempt_at <= now() AND w.disabled_at IS NULL
+			ORDER BY d.next_attempt_at
+			LIMIT $1
+			FOR UPDATE OF d SKIP LOCKED
+		)
+		RETURNING *`,
+		limit, lease.Seconds(),
+	).Bind(ctx, s.DB.DBS().Writer, &deliveries)
+	if err != nil {
+		return nil, err
+	}
+	if len(deliveries) == 0 {
+		return nil, nil
+	}
+
+	if err := (&models.WebhookDelivery{}).L.LoadWebhook(ctx, s.DB.DBS().Writer, false, &deliveries, nil); err != nil {
+		return nil, err
+	}
+	return deliveries, nil
+}
+
+// RetryWebhookDelivery makes the delivery due again at the given time.
+func (s Store) RetryWebhookDelivery(ctx context.Context, id int64, at time.Time) error {
+	_, err := models.WebhookDeliveries(
+		models.WebhookDeliveryWhere.ID.EQ(id),
+	).UpdateAll(ctx, s.DB.DBS().Writer, models.M{models.WebhookDeliveryColumns.NextAttemptAt: at})
+	return err
+}
+
+// FinishWebhookDelivery removes the delivery and records its outcome against its webhook. A
+// successful delivery clears the webhook's failure count. A failed one increments it,
+// disabling the webhook as of at once the count reaches disableAfter.
+func (s Store) FinishWebhookDelivery(ctx context.Context, dl *models.WebhookDelivery, delivered bool, disableAfter int, at time.Time) error {
+	tx, err := s.DB.DBS().Writer.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback() //nolint
+
+	if _, err := dl.Delete(ctx, tx); err != nil {
+		return err
+	}
+
+	webhook, err := models.Webhooks(
+		models.WebhookWhere.ID.EQ(dl.WebhookID),
+		qm.For("UPDATE"),
+	).One(ctx, tx)
+	if err != nil {
+		return err
+	}
+
+	if delivered {
+		webhook.FailureCount = 0
+	} else {
+		webhook.FailureCount++
+		if webhook.FailureCount >= disableAfter && !webhook.DisabledAt.Valid {
+			if err := disableWebhook(ctx, tx, webhook.ID, at); err != nil {
+				return err
+			}
+			webhook.DisabledAt = null.TimeFrom(at)
+		}
+	}
+
+	if _, err := webhook.Update(ctx, tx, boil.Whitelist(models.WebhookColumns.FailureCount, models.WebhookColumns.DisabledAt)); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// DisableWebhook disables the webhook as of at, unless it already is, and drops its pending
+// deliveries.
+func (s Store) DisableWebhook(ctx context.Context, id string, at time.Time) error {
+	tx, err := s.DB.DBS().Writer.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback() //nolint
+
+	if err := disableWebhook(ctx, tx, id, at); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func disableWebhook(ctx context.Context, exec boil.ContextExecutor, id string, at time.Time) error {
+	if _, err := models.Webhooks(
+		models.WebhookWhere.ID.EQ(id),
+		models.WebhookWhere.DisabledAt.IsNull(),
+	).UpdateAll(ctx, exec, models.M{models.WebhookColumns.DisabledAt: at}); err != nil {
+		return err
+	}
+	_, err := models.WebhookDeliveries(
+		models.WebhookDeliveryWhere.WebhookID.EQ(id),
+	).DeleteAll(ctx, exec)
+	return err
+}
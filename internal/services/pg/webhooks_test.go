@@ -0,0 +1,87 @@
+package pg
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DIMO-Network/trips-api/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+)
+
+func Test_WebhookDeliveries(t *testing.T) {
+	ctx := context.Background()
+	store := setupStore(ctx, t)
+
+	for _, id := range []string{"a", "b"} {
+		w := models.Webhook{ID: id, VehicleTokenID: 1, URL: "https://example.com", EventTypes: []string{"trip.completed"}, Secret: "s3cret", ClientID: null.StringFrom("0x01")}
+		require.NoError(t, w.Insert(ctx, store.DB.DBS().Writer, boil.Infer()))
+	}
+
+	require.NoError(t, store.QueueWebhookDeliveries(ctx, []string{"a", "b"}, "event1", []byte(`{}`)))
+	require.NoError(t, store.QueueWebhookDeliveries(ctx, []string{"a"}, "event2", []byte(`{}`)))
+
+	// Claimed deliveries aren't handed out again until their lease runs out.
+	first, err := store.ClaimWebhookDeliveries(ctx, 2, time.Minute)
+	require.NoError(t, err)
+	require.Len(t, first, 2)
+	assert.Equal(t, 1, first[0].Attempts)
+	assert.NotNil(t, first[0].R.Webhook)
+
+	second, err := store.ClaimWebhookDeliveries(ctx, 10, time.Minute)
+	require.NoError(t, err)
+	require.Len(t, second, 1)
+
+	none, err := store.ClaimWebhookDeliveries(ctx, 10, time.Minute)
+	require.NoError(t, err)
+	assert.Empty(t, none)
+
+	byWebhook := make(map[string]*models.WebhookDelivery)
+	for _, dl := range first {
+		byWebhook[dl.WebhookID] = dl
+	}
+	require.Len(t, byWebhook, 2)
+
+	// A rescheduled delivery is due again, with its attempts counted.
+	require.NoError(t, store.RetryWebhookDelivery(ctx, byWebhook["a"].ID, time.Now().Add(-time.Second)))
+	retried, err := store.ClaimWebhookDeliveries(ctx, 10, time.Minute)
+	require.NoError(t, err)
+	require.Len(t, retried, 1)
+	assert.Equal(t, byWebhook["a"].ID, retried[0].ID)
+	assert.Equal(t, 2, retried[0].Attempts)
+
+	// Delivering removes the delivery and clears the failure count.
+	_, err = models.Webhooks(models.WebhookWhere.ID.EQ("a")).UpdateAll(ctx, store.DB.DBS().Writer, models.M{models.WebhookColumns.FailureCount: 3})
+	require.NoError(t, err)
+	require.NoError(t, store.FinishWebhookDelivery(ctx, retried[0], true, 2, time.Now()))
+	w, err := models.FindWebhook(ctx, store.DB.DBS().Reader, "a")
+	require.NoError(t, err)
+	assert.Zero(t, w.FailureCount)
+	pending, err := models.WebhookDeliveries(models.WebhookDeliveryWhere.WebhookID.EQ("a")).Count(ctx, store.DB.DBS().Reader)
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, pending)
+
+	// Running out of failures disables the webhook and drops its pending deliveries.
+	require.NoError(t, store.QueueWebhookDeliveries(ctx, []string{"b"}, "event3", []byte(`{}`)))
+	_, err = models.Webhooks(models.WebhookWhere.ID.EQ("b")).UpdateAll(ctx, store.DB.DBS().Writer, models.M{models.WebhookColumns.FailureCount: 1})
+	require.NoError(t, err)
+	require.NoError(t, store.FinishWebhookDelivery(ctx, byWebhook["b"], false, 2, time.Now()))
+
+	w, err = models.FindWebhook(ctx, store.DB.DBS().Reader, "b")
+	require.NoError(t, err)
+	assert.Equal(t, 2, w.FailureCount)
+	assert.True(t, w.DisabledAt.Valid)
+
+	pending, err = models.WebhookDeliveries(models.WebhookDeliveryWhere.WebhookID.EQ("b")).Count(ctx, store.DB.DBS().Reader)
+	require.NoError(t, err)
+	assert.Zero(t, pending)
+
+	// Disabling a webhook drops its pending deliveries too.
+	require.NoError(t, store.DisableWebhook(ctx, "a", time.Now()))
+	pending, err = models.WebhookDeliveries().Count(ctx, store.DB.DBS().Reader)
+	require.NoError(t, err)
+	assert.Zero(t, pending)
+}
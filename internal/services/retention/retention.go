@@ -0,0 +1,219 @@
+// Package retention enforces how long trip data is kept.
+package retention
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/rs/zerolog"
+)
+
+const (
+	defaultInterval  = time.Hour
+	defaultBatchSize = 1000
+)
+
+// Actions that retention takes, used in reports and as metric labels.
+const (
+	ActionClearPositions = "clear_positions"
+	ActionDeleteTrips    = "delete_trips"
+)
+
+// Config sets the retention rules. Zero ages keep data indefinitely.
+type Config struct {
+	Interval time.Duration
+	// PositionAge is how long trips keep their positions and the key to their archived data.
+	PositionAge time.Duration
+	// TripAge is how long trips are kept at all.
+	TripAge time.Duration
+	// Aggregate keeps monthly totals of the trips deleted for age.
+	Aggregate bool
+	// DryRun only counts what the rules would change.
+	DryRun    bool
+	BatchSize int
+}
+
+type store interface {
+	CountPositionedTrips(ctx context.Context, since, before time.Time) (int64, error)
+	ClearTripPositions(ctx context.Context, before time.Time, limit int) (int64, error)
+	CountTripsBefore(ctx context.Context, before time.Time) (int64, error)
+	DeleteTripsBefore(ctx context.Context, before time.Time, limit int, aggregate bool) (int64, error)
+}
+
+// Report is what a run changed, or in a dry run would have changed, by action.
+type Report struct {
+	DryRun  bool
+	Changes map[string]int64
+}
+
+// Enforcer applies the retention rules to the trips table. Trip updates and annotation
+// history are deleted along with their trips.
+type Enforcer struct {
+	store  store
+	config Config
+	logger *zerolog.Logger
+}
+
+func New(store store, config Config, logger *zerolog.Logger) *Enforcer {
+	if config.Interval <= 0 {
+		config.Interval = defaultInterval
+	}
+	if config.BatchSize <= 0 {
+		config.BatchSize = defaultBatchSize
+	}
+	return &Enforcer{store, config, logger}
+}
+
+// Run enforces the rules every interval until ctx is cancelled.
+func (e *Enforcer) Run(ctx context.Context) {
+	ticker := time.NewTicker(e.config.Interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := e.Enforce(ctx, time.Now()); err != nil && ctx.Err() != nil {
+			return
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Enforce applies the rules as of now, logs the report and records metrics. After an error,
+// the report has what was changed before it.
+func (e *Enforcer) Enforce(ctx context.Context, now time.Time) (Report, error) {
+	start := time.Now()
+	report, err := e.enforce(ctx, now)
+
+	for action, n := range report.Changes {
+		RetentionRowsTotal.WithLabelValues(action, dryRunLabel(e.config.DryRun)).Add(float64(n))
+	}
+	RetentionRunDuration.Observe(time.Since(start).Seconds())
+
+	log := e.logger.Info()
+	if err != nil {
+		RetentionRunsTotal.WithLabelValues("error").Inc()
+		log = e.logger.Error().Err(err)
+	} else {
+		RetentionRunsTotal.WithLabelValues("success").Inc()
+		RetentionLastSuccess.SetToCurrentTime()
+	}
+	dict := zerolog.Dict()
+	for action, n := range report.Changes {
+		dict = dict.Int64(action, n)
+	}
+	log.Bool("dryRun", report.DryRun).Dict("changes", dict).Msg("Enforced retention.")
+
+	return report, err
+}
+
+func (e *Enforcer) enforce(ctx context.Context, now time.Time) (Report, error) {
+	report := Report{DryRun: e.config.DryRun, Changes: make(map[string]int64)}
+
+	// Trips are deleted first, so that positions aren't cleared from trips about to go.
+	var tripsBefore time.Time
+	if e.config.TripAge > 0 {
+		tripsBefore = now.Add(-e.config.TripAge)
+		n, err := e.apply(ctx, func() (int64, error) {
+			return e.store.CountTripsBefore(ctx, tripsBefore)
+		}, func() (int64, error) {
+			return e.store.DeleteTripsBefore(ctx, tripsBefore, e.config.BatchSize, e.config.Aggregate)
+		})
+		report.Changes[ActionDeleteTrips] = n
+		if err != nil {
+			return report, fmt.Errorf("failed to delete trips: %w", err)
+		}
+	}
+
+	if e.config.PositionAge > 0 {
+		before := now.Add(-e.config.PositionAge)
+		n, err := e.apply(ctx, func() (int64, error) {
+			return e.store.CountPositionedTrips(ctx, tripsBefore, before)
+		}, func() (int64, error) {
+			return e.store.ClearTripPositions(ctx, before, e.config.BatchSize)
+		})
+		report.Changes[ActionClearPositions] = n
+		if err != nil {
+			return report, fmt.Errorf("failed to clear positions: %w", err)
+		}
+	}
+
+	return report, nil
+}
+
+// apply counts the rows a rule applies to in a dry run, and otherwise runs it in batches until
+// a batch comes up short. It returns the number of rows.
+func (e *Enforcer) apply(ctx context.Context, count, batch func() (int64, error)) (int64, error) {
+	if e.config.DryRun {
+		return count()
+	}
+
+	var total int64
+	for {
+		n, err := batch()
+		total += n
+		if err != nil {
+			return total, err
+		}
+		if n < int64(e.config.BatchSize) {
+			return total, nil
+		}
+		if err := ctx.Err(); err != nil {
+			return total, err
+		}
+	}
+}
+
+func dryRunLabel(dryRun bool) string {
+	if dryRun {
+		return "true"
+	}
+	return "false"
+}
+
+var (
+	RetentionRowsTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "trips_api",
+			Subsystem: "retention",
+			Name:      "rows_total",
+			Help:      "The total number of trips changed by retention, or that would have been in dry runs, by action.",
+		},
+		[]string{"action", "dry_run"},
+	)
+
+	RetentionRunsTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "trips_api",
+			Subsystem: "retention",
+			Name:      "runs_total",
+			Help:      "The total number of retention runs, by outcome.",
+		},
+		[]string{"outcome"},
+	)
+
+	RetentionRunDuration = promauto.NewHistogram(
+		prometheus.HistogramOpts{
+			Namespace: "trips_api",
+			Subsystem: "retention",
+			Name:      "run_duration_seconds",
+			Help:      "The distribution of retention run durations in seconds.",
+			Buckets:   []float64{0.1, 0.5, 1, 5, 10, 30, 60, 300, 900},
+		},
+	)
+
+	RetentionLastSuccess = promauto.NewGauge(
+		prometheus.GaugeOpts{
+			Namespace: "trips_api",
+			Subsystem: "retention",
+			Name:      "last_success_timestamp_seconds",
+			Help:      "The time of the last successful retention run.",
+		},
+	)
+)
@@ -0,0 +1,140 @@
+package retention
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeStore holds trip start times, and which of them still have positions.
+type fakeStore struct {
+	trips      []time.Time
+	positioned []bool
+	aggregated int64
+	err        error
+}
+
+func (f *fakeStore) CountPositionedTrips(_ context.Context, since, before time.Time) (int64, error) {
+	var n int64
+	for i, t := range f.trips {
+		if !t.Before(since) && t.Before(before) && f.positioned[i] {
+			n++
+		}
+	}
+	return n, nil
+}
+
+func (f *fakeStore) ClearTripPositions(_ context.Context, before time.Time, limit int) (int64, error) {
+	var n int64
+	for i, t := range f.trips {
+		if t.Before(before) && f.positioned[i] && n < int64(limit) {
+			f.positioned[i] = false
+			n++
+		}
+	}
+	return n, nil
+}
+
+func (f *fakeStore) CountTripsBefore(_ context.Context, before time.Time) (int64, error) {
+	var n int64
+	for _, t := range f.trips {
+		if t.Before(before) {
+			n++
+		}
+	}
+	return n, nil
+}
+
+func (f *fakeStore) DeleteTripsBefore(_ context.Context, before time.Time, limit int, aggregate bool) (int64, error) {
+	if f.err != nil {
+		return 0, f.err
+	}
+	var n int64
+	for i := 0; i < len(f.trips); {
+		if f.trips[i].Before(before) && n < int64(limit) {
+			f.trips = append(f.trips[:i], f.trips[i+1:]...)
+			f.positioned = append(f.positioned[:i], f.positioned[i+1:]...)
+			n++
+			continue
+		}
+		i++
+	}
+	if aggregate {
+		f.aggregated += n
+	}
+	return n, nil
+}
+
+var now = time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+
+func newStore() *fakeStore {
+	f := &fakeStore{}
+	// Trips 1, 10, 100 and 1000 days old.
+	for _, days := range []int{1, 10, 100, 1000} {
+		f.trips = append(f.trips, now.AddDate(0, 0, -days))
+		f.positioned = append(f.positioned, true)
+	}
+	return f
+}
+
+func TestEnforce(t *testing.T) {
+	store := newStore()
+	e := New(store, Config{
+		PositionAge: 30 * 24 * time.Hour,
+		TripAge:     365 * 24 * time.Hour,
+		Aggregate:   true,
+		BatchSize:   1,
+	}, &zerolog.Logger{})
+
+	report, err := e.Enforce(context.Background(), now)
+	require.NoError(t, err)
+	assert.False(t, report.DryRun)
+	assert.Equal(t, map[string]int64{ActionDeleteTrips: 1, ActionClearPositions: 1}, report.Changes)
+	assert.Len(t, store.trips, 3)
+	assert.Equal(t, []bool{true, true, false}, store.positioned)
+	assert.EqualValues(t, 1, store.aggregated)
+
+	// Nothing is left to do.
+	report, err = e.Enforce(context.Background(), now)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int64{ActionDeleteTrips: 0, ActionClearPositions: 0}, report.Changes)
+}
+
+func TestEnforceDryRun(t *testing.T) {
+	store := newStore()
+	e := New(store, Config{
+		PositionAge: 5 * 24 * time.Hour,
+		TripAge:     50 * 24 * time.Hour,
+		DryRun:      true,
+	}, &zerolog.Logger{})
+
+	report, err := e.Enforce(context.Background(), now)
+	require.NoError(t, err)
+	assert.True(t, report.DryRun)
+	// Trips that would be deleted aren't counted again for their positions.
+	assert.Equal(t, map[string]int64{ActionDeleteTrips: 2, ActionClearPositions: 1}, report.Changes)
+	assert.Len(t, store.trips, 4)
+	assert.Equal(t, []bool{true, true, true, true}, store.positioned)
+}
+
+func TestEnforceKeepsByDefault(t *testing.T) {
+	store := newStore()
+	report, err := New(store, Config{}, &zerolog.Logger{}).Enforce(context.Background(), now)
+	require.NoError(t, err)
+	assert.Empty(t, report.Changes)
+	assert.Len(t, store.trips, 4)
+}
+
+func TestEnforceError(t *testing.T) {
+	store := newStore()
+	store.err = errors.New("connection reset")
+	report, err := New(store, Config{TripAge: time.Hour, PositionAge: time.Hour}, &zerolog.Logger{}).Enforce(context.Background(), now)
+	assert.ErrorIs(t, err, store.err)
+	// Later rules don't run after a failure.
+	assert.Equal(t, map[string]int64{ActionDeleteTrips: 0}, report.Changes)
+}
@@ -0,0 +1,202 @@
+package segmenter
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/DIMO-Network/trips-api/internal/geo"
+	"github.com/DIMO-Network/trips-api/internal/services/consumer"
+	"github.com/goccy/go-json"
+	"github.com/segmentio/ksuid"
+	"github.com/volatiletech/sqlboiler/v4/types/pgeo"
+)
+
+// Status is the part of a device status that trips are detected from.
+type Status struct {
+	Time     time.Time
+	Location *consumer.Location
+	// Speed is in km/h.
+	Speed    *float64
+	Ignition *bool
+}
+
+type rawStatus struct {
+	Data struct {
+		Timestamp time.Time `json:"timestamp"`
+		Latitude  *float64  `json:"latitude"`
+		Longitude *float64  `json:"longitude"`
+		Speed     *float64  `json:"speed"`
+		Ignition  *flag     `json:"ignition"`
+	} `json:"data"`
+}
+
+// flag accepts the 0 and 1 that some devices report as well as booleans.
+type flag bool
+
+func (f *flag) UnmarshalJSON(b []byte) error {
+	switch string(b) {
+	case "true", "1":
+		*f = true
+	case "false", "0":
+		*f = false
+	default:
+		return fmt.Errorf("invalid flag %s", b)
+	}
+	return nil
+}
+
+// ParseStatuses reads the statuses returned by the Elasticsearch client's FetchData, in time
+// order. Statuses without a timestamp are dropped.
+func ParseStatuses(data []byte) ([]Status, error) {
+	var raw []rawStatus
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+
+	statuses := make([]Status, 0, len(raw))
+	for _, r := range raw {
+		if r.Data.Timestamp.IsZero() {
+			continue
+		}
+		s := Status{Time: r.Data.Timestamp, Speed: r.Data.Speed}
+		if r.Data.Latitude != nil && r.Data.Longitude != nil {
+			s.Location = &consumer.Location{Latitude: *r.Data.Latitude, Longitude: *r.Data.Longitude}
+		}
+		if r.Data.Ignition != nil {
+			on := bool(*r.Data.Ignition)
+			s.Ignition = &on
+		}
+		statuses = append(statuses, s)
+	}
+
+	slices.SortStableFunc(statuses, func(a, b Status) int { return a.Time.Compare(b.Time) })
+	return statuses, nil
+}
+
+// Thresholds decide when trips start and end.
+type Thresholds struct {
+	// Idle is how long the vehicle may be stationary, or have its ignition off, before its
+	// trip ends.
+	Idle time.Duration
+	// Gap is how long the device may go without reporting before its trip ends.
+	Gap time.Duration
+	// MinSpeed is the speed in km/h at which the vehicle counts as moving.
+	MinSpeed float64
+}
+
+// State is where detection left off for a device. It is saved between polls.
+type State struct {
+	// Until is the time up to which statuses have been detected from.
+	Until time.Time `json:"until"`
+	// Position is the last reported location, from which speed is derived when it isn't
+	// reported.
+	Position *consumer.Endpoint `json:"position,omitempty"`
+	Trip     *Trip              `json:"trip,omitempty"`
+}
+
+// Trip is the trip in progress.
+type Trip struct {
+	ID string `json:"id"`
+	// Begun is set once a second moving status has confirmed the trip and it has been begun.
+	// Trips that end before then are dropped.
+	Begun bool              `json:"begun"`
+	Start consumer.Endpoint `json:"start"`
+	// Last is when and where the vehicle was last moving.
+	Last consumer.Endpoint `json:"last"`
+	// Seen is the time of the last status of any kind.
+	Seen time.Time `json:"seen"`
+}
+
+// Step advances the state past the status, returning the segment events that it gives rise
+// to. The events carry no device id.
+func (t Thresholds) Step(st State, s Status) (State, []consumer.SegmentEvent) {
+	var events []consumer.SegmentEvent
+	if st.Trip != nil && s.Time.Sub(st.Trip.Seen) > t.Gap {
+		st, events = t.end(st)
+	}
+
+	moving := t.moving(st.Position, s)
+	if s.Location != nil {
+		st.Position = &consumer.Endpoint{Time: s.Time, Location: s.Location}
+	}
+
+	switch {
+	case moving && st.Trip == nil:
+		start := consumer.Endpoint{Time: s.Time, Location: s.Location}
+		st.Trip = &Trip{
+			ID:    ksuid.New().String(),
+			Start: start,
+			Last:  start,
+			Seen:  s.Time,
+		}
+	case moving:
+		trip := *st.Trip
+		trip.Last = consumer.Endpoint{Time: s.Time}
+		if st.Position != nil {
+			trip.Last.Location = st.Position.Location
+		}
+		trip.Seen = s.Time
+		if !trip.Begun {
+			trip.Begun = true
+			events = append(events, consumer.SegmentEvent{ID: trip.ID, Start: trip.Start})
+		}
+		st.Trip = &trip
+	case st.Trip != nil:
+		trip := *st.Trip
+		trip.Seen = s.Time
+		st.Trip = &trip
+		if s.Time.Sub(trip.Last.Time) >= t.Idle {
+			var ended []consumer.SegmentEvent
+			st, ended = t.end(st)
+			events = append(events, ended...)
+		}
+	}
+
+	st.Until = s.Time
+	return st, events
+}
+
+// Flush ends the trip in progress if the device has gone quiet for longer than the gap as of
+// until, and moves the state up to then.
+func (t Thresholds) Flush(st State, until time.Time) (State, []consumer.SegmentEvent) {
+	var events []consumer.SegmentEvent
+	if st.Trip != nil && until.Sub(st.Trip.Seen) > t.Gap {
+		st, events = t.end(st)
+	}
+	if until.After(st.Until) {
+		st.Until = until
+	}
+	return st, events
+}
+
+func (t Thresholds) end(st State) (State, []consumer.SegmentEvent) {
+	trip := st.Trip
+	st.Trip = nil
+	if !trip.Begun {
+		return st, nil
+	}
+	return st, []consumer.SegmentEvent{{ID: trip.ID, Completed: true, Start: trip.Start, End: trip.Last}}
+}
+
+// moving decides whether the vehicle was moving at the status. The ignition being off rules it
+// out; otherwise the reported speed decides, or failing that the speed since the last
+// location, or failing that the ignition being on.
+func (t Thresholds) moving(last *consumer.Endpoint, s Status) bool {
+	if s.Ignition != nil && !*s.Ignition {
+		return false
+	}
+	if s.Speed != nil {
+		return *s.Speed >= t.MinSpeed
+	}
+	if last != nil && s.Location != nil {
+		if hours := s.Time.Sub(last.Time).Hours(); hours > 0 {
+			return geo.DistanceKm(point(last.Location), point(s.Location))/hours >= t.MinSpeed
+		}
+	}
+	return s.Ignition != nil
+}
+
+func point(l *consumer.Location) pgeo.Point {
+	return pgeo.NewPoint(l.Longitude, l.Latitude)
+}
@@ -0,0 +1,127 @@
+package segmenter
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/DIMO-Network/trips-api/internal/services/consumer"
+	es_store "github.com/DIMO-Network/trips-api/internal/services/es"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var thresholds = Thresholds{Idle: 5 * time.Minute, Gap: 10 * time.Minute, MinSpeed: 5}
+
+var t0 = time.Date(2024, 3, 1, 8, 0, 0, 0, time.UTC)
+
+func speed(v float64) *float64 { return &v }
+
+func ignition(on bool) *bool { return &on }
+
+func at(minutes float64) time.Time {
+	return t0.Add(time.Duration(minutes * float64(time.Minute)))
+}
+
+func run(st State, statuses []Status) (State, []consumer.SegmentEvent) {
+	var events []consumer.SegmentEvent
+	for _, s := range statuses {
+		var evs []consumer.SegmentEvent
+		st, evs = thresholds.Step(st, s)
+		events = append(events, evs...)
+	}
+	return st, events
+}
+
+func TestParseStatuses(t *testing.T) {
+	// Framed as FetchData returns them.
+	statuses, err := ParseStatuses(es_store.JoinStatuses([]json.RawMessage{
+		json.RawMessage(`{"data":{"timestamp":"2024-03-01T08:01:00Z","speed":30,"ignition":1,"latitude":40.75,"longitude":-73.98}}`),
+		json.RawMessage(`{"data":{"timestamp":"2024-03-01T08:00:00Z","ignition":false}}`),
+		json.RawMessage(`{"data":{"speed":10}}`),
+	}))
+	require.NoError(t, err)
+	require.Len(t, statuses, 2)
+
+	assert.Equal(t, t0, statuses[0].Time)
+	assert.Equal(t, ignition(false), statuses[0].Ignition)
+	assert.Nil(t, statuses[0].Speed)
+
+	assert.Equal(t, speed(30), statuses[1].Speed)
+	assert.Equal(t, ignition(true), statuses[1].Ignition)
+	assert.Equal(t, &consumer.Location{Latitude: 40.75, Longitude: -73.98}, statuses[1].Location)
+
+	statuses, err = ParseStatuses(es_store.JoinStatuses(nil))
+	require.NoError(t, err)
+	assert.Empty(t, statuses)
+
+	_, err = ParseStatuses([]byte(`[{"data":{"timestamp":"2024-03-01T08:01:00Z"}}`))
+	assert.Error(t, err, "unterminated array")
+}
+
+func TestStepIdle(t *testing.T) {
+	start := &consumer.Location{Latitude: 40.75, Longitude: -73.98}
+	end := &consumer.Location{Latitude: 40.8, Longitude: -73.98}
+
+	st, events := run(State{}, []Status{
+		{Time: at(0), Speed: speed(0)},
+		{Time: at(1), Speed: speed(20), Location: start},
+		{Time: at(2), Speed: speed(40)},
+		{Time: at(3), Speed: speed(30), Location: end},
+		{Time: at(4), Speed: speed(0), Location: end},
+		{Time: at(7), Ignition: ignition(false)},
+		{Time: at(8), Ignition: ignition(false)},
+	})
+
+	require.Len(t, events, 2)
+	begin, complete := events[0], events[1]
+	assert.False(t, begin.Completed)
+	assert.Equal(t, consumer.Endpoint{Time: at(1), Location: start}, begin.Start)
+
+	assert.True(t, complete.Completed)
+	assert.Equal(t, begin.ID, complete.ID)
+	assert.Equal(t, consumer.Endpoint{Time: at(3), Location: end}, complete.End)
+
+	assert.Nil(t, st.Trip)
+	assert.Equal(t, at(8), st.Until)
+}
+
+func TestStepGap(t *testing.T) {
+	st, events := run(State{}, []Status{
+		{Time: at(0), Ignition: ignition(true)},
+		{Time: at(1), Ignition: ignition(true)},
+		// The device went quiet, and the next trip starts on its return.
+		{Time: at(30), Ignition: ignition(true)},
+	})
+
+	require.Len(t, events, 2)
+	assert.True(t, events[1].Completed)
+	assert.Equal(t, at(1), events[1].End.Time)
+	require.NotNil(t, st.Trip)
+	assert.False(t, st.Trip.Begun)
+
+	st, events = thresholds.Flush(st, at(35))
+	assert.Empty(t, events)
+	assert.NotNil(t, st.Trip)
+
+	// A trip never confirmed by a second moving status is dropped.
+	st, events = thresholds.Flush(st, at(41))
+	assert.Empty(t, events)
+	assert.Nil(t, st.Trip)
+	assert.Equal(t, at(41), st.Until)
+}
+
+func TestStepDerivedSpeed(t *testing.T) {
+	// About 5.6 km a minute apart, with no speed or ignition reported.
+	_, events := run(State{}, []Status{
+		{Time: at(0), Location: &consumer.Location{Latitude: 40.75, Longitude: -73.98}},
+		{Time: at(1), Location: &consumer.Location{Latitude: 40.8, Longitude: -73.98}},
+		{Time: at(2), Location: &consumer.Location{Latitude: 40.85, Longitude: -73.98}},
+		{Time: at(3), Location: &consumer.Location{Latitude: 40.85, Longitude: -73.98}},
+		{Time: at(9), Location: &consumer.Location{Latitude: 40.85, Longitude: -73.98}},
+	})
+
+	require.Len(t, events, 2)
+	assert.Equal(t, at(1), events[0].Start.Time)
+	assert.Equal(t, at(2), events[1].End.Time)
+}
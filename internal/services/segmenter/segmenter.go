@@ -0,0 +1,251 @@
+// Package segmenter detects trips in the status data of devices that don't segment their own
+// and feeds them through the same processing as segment events from upstream.
+package segmenter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/DIMO-Network/shared"
+	"github.com/DIMO-Network/trips-api/internal/services/consumer"
+	"github.com/goccy/go-json"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/rs/zerolog"
+	"github.com/segmentio/ksuid"
+)
+
+// SegmentEventType is the type of the segment events that the segmenter produces.
+const SegmentEventType = "com.dimo.trips.segment"
+
+const source = "dimo/trips-api/segmenter"
+
+const (
+	defaultPollInterval = time.Minute
+	defaultIdle         = 5 * time.Minute
+	defaultGap          = 10 * time.Minute
+	defaultMinSpeed     = 5
+
+	// ingestDelay leaves time for statuses to be indexed before they are read.
+	ingestDelay = time.Minute
+	// maxWindow bounds the statuses read in one poll, so that a device catches up in steps
+	// after an outage.
+	maxWindow = 6 * time.Hour
+	// initialLookback is how far back the first poll of a device reads.
+	initialLookback = time.Hour
+)
+
+// Config chooses the devices to segment and how. Zero durations and speeds take the defaults.
+type Config struct {
+	UserDeviceIDs []string
+	PollInterval  time.Duration
+	Thresholds
+}
+
+type fetcher interface {
+	FetchData(ctx context.Context, userDeviceID string, start, end time.Time) ([]byte, error)
+}
+
+type store interface {
+	LockSegmenterDevice(ctx context.Context, userDeviceID string) (unlock func(), locked bool, err error)
+	SegmenterState(ctx context.Context, userDeviceID string) ([]byte, error)
+	SaveSegmenterState(ctx context.Context, userDeviceID string, state []byte, at time.Time) error
+}
+
+// Handler processes a detected segment, as the consumer does segment events from upstream.
+type Handler func(ctx context.Context, event shared.CloudEvent[consumer.SegmentEvent]) error
+
+// Segmenter polls the status data of its devices and hands the segments it finds to the
+// handler. Replicas share the work through locks on the devices.
+type Segmenter struct {
+	es     fetcher
+	store  store
+	handle Handler
+	config Config
+	logger *zerolog.Logger
+}
+
+func New(es fetcher, store store, handle Handler, config Config, logger *zerolog.Logger) *Segmenter {
+	config.UserDeviceIDs = slices.DeleteFunc(slices.Clone(config.UserDeviceIDs), func(id string) bool { return id == "" })
+	if config.PollInterval <= 0 {
+		config.PollInterval = defaultPollInterval
+	}
+	if config.Idle <= 0 {
+		config.Idle = defaultIdle
+	}
+	if config.Gap <= 0 {
+		config.Gap = defaultGap
+	}
+	if config.MinSpeed <= 0 {
+		config.MinSpeed = defaultMinSpeed
+	}
+
+	return &Segmenter{es, store, handle, config, logger}
+}
+
+// Run polls each device every poll interval until ctx is cancelled.
+func (s *Segmenter) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.config.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		for _, userDeviceID := range s.config.UserDeviceIDs {
+			if err := s.Poll(ctx, userDeviceID, time.Now()); err != nil {
+				if ctx.Err() != nil {
+					return
+				}
+				SegmenterPollErrorsTotal.Inc()
+				s.logger.Err(err).Str("userDeviceId", userDeviceID).Msg("Failed to segment device.")
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Poll detects trips in the device's statuses since the last poll, up to shortly before now.
+// It does nothing if another replica is polling the device.
+func (s *Segmenter) Poll(ctx context.Context, userDeviceID string, now time.Time) error {
+	unlock, locked, err := s.store.LockSegmenterDevice(ctx, userDeviceID)
+	if err != nil {
+		return fmt.Errorf("failed to lock device: %w", err)
+	}
+	if !locked {
+		return nil
+	}
+	defer unlock()
+
+	st, err := s.load(ctx, userDeviceID, now)
+	if err != nil {
+		return err
+	}
+
+	until := now.Add(-ingestDelay)
+	if until.Sub(st.Until) > maxWindow {
+		until = st.Until.Add(maxWindow)
+	}
+	if !until.After(st.Until) {
+		return nil
+	}
+
+	data, err := s.es.FetchData(ctx, userDeviceID, st.Until, until)
+	if err != nil {
+		return fmt.Errorf("call to Elasticsearch failed: %w", err)
+	}
+	statuses, err := ParseStatuses(data)
+	if err != nil {
+		return fmt.Errorf("failed to parse statuses: %w", err)
+	}
+
+	// The state is saved after each event is handled, so that a failure doesn't lead to the
+	// event being detected again under a different trip id.
+	advance := func(next State, events []consumer.SegmentEvent) error {
+		for _, event := range events {
+			event.DeviceID = userDeviceID
+			if err := s.handle(ctx, cloudEvent(event)); err != nil {
+				return errors.Join(fmt.Errorf("failed to process segment %s: %w", event.ID, err), s.save(ctx, userDeviceID, st, now))
+			}
+			SegmenterSegmentsTotal.WithLabelValues(kind(event)).Inc()
+		}
+		st = next
+		if len(events) > 0 {
+			return s.save(ctx, userDeviceID, st, now)
+		}
+		return nil
+	}
+
+	for _, status := range statuses {
+		// The range query is inclusive and only precise to the second.
+		if !status.Time.After(st.Until) || status.Time.After(until) {
+			continue
+		}
+		if err := advance(s.config.Step(st, status)); err != nil {
+			return err
+		}
+	}
+	if err := advance(s.config.Flush(st, until)); err != nil {
+		return err
+	}
+
+	return s.save(ctx, userDeviceID, st, now)
+}
+
+func (s *Segmenter) load(ctx context.Context, userDeviceID string, now time.Time) (State, error) {
+	raw, err := s.store.SegmenterState(ctx, userDeviceID)
+	if err != nil {
+		return State{}, fmt.Errorf("failed to load state: %w", err)
+	}
+	if raw == nil {
+		return State{Until: now.Add(-initialLookback)}, nil
+	}
+
+	var st State
+	if err := json.Unmarshal(raw, &st); err != nil {
+		return State{}, fmt.Errorf("failed to parse state: %w", err)
+	}
+	return st, nil
+}
+
+func (s *Segmenter) save(ctx context.Context, userDeviceID string, st State, now time.Time) error {
+	raw, err := json.Marshal(st)
+	if err != nil {
+		return err
+	}
+	if err := s.store.SaveSegmenterState(ctx, userDeviceID, raw, now); err != nil {
+		return fmt.Errorf("failed to save state: %w", err)
+	}
+	return nil
+}
+
+func cloudEvent(event consumer.SegmentEvent) shared.CloudEvent[consumer.SegmentEvent] {
+	at := event.Start.Time
+	if event.Completed {
+		at = event.End.Time
+	}
+
+	return shared.CloudEvent[consumer.SegmentEvent]{
+		ID:              ksuid.New().String(),
+		Source:          source,
+		SpecVersion:     "1.0",
+		Subject:         event.DeviceID,
+		Time:            at,
+		Type:            SegmentEventType,
+		DataContentType: "application/json",
+		Data:            event,
+	}
+}
+
+func kind(event consumer.SegmentEvent) string {
+	if event.Completed {
+		return "complete"
+	}
+	return "begin"
+}
+
+var (
+	SegmenterSegmentsTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "trips_api",
+			Subsystem: "segmenter",
+			Name:      "segments_total",
+			Help:      "The total number of segment begins and completions detected by the server-side segmenter.",
+		},
+		[]string{"kind"},
+	)
+
+	SegmenterPollErrorsTotal = promauto.NewCounter(
+		prometheus.CounterOpts{
+			Namespace: "trips_api",
+			Subsystem: "segmenter",
+			Name:      "poll_errors_total",
+			Help:      "The total number of failed polls of a device's status data.",
+		},
+	)
+)
@@ -0,0 +1,102 @@
+package segmenter
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/DIMO-Network/shared"
+	"github.com/DIMO-Network/trips-api/internal/services/consumer"
+	es_store "github.com/DIMO-Network/trips-api/internal/services/es"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const userDeviceID = "2Y83IHPItgk0uHD7hybGnA776Bo"
+
+type fakeES struct {
+	statuses []string
+	fetches  int
+}
+
+func (f *fakeES) FetchData(_ context.Context, _ string, start, end time.Time) ([]byte, error) {
+	f.fetches++
+	var hits []json.RawMessage
+	for _, s := range f.statuses {
+		var ts string
+		fmt.Sscanf(s, `{"data":{"timestamp":%q`, &ts) //nolint:errcheck
+		if t, _ := time.Parse(time.RFC3339, ts); !t.Before(start.Truncate(time.Second)) && !t.After(end) {
+			hits = append(hits, json.RawMessage(s))
+		}
+	}
+	return es_store.JoinStatuses(hits), nil
+}
+
+type fakeStore struct {
+	state  []byte
+	locked bool
+}
+
+func (f *fakeStore) LockSegmenterDevice(context.Context, string) (func(), bool, error) {
+	if f.locked {
+		return nil, false, nil
+	}
+	return func() {}, true, nil
+}
+
+func (f *fakeStore) SegmenterState(context.Context, string) ([]byte, error) {
+	return f.state, nil
+}
+
+func (f *fakeStore) SaveSegmenterState(_ context.Context, _ string, state []byte, _ time.Time) error {
+	f.state = state
+	return nil
+}
+
+func status(minutes int, kph float64) string {
+	return fmt.Sprintf(`{"data":{"timestamp":%q,"speed":%g}}`, at(float64(minutes)).Format(time.RFC3339), kph)
+}
+
+func TestPoll(t *testing.T) {
+	es := &fakeES{statuses: []string{status(0, 0), status(1, 30), status(2, 50), status(3, 0), status(9, 0)}}
+	store := &fakeStore{}
+
+	var handled []shared.CloudEvent[consumer.SegmentEvent]
+	fail := true
+	handle := func(_ context.Context, event shared.CloudEvent[consumer.SegmentEvent]) error {
+		// The completion fails the first time.
+		if event.Data.Completed && fail {
+			fail = false
+			return errors.New("database unavailable")
+		}
+		handled = append(handled, event)
+		return nil
+	}
+
+	s := New(es, store, handle, Config{UserDeviceIDs: []string{userDeviceID}, Thresholds: thresholds}, &zerolog.Logger{})
+
+	require.Error(t, s.Poll(context.Background(), userDeviceID, at(20)))
+	require.Len(t, handled, 1)
+	assert.Equal(t, userDeviceID, handled[0].Subject)
+	assert.Equal(t, userDeviceID, handled[0].Data.DeviceID)
+	assert.False(t, handled[0].Data.Completed)
+	assert.Equal(t, at(1), handled[0].Time)
+
+	require.NoError(t, s.Poll(context.Background(), userDeviceID, at(20)))
+	require.Len(t, handled, 2)
+	assert.True(t, handled[1].Data.Completed)
+	assert.Equal(t, handled[0].Data.ID, handled[1].Data.ID)
+	assert.Equal(t, at(2), handled[1].Data.End.Time)
+
+	// Nothing new to read.
+	require.NoError(t, s.Poll(context.Background(), userDeviceID, at(20)))
+	assert.Len(t, handled, 2)
+
+	store.locked = true
+	require.NoError(t, s.Poll(context.Background(), userDeviceID, at(40)))
+	assert.Equal(t, 2, es.fetches)
+}
@@ -0,0 +1,214 @@
+// Package tripevents publishes CloudEvents describing the lifecycle of a trip, so that other
+// services need not poll the API.
+package tripevents
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/DIMO-Network/shared"
+	"github.com/DIMO-Network/trips-api/internal/geo"
+	"github.com/DIMO-Network/trips-api/internal/privacy"
+	"github.com/DIMO-Network/trips-api/models"
+	"github.com/goccy/go-json"
+	"github.com/segmentio/ksuid"
+	"github.com/volatiletech/sqlboiler/v4/types/pgeo"
+)
+
+const (
+	// TripStartedType is sent when a segment begins.
+	TripStartedType = "com.dimo.trip.started"
+	// TripCompletedType is sent when a segment ends and its end has been recorded.
+	TripCompletedType = "com.dimo.trip.completed"
+	// TripArchivedType is sent once the trip's data has been uploaded to Bundlr.
+	TripArchivedType = "com.dimo.trip.archived"
+)
+
+const source = "dimo/trips-api"
+
+type Location struct {
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+}
+
+type Endpoint struct {
+	Time     time.Time `json:"time"`
+	Location *Location `json:"location,omitempty"`
+	// EstimatedLocation is only set on the start, when it was interpolated from the end of
+	// the vehicle's previous trip.
+	EstimatedLocation *Location `json:"estimatedLocation,omitempty"`
+}
+
+type Statistics struct {
+	DurationSeconds float64 `json:"durationSeconds"`
+	// DistanceKm is the distance travelled, measured from the vehicle's status data where
+	// available.
+	DistanceKm *float64 `json:"distanceKm,omitempty"`
+	// DisplacementKm is the straight-line distance between the start and end locations, if
+	// both are known.
+	DisplacementKm *float64 `json:"displacementKm,omitempty"`
+	DroppedData    bool     `json:"droppedData"`
+}
+
+// Trip is the data of every trip lifecycle event. End and Statistics are present from
+// completion on, and ArchiveID once archived.
+type Trip struct {
+	ID             string      `json:"id"`
+	VehicleTokenID int         `json:"vehicleTokenId"`
+	Start          Endpoint    `json:"start"`
+	End            *Endpoint   `json:"end,omitempty"`
+	Statistics     *Statistics `json:"statistics,omitempty"`
+	ArchiveID      string      `json:"archiveId,omitempty"`
+}
+
+// Event is a trip lifecycle CloudEvent.
+type Event = shared.CloudEvent[Trip]
+
+// Sink receives every published event.
+type Sink interface {
+	Publish(ctx context.Context, event Event) error
+}
+
+// Sender delivers encoded events. It is satisfied by kafka.Producer.
+type Sender interface {
+	Send(ctx context.Context, key string, value []byte) error
+}
+
+type kafkaSink struct {
+	sender Sender
+}
+
+// KafkaSink encodes events as JSON and sends them keyed by vehicle token id, which keeps each
+// vehicle's events in order. The topic is read by DIMO's own services, not apps, so unlike
+// webhook deliveries the events aren't recorded in the access log.
+func KafkaSink(sender Sender) Sink {
+	return kafkaSink{sender: sender}
+}
+
+func (k kafkaSink) Publish(ctx context.Context, event Event) error {
+	b, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	return k.sender.Send(ctx, strconv.Itoa(event.Data.VehicleTokenID), b)
+}
+
+// Zones looks up a vehicle's privacy zones. It is satisfied by pg.Store.
+type Zones interface {
+	PrivacyZones(ctx context.Context, tokenID int) (models.PrivacyZoneSlice, error)
+}
+
+// Publisher builds trip lifecycle events and hands them to each of its sinks. A nil Publisher
+// discards them.
+type Publisher struct {
+	zones     Zones
+	precision privacy.Precision
+	sinks     []Sink
+}
+
+// New creates a publisher. The sinks are outside the vehicle owner's control, so positions in
+// events are masked with the vehicle's privacy zones and then coarsened to precision, as they
+// are for apps reading trips through the API. Without zones, positions are only coarsened.
+func New(zones Zones, precision privacy.Precision, sinks ...Sink) *Publisher {
+	return &Publisher{zones: zones, precision: precision, sinks: sinks}
+}
+
+// Started announces a newly begun trip.
+func (p *Publisher) Started(ctx context.Context, trip *models.Trip) error {
+	return p.publish(ctx, TripStartedType, trip.StartTime, trip)
+}
+
+// Completed announces that a trip has ended.
+func (p *Publisher) Completed(ctx context.Context, trip *models.Trip) error {
+	return p.publish(ctx, TripCompletedType, trip.EndTime.Time, trip)
+}
+
+// Archived announces that a trip's data is available on Bundlr.
+func (p *Publisher) Archived(ctx context.Context, trip *models.Trip) error {
+	return p.publish(ctx, TripArchivedType, time.Now(), trip)
+}
+
+func (p *Publisher) publish(ctx context.Context, eventType string, at time.Time, trip *models.Trip) error {
+	if p == nil || len(p.sinks) == 0 {
+		return nil
+	}
+
+	// Masking works on a copy, so that the caller's trip keeps its positions.
+	masked := *trip
+	if p.zones != nil {
+		zones, err := p.zones.PrivacyZones(ctx, trip.VehicleTokenID)
+		if err != nil {
+			return fmt.Errorf("failed to load privacy zones: %w", err)
+		}
+		privacy.MaskTrip(&masked, zones)
+	}
+	privacy.Redaction{Precision: p.precision}.Trip(&masked)
+	data := tripData(&masked)
+
+	event := Event{
+		ID:              ksuid.New().String(),
+		Source:          source,
+		SpecVersion:     "1.0",
+		Subject:         data.ID,
+		Time:            at,
+		Type:            eventType,
+		DataContentType: "application/json",
+		VehicleTokenID:  uint32(data.VehicleTokenID),
+		Data:            data,
+	}
+
+	var errs []error
+	for _, sink := range p.sinks {
+		if err := sink.Publish(ctx, event); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func tripData(trip *models.Trip) Trip {
+	data := Trip{
+		ID:             trip.ID,
+		VehicleTokenID: trip.VehicleTokenID,
+		Start: Endpoint{
+			Time:              trip.StartTime,
+			Location:          location(trip.StartPosition),
+			EstimatedLocation: location(trip.StartPositionEstimate),
+		},
+		ArchiveID: trip.BundlrID.String,
+	}
+
+	if trip.EndTime.Valid {
+		data.End = &Endpoint{
+			Time:     trip.EndTime.Time,
+			Location: location(trip.EndPosition),
+		}
+
+		stats := &Statistics{
+			DurationSeconds: trip.EndTime.Time.Sub(trip.StartTime).Seconds(),
+			DistanceKm:      trip.DistanceKM.Ptr(),
+			DroppedData:     trip.DroppedData,
+		}
+		start := trip.StartPosition
+		if !start.Valid {
+			start = trip.StartPositionEstimate
+		}
+		if start.Valid && trip.EndPosition.Valid {
+			km := geo.DistanceKm(start.Point, trip.EndPosition.Point)
+			stats.DisplacementKm = &km
+		}
+		data.Statistics = stats
+	}
+
+	return data
+}
+
+func location(p pgeo.NullPoint) *Location {
+	if p.Valid {
+		return &Location{Latitude: p.Y, Longitude: p.X}
+	}
+	return nil
+}
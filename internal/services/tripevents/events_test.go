@@ -0,0 +1,125 @@
+package tripevents
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DIMO-Network/shared"
+	"github.com/DIMO-Network/trips-api/internal/privacy"
+	"github.com/DIMO-Network/trips-api/models"
+	"github.com/goccy/go-json"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/types/pgeo"
+)
+
+type sent struct {
+	key   string
+	value []byte
+}
+
+type fakeSender struct {
+	messages []sent
+}
+
+func (f *fakeSender) Send(_ context.Context, key string, value []byte) error {
+	f.messages = append(f.messages, sent{key, value})
+	return nil
+}
+
+func TestCompleted(t *testing.T) {
+	start := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	trip := &models.Trip{
+		ID:                    "2cZ4GjK0sbvh7vD4mdPDJhSq1Nt",
+		VehicleTokenID:        17,
+		StartTime:             start,
+		EndTime:               null.TimeFrom(start.Add(30 * time.Minute)),
+		StartPositionEstimate: pgeo.NewNullPoint(pgeo.NewPoint(-73.98, 40.75), true),
+		EndPosition:           pgeo.NewNullPoint(pgeo.NewPoint(-73.98, 40.85), true),
+		BundlrID:              null.StringFrom("iAm7bHyA8M2Ug2ZtvVtGq1DuDp5Iw3FhSpqp2cDz6Vs"),
+	}
+
+	sender := &fakeSender{}
+	require.NoError(t, New(nil, privacy.PrecisionFull, KafkaSink(sender)).Completed(context.Background(), trip))
+	require.Len(t, sender.messages, 1)
+	assert.Equal(t, "17", sender.messages[0].key)
+
+	var event shared.CloudEvent[Trip]
+	require.NoError(t, json.Unmarshal(sender.messages[0].value, &event))
+
+	assert.Equal(t, TripCompletedType, event.Type)
+	assert.Equal(t, trip.ID, event.Subject)
+	assert.Equal(t, uint32(17), event.VehicleTokenID)
+	assert.Equal(t, trip.EndTime.Time, event.Time.UTC())
+
+	assert.Nil(t, event.Data.Start.Location)
+	assert.Equal(t, &Location{Latitude: 40.75, Longitude: -73.98}, event.Data.Start.EstimatedLocation)
+	require.NotNil(t, event.Data.End)
+	assert.Equal(t, &Location{Latitude: 40.85, Longitude: -73.98}, event.Data.End.Location)
+	require.NotNil(t, event.Data.Statistics)
+	assert.Equal(t, 1800.0, event.Data.Statistics.DurationSeconds)
+	require.NotNil(t, event.Data.Statistics.DisplacementKm)
+	assert.InDelta(t, 11.1, *event.Data.Statistics.DisplacementKm, 0.1)
+	assert.Equal(t, trip.BundlrID.String, event.Data.ArchiveID)
+}
+
+func TestStartedOmitsEnd(t *testing.T) {
+	sender := &fakeSender{}
+	require.NoError(t, New(nil, privacy.PrecisionFull, KafkaSink(sender)).Started(context.Background(), &models.Trip{ID: "a", VehicleTokenID: 1, StartTime: time.Now()}))
+
+	var event shared.CloudEvent[Trip]
+	require.NoError(t, json.Unmarshal(sender.messages[0].value, &event))
+	assert.Equal(t, TripStartedType, event.Type)
+	assert.Nil(t, event.Data.End)
+	assert.Nil(t, event.Data.Statistics)
+}
+
+func TestNilPublisher(t *testing.T) {
+	var p *Publisher
+	assert.NoError(t, p.Archived(context.Background(), &models.Trip{}))
+}
+
+type fakeZones models.PrivacyZoneSlice
+
+func (f fakeZones) PrivacyZones(_ context.Context, tokenID int) (models.PrivacyZoneSlice, error) {
+	var out models.PrivacyZoneSlice
+	for _, z := range f {
+		if z.VehicleTokenID == tokenID {
+			out = append(out, z)
+		}
+	}
+	return out, nil
+}
+
+func TestEventsMasked(t *testing.T) {
+	start := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	trip := &models.Trip{
+		ID:             "2cZ4GjK0sbvh7vD4mdPDJhSq1Nt",
+		VehicleTokenID: 17,
+		StartTime:      start,
+		EndTime:        null.TimeFrom(start.Add(30 * time.Minute)),
+		StartPosition:  pgeo.NewNullPoint(pgeo.NewPoint(-73.9801, 40.7502), true),
+		EndPosition:    pgeo.NewNullPoint(pgeo.NewPoint(-73.98123, 40.85123), true),
+	}
+	zones := fakeZones{
+		{VehicleTokenID: 17, Center: pgeo.NewPoint(-73.98, 40.75), RadiusM: 300, Mask: privacy.MaskOmit},
+		{VehicleTokenID: 18, Center: pgeo.NewPoint(-73.98, 40.85), RadiusM: 300, Mask: privacy.MaskOmit},
+	}
+
+	sender := &fakeSender{}
+	require.NoError(t, New(zones, privacy.Precision100m, KafkaSink(sender)).Completed(context.Background(), trip))
+	require.Len(t, sender.messages, 1)
+
+	var event shared.CloudEvent[Trip]
+	require.NoError(t, json.Unmarshal(sender.messages[0].value, &event))
+
+	assert.Nil(t, event.Data.Start.Location, "the start is inside the vehicle's zone")
+	require.NotNil(t, event.Data.End)
+	assert.Equal(t, &Location{Latitude: 40.851, Longitude: -73.981}, event.Data.End.Location, "the end is coarsened")
+	assert.Nil(t, event.Data.Statistics.DisplacementKm)
+
+	assert.True(t, trip.StartPosition.Valid, "the caller's trip is left alone")
+	assert.Equal(t, -73.98123, trip.EndPosition.X)
+}
@@ -0,0 +1,385 @@
+// Package webhook delivers trip lifecycle events to the HTTPS endpoints that integrators have
+// registered for their vehicles.
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/netip"
+	"strconv"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/DIMO-Network/shared/privileges"
+	"github.com/DIMO-Network/trips-api/internal/services/pg"
+	"github.com/DIMO-Network/trips-api/internal/services/tripevents"
+	"github.com/DIMO-Network/trips-api/models"
+	"github.com/goccy/go-json"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/rs/zerolog"
+	"github.com/volatiletech/null/v8"
+)
+
+const (
+	// TimestampHeader carries the Unix time at which a delivery attempt was signed.
+	TimestampHeader = "X-Trips-Timestamp"
+	// SignatureHeader carries the hex HMAC-SHA256 of the timestamp, a period and the body,
+	// keyed with the webhook's secret.
+	SignatureHeader = "X-Trips-Signature"
+)
+
+// AccessEndpoint stands for the route in the access log records of deliveries.
+const AccessEndpoint = "webhook"
+
+// EventTypes lists the events that webhooks may subscribe to.
+var EventTypes = []string{tripevents.TripStartedType, tripevents.TripCompletedType}
+
+const (
+	defaultWorkers      = 4
+	defaultPollInterval = time.Second
+	defaultMaxAttempts  = 5
+	defaultRetryBackoff = time.Second
+	defaultDisableAfter = 10
+	defaultTimeout      = 10 * time.Second
+	// maxRetryBackoff caps the wait between attempts.
+	maxRetryBackoff = time.Hour
+)
+
+// Config tunes delivery. Zero values take the defaults.
+type Config struct {
+	Workers int
+	// PollInterval is how often the outbox is checked for deliveries that are due, besides
+	// whenever an event is published.
+	PollInterval time.Duration
+	// MaxAttempts is how many times each event is tried before the delivery counts as failed.
+	MaxAttempts int
+	// RetryBackoff is the wait before the first retry. It doubles with each further one.
+	RetryBackoff time.Duration
+	// DisableAfter is the number of consecutive failed deliveries after which a webhook is
+	// disabled.
+	DisableAfter int
+	Timeout      time.Duration
+	// AllowPrivateNetworks lets deliveries reach loopback, private and link-local addresses,
+	// for local development.
+	AllowPrivateNetworks bool
+}
+
+type store interface {
+	ActiveWebhooks(ctx context.Context, tokenID int, eventType string) (models.WebhookSlice, error)
+	QueueWebhookDeliveries(ctx context.Context, webhookIDs []string, eventID string, body []byte) error
+	ClaimWebhookDeliveries(ctx context.Context, limit int, lease time.Duration) (models.WebhookDeliverySlice, error)
+	RetryWebhookDelivery(ctx context.Context, id int64, at time.Time) error
+	FinishWebhookDelivery(ctx context.Context, dl *models.WebhookDelivery, delivered bool, disableAfter int, at time.Time) error
+	DisableWebhook(ctx context.Context, id string, at time.Time) error
+	RecordAccess(ctx context.Context, a pg.Access) error
+}
+
+// grants tells whether any of users still owns the vehicle or holds the privilege on it.
+type grants interface {
+	HasPrivilege(ctx context.Context, tokenID int, privilege privileges.Privilege, users ...string) (bool, error)
+}
+
+// Dispatcher records events in an outbox for the webhooks subscribed to them and delivers them
+// in the background. Pending deliveries survive restarts and are shared between replicas, so
+// an event may occasionally be delivered more than once.
+//
+// Before each delivery the dispatcher checks that the app that registered the webhook still
+// holds the all-time location privilege on the vehicle. A webhook whose grant has been revoked,
+// or whose vehicle has changed hands, is disabled instead.
+type Dispatcher struct {
+	store  store
+	grants grants
+	client *http.Client
+	config Config
+	wake   chan struct{}
+	logger *zerolog.Logger
+}
+
+func New(store store, grants grants, config Config, logger *zerolog.Logger) *Dispatcher {
+	if config.Workers <= 0 {
+		config.Workers = defaultWorkers
+	}
+	if config.PollInterval <= 0 {
+		config.PollInterval = defaultPollInterval
+	}
+	if config.MaxAttempts <= 0 {
+		config.MaxAttempts = defaultMaxAttempts
+	}
+	if config.RetryBackoff <= 0 {
+		config.RetryBackoff = defaultRetryBackoff
+	}
+	if config.DisableAfter <= 0 {
+		config.DisableAfter = defaultDisableAfter
+	}
+	if config.Timeout <= 0 {
+		config.Timeout = defaultTimeout
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if !config.AllowPrivateNetworks {
+		// Endpoints are chosen by integrators, so they mustn't be able to reach the internal
+		// network, whatever their hostnames resolve to at the time. Checking the dialed address
+		// covers DNS rebinding; going through a proxy would hide it.
+		dialer := &net.Dialer{Timeout: 30 * time.Second, KeepAlive: 30 * time.Second, Control: publicOnly}
+		transport.DialContext = dialer.DialContext
+		transport.Proxy = nil
+	}
+
+	return &Dispatcher{
+		store:  store,
+		grants: grants,
+		client: &http.Client{
+			Transport: transport,
+			Timeout:   config.Timeout,
+			// A redirect counts as a failure rather than sending the payload somewhere else.
+			CheckRedirect: func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse },
+		},
+		config: config,
+		wake:   make(chan struct{}, 1),
+		logger: logger,
+	}
+}
+
+// Publish records a pending delivery of the event for each webhook subscribed to it. It
+// implements tripevents.Sink.
+func (d *Dispatcher) Publish(ctx context.Context, event tripevents.Event) error {
+	webhooks, err := d.store.ActiveWebhooks(ctx, event.Data.VehicleTokenID, event.Type)
+	if err != nil {
+		return fmt.Errorf("failed to load webhooks: %w", err)
+	}
+	if len(webhooks) == 0 {
+		return nil
+	}
+
+	body, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	ids := make([]string, len(webhooks))
+	for i, w := range webhooks {
+		ids[i] = w.ID
+	}
+	if err := d.store.QueueWebhookDeliveries(ctx, ids, event.ID, body); err != nil {
+		return fmt.Errorf("failed to queue webhook deliveries: %w", err)
+	}
+
+	select {
+	case d.wake <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+// lease is how long a claimed delivery is left to this dispatcher before it's due again: time
+// for the grant check, the request and recording the outcome.
+func (d *Dispatcher) lease() time.Duration {
+	return 3 * d.config.Timeout
+}
+
+// Run delivers pending events until ctx is cancelled. Deliveries in progress at that point are
+// left in the outbox, and tried again once their lease runs out.
+func (d *Dispatcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(d.config.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		deliveries, err := d.store.ClaimWebhookDeliveries(ctx, d.config.Workers, d.lease())
+		if err != nil && ctx.Err() == nil {
+			d.logger.Err(err).Msg("Failed to claim webhook deliveries.")
+		}
+
+		var wg sync.WaitGroup
+		for _, dl := range deliveries {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				d.deliver(ctx, dl)
+			}()
+		}
+		wg.Wait()
+
+		// A full batch suggests more are due.
+		if err == nil && len(deliveries) == d.config.Workers {
+			continue
+		}
+
+		select {
+		case <-d.wake:
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// backoff is the wait after the given attempt before the next one.
+func (d *Dispatcher) backoff(attempt int) time.Duration {
+	wait := d.config.RetryBackoff
+	for i := 1; i < attempt && wait < maxRetryBackoff; i++ {
+		wait *= 2
+	}
+	return min(wait, maxRetryBackoff)
+}
+
+// deliver makes one attempt at a claimed delivery. If it fails and attempts remain, the
+// delivery is due again after a backoff; otherwise it's removed from the outbox and the outcome
+// recorded against the webhook. Deliveries to webhooks whose registrant has lost access to the
+// vehicle are dropped, and the webhook disabled. Registrants are identified by their client id
+// alone, as the token subject is the vehicle.
+func (d *Dispatcher) deliver(ctx context.Context, dl *models.WebhookDelivery) {
+	webhook := dl.R.Webhook
+
+	allowed, err := d.grants.HasPrivilege(ctx, webhook.VehicleTokenID, privileges.VehicleAllTimeLocation, webhook.ClientID.String)
+	if err != nil {
+		if ctx.Err() != nil {
+			return
+		}
+		// Not the receiver's fault, so no failure is recorded against the webhook.
+		WebhookDeliveryTotal.WithLabelValues("unchecked").Inc()
+		d.logger.Warn().Err(err).Str("webhookId", webhook.ID).Msg("Couldn't check webhook grant, retrying later.")
+		if err := d.store.RetryWebhookDelivery(ctx, dl.ID, time.Now().Add(d.backoff(dl.Attempts))); err != nil {
+			d.logger.Err(err).Str("webhookId", webhook.ID).Msg("Failed to reschedule webhook delivery.")
+		}
+		return
+	}
+	if !allowed {
+		WebhookDeliveryTotal.WithLabelValues("revoked").Inc()
+		d.logger.Info().Str("webhookId", webhook.ID).Int("vehicleTokenId", webhook.VehicleTokenID).Msg("Webhook registrant lost access to the vehicle, disabling webhook.")
+		if err := d.store.DisableWebhook(ctx, webhook.ID, time.Now()); err != nil {
+			d.logger.Err(err).Str("webhookId", webhook.ID).Msg("Failed to disable webhook.")
+		}
+		return
+	}
+
+	// Each attempt hands the trip to the registrant, so it's recorded in the access log like a
+	// read through the API. An attempt that can't be recorded isn't made.
+	if err := d.store.RecordAccess(ctx, pg.Access{
+		VehicleTokenID: webhook.VehicleTokenID,
+		Subject:        webhook.Subject,
+		ClientID:       webhook.ClientID.String,
+		Endpoint:       AccessEndpoint,
+		TripCount:      null.IntFrom(1),
+	}); err != nil {
+		if ctx.Err() != nil {
+			return
+		}
+		d.logger.Err(err).Str("webhookId", webhook.ID).Msg("Failed to record webhook access, retrying later.")
+		if err := d.store.RetryWebhookDelivery(ctx, dl.ID, time.Now().Add(d.backoff(dl.Attempts))); err != nil {
+			d.logger.Err(err).Str("webhookId", webhook.ID).Msg("Failed to reschedule webhook delivery.")
+		}
+		return
+	}
+
+	err = d.post(ctx, webhook, dl.Body)
+	if ctx.Err() != nil {
+		return
+	}
+
+	if err != nil && dl.Attempts < d.config.MaxAttempts {
+		d.logger.Debug().Err(err).Str("webhookId", webhook.ID).Int("attempt", dl.Attempts).Msg("Webhook delivery attempt failed.")
+		if err := d.store.RetryWebhookDelivery(ctx, dl.ID, time.Now().Add(d.backoff(dl.Attempts))); err != nil {
+			d.logger.Err(err).Str("webhookId", webhook.ID).Msg("Failed to reschedule webhook delivery.")
+		}
+		return
+	}
+
+	if err != nil {
+		WebhookDeliveryTotal.WithLabelValues("failed").Inc()
+		d.logger.Warn().Err(err).Str("webhookId", webhook.ID).Str("eventId", dl.EventID).Msg("Webhook delivery failed.")
+	} else {
+		WebhookDeliveryTotal.WithLabelValues("delivered").Inc()
+	}
+
+	if err := d.store.FinishWebhookDelivery(ctx, dl, err == nil, d.config.DisableAfter, time.Now()); err != nil {
+		d.logger.Err(err).Str("webhookId", webhook.ID).Msg("Failed to record webhook delivery.")
+	}
+}
+
+func (d *Dispatcher) post(ctx context.Context, webhook *models.Webhook, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	req.Header.Set("Content-Type", "application/cloudevents+json")
+	req.Header.Set(TimestampHeader, timestamp)
+	req.Header.Set(SignatureHeader, Sign(webhook.Secret, timestamp, body))
+
+	res, err := d.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(res.Body, 1<<16))
+
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return fmt.Errorf("status code %d", res.StatusCode)
+	}
+	return nil
+}
+
+// ErrPrivateAddress is returned when a webhook endpoint resolves to an address that isn't on
+// the public internet.
+var ErrPrivateAddress = errors.New("address is not public")
+
+// sharedAddressSpace is the carrier-grade NAT range of RFC 6598, which netip doesn't count as
+// private.
+var sharedAddressSpace = netip.MustParsePrefix("100.64.0.0/10")
+
+// IsPublic reports whether ip may be on the public internet: it isn't unspecified, loopback,
+// private, link-local, multicast or in the shared address space.
+func IsPublic(ip netip.Addr) bool {
+	ip = ip.Unmap()
+	return ip.IsValid() && !ip.IsUnspecified() && !ip.IsLoopback() && !ip.IsPrivate() &&
+		!ip.IsLinkLocalUnicast() && !ip.IsLinkLocalMulticast() && !ip.IsInterfaceLocalMulticast() &&
+		!ip.IsMulticast() && !sharedAddressSpace.Contains(ip)
+}
+
+// publicOnly is a net.Dialer Control function that refuses to connect to addresses that
+// aren't public.
+func publicOnly(_, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	ip, err := netip.ParseAddr(host)
+	if err != nil {
+		return err
+	}
+	if !IsPublic(ip) {
+		return fmt.Errorf("%w: %s", ErrPrivateAddress, ip)
+	}
+	return nil
+}
+
+// Sign returns the signature sent in SignatureHeader. Receivers should recompute it and
+// compare with hmac.Equal, and reject stale timestamps to guard against replays.
+func Sign(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte{'.'})
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+var WebhookDeliveryTotal = promauto.NewCounterVec(
+	prometheus.CounterOpts{
+		Namespace: "trips_api",
+		Subsystem: "webhook",
+		Name:      "deliveries_total",
+		Help:      "The total number of webhook deliveries, by outcome.",
+	},
+	[]string{"outcome"},
+)
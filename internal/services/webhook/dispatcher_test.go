@@ -0,0 +1,348 @@
+package webhook
+
+import (
+	"context"
+	"crypto/hmac"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/DIMO-Network/shared/privileges"
+	"github.com/DIMO-Network/trips-api/internal/services/pg"
+	"github.com/DIMO-Network/trips-api/internal/services/tripevents"
+	"github.com/DIMO-Network/trips-api/models"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/volatiletech/null/v8"
+)
+
+type result struct {
+	id           string
+	delivered    bool
+	disableAfter int
+}
+
+// fakeStore keeps the outbox in memory. Deliveries are due as soon as they're queued or
+// rescheduled.
+type fakeStore struct {
+	webhooks models.WebhookSlice
+	results  chan result
+	disabled chan string
+
+	mu         sync.Mutex
+	nextID     int64
+	deliveries []*models.WebhookDelivery
+	claimed    map[int64]bool
+	accesses   []pg.Access
+}
+
+func (f *fakeStore) ActiveWebhooks(_ context.Context, tokenID int, eventType string) (models.WebhookSlice, error) {
+	var out models.WebhookSlice
+	for _, w := range f.webhooks {
+		if w.VehicleTokenID == tokenID {
+			for _, t := range w.EventTypes {
+				if t == eventType {
+					out = append(out, w)
+				}
+			}
+		}
+	}
+	return out, nil
+}
+
+func (f *fakeStore) QueueWebhookDeliveries(_ context.Context, webhookIDs []string, eventID string, body []byte) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, id := range webhookIDs {
+		f.nextID++
+		f.deliveries = append(f.deliveries, &models.WebhookDelivery{ID: f.nextID, WebhookID: id, EventID: eventID, Body: body})
+	}
+	return nil
+}
+
+func (f *fakeStore) ClaimWebhookDeliveries(_ context.Context, limit int, _ time.Duration) (models.WebhookDeliverySlice, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.claimed == nil {
+		f.claimed = make(map[int64]bool)
+	}
+	var out models.WebhookDeliverySlice
+	for _, dl := range f.deliveries {
+		if len(out) == limit {
+			break
+		}
+		if f.claimed[dl.ID] {
+			continue
+		}
+		f.claimed[dl.ID] = true
+		dl.Attempts++
+		dl.R = dl.R.NewStruct()
+		for _, w := range f.webhooks {
+			if w.ID == dl.WebhookID {
+				dl.R.Webhook = w
+			}
+		}
+		out = append(out, dl)
+	}
+	return out, nil
+}
+
+func (f *fakeStore) RetryWebhookDelivery(_ context.Context, id int64, _ time.Time) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	delete(f.claimed, id)
+	return nil
+}
+
+func (f *fakeStore) FinishWebhookDelivery(_ context.Context, dl *models.WebhookDelivery, delivered bool, disableAfter int, _ time.Time) error {
+	f.mu.Lock()
+	f.deliveries = slices.DeleteFunc(f.deliveries, func(o *models.WebhookDelivery) bool { return o.ID == dl.ID })
+	f.mu.Unlock()
+	f.results <- result{dl.WebhookID, delivered, disableAfter}
+	return nil
+}
+
+func (f *fakeStore) DisableWebhook(_ context.Context, id string, _ time.Time) error {
+	f.mu.Lock()
+	f.deliveries = slices.DeleteFunc(f.deliveries, func(o *models.WebhookDelivery) bool { return o.WebhookID == id })
+	f.mu.Unlock()
+	f.disabled <- id
+	return nil
+}
+
+func (f *fakeStore) RecordAccess(_ context.Context, a pg.Access) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.accesses = append(f.accesses, a)
+	return nil
+}
+
+// recorded returns the accesses recorded so far.
+func (f *fakeStore) recorded() []pg.Access {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return slices.Clone(f.accesses)
+}
+
+// pending is the number of deliveries left in the outbox.
+func (f *fakeStore) pending() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return len(f.deliveries)
+}
+
+// fakeGrants lets the users in allowed through.
+type fakeGrants struct {
+	allowed []string
+}
+
+func (f *fakeGrants) HasPrivilege(_ context.Context, _ int, p privileges.Privilege, users ...string) (bool, error) {
+	if p != privileges.VehicleAllTimeLocation {
+		return false, nil
+	}
+	for _, u := range users {
+		if slices.Contains(f.allowed, u) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func newEvent(tokenID int, eventType string) tripevents.Event {
+	return tripevents.Event{ID: "2cZ4GjK0sbvh7vD4mdPDJhSq1Nt", Type: eventType, Data: tripevents.Trip{ID: "trip", VehicleTokenID: tokenID}}
+}
+
+func TestDeliverySignedAndRetried(t *testing.T) {
+	var (
+		calls atomic.Int32
+		mu    sync.Mutex
+		body  []byte
+		sig   string
+		ts    string
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		body, _ = io.ReadAll(r.Body)
+		sig = r.Header.Get(SignatureHeader)
+		ts = r.Header.Get(TimestampHeader)
+	}))
+	defer srv.Close()
+
+	store := &fakeStore{
+		webhooks: models.WebhookSlice{
+			{ID: "a", VehicleTokenID: 17, ClientID: null.StringFrom("0xowner"), URL: srv.URL, Secret: "s3cret", EventTypes: []string{tripevents.TripCompletedType}},
+			{ID: "b", VehicleTokenID: 17, ClientID: null.StringFrom("0xowner"), URL: srv.URL, Secret: "other", EventTypes: []string{tripevents.TripStartedType}},
+		},
+		results: make(chan result, 1),
+	}
+	d := New(store, &fakeGrants{allowed: []string{"0xowner"}}, Config{PollInterval: time.Millisecond, RetryBackoff: time.Millisecond, AllowPrivateNetworks: true}, &zerolog.Logger{})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go d.Run(ctx)
+
+	require.NoError(t, d.Publish(ctx, newEvent(17, tripevents.TripCompletedType)))
+
+	select {
+	case res := <-store.results:
+		assert.Equal(t, result{"a", true, defaultDisableAfter}, res)
+	case <-time.After(5 * time.Second):
+		t.Fatal("delivery not recorded")
+	}
+
+	assert.EqualValues(t, 2, calls.Load())
+	assert.Zero(t, store.pending())
+	// Both attempts sent the trip, so both are in the access log.
+	assert.Equal(t, []pg.Access{
+		{VehicleTokenID: 17, ClientID: "0xowner", Endpoint: AccessEndpoint, TripCount: null.IntFrom(1)},
+		{VehicleTokenID: 17, ClientID: "0xowner", Endpoint: AccessEndpoint, TripCount: null.IntFrom(1)},
+	}, store.recorded())
+	mu.Lock()
+	defer mu.Unlock()
+	assert.True(t, hmac.Equal([]byte(Sign("s3cret", ts, body)), []byte(sig)))
+	assert.Contains(t, string(body), tripevents.TripCompletedType)
+}
+
+func TestDeliveryFailure(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		http.Redirect(w, r, "https://example.com", http.StatusFound)
+	}))
+	defer srv.Close()
+
+	store := &fakeStore{
+		webhooks: models.WebhookSlice{{ID: "a", VehicleTokenID: 17, ClientID: null.StringFrom("0xowner"), URL: srv.URL, EventTypes: []string{tripevents.TripStartedType}}},
+		results:  make(chan result, 1),
+	}
+	d := New(store, &fakeGrants{allowed: []string{"0xowner"}}, Config{PollInterval: time.Millisecond, MaxAttempts: 3, RetryBackoff: time.Millisecond, DisableAfter: 2, AllowPrivateNetworks: true}, &zerolog.Logger{})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go d.Run(ctx)
+
+	require.NoError(t, d.Publish(ctx, newEvent(17, tripevents.TripStartedType)))
+
+	select {
+	case res := <-store.results:
+		assert.Equal(t, result{"a", false, 2}, res)
+	case <-time.After(5 * time.Second):
+		t.Fatal("delivery not recorded")
+	}
+	assert.EqualValues(t, 3, calls.Load())
+	assert.Zero(t, store.pending())
+}
+
+func TestDeliveryAfterRestart(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		calls.Add(1)
+	}))
+	defer srv.Close()
+
+	store := &fakeStore{
+		webhooks: models.WebhookSlice{{ID: "a", VehicleTokenID: 17, ClientID: null.StringFrom("0xowner"), URL: srv.URL, EventTypes: []string{tripevents.TripStartedType}}},
+		results:  make(chan result, 1),
+	}
+	grants := &fakeGrants{allowed: []string{"0xowner"}}
+	config := Config{PollInterval: time.Millisecond, AllowPrivateNetworks: true}
+
+	// Published while no dispatcher was running.
+	require.NoError(t, New(store, grants, config, &zerolog.Logger{}).Publish(context.Background(), newEvent(17, tripevents.TripStartedType)))
+	assert.Equal(t, 1, store.pending())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go New(store, grants, config, &zerolog.Logger{}).Run(ctx)
+
+	select {
+	case res := <-store.results:
+		assert.Equal(t, result{"a", true, defaultDisableAfter}, res)
+	case <-time.After(5 * time.Second):
+		t.Fatal("delivery not recorded")
+	}
+	assert.EqualValues(t, 1, calls.Load())
+	assert.Zero(t, store.pending())
+}
+
+func TestDeliveryAfterRevoke(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		calls.Add(1)
+	}))
+	defer srv.Close()
+
+	store := &fakeStore{
+		webhooks: models.WebhookSlice{
+			{ID: "a", VehicleTokenID: 17, ClientID: null.StringFrom("0xapp"), URL: srv.URL, EventTypes: []string{tripevents.TripStartedType}},
+		},
+		results:  make(chan result, 1),
+		disabled: make(chan string, 1),
+	}
+	d := New(store, &fakeGrants{allowed: []string{"0xowner"}}, Config{PollInterval: time.Millisecond, RetryBackoff: time.Millisecond, AllowPrivateNetworks: true}, &zerolog.Logger{})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go d.Run(ctx)
+
+	require.NoError(t, d.Publish(ctx, newEvent(17, tripevents.TripStartedType)))
+
+	select {
+	case id := <-store.disabled:
+		assert.Equal(t, "a", id)
+	case <-time.After(5 * time.Second):
+		t.Fatal("webhook not disabled")
+	}
+	assert.Zero(t, calls.Load())
+	assert.Empty(t, store.results)
+	assert.Zero(t, store.pending())
+	assert.Empty(t, store.recorded())
+}
+
+func TestDeliveryToPrivateAddress(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		calls.Add(1)
+	}))
+	defer srv.Close()
+
+	d := New(&fakeStore{}, &fakeGrants{}, Config{}, &zerolog.Logger{})
+
+	err := d.post(context.Background(), &models.Webhook{ID: "a", URL: srv.URL}, []byte("{}"))
+	assert.ErrorIs(t, err, ErrPrivateAddress)
+	assert.Zero(t, calls.Load())
+}
+
+func TestIsPublic(t *testing.T) {
+	for addr, public := range map[string]bool{
+		"93.184.215.14":        true,
+		"2606:2800:21f:cb07::": true,
+		"127.0.0.1":            false,
+		"::1":                  false,
+		"10.1.2.3":             false,
+		"172.16.0.1":           false,
+		"192.168.1.1":          false,
+		"169.254.169.254":      false,
+		"fe80::1":              false,
+		"fd00::1":              false,
+		"0.0.0.0":              false,
+		"::":                   false,
+		"100.64.0.1":           false,
+		"::ffff:10.0.0.1":      false,
+		"224.0.0.1":            false,
+	} {
+		assert.Equal(t, public, IsPublic(netip.MustParseAddr(addr)), addr)
+	}
+}
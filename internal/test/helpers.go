@@ -8,11 +8,14 @@ import (
 
 	"github.com/DIMO-Network/shared/db"
 	"github.com/DIMO-Network/trips-api/internal/config"
+	"github.com/DIMO-Network/trips-api/models"
 	"github.com/docker/go-connections/nat"
 	"github.com/pkg/errors"
 	"github.com/pressly/goose/v3"
+	"github.com/segmentio/ksuid"
 	"github.com/testcontainers/testcontainers-go"
 	"github.com/testcontainers/testcontainers-go/wait"
+	"github.com/volatiletech/sqlboiler/v4/boil"
 )
 
 const testDbName = "trips_api"
@@ -90,6 +93,17 @@ $$ LANGUAGE plpgsql;
 	return pdb
 }
 
+// CreateVehicles registers vehicles with the given token ids, so that trips can be inserted
+// for them.
+func CreateVehicles(ctx context.Context, t *testing.T, pdb db.Store, tokenIDs ...int) {
+	for _, tokenID := range tokenIDs {
+		v := models.Vehicle{TokenID: tokenID, UserDeviceID: ksuid.New().String()}
+		if err := v.Insert(ctx, pdb.DBS().Writer, boil.Infer()); err != nil {
+			t.Fatalf("create vehicle error: %s", err.Error())
+		}
+	}
+}
+
 func handleContainerStartErr(ctx context.Context, err error, t *testing.T) db.Store {
 	if err != nil {
 		t.Fatalf("start container error: %s", err.Error())
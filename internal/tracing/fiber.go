@@ -0,0 +1,63 @@
+package tracing
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// headerCarrier adapts fiber request headers for propagation.
+type headerCarrier struct {
+	c *fiber.Ctx
+}
+
+func (h headerCarrier) Get(key string) string { return h.c.Get(key) }
+func (h headerCarrier) Set(key, value string) { h.c.Request().Header.Set(key, value) }
+func (h headerCarrier) Keys() []string {
+	var keys []string
+	h.c.Request().Header.VisitAll(func(k, _ []byte) {
+		keys = append(keys, string(k))
+	})
+	return keys
+}
+
+// Middleware starts a server span for each request, continuing any trace passed in the
+// request headers. Handlers should use c.UserContext() so that their work joins the span.
+func Middleware(c *fiber.Ctx) error {
+	ctx := otel.GetTextMapPropagator().Extract(c.UserContext(), headerCarrier{c})
+	ctx, span := Tracer().Start(ctx, "HTTP "+c.Method(), trace.WithSpanKind(trace.SpanKindServer))
+	defer span.End()
+
+	c.SetUserContext(ctx)
+	err := c.Next()
+
+	status := c.Response().StatusCode()
+	if fe, ok := err.(*fiber.Error); ok {
+		status = fe.Code
+	} else if err != nil {
+		status = fiber.StatusInternalServerError
+	}
+
+	route := c.Route().Path
+	span.SetName(c.Method() + " " + route)
+	span.SetAttributes(
+		attribute.String(string(semconv.HTTPRequestMethodKey), c.Method()),
+		semconv.HTTPRoute(route),
+		semconv.HTTPResponseStatusCode(status),
+	)
+	if status >= fiber.StatusInternalServerError {
+		span.SetStatus(codes.Error, fiberErrorMessage(err))
+	}
+
+	return err
+}
+
+func fiberErrorMessage(err error) string {
+	if err == nil {
+		return ""
+	}
+	return err.Error()
+}
@@ -0,0 +1,86 @@
+// Package tracing configures OpenTelemetry and provides the helpers used to start spans across
+// the consumers, stores and API.
+package tracing
+
+import (
+	"context"
+
+	"github.com/DIMO-Network/trips-api/internal/config"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const instrumentationName = "github.com/DIMO-Network/trips-api"
+
+// Tracer returns the service's tracer. Spans started before Setup runs, or when tracing is
+// disabled, are no-ops.
+func Tracer() trace.Tracer {
+	return otel.Tracer(instrumentationName)
+}
+
+// Setup installs the global propagator and, if tracing is enabled, a tracer provider that
+// exports over OTLP/HTTP. The returned function flushes and stops the exporter.
+func Setup(ctx context.Context, settings *config.Settings) (func(context.Context) error, error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	if !settings.TracingEnabled {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	var opts []otlptracehttp.Option
+	if settings.OTLPEndpoint != "" {
+		opts = append(opts, otlptracehttp.WithEndpoint(settings.OTLPEndpoint))
+	}
+	if settings.OTLPInsecure {
+		opts = append(opts, otlptracehttp.WithInsecure())
+	}
+
+	exporter, err := otlptracehttp.New(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(resource.NewWithAttributes(semconv.SchemaURL, semconv.ServiceName("trips-api"))),
+	)
+	otel.SetTracerProvider(tp)
+
+	return tp.Shutdown, nil
+}
+
+// ExtractCloudEvent returns a context carrying the remote span context described by a
+// CloudEvent's distributed tracing extension attributes, if any.
+func ExtractCloudEvent(ctx context.Context, traceparent, tracestate string) context.Context {
+	if traceparent == "" {
+		return ctx
+	}
+	carrier := propagation.MapCarrier{"traceparent": traceparent}
+	if tracestate != "" {
+		carrier["tracestate"] = tracestate
+	}
+	return otel.GetTextMapPropagator().Extract(ctx, carrier)
+}
+
+// StartPostgres starts a client span around a Postgres operation.
+func StartPostgres(ctx context.Context, operation string) (context.Context, trace.Span) {
+	return Tracer().Start(ctx, "postgres "+operation,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(semconv.DBSystemPostgreSQL),
+	)
+}
+
+// End records err on the span, if there is one, and ends it.
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
@@ -0,0 +1,66 @@
+package tracing
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	traceparent = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+	traceID     = "4bf92f3577b34da6a3ce929d0e0e4736"
+)
+
+func setupRecorder(t *testing.T) *tracetest.SpanRecorder {
+	rec := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(rec))
+	otel.SetTracerProvider(tp)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	t.Cleanup(func() { _ = tp.Shutdown(context.Background()) })
+	return rec
+}
+
+func TestExtractCloudEvent(t *testing.T) {
+	setupRecorder(t)
+
+	sc := trace.SpanContextFromContext(ExtractCloudEvent(context.Background(), traceparent, ""))
+	assert.True(t, sc.IsRemote())
+	assert.Equal(t, traceID, sc.TraceID().String())
+
+	sc = trace.SpanContextFromContext(ExtractCloudEvent(context.Background(), "", ""))
+	assert.False(t, sc.IsValid())
+}
+
+func TestMiddlewareNamesSpanByRoute(t *testing.T) {
+	rec := setupRecorder(t)
+
+	app := fiber.New()
+	app.Use(Middleware)
+	app.Get("/v1/vehicle/:tokenID/trips", func(c *fiber.Ctx) error {
+		_, span := Tracer().Start(c.UserContext(), "child")
+		span.End()
+		return c.SendString("ok")
+	})
+
+	req := httptest.NewRequest("GET", "/v1/vehicle/1/trips", nil)
+	req.Header.Set("traceparent", traceparent)
+	_, err := app.Test(req)
+	require.NoError(t, err)
+
+	spans := rec.Ended()
+	require.Len(t, spans, 2)
+
+	child, server := spans[0], spans[1]
+	assert.Equal(t, "GET /v1/vehicle/:tokenID/trips", server.Name())
+	assert.Equal(t, traceID, server.SpanContext().TraceID().String())
+	assert.Equal(t, server.SpanContext().SpanID(), child.Parent().SpanID())
+}
@@ -0,0 +1,26 @@
+-- +goose Up
+-- +goose StatementBegin
+SET search_path = trips_api, public;
+
+ALTER TABLE vehicles
+    ADD COLUMN deleted_at timestamptz;
+
+-- A user device may be re-paired to a new token, so only active mappings need be unique.
+ALTER TABLE vehicles
+    DROP CONSTRAINT vehicles_user_device_id_key;
+
+CREATE UNIQUE INDEX vehicles_user_device_id_key ON vehicles (user_device_id) WHERE deleted_at IS NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SET search_path = trips_api, public;
+
+DROP INDEX vehicles_user_device_id_key;
+
+ALTER TABLE vehicles
+    ADD CONSTRAINT vehicles_user_device_id_key UNIQUE (user_device_id);
+
+ALTER TABLE vehicles
+    DROP COLUMN deleted_at;
+-- +goose StatementEnd
@@ -0,0 +1,26 @@
+-- +goose Up
+-- +goose StatementBegin
+SET search_path = trips_api, public;
+
+CREATE TABLE vehicle_mappings (
+    id bigserial CONSTRAINT vehicle_mappings_pkey PRIMARY KEY,
+    user_device_id varchar NOT NULL,
+    token_id int NOT NULL CONSTRAINT vehicle_mappings_token_id_fkey REFERENCES vehicles (token_id),
+    valid_from timestamptz NOT NULL,
+    valid_to timestamptz,
+    CONSTRAINT vehicle_mappings_valid_range_check CHECK (valid_to IS NULL OR valid_to >= valid_from)
+);
+
+CREATE INDEX vehicle_mappings_user_device_id_valid_from_idx ON vehicle_mappings (user_device_id, valid_from);
+
+-- We don't know when existing mappings began, so treat them as valid from the epoch.
+INSERT INTO vehicle_mappings (user_device_id, token_id, valid_from, valid_to)
+    SELECT user_device_id, token_id, TIMESTAMPTZ 'epoch', deleted_at FROM vehicles;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SET search_path = trips_api, public;
+
+DROP TABLE vehicle_mappings;
+-- +goose StatementEnd
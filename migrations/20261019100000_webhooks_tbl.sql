@@ -0,0 +1,24 @@
+-- +goose Up
+-- +goose StatementBegin
+SET search_path = trips_api, public;
+
+CREATE TABLE webhooks (
+    id varchar CONSTRAINT webhooks_pkey PRIMARY KEY,
+    vehicle_token_id int NOT NULL,
+    url text NOT NULL,
+    event_types text[] NOT NULL,
+    secret text NOT NULL,
+    failure_count int NOT NULL DEFAULT 0,
+    disabled_at timestamptz,
+    created_at timestamptz NOT NULL DEFAULT now()
+);
+
+CREATE INDEX webhooks_vehicle_token_id_idx ON webhooks (vehicle_token_id) WHERE disabled_at IS NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SET search_path = trips_api, public;
+
+DROP TABLE webhooks;
+-- +goose StatementEnd
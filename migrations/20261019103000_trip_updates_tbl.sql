@@ -0,0 +1,41 @@
+-- +goose Up
+-- +goose StatementBegin
+SET search_path = trips_api, public;
+
+CREATE TABLE trip_updates (
+    id bigserial CONSTRAINT trip_updates_pkey PRIMARY KEY,
+    trip_id text NOT NULL CONSTRAINT trip_updates_trip_id_fkey REFERENCES trips (id) ON DELETE CASCADE,
+    vehicle_token_id int NOT NULL,
+    kind varchar NOT NULL CONSTRAINT trip_updates_kind_check CHECK (kind IN ('begin', 'complete')),
+    created_at timestamptz NOT NULL DEFAULT now()
+);
+
+CREATE INDEX trip_updates_vehicle_token_id_id_idx ON trip_updates (vehicle_token_id, id);
+
+-- Record trips beginning and completing, and wake any API replica streaming updates for the
+-- vehicle. The notification is only sent if the transaction commits.
+CREATE FUNCTION trip_updates_notify() RETURNS trigger AS $$
+BEGIN
+    IF TG_OP = 'INSERT' THEN
+        INSERT INTO trips_api.trip_updates (trip_id, vehicle_token_id, kind) VALUES (NEW.id, NEW.vehicle_token_id, 'begin');
+    END IF;
+    IF NEW.end_time IS NOT NULL AND (TG_OP = 'INSERT' OR OLD.end_time IS NULL) THEN
+        INSERT INTO trips_api.trip_updates (trip_id, vehicle_token_id, kind) VALUES (NEW.id, NEW.vehicle_token_id, 'complete');
+    END IF;
+    PERFORM pg_notify('trip_updates', NEW.vehicle_token_id::text);
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trips_updates_notify AFTER INSERT OR UPDATE OF end_time ON trips
+    FOR EACH ROW EXECUTE FUNCTION trip_updates_notify();
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SET search_path = trips_api, public;
+
+DROP TRIGGER trips_updates_notify ON trips;
+DROP FUNCTION trip_updates_notify();
+DROP TABLE trip_updates;
+-- +goose StatementEnd
@@ -0,0 +1,27 @@
+-- +goose Up
+-- +goose StatementBegin
+SET search_path = trips_api, public;
+
+ALTER TABLE trips ADD COLUMN distance_km double precision;
+
+-- Without telemetry, the best we can do for existing trips is the great-circle distance
+-- between the start (or its estimate) and the end.
+WITH endpoints AS (
+    SELECT id, COALESCE(start_position, start_position_estimate) AS s, end_position AS e
+    FROM trips
+    WHERE end_time IS NOT NULL
+)
+UPDATE trips SET distance_km = 2 * 6371 * asin(sqrt(
+        sin(radians(endpoints.e[1] - endpoints.s[1]) / 2) ^ 2
+        + cos(radians(endpoints.s[1])) * cos(radians(endpoints.e[1])) * sin(radians(endpoints.e[0] - endpoints.s[0]) / 2) ^ 2
+    ))
+    FROM endpoints
+    WHERE trips.id = endpoints.id AND endpoints.s IS NOT NULL AND endpoints.e IS NOT NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SET search_path = trips_api, public;
+
+ALTER TABLE trips DROP COLUMN distance_km;
+-- +goose StatementEnd
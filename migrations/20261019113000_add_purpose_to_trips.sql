@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+SET search_path = trips_api, public;
+
+ALTER TABLE trips ADD COLUMN purpose varchar CONSTRAINT trips_purpose_check CHECK (purpose IN ('business', 'personal', 'commute'));
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SET search_path = trips_api, public;
+
+ALTER TABLE trips DROP COLUMN purpose;
+-- +goose StatementEnd
@@ -0,0 +1,42 @@
+-- +goose Up
+-- +goose StatementBegin
+SET search_path = trips_api, public;
+
+ALTER TABLE trips DROP CONSTRAINT trips_purpose_check;
+ALTER TABLE trips ADD CONSTRAINT trips_purpose_check CHECK (purpose IN ('business', 'personal', 'commute', 'custom'));
+
+-- A custom purpose is named by the user, and only custom purposes have a name.
+ALTER TABLE trips ADD COLUMN custom_purpose varchar(64);
+ALTER TABLE trips ADD CONSTRAINT trips_custom_purpose_check CHECK (COALESCE(purpose = 'custom', false) = (custom_purpose IS NOT NULL));
+
+ALTER TABLE trips ADD COLUMN note text;
+
+CREATE TABLE trip_annotation_changes (
+    id bigserial CONSTRAINT trip_annotation_changes_pkey PRIMARY KEY,
+    trip_id text NOT NULL CONSTRAINT trip_annotation_changes_trip_id_fkey REFERENCES trips (id) ON DELETE CASCADE,
+    vehicle_token_id int NOT NULL,
+    subject text NOT NULL,
+    client_id text,
+    field varchar NOT NULL CONSTRAINT trip_annotation_changes_field_check CHECK (field IN ('purpose', 'custom_purpose', 'note')),
+    old_value text,
+    new_value text,
+    created_at timestamptz NOT NULL DEFAULT now()
+);
+
+CREATE INDEX trip_annotation_changes_trip_id_id_idx ON trip_annotation_changes (trip_id, id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SET search_path = trips_api, public;
+
+DROP TABLE trip_annotation_changes;
+
+UPDATE trips SET purpose = NULL WHERE purpose = 'custom';
+
+ALTER TABLE trips DROP COLUMN note;
+ALTER TABLE trips DROP COLUMN custom_purpose;
+
+ALTER TABLE trips DROP CONSTRAINT trips_purpose_check;
+ALTER TABLE trips ADD CONSTRAINT trips_purpose_check CHECK (purpose IN ('business', 'personal', 'commute'));
+-- +goose StatementEnd
@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+SET search_path = trips_api, public;
+
+-- The upstream segments that make up each trip. Trips start out as a single segment with the
+-- same id, but merging and splitting trips breaks that correspondence.
+ALTER TABLE trips ADD COLUMN segment_ids text[] NOT NULL DEFAULT '{}';
+
+UPDATE trips SET segment_ids = ARRAY[id];
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SET search_path = trips_api, public;
+
+ALTER TABLE trips DROP COLUMN segment_ids;
+-- +goose StatementEnd
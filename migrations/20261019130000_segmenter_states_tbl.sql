@@ -0,0 +1,18 @@
+-- +goose Up
+-- +goose StatementBegin
+SET search_path = trips_api, public;
+
+-- Where the server-side segmenter left off for each device it watches.
+CREATE TABLE segmenter_states (
+    user_device_id varchar CONSTRAINT segmenter_states_pkey PRIMARY KEY,
+    state jsonb NOT NULL,
+    updated_at timestamptz NOT NULL DEFAULT now()
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SET search_path = trips_api, public;
+
+DROP TABLE segmenter_states;
+-- +goose StatementEnd
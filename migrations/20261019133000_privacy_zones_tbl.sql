@@ -0,0 +1,23 @@
+-- +goose Up
+-- +goose StatementBegin
+SET search_path = trips_api, public;
+
+CREATE TABLE privacy_zones (
+    id varchar CONSTRAINT privacy_zones_pkey PRIMARY KEY,
+    vehicle_token_id int NOT NULL,
+    name varchar(64) NOT NULL,
+    center point NOT NULL,
+    radius_m int NOT NULL CONSTRAINT privacy_zones_radius_m_check CHECK (radius_m BETWEEN 50 AND 5000),
+    mask varchar NOT NULL DEFAULT 'snap' CONSTRAINT privacy_zones_mask_check CHECK (mask IN ('snap', 'omit')),
+    created_at timestamptz NOT NULL DEFAULT now()
+);
+
+CREATE INDEX privacy_zones_vehicle_token_id_idx ON privacy_zones (vehicle_token_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SET search_path = trips_api, public;
+
+DROP TABLE privacy_zones;
+-- +goose StatementEnd
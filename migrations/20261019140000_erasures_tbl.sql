@@ -0,0 +1,27 @@
+-- +goose Up
+-- +goose StatementBegin
+SET search_path = trips_api, public;
+
+-- Receipts for deleted trip history. A missing range_start means from the first trip.
+CREATE TABLE erasures (
+    id varchar CONSTRAINT erasures_pkey PRIMARY KEY,
+    vehicle_token_id int NOT NULL,
+    range_start timestamptz,
+    range_end timestamptz NOT NULL,
+    subject text NOT NULL,
+    client_id text,
+    trip_count int NOT NULL,
+    archive_ids text[] NOT NULL DEFAULT '{}',
+    created_at timestamptz NOT NULL DEFAULT now(),
+    CONSTRAINT erasures_range_check CHECK (range_start < range_end)
+);
+
+CREATE INDEX erasures_vehicle_token_id_range_end_idx ON erasures (vehicle_token_id, range_end);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SET search_path = trips_api, public;
+
+DROP TABLE erasures;
+-- +goose StatementEnd
@@ -0,0 +1,26 @@
+-- +goose Up
+-- +goose StatementBegin
+SET search_path = trips_api, public;
+
+-- Monthly totals of trips deleted by retention, for vehicles whose history is kept only in
+-- aggregate.
+CREATE TABLE trip_aggregates (
+    vehicle_token_id int NOT NULL,
+    month date NOT NULL,
+    trip_count int NOT NULL,
+    duration_seconds float8 NOT NULL,
+    distance_km float8 NOT NULL,
+    updated_at timestamptz NOT NULL DEFAULT now(),
+    CONSTRAINT trip_aggregates_pkey PRIMARY KEY (vehicle_token_id, month)
+);
+
+CREATE INDEX trips_start_time_idx ON trips (start_time);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SET search_path = trips_api, public;
+
+DROP INDEX trips_start_time_idx;
+DROP TABLE trip_aggregates;
+-- +goose StatementEnd
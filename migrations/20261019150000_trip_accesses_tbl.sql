@@ -0,0 +1,36 @@
+-- +goose Up
+-- +goose StatementBegin
+SET search_path = trips_api, public;
+
+-- Audit log of reads of trip data. The range is that of the trips returned, where known.
+CREATE TABLE trip_accesses (
+    id bigserial CONSTRAINT trip_accesses_pkey PRIMARY KEY,
+    vehicle_token_id int NOT NULL,
+    subject text NOT NULL,
+    client_id text,
+    endpoint varchar NOT NULL,
+    range_start timestamptz,
+    range_end timestamptz,
+    trip_count int,
+    created_at timestamptz NOT NULL DEFAULT now()
+);
+
+CREATE INDEX trip_accesses_vehicle_token_id_idx ON trip_accesses (vehicle_token_id, created_at);
+
+CREATE FUNCTION trip_accesses_append_only() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'trip_accesses is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trip_accesses_append_only BEFORE UPDATE OR DELETE OR TRUNCATE ON trip_accesses
+    FOR EACH STATEMENT EXECUTE FUNCTION trip_accesses_append_only();
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SET search_path = trips_api, public;
+
+DROP TABLE trip_accesses;
+DROP FUNCTION trip_accesses_append_only();
+-- +goose StatementEnd
@@ -0,0 +1,14 @@
+-- +goose Up
+-- +goose StatementBegin
+SET search_path = trips_api, public;
+
+-- SHA-256 of the status data archived for the trip, before compression and encryption.
+ALTER TABLE trips ADD COLUMN data_sha256 bytea;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SET search_path = trips_api, public;
+
+ALTER TABLE trips DROP COLUMN data_sha256;
+-- +goose StatementEnd
@@ -0,0 +1,14 @@
+-- +goose Up
+-- +goose StatementBegin
+SET search_path = trips_api, public;
+
+-- SHA-256 of the encrypted archive uploaded to Bundlr.
+ALTER TABLE trips ADD COLUMN ciphertext_sha256 bytea;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SET search_path = trips_api, public;
+
+ALTER TABLE trips DROP COLUMN ciphertext_sha256;
+-- +goose StatementEnd
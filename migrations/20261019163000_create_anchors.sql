@@ -0,0 +1,38 @@
+-- +goose Up
+-- +goose StatementBegin
+SET search_path = trips_api, public;
+
+-- Merkle roots of batches of completed trips, and where they were published. A missing
+-- published_at means publishing hasn't succeeded yet.
+CREATE TABLE anchors (
+    id varchar CONSTRAINT anchors_pkey PRIMARY KEY,
+    root bytea NOT NULL,
+    leaf_count int NOT NULL,
+    publisher varchar NOT NULL,
+    reference text,
+    created_at timestamptz NOT NULL DEFAULT now(),
+    published_at timestamptz
+);
+
+CREATE INDEX anchors_unpublished_idx ON anchors (created_at) WHERE published_at IS NULL;
+
+-- The leaves of each anchor's tree, in order. They aren't tied to trips, so that proofs for the
+-- rest of a batch survive trips being edited or deleted.
+CREATE TABLE anchor_entries (
+    anchor_id varchar NOT NULL CONSTRAINT anchor_entries_anchor_id_fkey REFERENCES anchors (id) ON DELETE CASCADE,
+    leaf_index int NOT NULL,
+    trip_id text NOT NULL,
+    data_sha256 bytea NOT NULL,
+    CONSTRAINT anchor_entries_pkey PRIMARY KEY (anchor_id, leaf_index)
+);
+
+CREATE INDEX anchor_entries_trip_id_idx ON anchor_entries (trip_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SET search_path = trips_api, public;
+
+DROP TABLE anchor_entries;
+DROP TABLE anchors;
+-- +goose StatementEnd
@@ -0,0 +1,14 @@
+-- +goose Up
+-- +goose StatementBegin
+SET search_path = trips_api, public;
+
+-- How the archived data was compressed: zip, gzip or zstd. Earlier archives are zipped.
+ALTER TABLE trips ADD COLUMN compression varchar;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SET search_path = trips_api, public;
+
+ALTER TABLE trips DROP COLUMN compression;
+-- +goose StatementEnd
@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+SET search_path = trips_api, public;
+
+-- Who registered the webhook: the privilege token's subject and the client it was issued to.
+-- Earlier webhooks can't be attributed, so they're disabled.
+ALTER TABLE webhooks ADD COLUMN subject text NOT NULL DEFAULT '';
+ALTER TABLE webhooks ADD COLUMN client_id text;
+UPDATE webhooks SET disabled_at = now() WHERE subject = '' AND disabled_at IS NULL;
+ALTER TABLE webhooks ALTER COLUMN subject DROP DEFAULT;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SET search_path = trips_api, public;
+
+ALTER TABLE webhooks DROP COLUMN client_id;
+ALTER TABLE webhooks DROP COLUMN subject;
+-- +goose StatementEnd
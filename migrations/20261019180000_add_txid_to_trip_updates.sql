@@ -0,0 +1,22 @@
+-- +goose Up
+-- +goose StatementBegin
+SET search_path = trips_api, public;
+
+-- Ids are taken when rows are inserted, not when they commit, so a stream resuming after an
+-- id could skip an update from a transaction still in flight. Streams read updates in the
+-- order of the transactions that wrote them instead, once those transactions have finished.
+ALTER TABLE trip_updates ADD COLUMN txid bigint NOT NULL DEFAULT pg_current_xact_id()::text::bigint;
+
+DROP INDEX trip_updates_vehicle_token_id_id_idx;
+CREATE INDEX trip_updates_vehicle_token_id_txid_id_idx ON trip_updates (vehicle_token_id, txid, id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SET search_path = trips_api, public;
+
+DROP INDEX trip_updates_vehicle_token_id_txid_id_idx;
+CREATE INDEX trip_updates_vehicle_token_id_id_idx ON trip_updates (vehicle_token_id, id);
+
+ALTER TABLE trip_updates DROP COLUMN txid;
+-- +goose StatementEnd
@@ -0,0 +1,11 @@
+-- +goose Up
+-- +goose StatementBegin
+SET search_path = trips_api, public;
+
+-- Deliveries are authorized by the registering client's grants, so webhooks registered
+-- without a client id can never be delivered.
+UPDATE webhooks SET disabled_at = now() WHERE client_id IS NULL AND disabled_at IS NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+SELECT 1;
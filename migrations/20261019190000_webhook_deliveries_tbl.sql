@@ -0,0 +1,27 @@
+-- +goose Up
+-- +goose StatementBegin
+SET search_path = trips_api, public;
+
+-- Deliveries waiting to be made, so that they survive restarts and can be retried by any
+-- replica. A row is claimed by pushing next_attempt_at past the attempt, and deleted once
+-- the event is delivered or out of attempts.
+CREATE TABLE webhook_deliveries (
+    id bigserial CONSTRAINT webhook_deliveries_pkey PRIMARY KEY,
+    webhook_id varchar NOT NULL CONSTRAINT webhook_deliveries_webhook_id_fkey REFERENCES webhooks (id) ON DELETE CASCADE,
+    event_id text NOT NULL,
+    body bytea NOT NULL,
+    attempts int NOT NULL DEFAULT 0,
+    next_attempt_at timestamptz NOT NULL DEFAULT now(),
+    created_at timestamptz NOT NULL DEFAULT now()
+);
+
+CREATE INDEX webhook_deliveries_next_attempt_at_idx ON webhook_deliveries (next_attempt_at);
+CREATE INDEX webhook_deliveries_webhook_id_idx ON webhook_deliveries (webhook_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SET search_path = trips_api, public;
+
+DROP TABLE webhook_deliveries;
+-- +goose StatementEnd
@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+SET search_path = trips_api, public;
+
+-- The privilege token's subject is the vehicle, so also record the ethereum_address of the
+-- user the token was issued to. Earlier rows can't be attributed and are left null.
+ALTER TABLE trip_annotation_changes ADD COLUMN user_address text;
+ALTER TABLE erasures ADD COLUMN user_address text;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SET search_path = trips_api, public;
+
+ALTER TABLE erasures DROP COLUMN user_address;
+ALTER TABLE trip_annotation_changes DROP COLUMN user_address;
+-- +goose StatementEnd
@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+SET search_path = trips_api, public;
+
+-- Erasures up to now have no fixed range to recognize a retry by, so clients send a key
+-- instead, unique per vehicle.
+ALTER TABLE erasures ADD COLUMN idempotency_key text;
+CREATE UNIQUE INDEX erasures_vehicle_token_id_idempotency_key_idx ON erasures (vehicle_token_id, idempotency_key) WHERE idempotency_key IS NOT NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+SET search_path = trips_api, public;
+
+DROP INDEX erasures_vehicle_token_id_idempotency_key_idx;
+ALTER TABLE erasures DROP COLUMN idempotency_key;
+-- +goose StatementEnd
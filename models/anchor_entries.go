@@ -0,0 +1,1190 @@
+// Code generated by SQLBoiler 4.16.2 (https://github.com/volatiletech/sqlboiler). DO NOT EDIT.
+// This file is meant to be re-generated in place and/or deleted at any time.
+
+package models
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/friendsofgo/errors"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"github.com/volatiletech/sqlboiler/v4/queries/qmhelper"
+	"github.com/volatiletech/strmangle"
+)
+
+// AnchorEntry is an object representing the database table.
+type AnchorEntry struct {
+	AnchorID   string `boil:"anchor_id" json:"anchor_id" toml:"anchor_id" yaml:"anchor_id"`
+	LeafIndex  int    `boil:"leaf_index" json:"leaf_index" toml:"leaf_index" yaml:"leaf_index"`
+	TripID     string `boil:"trip_id" json:"trip_id" toml:"trip_id" yaml:"trip_id"`
+	DataSha256 []byte `boil:"data_sha256" json:"data_sha256" toml:"data_sha256" yaml:"data_sha256"`
+
+	R *anchorEntryR `boil:"-" json:"-" toml:"-" yaml:"-"`
+	L anchorEntryL  `boil:"-" json:"-" toml:"-" yaml:"-"`
+}
+
+var AnchorEntryColumns = struct {
+	AnchorID   string
+	LeafIndex  string
+	TripID     string
+	DataSha256 string
+}{
+	AnchorID:   "anchor_id",
+	LeafIndex:  "leaf_index",
+	TripID:     "trip_id",
+	DataSha256: "data_sha256",
+}
+
+var AnchorEntryTableColumns = struct {
+	AnchorID   string
+	LeafIndex  string
+	TripID     string
+	DataSha256 string
+}{
+	AnchorID:   "anchor_entries.anchor_id",
+	LeafIndex:  "anchor_entries.leaf_index",
+	TripID:     "anchor_entries.trip_id",
+	DataSha256: "anchor_entries.data_sha256",
+}
+
+// Generated where
+
+type whereHelperstring struct{ field string }
+
+func (w whereHelperstring) EQ(x string) qm.QueryMod     { return qmhelper.Where(w.field, qmhelper.EQ, x) }
+func (w whereHelperstring) NEQ(x string) qm.QueryMod    { return qmhelper.Where(w.field, qmhelper.NEQ, x) }
+func (w whereHelperstring) LT(x string) qm.QueryMod     { return qmhelper.Where(w.field, qmhelper.LT, x) }
+func (w whereHelperstring) LTE(x string) qm.QueryMod    { return qmhelper.Where(w.field, qmhelper.LTE, x) }
+func (w whereHelperstring) GT(x string) qm.QueryMod     { return qmhelper.Where(w.field, qmhelper.GT, x) }
+func (w whereHelperstring) GTE(x string) qm.QueryMod    { return qmhelper.Where(w.field, qmhelper.GTE, x) }
+func (w whereHelperstring) LIKE(x string) qm.QueryMod   { return qm.Where(w.field+" LIKE ?", x) }
+func (w whereHelperstring) NLIKE(x string) qm.QueryMod  { return qm.Where(w.field+" NOT LIKE ?", x) }
+func (w whereHelperstring) ILIKE(x string) qm.QueryMod  { return qm.Where(w.field+" ILIKE ?", x) }
+func (w whereHelperstring) NILIKE(x string) qm.QueryMod { return qm.Where(w.field+" NOT ILIKE ?", x) }
+func (w whereHelperstring) IN(slice []string) qm.QueryMod {
+	values := make([]interface{}, 0, len(slice))
+	for _, value := range slice {
+		values = append(values, value)
+	}
+	return qm.WhereIn(fmt.Sprintf("%s IN ?", w.field), values...)
+}
+func (w whereHelperstring) NIN(slice []string) qm.QueryMod {
+	values := make([]interface{}, 0, len(slice))
+	for _, value := range slice {
+		values = append(values, value)
+	}
+	return qm.WhereNotIn(fmt.Sprintf("%s NOT IN ?", w.field), values...)
+}
+
+type whereHelperint struct{ field string }
+
+func (w whereHelperint) EQ(x int) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.EQ, x) }
+func (w whereHelperint) NEQ(x int) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.NEQ, x) }
+func (w whereHelperint) LT(x int) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.LT, x) }
+func (w whereHelperint) LTE(x int) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.LTE, x) }
+func (w whereHelperint) GT(x int) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.GT, x) }
+func (w whereHelperint) GTE(x int) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.GTE, x) }
+func (w whereHelperint) IN(slice []int) qm.QueryMod {
+	values := make([]interface{}, 0, len(slice))
+	for _, value := range slice {
+		values = append(values, value)
+	}
+	return qm.WhereIn(fmt.Sprintf("%s IN ?", w.field), values...)
+}
+func (w whereHelperint) NIN(slice []int) qm.QueryMod {
+	values := make([]interface{}, 0, len(slice))
+	for _, value := range slice {
+		values = append(values, value)
+	}
+	return qm.WhereNotIn(fmt.Sprintf("%s NOT IN ?", w.field), values...)
+}
+
+type whereHelper__byte struct{ field string }
+
+func (w whereHelper__byte) EQ(x []byte) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.EQ, x) }
+func (w whereHelper__byte) NEQ(x []byte) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.NEQ, x) }
+func (w whereHelper__byte) LT(x []byte) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.LT, x) }
+func (w whereHelper__byte) LTE(x []byte) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.LTE, x) }
+func (w whereHelper__byte) GT(x []byte) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.GT, x) }
+func (w whereHelper__byte) GTE(x []byte) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.GTE, x) }
+
+var AnchorEntryWhere = struct {
+	AnchorID   whereHelperstring
+	LeafIndex  whereHelperint
+	TripID     whereHelperstring
+	DataSha256 whereHelper__byte
+}{
+	AnchorID:   whereHelperstring{field: "\"trips_api\".\"anchor_entries\".\"anchor_id\""},
+	LeafIndex:  whereHelperint{field: "\"trips_api\".\"anchor_entries\".\"leaf_index\""},
+	TripID:     whereHelperstring{field: "\"trips_api\".\"anchor_entries\".\"trip_id\""},
+	DataSha256: whereHelper__byte{field: "\"trips_api\".\"anchor_entries\".\"data_sha256\""},
+}
+
+// AnchorEntryRels is where relationship names are stored.
+var AnchorEntryRels = struct {
+	Anchor string
+}{
+	Anchor: "Anchor",
+}
+
+// anchorEntryR is where relationships are stored.
+type anchorEntryR struct {
+	Anchor *Anchor `boil:"Anchor" json:"Anchor" toml:"Anchor" yaml:"Anchor"`
+}
+
+// NewStruct creates a new relationship struct
+func (*anchorEntryR) NewStruct() *anchorEntryR {
+	return &anchorEntryR{}
+}
+
+func (r *anchorEntryR) GetAnchor() *Anchor {
+	if r == nil {
+		return nil
+	}
+	return r.Anchor
+}
+
+// anchorEntryL is where Load methods for each relationship are stored.
+type anchorEntryL struct{}
+
+var (
+	anchorEntryAllColumns            = []string{"anchor_id", "leaf_index", "trip_id", "data_sha256"}
+	anchorEntryColumnsWithoutDefault = []string{"anchor_id", "leaf_index", "trip_id", "data_sha256"}
+	anchorEntryColumnsWithDefault    = []string{}
+	anchorEntryPrimaryKeyColumns     = []string{"anchor_id", "leaf_index"}
+	anchorEntryGeneratedColumns      = []string{}
+)
+
+type (
+	// AnchorEntrySlice is an alias for a slice of pointers to AnchorEntry.
+	// This should almost always be used instead of []AnchorEntry.
+	AnchorEntrySlice []*AnchorEntry
+	// AnchorEntryHook is the signature for custom AnchorEntry hook methods
+	AnchorEntryHook func(context.Context, boil.ContextExecutor, *AnchorEntry) error
+
+	anchorEntryQuery struct {
+		*queries.Query
+	}
+)
+
+// Cache for insert, update and upsert
+var (
+	anchorEntryType                 = reflect.TypeOf(&AnchorEntry{})
+	anchorEntryMapping              = queries.MakeStructMapping(anchorEntryType)
+	anchorEntryPrimaryKeyMapping, _ = queries.BindMapping(anchorEntryType, anchorEntryMapping, anchorEntryPrimaryKeyColumns)
+	anchorEntryInsertCacheMut       sync.RWMutex
+	anchorEntryInsertCache          = make(map[string]insertCache)
+	anchorEntryUpdateCacheMut       sync.RWMutex
+	anchorEntryUpdateCache          = make(map[string]updateCache)
+	anchorEntryUpsertCacheMut       sync.RWMutex
+	anchorEntryUpsertCache          = make(map[string]insertCache)
+)
+
+var (
+	// Force time package dependency for automated UpdatedAt/CreatedAt.
+	_ = time.Second
+	// Force qmhelper dependency for where clause generation (which doesn't
+	// always happen)
+	_ = qmhelper.Where
+)
+
+var anchorEntryAfterSelectMu sync.Mutex
+var anchorEntryAfterSelectHooks []AnchorEntryHook
+
+var anchorEntryBeforeInsertMu sync.Mutex
+var anchorEntryBeforeInsertHooks []AnchorEntryHook
+var anchorEntryAfterInsertMu sync.Mutex
+var anchorEntryAfterInsertHooks []AnchorEntryHook
+
+var anchorEntryBeforeUpdateMu sync.Mutex
+var anchorEntryBeforeUpdateHooks []AnchorEntryHook
+var anchorEntryAfterUpdateMu sync.Mutex
+var anchorEntryAfterUpdateHooks []AnchorEntryHook
+
+var anchorEntryBeforeDeleteMu sync.Mutex
+var anchorEntryBeforeDeleteHooks []AnchorEntryHook
+var anchorEntryAfterDeleteMu sync.Mutex
+var anchorEntryAfterDeleteHooks []AnchorEntryHook
+
+var anchorEntryBeforeUpsertMu sync.Mutex
+var anchorEntryBeforeUpsertHooks []AnchorEntryHook
+var anchorEntryAfterUpsertMu sync.Mutex
+var anchorEntryAfterUpsertHooks []AnchorEntryHook
+
+// doAfterSelectHooks executes all "after Select" hooks.
+func (o *AnchorEntry) doAfterSelectHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range anchorEntryAfterSelectHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeInsertHooks executes all "before insert" hooks.
+func (o *AnchorEntry) doBeforeInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range anchorEntryBeforeInsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterInsertHooks executes all "after Insert" hooks.
+func (o *AnchorEntry) doAfterInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range anchorEntryAfterInsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpdateHooks executes all "before Update" hooks.
+func (o *AnchorEntry) doBeforeUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range anchorEntryBeforeUpdateHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpdateHooks executes all "after Update" hooks.
+func (o *AnchorEntry) doAfterUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range anchorEntryAfterUpdateHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeDeleteHooks executes all "before Delete" hooks.
+func (o *AnchorEntry) doBeforeDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range anchorEntryBeforeDeleteHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterDeleteHooks executes all "after Delete" hooks.
+func (o *AnchorEntry) doAfterDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range anchorEntryAfterDeleteHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpsertHooks executes all "before Upsert" hooks.
+func (o *AnchorEntry) doBeforeUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range anchorEntryBeforeUpsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpsertHooks executes all "after Upsert" hooks.
+func (o *AnchorEntry) doAfterUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range anchorEntryAfterUpsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// AddAnchorEntryHook registers your hook function for all future operations.
+func AddAnchorEntryHook(hookPoint boil.HookPoint, anchorEntryHook AnchorEntryHook) {
+	switch hookPoint {
+	case boil.AfterSelectHook:
+		anchorEntryAfterSelectMu.Lock()
+		anchorEntryAfterSelectHooks = append(anchorEntryAfterSelectHooks, anchorEntryHook)
+		anchorEntryAfterSelectMu.Unlock()
+	case boil.BeforeInsertHook:
+		anchorEntryBeforeInsertMu.Lock()
+		anchorEntryBeforeInsertHooks = append(anchorEntryBeforeInsertHooks, anchorEntryHook)
+		anchorEntryBeforeInsertMu.Unlock()
+	case boil.AfterInsertHook:
+		anchorEntryAfterInsertMu.Lock()
+		anchorEntryAfterInsertHooks = append(anchorEntryAfterInsertHooks, anchorEntryHook)
+		anchorEntryAfterInsertMu.Unlock()
+	case boil.BeforeUpdateHook:
+		anchorEntryBeforeUpdateMu.Lock()
+		anchorEntryBeforeUpdateHooks = append(anchorEntryBeforeUpdateHooks, anchorEntryHook)
+		anchorEntryBeforeUpdateMu.Unlock()
+	case boil.AfterUpdateHook:
+		anchorEntryAfterUpdateMu.Lock()
+		anchorEntryAfterUpdateHooks = append(anchorEntryAfterUpdateHooks, anchorEntryHook)
+		anchorEntryAfterUpdateMu.Unlock()
+	case boil.BeforeDeleteHook:
+		anchorEntryBeforeDeleteMu.Lock()
+		anchorEntryBeforeDeleteHooks = append(anchorEntryBeforeDeleteHooks, anchorEntryHook)
+		anchorEntryBeforeDeleteMu.Unlock()
+	case boil.AfterDeleteHook:
+		anchorEntryAfterDeleteMu.Lock()
+		anchorEntryAfterDeleteHooks = append(anchorEntryAfterDeleteHooks, anchorEntryHook)
+		anchorEntryAfterDeleteMu.Unlock()
+	case boil.BeforeUpsertHook:
+		anchorEntryBeforeUpsertMu.Lock()
+		anchorEntryBeforeUpsertHooks = append(anchorEntryBeforeUpsertHooks, anchorEntryHook)
+		anchorEntryBeforeUpsertMu.Unlock()
+	case boil.AfterUpsertHook:
+		anchorEntryAfterUpsertMu.Lock()
+		anchorEntryAfterUpsertHooks = append(anchorEntryAfterUpsertHooks, anchorEntryHook)
+		anchorEntryAfterUpsertMu.Unlock()
+	}
+}
+
+// One returns a single anchorEntry record from the query.
+func (q anchorEntryQuery) One(ctx context.Context, exec boil.ContextExecutor) (*AnchorEntry, error) {
+	o := &AnchorEntry{}
+
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Bind(ctx, exec, o)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "models: failed to execute a one query for anchor_entries")
+	}
+
+	if err := o.doAfterSelectHooks(ctx, exec); err != nil {
+		return o, err
+	}
+
+	return o, nil
+}
+
+// All returns all AnchorEntry records from the query.
+func (q anchorEntryQuery) All(ctx context.Context, exec boil.ContextExecutor) (AnchorEntrySlice, error) {
+	var o []*AnchorEntry
+
+	err := q.Bind(ctx, exec, &o)
+	if err != nil {
+		return nil, errors.Wrap(err, "models: failed to assign all query results to AnchorEntry slice")
+	}
+
+	if len(anchorEntryAfterSelectHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterSelectHooks(ctx, exec); err != nil {
+				return o, err
+			}
+		}
+	}
+
+	return o, nil
+}
+
+// Count returns the count of all AnchorEntry records in the query.
+func (q anchorEntryQuery) Count(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to count anchor_entries rows")
+	}
+
+	return count, nil
+}
+
+// Exists checks if the row exists in the table.
+func (q anchorEntryQuery) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return false, errors.Wrap(err, "models: failed to check if anchor_entries exists")
+	}
+
+	return count > 0, nil
+}
+
+// Anchor pointed to by the foreign key.
+func (o *AnchorEntry) Anchor(mods ...qm.QueryMod) anchorQuery {
+	queryMods := []qm.QueryMod{
+		qm.Where("\"id\" = ?", o.AnchorID),
+	}
+
+	queryMods = append(queryMods, mods...)
+
+	return Anchors(queryMods...)
+}
+
+// LoadAnchor allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for an N-1 relationship.
+func (anchorEntryL) LoadAnchor(ctx context.Context, e boil.ContextExecutor, singular bool, maybeAnchorEntry interface{}, mods queries.Applicator) error {
+	var slice []*AnchorEntry
+	var object *AnchorEntry
+
+	if singular {
+		var ok bool
+		object, ok = maybeAnchorEntry.(*AnchorEntry)
+		if !ok {
+			object = new(AnchorEntry)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeAnchorEntry)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeAnchorEntry))
+			}
+		}
+	} else {
+		s, ok := maybeAnchorEntry.(*[]*AnchorEntry)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeAnchorEntry)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeAnchorEntry))
+			}
+		}
+	}
+
+	args := make(map[interface{}]struct{})
+	if singular {
+		if object.R == nil {
+			object.R = &anchorEntryR{}
+		}
+		args[object.AnchorID] = struct{}{}
+
+	} else {
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &anchorEntryR{}
+			}
+
+			args[obj.AnchorID] = struct{}{}
+
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	argsSlice := make([]interface{}, len(args))
+	i := 0
+	for arg := range args {
+		argsSlice[i] = arg
+		i++
+	}
+
+	query := NewQuery(
+		qm.From(`trips_api.anchors`),
+		qm.WhereIn(`trips_api.anchors.id in ?`, argsSlice...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load Anchor")
+	}
+
+	var resultSlice []*Anchor
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice Anchor")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results of eager load for anchors")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for anchors")
+	}
+
+	if len(anchorAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+
+	if len(resultSlice) == 0 {
+		return nil
+	}
+
+	if singular {
+		foreign := resultSlice[0]
+		object.R.Anchor = foreign
+		if foreign.R == nil {
+			foreign.R = &anchorR{}
+		}
+		foreign.R.AnchorEntries = append(foreign.R.AnchorEntries, object)
+		return nil
+	}
+
+	for _, local := range slice {
+		for _, foreign := range resultSlice {
+			if local.AnchorID == foreign.ID {
+				local.R.Anchor = foreign
+				if foreign.R == nil {
+					foreign.R = &anchorR{}
+				}
+				foreign.R.AnchorEntries = append(foreign.R.AnchorEntries, local)
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// SetAnchor of the anchorEntry to the related item.
+// Sets o.R.Anchor to related.
+// Adds o to related.R.AnchorEntries.
+func (o *AnchorEntry) SetAnchor(ctx context.Context, exec boil.ContextExecutor, insert bool, related *Anchor) error {
+	var err error
+	if insert {
+		if err = related.Insert(ctx, exec, boil.Infer()); err != nil {
+			return errors.Wrap(err, "failed to insert into foreign table")
+		}
+	}
+
+	updateQuery := fmt.Sprintf(
+		"UPDATE \"trips_api\".\"anchor_entries\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, []string{"anchor_id"}),
+		strmangle.WhereClause("\"", "\"", 2, anchorEntryPrimaryKeyColumns),
+	)
+	values := []interface{}{related.ID, o.AnchorID, o.LeafIndex}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, updateQuery)
+		fmt.Fprintln(writer, values)
+	}
+	if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+		return errors.Wrap(err, "failed to update local table")
+	}
+
+	o.AnchorID = related.ID
+	if o.R == nil {
+		o.R = &anchorEntryR{
+			Anchor: related,
+		}
+	} else {
+		o.R.Anchor = related
+	}
+
+	if related.R == nil {
+		related.R = &anchorR{
+			AnchorEntries: AnchorEntrySlice{o},
+		}
+	} else {
+		related.R.AnchorEntries = append(related.R.AnchorEntries, o)
+	}
+
+	return nil
+}
+
+// AnchorEntries retrieves all the records using an executor.
+func AnchorEntries(mods ...qm.QueryMod) anchorEntryQuery {
+	mods = append(mods, qm.From("\"trips_api\".\"anchor_entries\""))
+	q := NewQuery(mods...)
+	if len(queries.GetSelect(q)) == 0 {
+		queries.SetSelect(q, []string{"\"trips_api\".\"anchor_entries\".*"})
+	}
+
+	return anchorEntryQuery{q}
+}
+
+// FindAnchorEntry retrieves a single record by ID with an executor.
+// If selectCols is empty Find will return all columns.
+func FindAnchorEntry(ctx context.Context, exec boil.ContextExecutor, anchorID string, leafIndex int, selectCols ...string) (*AnchorEntry, error) {
+	anchorEntryObj := &AnchorEntry{}
+
+	sel := "*"
+	if len(selectCols) > 0 {
+		sel = strings.Join(strmangle.IdentQuoteSlice(dialect.LQ, dialect.RQ, selectCols), ",")
+	}
+	query := fmt.Sprintf(
+		"select %s from \"trips_api\".\"anchor_entries\" where \"anchor_id\"=$1 AND \"leaf_index\"=$2", sel,
+	)
+
+	q := queries.Raw(query, anchorID, leafIndex)
+
+	err := q.Bind(ctx, exec, anchorEntryObj)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "models: unable to select from anchor_entries")
+	}
+
+	if err = anchorEntryObj.doAfterSelectHooks(ctx, exec); err != nil {
+		return anchorEntryObj, err
+	}
+
+	return anchorEntryObj, nil
+}
+
+// Insert a single record using an executor.
+// See boil.Columns.InsertColumnSet documentation to understand column list inference for inserts.
+func (o *AnchorEntry) Insert(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) error {
+	if o == nil {
+		return errors.New("models: no anchor_entries provided for insertion")
+	}
+
+	var err error
+
+	if err := o.doBeforeInsertHooks(ctx, exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(anchorEntryColumnsWithDefault, o)
+
+	key := makeCacheKey(columns, nzDefaults)
+	anchorEntryInsertCacheMut.RLock()
+	cache, cached := anchorEntryInsertCache[key]
+	anchorEntryInsertCacheMut.RUnlock()
+
+	if !cached {
+		wl, returnColumns := columns.InsertColumnSet(
+			anchorEntryAllColumns,
+			anchorEntryColumnsWithDefault,
+			anchorEntryColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		cache.valueMapping, err = queries.BindMapping(anchorEntryType, anchorEntryMapping, wl)
+		if err != nil {
+			return err
+		}
+		cache.retMapping, err = queries.BindMapping(anchorEntryType, anchorEntryMapping, returnColumns)
+		if err != nil {
+			return err
+		}
+		if len(wl) != 0 {
+			cache.query = fmt.Sprintf("INSERT INTO \"trips_api\".\"anchor_entries\" (\"%s\") %%sVALUES (%s)%%s", strings.Join(wl, "\",\""), strmangle.Placeholders(dialect.UseIndexPlaceholders, len(wl), 1, 1))
+		} else {
+			cache.query = "INSERT INTO \"trips_api\".\"anchor_entries\" %sDEFAULT VALUES%s"
+		}
+
+		var queryOutput, queryReturning string
+
+		if len(cache.retMapping) != 0 {
+			queryReturning = fmt.Sprintf(" RETURNING \"%s\"", strings.Join(returnColumns, "\",\""))
+		}
+
+		cache.query = fmt.Sprintf(cache.query, queryOutput, queryReturning)
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRowContext(ctx, cache.query, vals...).Scan(queries.PtrsFromMapping(value, cache.retMapping)...)
+	} else {
+		_, err = exec.ExecContext(ctx, cache.query, vals...)
+	}
+
+	if err != nil {
+		return errors.Wrap(err, "models: unable to insert into anchor_entries")
+	}
+
+	if !cached {
+		anchorEntryInsertCacheMut.Lock()
+		anchorEntryInsertCache[key] = cache
+		anchorEntryInsertCacheMut.Unlock()
+	}
+
+	return o.doAfterInsertHooks(ctx, exec)
+}
+
+// Update uses an executor to update the AnchorEntry.
+// See boil.Columns.UpdateColumnSet documentation to understand column list inference for updates.
+// Update does not automatically update the record in case of default values. Use .Reload() to refresh the records.
+func (o *AnchorEntry) Update(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) (int64, error) {
+	var err error
+	if err = o.doBeforeUpdateHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+	key := makeCacheKey(columns, nil)
+	anchorEntryUpdateCacheMut.RLock()
+	cache, cached := anchorEntryUpdateCache[key]
+	anchorEntryUpdateCacheMut.RUnlock()
+
+	if !cached {
+		wl := columns.UpdateColumnSet(
+			anchorEntryAllColumns,
+			anchorEntryPrimaryKeyColumns,
+		)
+
+		if !columns.IsWhitelist() {
+			wl = strmangle.SetComplement(wl, []string{"created_at"})
+		}
+		if len(wl) == 0 {
+			return 0, errors.New("models: unable to update anchor_entries, could not build whitelist")
+		}
+
+		cache.query = fmt.Sprintf("UPDATE \"trips_api\".\"anchor_entries\" SET %s WHERE %s",
+			strmangle.SetParamNames("\"", "\"", 1, wl),
+			strmangle.WhereClause("\"", "\"", len(wl)+1, anchorEntryPrimaryKeyColumns),
+		)
+		cache.valueMapping, err = queries.BindMapping(anchorEntryType, anchorEntryMapping, append(wl, anchorEntryPrimaryKeyColumns...))
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	values := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, values)
+	}
+	var result sql.Result
+	result, err = exec.ExecContext(ctx, cache.query, values...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update anchor_entries row")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by update for anchor_entries")
+	}
+
+	if !cached {
+		anchorEntryUpdateCacheMut.Lock()
+		anchorEntryUpdateCache[key] = cache
+		anchorEntryUpdateCacheMut.Unlock()
+	}
+
+	return rowsAff, o.doAfterUpdateHooks(ctx, exec)
+}
+
+// UpdateAll updates all rows with the specified column values.
+func (q anchorEntryQuery) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	queries.SetUpdate(q.Query, cols)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update all for anchor_entries")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to retrieve rows affected for anchor_entries")
+	}
+
+	return rowsAff, nil
+}
+
+// UpdateAll updates all rows with the specified column values, using an executor.
+func (o AnchorEntrySlice) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	ln := int64(len(o))
+	if ln == 0 {
+		return 0, nil
+	}
+
+	if len(cols) == 0 {
+		return 0, errors.New("models: update all requires at least one column argument")
+	}
+
+	colNames := make([]string, len(cols))
+	args := make([]interface{}, len(cols))
+
+	i := 0
+	for name, value := range cols {
+		colNames[i] = name
+		args[i] = value
+		i++
+	}
+
+	// Append all of the primary key values for each column
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), anchorEntryPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := fmt.Sprintf("UPDATE \"trips_api\".\"anchor_entries\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, colNames),
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), len(colNames)+1, anchorEntryPrimaryKeyColumns, len(o)))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update all in anchorEntry slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to retrieve rows affected all in update all anchorEntry")
+	}
+	return rowsAff, nil
+}
+
+// Upsert attempts an insert using an executor, and does an update or ignore on conflict.
+// See boil.Columns documentation for how to properly use updateColumns and insertColumns.
+func (o *AnchorEntry) Upsert(ctx context.Context, exec boil.ContextExecutor, updateOnConflict bool, conflictColumns []string, updateColumns, insertColumns boil.Columns, opts ...UpsertOptionFunc) error {
+	if o == nil {
+		return errors.New("models: no anchor_entries provided for upsert")
+	}
+
+	if err := o.doBeforeUpsertHooks(ctx, exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(anchorEntryColumnsWithDefault, o)
+
+	// Build cache key in-line uglily - mysql vs psql problems
+	buf := strmangle.GetBuffer()
+	if updateOnConflict {
+		buf.WriteByte('t')
+	} else {
+		buf.WriteByte('f')
+	}
+	buf.WriteByte('.')
+	for _, c := range conflictColumns {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(updateColumns.Kind))
+	for _, c := range updateColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(insertColumns.Kind))
+	for _, c := range insertColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	for _, c := range nzDefaults {
+		buf.WriteString(c)
+	}
+	key := buf.String()
+	strmangle.PutBuffer(buf)
+
+	anchorEntryUpsertCacheMut.RLock()
+	cache, cached := anchorEntryUpsertCache[key]
+	anchorEntryUpsertCacheMut.RUnlock()
+
+	var err error
+
+	if !cached {
+		insert, _ := insertColumns.InsertColumnSet(
+			anchorEntryAllColumns,
+			anchorEntryColumnsWithDefault,
+			anchorEntryColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		update := updateColumns.UpdateColumnSet(
+			anchorEntryAllColumns,
+			anchorEntryPrimaryKeyColumns,
+		)
+
+		if updateOnConflict && len(update) == 0 {
+			return errors.New("models: unable to upsert anchor_entries, could not build update column list")
+		}
+
+		ret := strmangle.SetComplement(anchorEntryAllColumns, strmangle.SetIntersect(insert, update))
+
+		conflict := conflictColumns
+		if len(conflict) == 0 && updateOnConflict && len(update) != 0 {
+			if len(anchorEntryPrimaryKeyColumns) == 0 {
+				return errors.New("models: unable to upsert anchor_entries, could not build conflict column list")
+			}
+
+			conflict = make([]string, len(anchorEntryPrimaryKeyColumns))
+			copy(conflict, anchorEntryPrimaryKeyColumns)
+		}
+		cache.query = buildUpsertQueryPostgres(dialect, "\"trips_api\".\"anchor_entries\"", updateOnConflict, ret, update, conflict, insert, opts...)
+
+		cache.valueMapping, err = queries.BindMapping(anchorEntryType, anchorEntryMapping, insert)
+		if err != nil {
+			return err
+		}
+		if len(ret) != 0 {
+			cache.retMapping, err = queries.BindMapping(anchorEntryType, anchorEntryMapping, ret)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+	var returns []interface{}
+	if len(cache.retMapping) != 0 {
+		returns = queries.PtrsFromMapping(value, cache.retMapping)
+	}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRowContext(ctx, cache.query, vals...).Scan(returns...)
+		if errors.Is(err, sql.ErrNoRows) {
+			err = nil // Postgres doesn't return anything when there's no update
+		}
+	} else {
+		_, err = exec.ExecContext(ctx, cache.query, vals...)
+	}
+	if err != nil {
+		return errors.Wrap(err, "models: unable to upsert anchor_entries")
+	}
+
+	if !cached {
+		anchorEntryUpsertCacheMut.Lock()
+		anchorEntryUpsertCache[key] = cache
+		anchorEntryUpsertCacheMut.Unlock()
+	}
+
+	return o.doAfterUpsertHooks(ctx, exec)
+}
+
+// Delete deletes a single AnchorEntry record with an executor.
+// Delete will match against the primary key column to find the record to delete.
+func (o *AnchorEntry) Delete(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if o == nil {
+		return 0, errors.New("models: no AnchorEntry provided for delete")
+	}
+
+	if err := o.doBeforeDeleteHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+
+	args := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), anchorEntryPrimaryKeyMapping)
+	sql := "DELETE FROM \"trips_api\".\"anchor_entries\" WHERE \"anchor_id\"=$1 AND \"leaf_index\"=$2"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete from anchor_entries")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by delete for anchor_entries")
+	}
+
+	if err := o.doAfterDeleteHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all matching rows.
+func (q anchorEntryQuery) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if q.Query == nil {
+		return 0, errors.New("models: no anchorEntryQuery provided for delete all")
+	}
+
+	queries.SetDelete(q.Query)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete all from anchor_entries")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by deleteall for anchor_entries")
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all rows in the slice, using an executor.
+func (o AnchorEntrySlice) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if len(o) == 0 {
+		return 0, nil
+	}
+
+	if len(anchorEntryBeforeDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doBeforeDeleteHooks(ctx, exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	var args []interface{}
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), anchorEntryPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "DELETE FROM \"trips_api\".\"anchor_entries\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, anchorEntryPrimaryKeyColumns, len(o))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete all from anchorEntry slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by deleteall for anchor_entries")
+	}
+
+	if len(anchorEntryAfterDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterDeleteHooks(ctx, exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	return rowsAff, nil
+}
+
+// Reload refetches the object from the database
+// using the primary keys with an executor.
+func (o *AnchorEntry) Reload(ctx context.Context, exec boil.ContextExecutor) error {
+	ret, err := FindAnchorEntry(ctx, exec, o.AnchorID, o.LeafIndex)
+	if err != nil {
+		return err
+	}
+
+	*o = *ret
+	return nil
+}
+
+// ReloadAll refetches every row with matching primary key column values
+// and overwrites the original object slice with the newly updated slice.
+func (o *AnchorEntrySlice) ReloadAll(ctx context.Context, exec boil.ContextExecutor) error {
+	if o == nil || len(*o) == 0 {
+		return nil
+	}
+
+	slice := AnchorEntrySlice{}
+	var args []interface{}
+	for _, obj := range *o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), anchorEntryPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "SELECT \"trips_api\".\"anchor_entries\".* FROM \"trips_api\".\"anchor_entries\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, anchorEntryPrimaryKeyColumns, len(*o))
+
+	q := queries.Raw(sql, args...)
+
+	err := q.Bind(ctx, exec, &slice)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to reload all in AnchorEntrySlice")
+	}
+
+	*o = slice
+
+	return nil
+}
+
+// AnchorEntryExists checks if the AnchorEntry row exists.
+func AnchorEntryExists(ctx context.Context, exec boil.ContextExecutor, anchorID string, leafIndex int) (bool, error) {
+	var exists bool
+	sql := "select exists(select 1 from \"trips_api\".\"anchor_entries\" where \"anchor_id\"=$1 AND \"leaf_index\"=$2 limit 1)"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, anchorID, leafIndex)
+	}
+	row := exec.QueryRowContext(ctx, sql, anchorID, leafIndex)
+
+	err := row.Scan(&exists)
+	if err != nil {
+		return false, errors.Wrap(err, "models: unable to check if anchor_entries exists")
+	}
+
+	return exists, nil
+}
+
+// Exists checks if the AnchorEntry row exists.
+func (o *AnchorEntry) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	return AnchorEntryExists(ctx, exec, o.AnchorID, o.LeafIndex)
+}
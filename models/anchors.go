@@ -0,0 +1,1264 @@
+// Code generated by SQLBoiler 4.16.2 (https://github.com/volatiletech/sqlboiler). DO NOT EDIT.
+// This file is meant to be re-generated in place and/or deleted at any time.
+
+package models
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/friendsofgo/errors"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"github.com/volatiletech/sqlboiler/v4/queries/qmhelper"
+	"github.com/volatiletech/strmangle"
+)
+
+// Anchor is an object representing the database table.
+type Anchor struct {
+	ID          string      `boil:"id" json:"id" toml:"id" yaml:"id"`
+	Root        []byte      `boil:"root" json:"root" toml:"root" yaml:"root"`
+	LeafCount   int         `boil:"leaf_count" json:"leaf_count" toml:"leaf_count" yaml:"leaf_count"`
+	Publisher   string      `boil:"publisher" json:"publisher" toml:"publisher" yaml:"publisher"`
+	Reference   null.String `boil:"reference" json:"reference,omitempty" toml:"reference" yaml:"reference,omitempty"`
+	CreatedAt   time.Time   `boil:"created_at" json:"created_at" toml:"created_at" yaml:"created_at"`
+	PublishedAt null.Time   `boil:"published_at" json:"published_at,omitempty" toml:"published_at" yaml:"published_at,omitempty"`
+
+	R *anchorR `boil:"-" json:"-" toml:"-" yaml:"-"`
+	L anchorL  `boil:"-" json:"-" toml:"-" yaml:"-"`
+}
+
+var AnchorColumns = struct {
+	ID          string
+	Root        string
+	LeafCount   string
+	Publisher   string
+	Reference   string
+	CreatedAt   string
+	PublishedAt string
+}{
+	ID:          "id",
+	Root:        "root",
+	LeafCount:   "leaf_count",
+	Publisher:   "publisher",
+	Reference:   "reference",
+	CreatedAt:   "created_at",
+	PublishedAt: "published_at",
+}
+
+var AnchorTableColumns = struct {
+	ID          string
+	Root        string
+	LeafCount   string
+	Publisher   string
+	Reference   string
+	CreatedAt   string
+	PublishedAt string
+}{
+	ID:          "anchors.id",
+	Root:        "anchors.root",
+	LeafCount:   "anchors.leaf_count",
+	Publisher:   "anchors.publisher",
+	Reference:   "anchors.reference",
+	CreatedAt:   "anchors.created_at",
+	PublishedAt: "anchors.published_at",
+}
+
+// Generated where
+
+type whereHelpernull_String struct{ field string }
+
+func (w whereHelpernull_String) EQ(x null.String) qm.QueryMod {
+	return qmhelper.WhereNullEQ(w.field, false, x)
+}
+func (w whereHelpernull_String) NEQ(x null.String) qm.QueryMod {
+	return qmhelper.WhereNullEQ(w.field, true, x)
+}
+func (w whereHelpernull_String) LT(x null.String) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.LT, x)
+}
+func (w whereHelpernull_String) LTE(x null.String) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.LTE, x)
+}
+func (w whereHelpernull_String) GT(x null.String) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.GT, x)
+}
+func (w whereHelpernull_String) GTE(x null.String) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.GTE, x)
+}
+func (w whereHelpernull_String) LIKE(x null.String) qm.QueryMod {
+	return qm.Where(w.field+" LIKE ?", x)
+}
+func (w whereHelpernull_String) NLIKE(x null.String) qm.QueryMod {
+	return qm.Where(w.field+" NOT LIKE ?", x)
+}
+func (w whereHelpernull_String) ILIKE(x null.String) qm.QueryMod {
+	return qm.Where(w.field+" ILIKE ?", x)
+}
+func (w whereHelpernull_String) NILIKE(x null.String) qm.QueryMod {
+	return qm.Where(w.field+" NOT ILIKE ?", x)
+}
+func (w whereHelpernull_String) IN(slice []string) qm.QueryMod {
+	values := make([]interface{}, 0, len(slice))
+	for _, value := range slice {
+		values = append(values, value)
+	}
+	return qm.WhereIn(fmt.Sprintf("%s IN ?", w.field), values...)
+}
+func (w whereHelpernull_String) NIN(slice []string) qm.QueryMod {
+	values := make([]interface{}, 0, len(slice))
+	for _, value := range slice {
+		values = append(values, value)
+	}
+	return qm.WhereNotIn(fmt.Sprintf("%s NOT IN ?", w.field), values...)
+}
+
+func (w whereHelpernull_String) IsNull() qm.QueryMod    { return qmhelper.WhereIsNull(w.field) }
+func (w whereHelpernull_String) IsNotNull() qm.QueryMod { return qmhelper.WhereIsNotNull(w.field) }
+
+type whereHelpertime_Time struct{ field string }
+
+func (w whereHelpertime_Time) EQ(x time.Time) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.EQ, x)
+}
+func (w whereHelpertime_Time) NEQ(x time.Time) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.NEQ, x)
+}
+func (w whereHelpertime_Time) LT(x time.Time) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.LT, x)
+}
+func (w whereHelpertime_Time) LTE(x time.Time) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.LTE, x)
+}
+func (w whereHelpertime_Time) GT(x time.Time) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.GT, x)
+}
+func (w whereHelpertime_Time) GTE(x time.Time) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.GTE, x)
+}
+
+type whereHelpernull_Time struct{ field string }
+
+func (w whereHelpernull_Time) EQ(x null.Time) qm.QueryMod {
+	return qmhelper.WhereNullEQ(w.field, false, x)
+}
+func (w whereHelpernull_Time) NEQ(x null.Time) qm.QueryMod {
+	return qmhelper.WhereNullEQ(w.field, true, x)
+}
+func (w whereHelpernull_Time) LT(x null.Time) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.LT, x)
+}
+func (w whereHelpernull_Time) LTE(x null.Time) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.LTE, x)
+}
+func (w whereHelpernull_Time) GT(x null.Time) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.GT, x)
+}
+func (w whereHelpernull_Time) GTE(x null.Time) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.GTE, x)
+}
+
+func (w whereHelpernull_Time) IsNull() qm.QueryMod    { return qmhelper.WhereIsNull(w.field) }
+func (w whereHelpernull_Time) IsNotNull() qm.QueryMod { return qmhelper.WhereIsNotNull(w.field) }
+
+var AnchorWhere = struct {
+	ID          whereHelperstring
+	Root        whereHelper__byte
+	LeafCount   whereHelperint
+	Publisher   whereHelperstring
+	Reference   whereHelpernull_String
+	CreatedAt   whereHelpertime_Time
+	PublishedAt whereHelpernull_Time
+}{
+	ID:          whereHelperstring{field: "\"trips_api\".\"anchors\".\"id\""},
+	Root:        whereHelper__byte{field: "\"trips_api\".\"anchors\".\"root\""},
+	LeafCount:   whereHelperint{field: "\"trips_api\".\"anchors\".\"leaf_count\""},
+	Publisher:   whereHelperstring{field: "\"trips_api\".\"anchors\".\"publisher\""},
+	Reference:   whereHelpernull_String{field: "\"trips_api\".\"anchors\".\"reference\""},
+	CreatedAt:   whereHelpertime_Time{field: "\"trips_api\".\"anchors\".\"created_at\""},
+	PublishedAt: whereHelpernull_Time{field: "\"trips_api\".\"anchors\".\"published_at\""},
+}
+
+// AnchorRels is where relationship names are stored.
+var AnchorRels = struct {
+	AnchorEntries string
+}{
+	AnchorEntries: "AnchorEntries",
+}
+
+// anchorR is where relationships are stored.
+type anchorR struct {
+	AnchorEntries AnchorEntrySlice `boil:"AnchorEntries" json:"AnchorEntries" toml:"AnchorEntries" yaml:"AnchorEntries"`
+}
+
+// NewStruct creates a new relationship struct
+func (*anchorR) NewStruct() *anchorR {
+	return &anchorR{}
+}
+
+func (r *anchorR) GetAnchorEntries() AnchorEntrySlice {
+	if r == nil {
+		return nil
+	}
+	return r.AnchorEntries
+}
+
+// anchorL is where Load methods for each relationship are stored.
+type anchorL struct{}
+
+var (
+	anchorAllColumns            = []string{"id", "root", "leaf_count", "publisher", "reference", "created_at", "published_at"}
+	anchorColumnsWithoutDefault = []string{"id", "root", "leaf_count", "publisher"}
+	anchorColumnsWithDefault    = []string{"reference", "created_at", "published_at"}
+	anchorPrimaryKeyColumns     = []string{"id"}
+	anchorGeneratedColumns      = []string{}
+)
+
+type (
+	// AnchorSlice is an alias for a slice of pointers to Anchor.
+	// This should almost always be used instead of []Anchor.
+	AnchorSlice []*Anchor
+	// AnchorHook is the signature for custom Anchor hook methods
+	AnchorHook func(context.Context, boil.ContextExecutor, *Anchor) error
+
+	anchorQuery struct {
+		*queries.Query
+	}
+)
+
+// Cache for insert, update and upsert
+var (
+	anchorType                 = reflect.TypeOf(&Anchor{})
+	anchorMapping              = queries.MakeStructMapping(anchorType)
+	anchorPrimaryKeyMapping, _ = queries.BindMapping(anchorType, anchorMapping, anchorPrimaryKeyColumns)
+	anchorInsertCacheMut       sync.RWMutex
+	anchorInsertCache          = make(map[string]insertCache)
+	anchorUpdateCacheMut       sync.RWMutex
+	anchorUpdateCache          = make(map[string]updateCache)
+	anchorUpsertCacheMut       sync.RWMutex
+	anchorUpsertCache          = make(map[string]insertCache)
+)
+
+var (
+	// Force time package dependency for automated UpdatedAt/CreatedAt.
+	_ = time.Second
+	// Force qmhelper dependency for where clause generation (which doesn't
+	// always happen)
+	_ = qmhelper.Where
+)
+
+var anchorAfterSelectMu sync.Mutex
+var anchorAfterSelectHooks []AnchorHook
+
+var anchorBeforeInsertMu sync.Mutex
+var anchorBeforeInsertHooks []AnchorHook
+var anchorAfterInsertMu sync.Mutex
+var anchorAfterInsertHooks []AnchorHook
+
+var anchorBeforeUpdateMu sync.Mutex
+var anchorBeforeUpdateHooks []AnchorHook
+var anchorAfterUpdateMu sync.Mutex
+var anchorAfterUpdateHooks []AnchorHook
+
+var anchorBeforeDeleteMu sync.Mutex
+var anchorBeforeDeleteHooks []AnchorHook
+var anchorAfterDeleteMu sync.Mutex
+var anchorAfterDeleteHooks []AnchorHook
+
+var anchorBeforeUpsertMu sync.Mutex
+var anchorBeforeUpsertHooks []AnchorHook
+var anchorAfterUpsertMu sync.Mutex
+var anchorAfterUpsertHooks []AnchorHook
+
+// doAfterSelectHooks executes all "after Select" hooks.
+func (o *Anchor) doAfterSelectHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range anchorAfterSelectHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeInsertHooks executes all "before insert" hooks.
+func (o *Anchor) doBeforeInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range anchorBeforeInsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterInsertHooks executes all "after Insert" hooks.
+func (o *Anchor) doAfterInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range anchorAfterInsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpdateHooks executes all "before Update" hooks.
+func (o *Anchor) doBeforeUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range anchorBeforeUpdateHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpdateHooks executes all "after Update" hooks.
+func (o *Anchor) doAfterUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range anchorAfterUpdateHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeDeleteHooks executes all "before Delete" hooks.
+func (o *Anchor) doBeforeDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range anchorBeforeDeleteHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterDeleteHooks executes all "after Delete" hooks.
+func (o *Anchor) doAfterDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range anchorAfterDeleteHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpsertHooks executes all "before Upsert" hooks.
+func (o *Anchor) doBeforeUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range anchorBeforeUpsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpsertHooks executes all "after Upsert" hooks.
+func (o *Anchor) doAfterUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range anchorAfterUpsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// AddAnchorHook registers your hook function for all future operations.
+func AddAnchorHook(hookPoint boil.HookPoint, anchorHook AnchorHook) {
+	switch hookPoint {
+	case boil.AfterSelectHook:
+		anchorAfterSelectMu.Lock()
+		anchorAfterSelectHooks = append(anchorAfterSelectHooks, anchorHook)
+		anchorAfterSelectMu.Unlock()
+	case boil.BeforeInsertHook:
+		anchorBeforeInsertMu.Lock()
+		anchorBeforeInsertHooks = append(anchorBeforeInsertHooks, anchorHook)
+		anchorBeforeInsertMu.Unlock()
+	case boil.AfterInsertHook:
+		anchorAfterInsertMu.Lock()
+		anchorAfterInsertHooks = append(anchorAfterInsertHooks, anchorHook)
+		anchorAfterInsertMu.Unlock()
+	case boil.BeforeUpdateHook:
+		anchorBeforeUpdateMu.Lock()
+		anchorBeforeUpdateHooks = append(anchorBeforeUpdateHooks, anchorHook)
+		anchorBeforeUpdateMu.Unlock()
+	case boil.AfterUpdateHook:
+		anchorAfterUpdateMu.Lock()
+		anchorAfterUpdateHooks = append(anchorAfterUpdateHooks, anchorHook)
+		anchorAfterUpdateMu.Unlock()
+	case boil.BeforeDeleteHook:
+		anchorBeforeDeleteMu.Lock()
+		anchorBeforeDeleteHooks = append(anchorBeforeDeleteHooks, anchorHook)
+		anchorBeforeDeleteMu.Unlock()
+	case boil.AfterDeleteHook:
+		anchorAfterDeleteMu.Lock()
+		anchorAfterDeleteHooks = append(anchorAfterDeleteHooks, anchorHook)
+		anchorAfterDeleteMu.Unlock()
+	case boil.BeforeUpsertHook:
+		anchorBeforeUpsertMu.Lock()
+		anchorBeforeUpsertHooks = append(anchorBeforeUpsertHooks, anchorHook)
+		anchorBeforeUpsertMu.Unlock()
+	case boil.AfterUpsertHook:
+		anchorAfterUpsertMu.Lock()
+		anchorAfterUpsertHooks = append(anchorAfterUpsertHooks, anchorHook)
+		anchorAfterUpsertMu.Unlock()
+	}
+}
+
+// One returns a single anchor record from the query.
+func (q anchorQuery) One(ctx context.Context, exec boil.ContextExecutor) (*Anchor, error) {
+	o := &Anchor{}
+
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Bind(ctx, exec, o)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "models: failed to execute a one query for anchors")
+	}
+
+	if err := o.doAfterSelectHooks(ctx, exec); err != nil {
+		return o, err
+	}
+
+	return o, nil
+}
+
+// All returns all Anchor records from the query.
+func (q anchorQuery) All(ctx context.Context, exec boil.ContextExecutor) (AnchorSlice, error) {
+	var o []*Anchor
+
+	err := q.Bind(ctx, exec, &o)
+	if err != nil {
+		return nil, errors.Wrap(err, "models: failed to assign all query results to Anchor slice")
+	}
+
+	if len(anchorAfterSelectHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterSelectHooks(ctx, exec); err != nil {
+				return o, err
+			}
+		}
+	}
+
+	return o, nil
+}
+
+// Count returns the count of all Anchor records in the query.
+func (q anchorQuery) Count(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to count anchors rows")
+	}
+
+	return count, nil
+}
+
+// Exists checks if the row exists in the table.
+func (q anchorQuery) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return false, errors.Wrap(err, "models: failed to check if anchors exists")
+	}
+
+	return count > 0, nil
+}
+
+// AnchorEntries retrieves all the anchor_entry's AnchorEntries with an executor.
+func (o *Anchor) AnchorEntries(mods ...qm.QueryMod) anchorEntryQuery {
+	var queryMods []qm.QueryMod
+	if len(mods) != 0 {
+		queryMods = append(queryMods, mods...)
+	}
+
+	queryMods = append(queryMods,
+		qm.Where("\"trips_api\".\"anchor_entries\".\"anchor_id\"=?", o.ID),
+	)
+
+	return AnchorEntries(queryMods...)
+}
+
+// LoadAnchorEntries allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-M or N-M relationship.
+func (anchorL) LoadAnchorEntries(ctx context.Context, e boil.ContextExecutor, singular bool, maybeAnchor interface{}, mods queries.Applicator) error {
+	var slice []*Anchor
+	var object *Anchor
+
+	if singular {
+		var ok bool
+		object, ok = maybeAnchor.(*Anchor)
+		if !ok {
+			object = new(Anchor)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeAnchor)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeAnchor))
+			}
+		}
+	} else {
+		s, ok := maybeAnchor.(*[]*Anchor)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeAnchor)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeAnchor))
+			}
+		}
+	}
+
+	args := make(map[interface{}]struct{})
+	if singular {
+		if object.R == nil {
+			object.R = &anchorR{}
+		}
+		args[object.ID] = struct{}{}
+	} else {
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &anchorR{}
+			}
+			args[obj.ID] = struct{}{}
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	argsSlice := make([]interface{}, len(args))
+	i := 0
+	for arg := range args {
+		argsSlice[i] = arg
+		i++
+	}
+
+	query := NewQuery(
+		qm.From(`trips_api.anchor_entries`),
+		qm.WhereIn(`trips_api.anchor_entries.anchor_id in ?`, argsSlice...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load anchor_entries")
+	}
+
+	var resultSlice []*AnchorEntry
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice anchor_entries")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on anchor_entries")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for anchor_entries")
+	}
+
+	if len(anchorEntryAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+	if singular {
+		object.R.AnchorEntries = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &anchorEntryR{}
+			}
+			foreign.R.Anchor = object
+		}
+		return nil
+	}
+
+	for _, foreign := range resultSlice {
+		for _, local := range slice {
+			if local.ID == foreign.AnchorID {
+				local.R.AnchorEntries = append(local.R.AnchorEntries, foreign)
+				if foreign.R == nil {
+					foreign.R = &anchorEntryR{}
+				}
+				foreign.R.Anchor = local
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// AddAnchorEntries adds the given related objects to the existing relationships
+// of the anchor, optionally inserting them as new records.
+// Appends related to o.R.AnchorEntries.
+// Sets related.R.Anchor appropriately.
+func (o *Anchor) AddAnchorEntries(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*AnchorEntry) error {
+	var err error
+	for _, rel := range related {
+		if insert {
+			rel.AnchorID = o.ID
+			if err = rel.Insert(ctx, exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		} else {
+			updateQuery := fmt.Sprintf(
+				"UPDATE \"trips_api\".\"anchor_entries\" SET %s WHERE %s",
+				strmangle.SetParamNames("\"", "\"", 1, []string{"anchor_id"}),
+				strmangle.WhereClause("\"", "\"", 2, anchorEntryPrimaryKeyColumns),
+			)
+			values := []interface{}{o.ID, rel.AnchorID, rel.LeafIndex}
+
+			if boil.IsDebug(ctx) {
+				writer := boil.DebugWriterFrom(ctx)
+				fmt.Fprintln(writer, updateQuery)
+				fmt.Fprintln(writer, values)
+			}
+			if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+				return errors.Wrap(err, "failed to update foreign table")
+			}
+
+			rel.AnchorID = o.ID
+		}
+	}
+
+	if o.R == nil {
+		o.R = &anchorR{
+			AnchorEntries: related,
+		}
+	} else {
+		o.R.AnchorEntries = append(o.R.AnchorEntries, related...)
+	}
+
+	for _, rel := range related {
+		if rel.R == nil {
+			rel.R = &anchorEntryR{
+				Anchor: o,
+			}
+		} else {
+			rel.R.Anchor = o
+		}
+	}
+	return nil
+}
+
+// Anchors retrieves all the records using an executor.
+func Anchors(mods ...qm.QueryMod) anchorQuery {
+	mods = append(mods, qm.From("\"trips_api\".\"anchors\""))
+	q := NewQuery(mods...)
+	if len(queries.GetSelect(q)) == 0 {
+		queries.SetSelect(q, []string{"\"trips_api\".\"anchors\".*"})
+	}
+
+	return anchorQuery{q}
+}
+
+// FindAnchor retrieves a single record by ID with an executor.
+// If selectCols is empty Find will return all columns.
+func FindAnchor(ctx context.Context, exec boil.ContextExecutor, iD string, selectCols ...string) (*Anchor, error) {
+	anchorObj := &Anchor{}
+
+	sel := "*"
+	if len(selectCols) > 0 {
+		sel = strings.Join(strmangle.IdentQuoteSlice(dialect.LQ, dialect.RQ, selectCols), ",")
+	}
+	query := fmt.Sprintf(
+		"select %s from \"trips_api\".\"anchors\" where \"id\"=$1", sel,
+	)
+
+	q := queries.Raw(query, iD)
+
+	err := q.Bind(ctx, exec, anchorObj)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "models: unable to select from anchors")
+	}
+
+	if err = anchorObj.doAfterSelectHooks(ctx, exec); err != nil {
+		return anchorObj, err
+	}
+
+	return anchorObj, nil
+}
+
+// Insert a single record using an executor.
+// See boil.Columns.InsertColumnSet documentation to understand column list inference for inserts.
+func (o *Anchor) Insert(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) error {
+	if o == nil {
+		return errors.New("models: no anchors provided for insertion")
+	}
+
+	var err error
+	if !boil.TimestampsAreSkipped(ctx) {
+		currTime := time.Now().In(boil.GetLocation())
+
+		if o.CreatedAt.IsZero() {
+			o.CreatedAt = currTime
+		}
+	}
+
+	if err := o.doBeforeInsertHooks(ctx, exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(anchorColumnsWithDefault, o)
+
+	key := makeCacheKey(columns, nzDefaults)
+	anchorInsertCacheMut.RLock()
+	cache, cached := anchorInsertCache[key]
+	anchorInsertCacheMut.RUnlock()
+
+	if !cached {
+		wl, returnColumns := columns.InsertColumnSet(
+			anchorAllColumns,
+			anchorColumnsWithDefault,
+			anchorColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		cache.valueMapping, err = queries.BindMapping(anchorType, anchorMapping, wl)
+		if err != nil {
+			return err
+		}
+		cache.retMapping, err = queries.BindMapping(anchorType, anchorMapping, returnColumns)
+		if err != nil {
+			return err
+		}
+		if len(wl) != 0 {
+			cache.query = fmt.Sprintf("INSERT INTO \"trips_api\".\"anchors\" (\"%s\") %%sVALUES (%s)%%s", strings.Join(wl, "\",\""), strmangle.Placeholders(dialect.UseIndexPlaceholders, len(wl), 1, 1))
+		} else {
+			cache.query = "INSERT INTO \"trips_api\".\"anchors\" %sDEFAULT VALUES%s"
+		}
+
+		var queryOutput, queryReturning string
+
+		if len(cache.retMapping) != 0 {
+			queryReturning = fmt.Sprintf(" RETURNING \"%s\"", strings.Join(returnColumns, "\",\""))
+		}
+
+		cache.query = fmt.Sprintf(cache.query, queryOutput, queryReturning)
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRowContext(ctx, cache.query, vals...).Scan(queries.PtrsFromMapping(value, cache.retMapping)...)
+	} else {
+		_, err = exec.ExecContext(ctx, cache.query, vals...)
+	}
+
+	if err != nil {
+		return errors.Wrap(err, "models: unable to insert into anchors")
+	}
+
+	if !cached {
+		anchorInsertCacheMut.Lock()
+		anchorInsertCache[key] = cache
+		anchorInsertCacheMut.Unlock()
+	}
+
+	return o.doAfterInsertHooks(ctx, exec)
+}
+
+// Update uses an executor to update the Anchor.
+// See boil.Columns.UpdateColumnSet documentation to understand column list inference for updates.
+// Update does not automatically update the record in case of default values. Use .Reload() to refresh the records.
+func (o *Anchor) Update(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) (int64, error) {
+	var err error
+	if err = o.doBeforeUpdateHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+	key := makeCacheKey(columns, nil)
+	anchorUpdateCacheMut.RLock()
+	cache, cached := anchorUpdateCache[key]
+	anchorUpdateCacheMut.RUnlock()
+
+	if !cached {
+		wl := columns.UpdateColumnSet(
+			anchorAllColumns,
+			anchorPrimaryKeyColumns,
+		)
+
+		if !columns.IsWhitelist() {
+			wl = strmangle.SetComplement(wl, []string{"created_at"})
+		}
+		if len(wl) == 0 {
+			return 0, errors.New("models: unable to update anchors, could not build whitelist")
+		}
+
+		cache.query = fmt.Sprintf("UPDATE \"trips_api\".\"anchors\" SET %s WHERE %s",
+			strmangle.SetParamNames("\"", "\"", 1, wl),
+			strmangle.WhereClause("\"", "\"", len(wl)+1, anchorPrimaryKeyColumns),
+		)
+		cache.valueMapping, err = queries.BindMapping(anchorType, anchorMapping, append(wl, anchorPrimaryKeyColumns...))
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	values := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, values)
+	}
+	var result sql.Result
+	result, err = exec.ExecContext(ctx, cache.query, values...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update anchors row")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by update for anchors")
+	}
+
+	if !cached {
+		anchorUpdateCacheMut.Lock()
+		anchorUpdateCache[key] = cache
+		anchorUpdateCacheMut.Unlock()
+	}
+
+	return rowsAff, o.doAfterUpdateHooks(ctx, exec)
+}
+
+// UpdateAll updates all rows with the specified column values.
+func (q anchorQuery) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	queries.SetUpdate(q.Query, cols)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update all for anchors")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to retrieve rows affected for anchors")
+	}
+
+	return rowsAff, nil
+}
+
+// UpdateAll updates all rows with the specified column values, using an executor.
+func (o AnchorSlice) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	ln := int64(len(o))
+	if ln == 0 {
+		return 0, nil
+	}
+
+	if len(cols) == 0 {
+		return 0, errors.New("models: update all requires at least one column argument")
+	}
+
+	colNames := make([]string, len(cols))
+	args := make([]interface{}, len(cols))
+
+	i := 0
+	for name, value := range cols {
+		colNames[i] = name
+		args[i] = value
+		i++
+	}
+
+	// Append all of the primary key values for each column
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), anchorPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := fmt.Sprintf("UPDATE \"trips_api\".\"anchors\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, colNames),
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), len(colNames)+1, anchorPrimaryKeyColumns, len(o)))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update all in anchor slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to retrieve rows affected all in update all anchor")
+	}
+	return rowsAff, nil
+}
+
+// Upsert attempts an insert using an executor, and does an update or ignore on conflict.
+// See boil.Columns documentation for how to properly use updateColumns and insertColumns.
+func (o *Anchor) Upsert(ctx context.Context, exec boil.ContextExecutor, updateOnConflict bool, conflictColumns []string, updateColumns, insertColumns boil.Columns, opts ...UpsertOptionFunc) error {
+	if o == nil {
+		return errors.New("models: no anchors provided for upsert")
+	}
+	if !boil.TimestampsAreSkipped(ctx) {
+		currTime := time.Now().In(boil.GetLocation())
+
+		if o.CreatedAt.IsZero() {
+			o.CreatedAt = currTime
+		}
+	}
+
+	if err := o.doBeforeUpsertHooks(ctx, exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(anchorColumnsWithDefault, o)
+
+	// Build cache key in-line uglily - mysql vs psql problems
+	buf := strmangle.GetBuffer()
+	if updateOnConflict {
+		buf.WriteByte('t')
+	} else {
+		buf.WriteByte('f')
+	}
+	buf.WriteByte('.')
+	for _, c := range conflictColumns {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(updateColumns.Kind))
+	for _, c := range updateColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(insertColumns.Kind))
+	for _, c := range insertColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	for _, c := range nzDefaults {
+		buf.WriteString(c)
+	}
+	key := buf.String()
+	strmangle.PutBuffer(buf)
+
+	anchorUpsertCacheMut.RLock()
+	cache, cached := anchorUpsertCache[key]
+	anchorUpsertCacheMut.RUnlock()
+
+	var err error
+
+	if !cached {
+		insert, _ := insertColumns.InsertColumnSet(
+			anchorAllColumns,
+			anchorColumnsWithDefault,
+			anchorColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		update := updateColumns.UpdateColumnSet(
+			anchorAllColumns,
+			anchorPrimaryKeyColumns,
+		)
+
+		if updateOnConflict && len(update) == 0 {
+			return errors.New("models: unable to upsert anchors, could not build update column list")
+		}
+
+		ret := strmangle.SetComplement(anchorAllColumns, strmangle.SetIntersect(insert, update))
+
+		conflict := conflictColumns
+		if len(conflict) == 0 && updateOnConflict && len(update) != 0 {
+			if len(anchorPrimaryKeyColumns) == 0 {
+				return errors.New("models: unable to upsert anchors, could not build conflict column list")
+			}
+
+			conflict = make([]string, len(anchorPrimaryKeyColumns))
+			copy(conflict, anchorPrimaryKeyColumns)
+		}
+		cache.query = buildUpsertQueryPostgres(dialect, "\"trips_api\".\"anchors\"", updateOnConflict, ret, update, conflict, insert, opts...)
+
+		cache.valueMapping, err = queries.BindMapping(anchorType, anchorMapping, insert)
+		if err != nil {
+			return err
+		}
+		if len(ret) != 0 {
+			cache.retMapping, err = queries.BindMapping(anchorType, anchorMapping, ret)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+	var returns []interface{}
+	if len(cache.retMapping) != 0 {
+		returns = queries.PtrsFromMapping(value, cache.retMapping)
+	}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRowContext(ctx, cache.query, vals...).Scan(returns...)
+		if errors.Is(err, sql.ErrNoRows) {
+			err = nil // Postgres doesn't return anything when there's no update
+		}
+	} else {
+		_, err = exec.ExecContext(ctx, cache.query, vals...)
+	}
+	if err != nil {
+		return errors.Wrap(err, "models: unable to upsert anchors")
+	}
+
+	if !cached {
+		anchorUpsertCacheMut.Lock()
+		anchorUpsertCache[key] = cache
+		anchorUpsertCacheMut.Unlock()
+	}
+
+	return o.doAfterUpsertHooks(ctx, exec)
+}
+
+// Delete deletes a single Anchor record with an executor.
+// Delete will match against the primary key column to find the record to delete.
+func (o *Anchor) Delete(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if o == nil {
+		return 0, errors.New("models: no Anchor provided for delete")
+	}
+
+	if err := o.doBeforeDeleteHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+
+	args := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), anchorPrimaryKeyMapping)
+	sql := "DELETE FROM \"trips_api\".\"anchors\" WHERE \"id\"=$1"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete from anchors")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by delete for anchors")
+	}
+
+	if err := o.doAfterDeleteHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all matching rows.
+func (q anchorQuery) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if q.Query == nil {
+		return 0, errors.New("models: no anchorQuery provided for delete all")
+	}
+
+	queries.SetDelete(q.Query)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete all from anchors")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by deleteall for anchors")
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all rows in the slice, using an executor.
+func (o AnchorSlice) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if len(o) == 0 {
+		return 0, nil
+	}
+
+	if len(anchorBeforeDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doBeforeDeleteHooks(ctx, exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	var args []interface{}
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), anchorPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "DELETE FROM \"trips_api\".\"anchors\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, anchorPrimaryKeyColumns, len(o))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete all from anchor slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by deleteall for anchors")
+	}
+
+	if len(anchorAfterDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterDeleteHooks(ctx, exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	return rowsAff, nil
+}
+
+// Reload refetches the object from the database
+// using the primary keys with an executor.
+func (o *Anchor) Reload(ctx context.Context, exec boil.ContextExecutor) error {
+	ret, err := FindAnchor(ctx, exec, o.ID)
+	if err != nil {
+		return err
+	}
+
+	*o = *ret
+	return nil
+}
+
+// ReloadAll refetches every row with matching primary key column values
+// and overwrites the original object slice with the newly updated slice.
+func (o *AnchorSlice) ReloadAll(ctx context.Context, exec boil.ContextExecutor) error {
+	if o == nil || len(*o) == 0 {
+		return nil
+	}
+
+	slice := AnchorSlice{}
+	var args []interface{}
+	for _, obj := range *o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), anchorPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "SELECT \"trips_api\".\"anchors\".* FROM \"trips_api\".\"anchors\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, anchorPrimaryKeyColumns, len(*o))
+
+	q := queries.Raw(sql, args...)
+
+	err := q.Bind(ctx, exec, &slice)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to reload all in AnchorSlice")
+	}
+
+	*o = slice
+
+	return nil
+}
+
+// AnchorExists checks if the Anchor row exists.
+func AnchorExists(ctx context.Context, exec boil.ContextExecutor, iD string) (bool, error) {
+	var exists bool
+	sql := "select exists(select 1 from \"trips_api\".\"anchors\" where \"id\"=$1 limit 1)"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, iD)
+	}
+	row := exec.QueryRowContext(ctx, sql, iD)
+
+	err := row.Scan(&exists)
+	if err != nil {
+		return false, errors.Wrap(err, "models: unable to check if anchors exists")
+	}
+
+	return exists, nil
+}
+
+// Exists checks if the Anchor row exists.
+func (o *Anchor) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	return AnchorExists(ctx, exec, o.ID)
+}
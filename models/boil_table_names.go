@@ -4,9 +4,33 @@
 package models
 
 var TableNames = struct {
-	Trips    string
-	Vehicles string
+	AnchorEntries         string
+	Anchors               string
+	Erasures              string
+	PrivacyZones          string
+	SegmenterStates       string
+	TripAccesses          string
+	TripAggregates        string
+	TripAnnotationChanges string
+	TripUpdates           string
+	Trips                 string
+	VehicleMappings       string
+	Vehicles              string
+	WebhookDeliveries     string
+	Webhooks              string
 }{
-	Trips:    "trips",
-	Vehicles: "vehicles",
+	AnchorEntries:         "anchor_entries",
+	Anchors:               "anchors",
+	Erasures:              "erasures",
+	PrivacyZones:          "privacy_zones",
+	SegmenterStates:       "segmenter_states",
+	TripAccesses:          "trip_accesses",
+	TripAggregates:        "trip_aggregates",
+	TripAnnotationChanges: "trip_annotation_changes",
+	TripUpdates:           "trip_updates",
+	Trips:                 "trips",
+	VehicleMappings:       "vehicle_mappings",
+	Vehicles:              "vehicles",
+	WebhookDeliveries:     "webhook_deliveries",
+	Webhooks:              "webhooks",
 }
@@ -0,0 +1,1028 @@
+// Code generated by SQLBoiler 4.16.2 (https://github.com/volatiletech/sqlboiler). DO NOT EDIT.
+// This file is meant to be re-generated in place and/or deleted at any time.
+
+package models
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/friendsofgo/errors"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"github.com/volatiletech/sqlboiler/v4/queries/qmhelper"
+	"github.com/volatiletech/sqlboiler/v4/types"
+	"github.com/volatiletech/strmangle"
+)
+
+// Erasure is an object representing the database table.
+type Erasure struct {
+	ID             string            `boil:"id" json:"id" toml:"id" yaml:"id"`
+	VehicleTokenID int               `boil:"vehicle_token_id" json:"vehicle_token_id" toml:"vehicle_token_id" yaml:"vehicle_token_id"`
+	RangeStart     null.Time         `boil:"range_start" json:"range_start,omitempty" toml:"range_start" yaml:"range_start,omitempty"`
+	RangeEnd       time.Time         `boil:"range_end" json:"range_end" toml:"range_end" yaml:"range_end"`
+	Subject        string            `boil:"subject" json:"subject" toml:"subject" yaml:"subject"`
+	ClientID       null.String       `boil:"client_id" json:"client_id,omitempty" toml:"client_id" yaml:"client_id,omitempty"`
+	TripCount      int               `boil:"trip_count" json:"trip_count" toml:"trip_count" yaml:"trip_count"`
+	ArchiveIds     types.StringArray `boil:"archive_ids" json:"archive_ids" toml:"archive_ids" yaml:"archive_ids"`
+	CreatedAt      time.Time         `boil:"created_at" json:"created_at" toml:"created_at" yaml:"created_at"`
+	UserAddress    null.String       `boil:"user_address" json:"user_address,omitempty" toml:"user_address" yaml:"user_address,omitempty"`
+	IdempotencyKey null.String       `boil:"idempotency_key" json:"idempotency_key,omitempty" toml:"idempotency_key" yaml:"idempotency_key,omitempty"`
+
+	R *erasureR `boil:"-" json:"-" toml:"-" yaml:"-"`
+	L erasureL  `boil:"-" json:"-" toml:"-" yaml:"-"`
+}
+
+var ErasureColumns = struct {
+	ID             string
+	VehicleTokenID string
+	RangeStart     string
+	RangeEnd       string
+	Subject        string
+	ClientID       string
+	TripCount      string
+	ArchiveIds     string
+	CreatedAt      string
+	UserAddress    string
+	IdempotencyKey string
+}{
+	ID:             "id",
+	VehicleTokenID: "vehicle_token_id",
+	RangeStart:     "range_start",
+	RangeEnd:       "range_end",
+	Subject:        "subject",
+	ClientID:       "client_id",
+	TripCount:      "trip_count",
+	ArchiveIds:     "archive_ids",
+	CreatedAt:      "created_at",
+	UserAddress:    "user_address",
+	IdempotencyKey: "idempotency_key",
+}
+
+var ErasureTableColumns = struct {
+	ID             string
+	VehicleTokenID string
+	RangeStart     string
+	RangeEnd       string
+	Subject        string
+	ClientID       string
+	TripCount      string
+	ArchiveIds     string
+	CreatedAt      string
+	UserAddress    string
+	IdempotencyKey string
+}{
+	ID:             "erasures.id",
+	VehicleTokenID: "erasures.vehicle_token_id",
+	RangeStart:     "erasures.range_start",
+	RangeEnd:       "erasures.range_end",
+	Subject:        "erasures.subject",
+	ClientID:       "erasures.client_id",
+	TripCount:      "erasures.trip_count",
+	ArchiveIds:     "erasures.archive_ids",
+	CreatedAt:      "erasures.created_at",
+	UserAddress:    "erasures.user_address",
+	IdempotencyKey: "erasures.idempotency_key",
+}
+
+// Generated where
+
+type whereHelpertypes_StringArray struct{ field string }
+
+func (w whereHelpertypes_StringArray) EQ(x types.StringArray) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.EQ, x)
+}
+func (w whereHelpertypes_StringArray) NEQ(x types.StringArray) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.NEQ, x)
+}
+func (w whereHelpertypes_StringArray) LT(x types.StringArray) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.LT, x)
+}
+func (w whereHelpertypes_StringArray) LTE(x types.StringArray) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.LTE, x)
+}
+func (w whereHelpertypes_StringArray) GT(x types.StringArray) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.GT, x)
+}
+func (w whereHelpertypes_StringArray) GTE(x types.StringArray) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.GTE, x)
+}
+
+var ErasureWhere = struct {
+	ID             whereHelperstring
+	VehicleTokenID whereHelperint
+	RangeStart     whereHelpernull_Time
+	RangeEnd       whereHelpertime_Time
+	Subject        whereHelperstring
+	ClientID       whereHelpernull_String
+	TripCount      whereHelperint
+	ArchiveIds     whereHelpertypes_StringArray
+	CreatedAt      whereHelpertime_Time
+	UserAddress    whereHelpernull_String
+	IdempotencyKey whereHelpernull_String
+}{
+	ID:             whereHelperstring{field: "\"trips_api\".\"erasures\".\"id\""},
+	VehicleTokenID: whereHelperint{field: "\"trips_api\".\"erasures\".\"vehicle_token_id\""},
+	RangeStart:     whereHelpernull_Time{field: "\"trips_api\".\"erasures\".\"range_start\""},
+	RangeEnd:       whereHelpertime_Time{field: "\"trips_api\".\"erasures\".\"range_end\""},
+	Subject:        whereHelperstring{field: "\"trips_api\".\"erasures\".\"subject\""},
+	ClientID:       whereHelpernull_String{field: "\"trips_api\".\"erasures\".\"client_id\""},
+	TripCount:      whereHelperint{field: "\"trips_api\".\"erasures\".\"trip_count\""},
+	ArchiveIds:     whereHelpertypes_StringArray{field: "\"trips_api\".\"erasures\".\"archive_ids\""},
+	CreatedAt:      whereHelpertime_Time{field: "\"trips_api\".\"erasures\".\"created_at\""},
+	UserAddress:    whereHelpernull_String{field: "\"trips_api\".\"erasures\".\"user_address\""},
+	IdempotencyKey: whereHelpernull_String{field: "\"trips_api\".\"erasures\".\"idempotency_key\""},
+}
+
+// ErasureRels is where relationship names are stored.
+var ErasureRels = struct {
+}{}
+
+// erasureR is where relationships are stored.
+type erasureR struct {
+}
+
+// NewStruct creates a new relationship struct
+func (*erasureR) NewStruct() *erasureR {
+	return &erasureR{}
+}
+
+// erasureL is where Load methods for each relationship are stored.
+type erasureL struct{}
+
+var (
+	erasureAllColumns            = []string{"id", "vehicle_token_id", "range_start", "range_end", "subject", "client_id", "trip_count", "archive_ids", "created_at", "user_address", "idempotency_key"}
+	erasureColumnsWithoutDefault = []string{"id", "vehicle_token_id", "range_end", "subject", "trip_count"}
+	erasureColumnsWithDefault    = []string{"range_start", "client_id", "archive_ids", "created_at", "user_address", "idempotency_key"}
+	erasurePrimaryKeyColumns     = []string{"id"}
+	erasureGeneratedColumns      = []string{}
+)
+
+type (
+	// ErasureSlice is an alias for a slice of pointers to Erasure.
+	// This should almost always be used instead of []Erasure.
+	ErasureSlice []*Erasure
+	// ErasureHook is the signature for custom Erasure hook methods
+	ErasureHook func(context.Context, boil.ContextExecutor, *Erasure) error
+
+	erasureQuery struct {
+		*queries.Query
+	}
+)
+
+// Cache for insert, update and upsert
+var (
+	erasureType                 = reflect.TypeOf(&Erasure{})
+	erasureMapping              = queries.MakeStructMapping(erasureType)
+	erasurePrimaryKeyMapping, _ = queries.BindMapping(erasureType, erasureMapping, erasurePrimaryKeyColumns)
+	erasureInsertCacheMut       sync.RWMutex
+	erasureInsertCache          = make(map[string]insertCache)
+	erasureUpdateCacheMut       sync.RWMutex
+	erasureUpdateCache          = make(map[string]updateCache)
+	erasureUpsertCacheMut       sync.RWMutex
+	erasureUpsertCache          = make(map[string]insertCache)
+)
+
+var (
+	// Force time package dependency for automated UpdatedAt/CreatedAt.
+	_ = time.Second
+	// Force qmhelper dependency for where clause generation (which doesn't
+	// always happen)
+	_ = qmhelper.Where
+)
+
+var erasureAfterSelectMu sync.Mutex
+var erasureAfterSelectHooks []ErasureHook
+
+var erasureBeforeInsertMu sync.Mutex
+var erasureBeforeInsertHooks []ErasureHook
+var erasureAfterInsertMu sync.Mutex
+var erasureAfterInsertHooks []ErasureHook
+
+var erasureBeforeUpdateMu sync.Mutex
+var erasureBeforeUpdateHooks []ErasureHook
+var erasureAfterUpdateMu sync.Mutex
+var erasureAfterUpdateHooks []ErasureHook
+
+var erasureBeforeDeleteMu sync.Mutex
+var erasureBeforeDeleteHooks []ErasureHook
+var erasureAfterDeleteMu sync.Mutex
+var erasureAfterDeleteHooks []ErasureHook
+
+var erasureBeforeUpsertMu sync.Mutex
+var erasureBeforeUpsertHooks []ErasureHook
+var erasureAfterUpsertMu sync.Mutex
+var erasureAfterUpsertHooks []ErasureHook
+
+// doAfterSelectHooks executes all "after Select" hooks.
+func (o *Erasure) doAfterSelectHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range erasureAfterSelectHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeInsertHooks executes all "before insert" hooks.
+func (o *Erasure) doBeforeInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range erasureBeforeInsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterInsertHooks executes all "after Insert" hooks.
+func (o *Erasure) doAfterInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range erasureAfterInsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpdateHooks executes all "before Update" hooks.
+func (o *Erasure) doBeforeUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range erasureBeforeUpdateHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpdateHooks executes all "after Update" hooks.
+func (o *Erasure) doAfterUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range erasureAfterUpdateHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeDeleteHooks executes all "before Delete" hooks.
+func (o *Erasure) doBeforeDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range erasureBeforeDeleteHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterDeleteHooks executes all "after Delete" hooks.
+func (o *Erasure) doAfterDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range erasureAfterDeleteHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpsertHooks executes all "before Upsert" hooks.
+func (o *Erasure) doBeforeUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range erasureBeforeUpsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpsertHooks executes all "after Upsert" hooks.
+func (o *Erasure) doAfterUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range erasureAfterUpsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// AddErasureHook registers your hook function for all future operations.
+func AddErasureHook(hookPoint boil.HookPoint, erasureHook ErasureHook) {
+	switch hookPoint {
+	case boil.AfterSelectHook:
+		erasureAfterSelectMu.Lock()
+		erasureAfterSelectHooks = append(erasureAfterSelectHooks, erasureHook)
+		erasureAfterSelectMu.Unlock()
+	case boil.BeforeInsertHook:
+		erasureBeforeInsertMu.Lock()
+		erasureBeforeInsertHooks = append(erasureBeforeInsertHooks, erasureHook)
+		erasureBeforeInsertMu.Unlock()
+	case boil.AfterInsertHook:
+		erasureAfterInsertMu.Lock()
+		erasureAfterInsertHooks = append(erasureAfterInsertHooks, erasureHook)
+		erasureAfterInsertMu.Unlock()
+	case boil.BeforeUpdateHook:
+		erasureBeforeUpdateMu.Lock()
+		erasureBeforeUpdateHooks = append(erasureBeforeUpdateHooks, erasureHook)
+		erasureBeforeUpdateMu.Unlock()
+	case boil.AfterUpdateHook:
+		erasureAfterUpdateMu.Lock()
+		erasureAfterUpdateHooks = append(erasureAfterUpdateHooks, erasureHook)
+		erasureAfterUpdateMu.Unlock()
+	case boil.BeforeDeleteHook:
+		erasureBeforeDeleteMu.Lock()
+		erasureBeforeDeleteHooks = append(erasureBeforeDeleteHooks, erasureHook)
+		erasureBeforeDeleteMu.Unlock()
+	case boil.AfterDeleteHook:
+		erasureAfterDeleteMu.Lock()
+		erasureAfterDeleteHooks = append(erasureAfterDeleteHooks, erasureHook)
+		erasureAfterDeleteMu.Unlock()
+	case boil.BeforeUpsertHook:
+		erasureBeforeUpsertMu.Lock()
+		erasureBeforeUpsertHooks = append(erasureBeforeUpsertHooks, erasureHook)
+		erasureBeforeUpsertMu.Unlock()
+	case boil.AfterUpsertHook:
+		erasureAfterUpsertMu.Lock()
+		erasureAfterUpsertHooks = append(erasureAfterUpsertHooks, erasureHook)
+		erasureAfterUpsertMu.Unlock()
+	}
+}
+
+// One returns a single erasure record from the query.
+func (q erasureQuery) One(ctx context.Context, exec boil.ContextExecutor) (*Erasure, error) {
+	o := &Erasure{}
+
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Bind(ctx, exec, o)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "models: failed to execute a one query for erasures")
+	}
+
+	if err := o.doAfterSelectHooks(ctx, exec); err != nil {
+		return o, err
+	}
+
+	return o, nil
+}
+
+// All returns all Erasure records from the query.
+func (q erasureQuery) All(ctx context.Context, exec boil.ContextExecutor) (ErasureSlice, error) {
+	var o []*Erasure
+
+	err := q.Bind(ctx, exec, &o)
+	if err != nil {
+		return nil, errors.Wrap(err, "models: failed to assign all query results to Erasure slice")
+	}
+
+	if len(erasureAfterSelectHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterSelectHooks(ctx, exec); err != nil {
+				return o, err
+			}
+		}
+	}
+
+	return o, nil
+}
+
+// Count returns the count of all Erasure records in the query.
+func (q erasureQuery) Count(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to count erasures rows")
+	}
+
+	return count, nil
+}
+
+// Exists checks if the row exists in the table.
+func (q erasureQuery) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return false, errors.Wrap(err, "models: failed to check if erasures exists")
+	}
+
+	return count > 0, nil
+}
+
+// Erasures retrieves all the records using an executor.
+func Erasures(mods ...qm.QueryMod) erasureQuery {
+	mods = append(mods, qm.From("\"trips_api\".\"erasures\""))
+	q := NewQuery(mods...)
+	if len(queries.GetSelect(q)) == 0 {
+		queries.SetSelect(q, []string{"\"trips_api\".\"erasures\".*"})
+	}
+
+	return erasureQuery{q}
+}
+
+// FindErasure retrieves a single record by ID with an executor.
+// If selectCols is empty Find will return all columns.
+func FindErasure(ctx context.Context, exec boil.ContextExecutor, iD string, selectCols ...string) (*Erasure, error) {
+	erasureObj := &Erasure{}
+
+	sel := "*"
+	if len(selectCols) > 0 {
+		sel = strings.Join(strmangle.IdentQuoteSlice(dialect.LQ, dialect.RQ, selectCols), ",")
+	}
+	query := fmt.Sprintf(
+		"select %s from \"trips_api\".\"erasures\" where \"id\"=$1", sel,
+	)
+
+	q := queries.Raw(query, iD)
+
+	err := q.Bind(ctx, exec, erasureObj)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "models: unable to select from erasures")
+	}
+
+	if err = erasureObj.doAfterSelectHooks(ctx, exec); err != nil {
+		return erasureObj, err
+	}
+
+	return erasureObj, nil
+}
+
+// Insert a single record using an executor.
+// See boil.Columns.InsertColumnSet documentation to understand column list inference for inserts.
+func (o *Erasure) Insert(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) error {
+	if o == nil {
+		return errors.New("models: no erasures provided for insertion")
+	}
+
+	var err error
+	if !boil.TimestampsAreSkipped(ctx) {
+		currTime := time.Now().In(boil.GetLocation())
+
+		if o.CreatedAt.IsZero() {
+			o.CreatedAt = currTime
+		}
+	}
+
+	if err := o.doBeforeInsertHooks(ctx, exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(erasureColumnsWithDefault, o)
+
+	key := makeCacheKey(columns, nzDefaults)
+	erasureInsertCacheMut.RLock()
+	cache, cached := erasureInsertCache[key]
+	erasureInsertCacheMut.RUnlock()
+
+	if !cached {
+		wl, returnColumns := columns.InsertColumnSet(
+			erasureAllColumns,
+			erasureColumnsWithDefault,
+			erasureColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		cache.valueMapping, err = queries.BindMapping(erasureType, erasureMapping, wl)
+		if err != nil {
+			return err
+		}
+		cache.retMapping, err = queries.BindMapping(erasureType, erasureMapping, returnColumns)
+		if err != nil {
+			return err
+		}
+		if len(wl) != 0 {
+			cache.query = fmt.Sprintf("INSERT INTO \"trips_api\".\"erasures\" (\"%s\") %%sVALUES (%s)%%s", strings.Join(wl, "\",\""), strmangle.Placeholders(dialect.UseIndexPlaceholders, len(wl), 1, 1))
+		} else {
+			cache.query = "INSERT INTO \"trips_api\".\"erasures\" %sDEFAULT VALUES%s"
+		}
+
+		var queryOutput, queryReturning string
+
+		if len(cache.retMapping) != 0 {
+			queryReturning = fmt.Sprintf(" RETURNING \"%s\"", strings.Join(returnColumns, "\",\""))
+		}
+
+		cache.query = fmt.Sprintf(cache.query, queryOutput, queryReturning)
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRowContext(ctx, cache.query, vals...).Scan(queries.PtrsFromMapping(value, cache.retMapping)...)
+	} else {
+		_, err = exec.ExecContext(ctx, cache.query, vals...)
+	}
+
+	if err != nil {
+		return errors.Wrap(err, "models: unable to insert into erasures")
+	}
+
+	if !cached {
+		erasureInsertCacheMut.Lock()
+		erasureInsertCache[key] = cache
+		erasureInsertCacheMut.Unlock()
+	}
+
+	return o.doAfterInsertHooks(ctx, exec)
+}
+
+// Update uses an executor to update the Erasure.
+// See boil.Columns.UpdateColumnSet documentation to understand column list inference for updates.
+// Update does not automatically update the record in case of default values. Use .Reload() to refresh the records.
+func (o *Erasure) Update(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) (int64, error) {
+	var err error
+	if err = o.doBeforeUpdateHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+	key := makeCacheKey(columns, nil)
+	erasureUpdateCacheMut.RLock()
+	cache, cached := erasureUpdateCache[key]
+	erasureUpdateCacheMut.RUnlock()
+
+	if !cached {
+		wl := columns.UpdateColumnSet(
+			erasureAllColumns,
+			erasurePrimaryKeyColumns,
+		)
+
+		if !columns.IsWhitelist() {
+			wl = strmangle.SetComplement(wl, []string{"created_at"})
+		}
+		if len(wl) == 0 {
+			return 0, errors.New("models: unable to update erasures, could not build whitelist")
+		}
+
+		cache.query = fmt.Sprintf("UPDATE \"trips_api\".\"erasures\" SET %s WHERE %s",
+			strmangle.SetParamNames("\"", "\"", 1, wl),
+			strmangle.WhereClause("\"", "\"", len(wl)+1, erasurePrimaryKeyColumns),
+		)
+		cache.valueMapping, err = queries.BindMapping(erasureType, erasureMapping, append(wl, erasurePrimaryKeyColumns...))
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	values := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, values)
+	}
+	var result sql.Result
+	result, err = exec.ExecContext(ctx, cache.query, values...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update erasures row")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by update for erasures")
+	}
+
+	if !cached {
+		erasureUpdateCacheMut.Lock()
+		erasureUpdateCache[key] = cache
+		erasureUpdateCacheMut.Unlock()
+	}
+
+	return rowsAff, o.doAfterUpdateHooks(ctx, exec)
+}
+
+// UpdateAll updates all rows with the specified column values.
+func (q erasureQuery) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	queries.SetUpdate(q.Query, cols)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update all for erasures")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to retrieve rows affected for erasures")
+	}
+
+	return rowsAff, nil
+}
+
+// UpdateAll updates all rows with the specified column values, using an executor.
+func (o ErasureSlice) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	ln := int64(len(o))
+	if ln == 0 {
+		return 0, nil
+	}
+
+	if len(cols) == 0 {
+		return 0, errors.New("models: update all requires at least one column argument")
+	}
+
+	colNames := make([]string, len(cols))
+	args := make([]interface{}, len(cols))
+
+	i := 0
+	for name, value := range cols {
+		colNames[i] = name
+		args[i] = value
+		i++
+	}
+
+	// Append all of the primary key values for each column
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), erasurePrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := fmt.Sprintf("UPDATE \"trips_api\".\"erasures\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, colNames),
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), len(colNames)+1, erasurePrimaryKeyColumns, len(o)))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update all in erasure slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to retrieve rows affected all in update all erasure")
+	}
+	return rowsAff, nil
+}
+
+// Upsert attempts an insert using an executor, and does an update or ignore on conflict.
+// See boil.Columns documentation for how to properly use updateColumns and insertColumns.
+func (o *Erasure) Upsert(ctx context.Context, exec boil.ContextExecutor, updateOnConflict bool, conflictColumns []string, updateColumns, insertColumns boil.Columns, opts ...UpsertOptionFunc) error {
+	if o == nil {
+		return errors.New("models: no erasures provided for upsert")
+	}
+	if !boil.TimestampsAreSkipped(ctx) {
+		currTime := time.Now().In(boil.GetLocation())
+
+		if o.CreatedAt.IsZero() {
+			o.CreatedAt = currTime
+		}
+	}
+
+	if err := o.doBeforeUpsertHooks(ctx, exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(erasureColumnsWithDefault, o)
+
+	// Build cache key in-line uglily - mysql vs psql problems
+	buf := strmangle.GetBuffer()
+	if updateOnConflict {
+		buf.WriteByte('t')
+	} else {
+		buf.WriteByte('f')
+	}
+	buf.WriteByte('.')
+	for _, c := range conflictColumns {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(updateColumns.Kind))
+	for _, c := range updateColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(insertColumns.Kind))
+	for _, c := range insertColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	for _, c := range nzDefaults {
+		buf.WriteString(c)
+	}
+	key := buf.String()
+	strmangle.PutBuffer(buf)
+
+	erasureUpsertCacheMut.RLock()
+	cache, cached := erasureUpsertCache[key]
+	erasureUpsertCacheMut.RUnlock()
+
+	var err error
+
+	if !cached {
+		insert, _ := insertColumns.InsertColumnSet(
+			erasureAllColumns,
+			erasureColumnsWithDefault,
+			erasureColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		update := updateColumns.UpdateColumnSet(
+			erasureAllColumns,
+			erasurePrimaryKeyColumns,
+		)
+
+		if updateOnConflict && len(update) == 0 {
+			return errors.New("models: unable to upsert erasures, could not build update column list")
+		}
+
+		ret := strmangle.SetComplement(erasureAllColumns, strmangle.SetIntersect(insert, update))
+
+		conflict := conflictColumns
+		if len(conflict) == 0 && updateOnConflict && len(update) != 0 {
+			if len(erasurePrimaryKeyColumns) == 0 {
+				return errors.New("models: unable to upsert erasures, could not build conflict column list")
+			}
+
+			conflict = make([]string, len(erasurePrimaryKeyColumns))
+			copy(conflict, erasurePrimaryKeyColumns)
+		}
+		cache.query = buildUpsertQueryPostgres(dialect, "\"trips_api\".\"erasures\"", updateOnConflict, ret, update, conflict, insert, opts...)
+
+		cache.valueMapping, err = queries.BindMapping(erasureType, erasureMapping, insert)
+		if err != nil {
+			return err
+		}
+		if len(ret) != 0 {
+			cache.retMapping, err = queries.BindMapping(erasureType, erasureMapping, ret)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+	var returns []interface{}
+	if len(cache.retMapping) != 0 {
+		returns = queries.PtrsFromMapping(value, cache.retMapping)
+	}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRowContext(ctx, cache.query, vals...).Scan(returns...)
+		if errors.Is(err, sql.ErrNoRows) {
+			err = nil // Postgres doesn't return anything when there's no update
+		}
+	} else {
+		_, err = exec.ExecContext(ctx, cache.query, vals...)
+	}
+	if err != nil {
+		return errors.Wrap(err, "models: unable to upsert erasures")
+	}
+
+	if !cached {
+		erasureUpsertCacheMut.Lock()
+		erasureUpsertCache[key] = cache
+		erasureUpsertCacheMut.Unlock()
+	}
+
+	return o.doAfterUpsertHooks(ctx, exec)
+}
+
+// Delete deletes a single Erasure record with an executor.
+// Delete will match against the primary key column to find the record to delete.
+func (o *Erasure) Delete(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if o == nil {
+		return 0, errors.New("models: no Erasure provided for delete")
+	}
+
+	if err := o.doBeforeDeleteHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+
+	args := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), erasurePrimaryKeyMapping)
+	sql := "DELETE FROM \"trips_api\".\"erasures\" WHERE \"id\"=$1"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete from erasures")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by delete for erasures")
+	}
+
+	if err := o.doAfterDeleteHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all matching rows.
+func (q erasureQuery) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if q.Query == nil {
+		return 0, errors.New("models: no erasureQuery provided for delete all")
+	}
+
+	queries.SetDelete(q.Query)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete all from erasures")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by deleteall for erasures")
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all rows in the slice, using an executor.
+func (o ErasureSlice) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if len(o) == 0 {
+		return 0, nil
+	}
+
+	if len(erasureBeforeDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doBeforeDeleteHooks(ctx, exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	var args []interface{}
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), erasurePrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "DELETE FROM \"trips_api\".\"erasures\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, erasurePrimaryKeyColumns, len(o))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete all from erasure slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by deleteall for erasures")
+	}
+
+	if len(erasureAfterDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterDeleteHooks(ctx, exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	return rowsAff, nil
+}
+
+// Reload refetches the object from the database
+// using the primary keys with an executor.
+func (o *Erasure) Reload(ctx context.Context, exec boil.ContextExecutor) error {
+	ret, err := FindErasure(ctx, exec, o.ID)
+	if err != nil {
+		return err
+	}
+
+	*o = *ret
+	return nil
+}
+
+// ReloadAll refetches every row with matching primary key column values
+// and overwrites the original object slice with the newly updated slice.
+func (o *ErasureSlice) ReloadAll(ctx context.Context, exec boil.ContextExecutor) error {
+	if o == nil || len(*o) == 0 {
+		return nil
+	}
+
+	slice := ErasureSlice{}
+	var args []interface{}
+	for _, obj := range *o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), erasurePrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "SELECT \"trips_api\".\"erasures\".* FROM \"trips_api\".\"erasures\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, erasurePrimaryKeyColumns, len(*o))
+
+	q := queries.Raw(sql, args...)
+
+	err := q.Bind(ctx, exec, &slice)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to reload all in ErasureSlice")
+	}
+
+	*o = slice
+
+	return nil
+}
+
+// ErasureExists checks if the Erasure row exists.
+func ErasureExists(ctx context.Context, exec boil.ContextExecutor, iD string) (bool, error) {
+	var exists bool
+	sql := "select exists(select 1 from \"trips_api\".\"erasures\" where \"id\"=$1 limit 1)"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, iD)
+	}
+	row := exec.QueryRowContext(ctx, sql, iD)
+
+	err := row.Scan(&exists)
+	if err != nil {
+		return false, errors.Wrap(err, "models: unable to check if erasures exists")
+	}
+
+	return exists, nil
+}
+
+// Exists checks if the Erasure row exists.
+func (o *Erasure) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	return ErasureExists(ctx, exec, o.ID)
+}
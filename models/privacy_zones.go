@@ -0,0 +1,999 @@
+// Code generated by SQLBoiler 4.16.2 (https://github.com/volatiletech/sqlboiler). DO NOT EDIT.
+// This file is meant to be re-generated in place and/or deleted at any time.
+
+package models
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/friendsofgo/errors"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"github.com/volatiletech/sqlboiler/v4/queries/qmhelper"
+	"github.com/volatiletech/sqlboiler/v4/types/pgeo"
+	"github.com/volatiletech/strmangle"
+)
+
+// PrivacyZone is an object representing the database table.
+type PrivacyZone struct {
+	ID             string     `boil:"id" json:"id" toml:"id" yaml:"id"`
+	VehicleTokenID int        `boil:"vehicle_token_id" json:"vehicle_token_id" toml:"vehicle_token_id" yaml:"vehicle_token_id"`
+	Name           string     `boil:"name" json:"name" toml:"name" yaml:"name"`
+	Center         pgeo.Point `boil:"center" json:"center" toml:"center" yaml:"center"`
+	RadiusM        int        `boil:"radius_m" json:"radius_m" toml:"radius_m" yaml:"radius_m"`
+	Mask           string     `boil:"mask" json:"mask" toml:"mask" yaml:"mask"`
+	CreatedAt      time.Time  `boil:"created_at" json:"created_at" toml:"created_at" yaml:"created_at"`
+
+	R *privacyZoneR `boil:"-" json:"-" toml:"-" yaml:"-"`
+	L privacyZoneL  `boil:"-" json:"-" toml:"-" yaml:"-"`
+}
+
+var PrivacyZoneColumns = struct {
+	ID             string
+	VehicleTokenID string
+	Name           string
+	Center         string
+	RadiusM        string
+	Mask           string
+	CreatedAt      string
+}{
+	ID:             "id",
+	VehicleTokenID: "vehicle_token_id",
+	Name:           "name",
+	Center:         "center",
+	RadiusM:        "radius_m",
+	Mask:           "mask",
+	CreatedAt:      "created_at",
+}
+
+var PrivacyZoneTableColumns = struct {
+	ID             string
+	VehicleTokenID string
+	Name           string
+	Center         string
+	RadiusM        string
+	Mask           string
+	CreatedAt      string
+}{
+	ID:             "privacy_zones.id",
+	VehicleTokenID: "privacy_zones.vehicle_token_id",
+	Name:           "privacy_zones.name",
+	Center:         "privacy_zones.center",
+	RadiusM:        "privacy_zones.radius_m",
+	Mask:           "privacy_zones.mask",
+	CreatedAt:      "privacy_zones.created_at",
+}
+
+// Generated where
+
+type whereHelperpgeo_Point struct{ field string }
+
+func (w whereHelperpgeo_Point) EQ(x pgeo.Point) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.EQ, x)
+}
+func (w whereHelperpgeo_Point) NEQ(x pgeo.Point) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.NEQ, x)
+}
+func (w whereHelperpgeo_Point) LT(x pgeo.Point) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.LT, x)
+}
+func (w whereHelperpgeo_Point) LTE(x pgeo.Point) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.LTE, x)
+}
+func (w whereHelperpgeo_Point) GT(x pgeo.Point) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.GT, x)
+}
+func (w whereHelperpgeo_Point) GTE(x pgeo.Point) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.GTE, x)
+}
+
+var PrivacyZoneWhere = struct {
+	ID             whereHelperstring
+	VehicleTokenID whereHelperint
+	Name           whereHelperstring
+	Center         whereHelperpgeo_Point
+	RadiusM        whereHelperint
+	Mask           whereHelperstring
+	CreatedAt      whereHelpertime_Time
+}{
+	ID:             whereHelperstring{field: "\"trips_api\".\"privacy_zones\".\"id\""},
+	VehicleTokenID: whereHelperint{field: "\"trips_api\".\"privacy_zones\".\"vehicle_token_id\""},
+	Name:           whereHelperstring{field: "\"trips_api\".\"privacy_zones\".\"name\""},
+	Center:         whereHelperpgeo_Point{field: "\"trips_api\".\"privacy_zones\".\"center\""},
+	RadiusM:        whereHelperint{field: "\"trips_api\".\"privacy_zones\".\"radius_m\""},
+	Mask:           whereHelperstring{field: "\"trips_api\".\"privacy_zones\".\"mask\""},
+	CreatedAt:      whereHelpertime_Time{field: "\"trips_api\".\"privacy_zones\".\"created_at\""},
+}
+
+// PrivacyZoneRels is where relationship names are stored.
+var PrivacyZoneRels = struct {
+}{}
+
+// privacyZoneR is where relationships are stored.
+type privacyZoneR struct {
+}
+
+// NewStruct creates a new relationship struct
+func (*privacyZoneR) NewStruct() *privacyZoneR {
+	return &privacyZoneR{}
+}
+
+// privacyZoneL is where Load methods for each relationship are stored.
+type privacyZoneL struct{}
+
+var (
+	privacyZoneAllColumns            = []string{"id", "vehicle_token_id", "name", "center", "radius_m", "mask", "created_at"}
+	privacyZoneColumnsWithoutDefault = []string{"id", "vehicle_token_id", "name", "center", "radius_m"}
+	privacyZoneColumnsWithDefault    = []string{"mask", "created_at"}
+	privacyZonePrimaryKeyColumns     = []string{"id"}
+	privacyZoneGeneratedColumns      = []string{}
+)
+
+type (
+	// PrivacyZoneSlice is an alias for a slice of pointers to PrivacyZone.
+	// This should almost always be used instead of []PrivacyZone.
+	PrivacyZoneSlice []*PrivacyZone
+	// PrivacyZoneHook is the signature for custom PrivacyZone hook methods
+	PrivacyZoneHook func(context.Context, boil.ContextExecutor, *PrivacyZone) error
+
+	privacyZoneQuery struct {
+		*queries.Query
+	}
+)
+
+// Cache for insert, update and upsert
+var (
+	privacyZoneType                 = reflect.TypeOf(&PrivacyZone{})
+	privacyZoneMapping              = queries.MakeStructMapping(privacyZoneType)
+	privacyZonePrimaryKeyMapping, _ = queries.BindMapping(privacyZoneType, privacyZoneMapping, privacyZonePrimaryKeyColumns)
+	privacyZoneInsertCacheMut       sync.RWMutex
+	privacyZoneInsertCache          = make(map[string]insertCache)
+	privacyZoneUpdateCacheMut       sync.RWMutex
+	privacyZoneUpdateCache          = make(map[string]updateCache)
+	privacyZoneUpsertCacheMut       sync.RWMutex
+	privacyZoneUpsertCache          = make(map[string]insertCache)
+)
+
+var (
+	// Force time package dependency for automated UpdatedAt/CreatedAt.
+	_ = time.Second
+	// Force qmhelper dependency for where clause generation (which doesn't
+	// always happen)
+	_ = qmhelper.Where
+)
+
+var privacyZoneAfterSelectMu sync.Mutex
+var privacyZoneAfterSelectHooks []PrivacyZoneHook
+
+var privacyZoneBeforeInsertMu sync.Mutex
+var privacyZoneBeforeInsertHooks []PrivacyZoneHook
+var privacyZoneAfterInsertMu sync.Mutex
+var privacyZoneAfterInsertHooks []PrivacyZoneHook
+
+var privacyZoneBeforeUpdateMu sync.Mutex
+var privacyZoneBeforeUpdateHooks []PrivacyZoneHook
+var privacyZoneAfterUpdateMu sync.Mutex
+var privacyZoneAfterUpdateHooks []PrivacyZoneHook
+
+var privacyZoneBeforeDeleteMu sync.Mutex
+var privacyZoneBeforeDeleteHooks []PrivacyZoneHook
+var privacyZoneAfterDeleteMu sync.Mutex
+var privacyZoneAfterDeleteHooks []PrivacyZoneHook
+
+var privacyZoneBeforeUpsertMu sync.Mutex
+var privacyZoneBeforeUpsertHooks []PrivacyZoneHook
+var privacyZoneAfterUpsertMu sync.Mutex
+var privacyZoneAfterUpsertHooks []PrivacyZoneHook
+
+// doAfterSelectHooks executes all "after Select" hooks.
+func (o *PrivacyZone) doAfterSelectHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range privacyZoneAfterSelectHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeInsertHooks executes all "before insert" hooks.
+func (o *PrivacyZone) doBeforeInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range privacyZoneBeforeInsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterInsertHooks executes all "after Insert" hooks.
+func (o *PrivacyZone) doAfterInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range privacyZoneAfterInsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpdateHooks executes all "before Update" hooks.
+func (o *PrivacyZone) doBeforeUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range privacyZoneBeforeUpdateHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpdateHooks executes all "after Update" hooks.
+func (o *PrivacyZone) doAfterUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range privacyZoneAfterUpdateHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeDeleteHooks executes all "before Delete" hooks.
+func (o *PrivacyZone) doBeforeDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range privacyZoneBeforeDeleteHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterDeleteHooks executes all "after Delete" hooks.
+func (o *PrivacyZone) doAfterDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range privacyZoneAfterDeleteHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpsertHooks executes all "before Upsert" hooks.
+func (o *PrivacyZone) doBeforeUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range privacyZoneBeforeUpsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpsertHooks executes all "after Upsert" hooks.
+func (o *PrivacyZone) doAfterUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range privacyZoneAfterUpsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// AddPrivacyZoneHook registers your hook function for all future operations.
+func AddPrivacyZoneHook(hookPoint boil.HookPoint, privacyZoneHook PrivacyZoneHook) {
+	switch hookPoint {
+	case boil.AfterSelectHook:
+		privacyZoneAfterSelectMu.Lock()
+		privacyZoneAfterSelectHooks = append(privacyZoneAfterSelectHooks, privacyZoneHook)
+		privacyZoneAfterSelectMu.Unlock()
+	case boil.BeforeInsertHook:
+		privacyZoneBeforeInsertMu.Lock()
+		privacyZoneBeforeInsertHooks = append(privacyZoneBeforeInsertHooks, privacyZoneHook)
+		privacyZoneBeforeInsertMu.Unlock()
+	case boil.AfterInsertHook:
+		privacyZoneAfterInsertMu.Lock()
+		privacyZoneAfterInsertHooks = append(privacyZoneAfterInsertHooks, privacyZoneHook)
+		privacyZoneAfterInsertMu.Unlock()
+	case boil.BeforeUpdateHook:
+		privacyZoneBeforeUpdateMu.Lock()
+		privacyZoneBeforeUpdateHooks = append(privacyZoneBeforeUpdateHooks, privacyZoneHook)
+		privacyZoneBeforeUpdateMu.Unlock()
+	case boil.AfterUpdateHook:
+		privacyZoneAfterUpdateMu.Lock()
+		privacyZoneAfterUpdateHooks = append(privacyZoneAfterUpdateHooks, privacyZoneHook)
+		privacyZoneAfterUpdateMu.Unlock()
+	case boil.BeforeDeleteHook:
+		privacyZoneBeforeDeleteMu.Lock()
+		privacyZoneBeforeDeleteHooks = append(privacyZoneBeforeDeleteHooks, privacyZoneHook)
+		privacyZoneBeforeDeleteMu.Unlock()
+	case boil.AfterDeleteHook:
+		privacyZoneAfterDeleteMu.Lock()
+		privacyZoneAfterDeleteHooks = append(privacyZoneAfterDeleteHooks, privacyZoneHook)
+		privacyZoneAfterDeleteMu.Unlock()
+	case boil.BeforeUpsertHook:
+		privacyZoneBeforeUpsertMu.Lock()
+		privacyZoneBeforeUpsertHooks = append(privacyZoneBeforeUpsertHooks, privacyZoneHook)
+		privacyZoneBeforeUpsertMu.Unlock()
+	case boil.AfterUpsertHook:
+		privacyZoneAfterUpsertMu.Lock()
+		privacyZoneAfterUpsertHooks = append(privacyZoneAfterUpsertHooks, privacyZoneHook)
+		privacyZoneAfterUpsertMu.Unlock()
+	}
+}
+
+// One returns a single privacyZone record from the query.
+func (q privacyZoneQuery) One(ctx context.Context, exec boil.ContextExecutor) (*PrivacyZone, error) {
+	o := &PrivacyZone{}
+
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Bind(ctx, exec, o)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "models: failed to execute a one query for privacy_zones")
+	}
+
+	if err := o.doAfterSelectHooks(ctx, exec); err != nil {
+		return o, err
+	}
+
+	return o, nil
+}
+
+// All returns all PrivacyZone records from the query.
+func (q privacyZoneQuery) All(ctx context.Context, exec boil.ContextExecutor) (PrivacyZoneSlice, error) {
+	var o []*PrivacyZone
+
+	err := q.Bind(ctx, exec, &o)
+	if err != nil {
+		return nil, errors.Wrap(err, "models: failed to assign all query results to PrivacyZone slice")
+	}
+
+	if len(privacyZoneAfterSelectHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterSelectHooks(ctx, exec); err != nil {
+				return o, err
+			}
+		}
+	}
+
+	return o, nil
+}
+
+// Count returns the count of all PrivacyZone records in the query.
+func (q privacyZoneQuery) Count(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to count privacy_zones rows")
+	}
+
+	return count, nil
+}
+
+// Exists checks if the row exists in the table.
+func (q privacyZoneQuery) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return false, errors.Wrap(err, "models: failed to check if privacy_zones exists")
+	}
+
+	return count > 0, nil
+}
+
+// PrivacyZones retrieves all the records using an executor.
+func PrivacyZones(mods ...qm.QueryMod) privacyZoneQuery {
+	mods = append(mods, qm.From("\"trips_api\".\"privacy_zones\""))
+	q := NewQuery(mods...)
+	if len(queries.GetSelect(q)) == 0 {
+		queries.SetSelect(q, []string{"\"trips_api\".\"privacy_zones\".*"})
+	}
+
+	return privacyZoneQuery{q}
+}
+
+// FindPrivacyZone retrieves a single record by ID with an executor.
+// If selectCols is empty Find will return all columns.
+func FindPrivacyZone(ctx context.Context, exec boil.ContextExecutor, iD string, selectCols ...string) (*PrivacyZone, error) {
+	privacyZoneObj := &PrivacyZone{}
+
+	sel := "*"
+	if len(selectCols) > 0 {
+		sel = strings.Join(strmangle.IdentQuoteSlice(dialect.LQ, dialect.RQ, selectCols), ",")
+	}
+	query := fmt.Sprintf(
+		"select %s from \"trips_api\".\"privacy_zones\" where \"id\"=$1", sel,
+	)
+
+	q := queries.Raw(query, iD)
+
+	err := q.Bind(ctx, exec, privacyZoneObj)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "models: unable to select from privacy_zones")
+	}
+
+	if err = privacyZoneObj.doAfterSelectHooks(ctx, exec); err != nil {
+		return privacyZoneObj, err
+	}
+
+	return privacyZoneObj, nil
+}
+
+// Insert a single record using an executor.
+// See boil.Columns.InsertColumnSet documentation to understand column list inference for inserts.
+func (o *PrivacyZone) Insert(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) error {
+	if o == nil {
+		return errors.New("models: no privacy_zones provided for insertion")
+	}
+
+	var err error
+	if !boil.TimestampsAreSkipped(ctx) {
+		currTime := time.Now().In(boil.GetLocation())
+
+		if o.CreatedAt.IsZero() {
+			o.CreatedAt = currTime
+		}
+	}
+
+	if err := o.doBeforeInsertHooks(ctx, exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(privacyZoneColumnsWithDefault, o)
+
+	key := makeCacheKey(columns, nzDefaults)
+	privacyZoneInsertCacheMut.RLock()
+	cache, cached := privacyZoneInsertCache[key]
+	privacyZoneInsertCacheMut.RUnlock()
+
+	if !cached {
+		wl, returnColumns := columns.InsertColumnSet(
+			privacyZoneAllColumns,
+			privacyZoneColumnsWithDefault,
+			privacyZoneColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		cache.valueMapping, err = queries.BindMapping(privacyZoneType, privacyZoneMapping, wl)
+		if err != nil {
+			return err
+		}
+		cache.retMapping, err = queries.BindMapping(privacyZoneType, privacyZoneMapping, returnColumns)
+		if err != nil {
+			return err
+		}
+		if len(wl) != 0 {
+			cache.query = fmt.Sprintf("INSERT INTO \"trips_api\".\"privacy_zones\" (\"%s\") %%sVALUES (%s)%%s", strings.Join(wl, "\",\""), strmangle.Placeholders(dialect.UseIndexPlaceholders, len(wl), 1, 1))
+		} else {
+			cache.query = "INSERT INTO \"trips_api\".\"privacy_zones\" %sDEFAULT VALUES%s"
+		}
+
+		var queryOutput, queryReturning string
+
+		if len(cache.retMapping) != 0 {
+			queryReturning = fmt.Sprintf(" RETURNING \"%s\"", strings.Join(returnColumns, "\",\""))
+		}
+
+		cache.query = fmt.Sprintf(cache.query, queryOutput, queryReturning)
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRowContext(ctx, cache.query, vals...).Scan(queries.PtrsFromMapping(value, cache.retMapping)...)
+	} else {
+		_, err = exec.ExecContext(ctx, cache.query, vals...)
+	}
+
+	if err != nil {
+		return errors.Wrap(err, "models: unable to insert into privacy_zones")
+	}
+
+	if !cached {
+		privacyZoneInsertCacheMut.Lock()
+		privacyZoneInsertCache[key] = cache
+		privacyZoneInsertCacheMut.Unlock()
+	}
+
+	return o.doAfterInsertHooks(ctx, exec)
+}
+
+// Update uses an executor to update the PrivacyZone.
+// See boil.Columns.UpdateColumnSet documentation to understand column list inference for updates.
+// Update does not automatically update the record in case of default values. Use .Reload() to refresh the records.
+func (o *PrivacyZone) Update(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) (int64, error) {
+	var err error
+	if err = o.doBeforeUpdateHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+	key := makeCacheKey(columns, nil)
+	privacyZoneUpdateCacheMut.RLock()
+	cache, cached := privacyZoneUpdateCache[key]
+	privacyZoneUpdateCacheMut.RUnlock()
+
+	if !cached {
+		wl := columns.UpdateColumnSet(
+			privacyZoneAllColumns,
+			privacyZonePrimaryKeyColumns,
+		)
+
+		if !columns.IsWhitelist() {
+			wl = strmangle.SetComplement(wl, []string{"created_at"})
+		}
+		if len(wl) == 0 {
+			return 0, errors.New("models: unable to update privacy_zones, could not build whitelist")
+		}
+
+		cache.query = fmt.Sprintf("UPDATE \"trips_api\".\"privacy_zones\" SET %s WHERE %s",
+			strmangle.SetParamNames("\"", "\"", 1, wl),
+			strmangle.WhereClause("\"", "\"", len(wl)+1, privacyZonePrimaryKeyColumns),
+		)
+		cache.valueMapping, err = queries.BindMapping(privacyZoneType, privacyZoneMapping, append(wl, privacyZonePrimaryKeyColumns...))
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	values := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, values)
+	}
+	var result sql.Result
+	result, err = exec.ExecContext(ctx, cache.query, values...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update privacy_zones row")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by update for privacy_zones")
+	}
+
+	if !cached {
+		privacyZoneUpdateCacheMut.Lock()
+		privacyZoneUpdateCache[key] = cache
+		privacyZoneUpdateCacheMut.Unlock()
+	}
+
+	return rowsAff, o.doAfterUpdateHooks(ctx, exec)
+}
+
+// UpdateAll updates all rows with the specified column values.
+func (q privacyZoneQuery) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	queries.SetUpdate(q.Query, cols)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update all for privacy_zones")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to retrieve rows affected for privacy_zones")
+	}
+
+	return rowsAff, nil
+}
+
+// UpdateAll updates all rows with the specified column values, using an executor.
+func (o PrivacyZoneSlice) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	ln := int64(len(o))
+	if ln == 0 {
+		return 0, nil
+	}
+
+	if len(cols) == 0 {
+		return 0, errors.New("models: update all requires at least one column argument")
+	}
+
+	colNames := make([]string, len(cols))
+	args := make([]interface{}, len(cols))
+
+	i := 0
+	for name, value := range cols {
+		colNames[i] = name
+		args[i] = value
+		i++
+	}
+
+	// Append all of the primary key values for each column
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), privacyZonePrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := fmt.Sprintf("UPDATE \"trips_api\".\"privacy_zones\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, colNames),
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), len(colNames)+1, privacyZonePrimaryKeyColumns, len(o)))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update all in privacyZone slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to retrieve rows affected all in update all privacyZone")
+	}
+	return rowsAff, nil
+}
+
+// Upsert attempts an insert using an executor, and does an update or ignore on conflict.
+// See boil.Columns documentation for how to properly use updateColumns and insertColumns.
+func (o *PrivacyZone) Upsert(ctx context.Context, exec boil.ContextExecutor, updateOnConflict bool, conflictColumns []string, updateColumns, insertColumns boil.Columns, opts ...UpsertOptionFunc) error {
+	if o == nil {
+		return errors.New("models: no privacy_zones provided for upsert")
+	}
+	if !boil.TimestampsAreSkipped(ctx) {
+		currTime := time.Now().In(boil.GetLocation())
+
+		if o.CreatedAt.IsZero() {
+			o.CreatedAt = currTime
+		}
+	}
+
+	if err := o.doBeforeUpsertHooks(ctx, exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(privacyZoneColumnsWithDefault, o)
+
+	// Build cache key in-line uglily - mysql vs psql problems
+	buf := strmangle.GetBuffer()
+	if updateOnConflict {
+		buf.WriteByte('t')
+	} else {
+		buf.WriteByte('f')
+	}
+	buf.WriteByte('.')
+	for _, c := range conflictColumns {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(updateColumns.Kind))
+	for _, c := range updateColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(insertColumns.Kind))
+	for _, c := range insertColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	for _, c := range nzDefaults {
+		buf.WriteString(c)
+	}
+	key := buf.String()
+	strmangle.PutBuffer(buf)
+
+	privacyZoneUpsertCacheMut.RLock()
+	cache, cached := privacyZoneUpsertCache[key]
+	privacyZoneUpsertCacheMut.RUnlock()
+
+	var err error
+
+	if !cached {
+		insert, _ := insertColumns.InsertColumnSet(
+			privacyZoneAllColumns,
+			privacyZoneColumnsWithDefault,
+			privacyZoneColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		update := updateColumns.UpdateColumnSet(
+			privacyZoneAllColumns,
+			privacyZonePrimaryKeyColumns,
+		)
+
+		if updateOnConflict && len(update) == 0 {
+			return errors.New("models: unable to upsert privacy_zones, could not build update column list")
+		}
+
+		ret := strmangle.SetComplement(privacyZoneAllColumns, strmangle.SetIntersect(insert, update))
+
+		conflict := conflictColumns
+		if len(conflict) == 0 && updateOnConflict && len(update) != 0 {
+			if len(privacyZonePrimaryKeyColumns) == 0 {
+				return errors.New("models: unable to upsert privacy_zones, could not build conflict column list")
+			}
+
+			conflict = make([]string, len(privacyZonePrimaryKeyColumns))
+			copy(conflict, privacyZonePrimaryKeyColumns)
+		}
+		cache.query = buildUpsertQueryPostgres(dialect, "\"trips_api\".\"privacy_zones\"", updateOnConflict, ret, update, conflict, insert, opts...)
+
+		cache.valueMapping, err = queries.BindMapping(privacyZoneType, privacyZoneMapping, insert)
+		if err != nil {
+			return err
+		}
+		if len(ret) != 0 {
+			cache.retMapping, err = queries.BindMapping(privacyZoneType, privacyZoneMapping, ret)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+	var returns []interface{}
+	if len(cache.retMapping) != 0 {
+		returns = queries.PtrsFromMapping(value, cache.retMapping)
+	}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRowContext(ctx, cache.query, vals...).Scan(returns...)
+		if errors.Is(err, sql.ErrNoRows) {
+			err = nil // Postgres doesn't return anything when there's no update
+		}
+	} else {
+		_, err = exec.ExecContext(ctx, cache.query, vals...)
+	}
+	if err != nil {
+		return errors.Wrap(err, "models: unable to upsert privacy_zones")
+	}
+
+	if !cached {
+		privacyZoneUpsertCacheMut.Lock()
+		privacyZoneUpsertCache[key] = cache
+		privacyZoneUpsertCacheMut.Unlock()
+	}
+
+	return o.doAfterUpsertHooks(ctx, exec)
+}
+
+// Delete deletes a single PrivacyZone record with an executor.
+// Delete will match against the primary key column to find the record to delete.
+func (o *PrivacyZone) Delete(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if o == nil {
+		return 0, errors.New("models: no PrivacyZone provided for delete")
+	}
+
+	if err := o.doBeforeDeleteHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+
+	args := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), privacyZonePrimaryKeyMapping)
+	sql := "DELETE FROM \"trips_api\".\"privacy_zones\" WHERE \"id\"=$1"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete from privacy_zones")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by delete for privacy_zones")
+	}
+
+	if err := o.doAfterDeleteHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all matching rows.
+func (q privacyZoneQuery) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if q.Query == nil {
+		return 0, errors.New("models: no privacyZoneQuery provided for delete all")
+	}
+
+	queries.SetDelete(q.Query)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete all from privacy_zones")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by deleteall for privacy_zones")
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all rows in the slice, using an executor.
+func (o PrivacyZoneSlice) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if len(o) == 0 {
+		return 0, nil
+	}
+
+	if len(privacyZoneBeforeDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doBeforeDeleteHooks(ctx, exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	var args []interface{}
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), privacyZonePrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "DELETE FROM \"trips_api\".\"privacy_zones\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, privacyZonePrimaryKeyColumns, len(o))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete all from privacyZone slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by deleteall for privacy_zones")
+	}
+
+	if len(privacyZoneAfterDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterDeleteHooks(ctx, exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	return rowsAff, nil
+}
+
+// Reload refetches the object from the database
+// using the primary keys with an executor.
+func (o *PrivacyZone) Reload(ctx context.Context, exec boil.ContextExecutor) error {
+	ret, err := FindPrivacyZone(ctx, exec, o.ID)
+	if err != nil {
+		return err
+	}
+
+	*o = *ret
+	return nil
+}
+
+// ReloadAll refetches every row with matching primary key column values
+// and overwrites the original object slice with the newly updated slice.
+func (o *PrivacyZoneSlice) ReloadAll(ctx context.Context, exec boil.ContextExecutor) error {
+	if o == nil || len(*o) == 0 {
+		return nil
+	}
+
+	slice := PrivacyZoneSlice{}
+	var args []interface{}
+	for _, obj := range *o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), privacyZonePrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "SELECT \"trips_api\".\"privacy_zones\".* FROM \"trips_api\".\"privacy_zones\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, privacyZonePrimaryKeyColumns, len(*o))
+
+	q := queries.Raw(sql, args...)
+
+	err := q.Bind(ctx, exec, &slice)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to reload all in PrivacyZoneSlice")
+	}
+
+	*o = slice
+
+	return nil
+}
+
+// PrivacyZoneExists checks if the PrivacyZone row exists.
+func PrivacyZoneExists(ctx context.Context, exec boil.ContextExecutor, iD string) (bool, error) {
+	var exists bool
+	sql := "select exists(select 1 from \"trips_api\".\"privacy_zones\" where \"id\"=$1 limit 1)"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, iD)
+	}
+	row := exec.QueryRowContext(ctx, sql, iD)
+
+	err := row.Scan(&exists)
+	if err != nil {
+		return false, errors.Wrap(err, "models: unable to check if privacy_zones exists")
+	}
+
+	return exists, nil
+}
+
+// Exists checks if the PrivacyZone row exists.
+func (o *PrivacyZone) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	return PrivacyZoneExists(ctx, exec, o.ID)
+}
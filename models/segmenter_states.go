@@ -0,0 +1,975 @@
+// Code generated by SQLBoiler 4.16.2 (https://github.com/volatiletech/sqlboiler). DO NOT EDIT.
+// This file is meant to be re-generated in place and/or deleted at any time.
+
+package models
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/friendsofgo/errors"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"github.com/volatiletech/sqlboiler/v4/queries/qmhelper"
+	"github.com/volatiletech/sqlboiler/v4/types"
+	"github.com/volatiletech/strmangle"
+)
+
+// SegmenterState is an object representing the database table.
+type SegmenterState struct {
+	UserDeviceID string     `boil:"user_device_id" json:"user_device_id" toml:"user_device_id" yaml:"user_device_id"`
+	State        types.JSON `boil:"state" json:"state" toml:"state" yaml:"state"`
+	UpdatedAt    time.Time  `boil:"updated_at" json:"updated_at" toml:"updated_at" yaml:"updated_at"`
+
+	R *segmenterStateR `boil:"-" json:"-" toml:"-" yaml:"-"`
+	L segmenterStateL  `boil:"-" json:"-" toml:"-" yaml:"-"`
+}
+
+var SegmenterStateColumns = struct {
+	UserDeviceID string
+	State        string
+	UpdatedAt    string
+}{
+	UserDeviceID: "user_device_id",
+	State:        "state",
+	UpdatedAt:    "updated_at",
+}
+
+var SegmenterStateTableColumns = struct {
+	UserDeviceID string
+	State        string
+	UpdatedAt    string
+}{
+	UserDeviceID: "segmenter_states.user_device_id",
+	State:        "segmenter_states.state",
+	UpdatedAt:    "segmenter_states.updated_at",
+}
+
+// Generated where
+
+type whereHelpertypes_JSON struct{ field string }
+
+func (w whereHelpertypes_JSON) EQ(x types.JSON) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.EQ, x)
+}
+func (w whereHelpertypes_JSON) NEQ(x types.JSON) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.NEQ, x)
+}
+func (w whereHelpertypes_JSON) LT(x types.JSON) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.LT, x)
+}
+func (w whereHelpertypes_JSON) LTE(x types.JSON) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.LTE, x)
+}
+func (w whereHelpertypes_JSON) GT(x types.JSON) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.GT, x)
+}
+func (w whereHelpertypes_JSON) GTE(x types.JSON) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.GTE, x)
+}
+
+var SegmenterStateWhere = struct {
+	UserDeviceID whereHelperstring
+	State        whereHelpertypes_JSON
+	UpdatedAt    whereHelpertime_Time
+}{
+	UserDeviceID: whereHelperstring{field: "\"trips_api\".\"segmenter_states\".\"user_device_id\""},
+	State:        whereHelpertypes_JSON{field: "\"trips_api\".\"segmenter_states\".\"state\""},
+	UpdatedAt:    whereHelpertime_Time{field: "\"trips_api\".\"segmenter_states\".\"updated_at\""},
+}
+
+// SegmenterStateRels is where relationship names are stored.
+var SegmenterStateRels = struct {
+}{}
+
+// segmenterStateR is where relationships are stored.
+type segmenterStateR struct {
+}
+
+// NewStruct creates a new relationship struct
+func (*segmenterStateR) NewStruct() *segmenterStateR {
+	return &segmenterStateR{}
+}
+
+// segmenterStateL is where Load methods for each relationship are stored.
+type segmenterStateL struct{}
+
+var (
+	segmenterStateAllColumns            = []string{"user_device_id", "state", "updated_at"}
+	segmenterStateColumnsWithoutDefault = []string{"user_device_id", "state"}
+	segmenterStateColumnsWithDefault    = []string{"updated_at"}
+	segmenterStatePrimaryKeyColumns     = []string{"user_device_id"}
+	segmenterStateGeneratedColumns      = []string{}
+)
+
+type (
+	// SegmenterStateSlice is an alias for a slice of pointers to SegmenterState.
+	// This should almost always be used instead of []SegmenterState.
+	SegmenterStateSlice []*SegmenterState
+	// SegmenterStateHook is the signature for custom SegmenterState hook methods
+	SegmenterStateHook func(context.Context, boil.ContextExecutor, *SegmenterState) error
+
+	segmenterStateQuery struct {
+		*queries.Query
+	}
+)
+
+// Cache for insert, update and upsert
+var (
+	segmenterStateType                 = reflect.TypeOf(&SegmenterState{})
+	segmenterStateMapping              = queries.MakeStructMapping(segmenterStateType)
+	segmenterStatePrimaryKeyMapping, _ = queries.BindMapping(segmenterStateType, segmenterStateMapping, segmenterStatePrimaryKeyColumns)
+	segmenterStateInsertCacheMut       sync.RWMutex
+	segmenterStateInsertCache          = make(map[string]insertCache)
+	segmenterStateUpdateCacheMut       sync.RWMutex
+	segmenterStateUpdateCache          = make(map[string]updateCache)
+	segmenterStateUpsertCacheMut       sync.RWMutex
+	segmenterStateUpsertCache          = make(map[string]insertCache)
+)
+
+var (
+	// Force time package dependency for automated UpdatedAt/CreatedAt.
+	_ = time.Second
+	// Force qmhelper dependency for where clause generation (which doesn't
+	// always happen)
+	_ = qmhelper.Where
+)
+
+var segmenterStateAfterSelectMu sync.Mutex
+var segmenterStateAfterSelectHooks []SegmenterStateHook
+
+var segmenterStateBeforeInsertMu sync.Mutex
+var segmenterStateBeforeInsertHooks []SegmenterStateHook
+var segmenterStateAfterInsertMu sync.Mutex
+var segmenterStateAfterInsertHooks []SegmenterStateHook
+
+var segmenterStateBeforeUpdateMu sync.Mutex
+var segmenterStateBeforeUpdateHooks []SegmenterStateHook
+var segmenterStateAfterUpdateMu sync.Mutex
+var segmenterStateAfterUpdateHooks []SegmenterStateHook
+
+var segmenterStateBeforeDeleteMu sync.Mutex
+var segmenterStateBeforeDeleteHooks []SegmenterStateHook
+var segmenterStateAfterDeleteMu sync.Mutex
+var segmenterStateAfterDeleteHooks []SegmenterStateHook
+
+var segmenterStateBeforeUpsertMu sync.Mutex
+var segmenterStateBeforeUpsertHooks []SegmenterStateHook
+var segmenterStateAfterUpsertMu sync.Mutex
+var segmenterStateAfterUpsertHooks []SegmenterStateHook
+
+// doAfterSelectHooks executes all "after Select" hooks.
+func (o *SegmenterState) doAfterSelectHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range segmenterStateAfterSelectHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeInsertHooks executes all "before insert" hooks.
+func (o *SegmenterState) doBeforeInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range segmenterStateBeforeInsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterInsertHooks executes all "after Insert" hooks.
+func (o *SegmenterState) doAfterInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range segmenterStateAfterInsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpdateHooks executes all "before Update" hooks.
+func (o *SegmenterState) doBeforeUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range segmenterStateBeforeUpdateHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpdateHooks executes all "after Update" hooks.
+func (o *SegmenterState) doAfterUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range segmenterStateAfterUpdateHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeDeleteHooks executes all "before Delete" hooks.
+func (o *SegmenterState) doBeforeDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range segmenterStateBeforeDeleteHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterDeleteHooks executes all "after Delete" hooks.
+func (o *SegmenterState) doAfterDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range segmenterStateAfterDeleteHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpsertHooks executes all "before Upsert" hooks.
+func (o *SegmenterState) doBeforeUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range segmenterStateBeforeUpsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpsertHooks executes all "after Upsert" hooks.
+func (o *SegmenterState) doAfterUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range segmenterStateAfterUpsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// AddSegmenterStateHook registers your hook function for all future operations.
+func AddSegmenterStateHook(hookPoint boil.HookPoint, segmenterStateHook SegmenterStateHook) {
+	switch hookPoint {
+	case boil.AfterSelectHook:
+		segmenterStateAfterSelectMu.Lock()
+		segmenterStateAfterSelectHooks = append(segmenterStateAfterSelectHooks, segmenterStateHook)
+		segmenterStateAfterSelectMu.Unlock()
+	case boil.BeforeInsertHook:
+		segmenterStateBeforeInsertMu.Lock()
+		segmenterStateBeforeInsertHooks = append(segmenterStateBeforeInsertHooks, segmenterStateHook)
+		segmenterStateBeforeInsertMu.Unlock()
+	case boil.AfterInsertHook:
+		segmenterStateAfterInsertMu.Lock()
+		segmenterStateAfterInsertHooks = append(segmenterStateAfterInsertHooks, segmenterStateHook)
+		segmenterStateAfterInsertMu.Unlock()
+	case boil.BeforeUpdateHook:
+		segmenterStateBeforeUpdateMu.Lock()
+		segmenterStateBeforeUpdateHooks = append(segmenterStateBeforeUpdateHooks, segmenterStateHook)
+		segmenterStateBeforeUpdateMu.Unlock()
+	case boil.AfterUpdateHook:
+		segmenterStateAfterUpdateMu.Lock()
+		segmenterStateAfterUpdateHooks = append(segmenterStateAfterUpdateHooks, segmenterStateHook)
+		segmenterStateAfterUpdateMu.Unlock()
+	case boil.BeforeDeleteHook:
+		segmenterStateBeforeDeleteMu.Lock()
+		segmenterStateBeforeDeleteHooks = append(segmenterStateBeforeDeleteHooks, segmenterStateHook)
+		segmenterStateBeforeDeleteMu.Unlock()
+	case boil.AfterDeleteHook:
+		segmenterStateAfterDeleteMu.Lock()
+		segmenterStateAfterDeleteHooks = append(segmenterStateAfterDeleteHooks, segmenterStateHook)
+		segmenterStateAfterDeleteMu.Unlock()
+	case boil.BeforeUpsertHook:
+		segmenterStateBeforeUpsertMu.Lock()
+		segmenterStateBeforeUpsertHooks = append(segmenterStateBeforeUpsertHooks, segmenterStateHook)
+		segmenterStateBeforeUpsertMu.Unlock()
+	case boil.AfterUpsertHook:
+		segmenterStateAfterUpsertMu.Lock()
+		segmenterStateAfterUpsertHooks = append(segmenterStateAfterUpsertHooks, segmenterStateHook)
+		segmenterStateAfterUpsertMu.Unlock()
+	}
+}
+
+// One returns a single segmenterState record from the query.
+func (q segmenterStateQuery) One(ctx context.Context, exec boil.ContextExecutor) (*SegmenterState, error) {
+	o := &SegmenterState{}
+
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Bind(ctx, exec, o)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "models: failed to execute a one query for segmenter_states")
+	}
+
+	if err := o.doAfterSelectHooks(ctx, exec); err != nil {
+		return o, err
+	}
+
+	return o, nil
+}
+
+// All returns all SegmenterState records from the query.
+func (q segmenterStateQuery) All(ctx context.Context, exec boil.ContextExecutor) (SegmenterStateSlice, error) {
+	var o []*SegmenterState
+
+	err := q.Bind(ctx, exec, &o)
+	if err != nil {
+		return nil, errors.Wrap(err, "models: failed to assign all query results to SegmenterState slice")
+	}
+
+	if len(segmenterStateAfterSelectHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterSelectHooks(ctx, exec); err != nil {
+				return o, err
+			}
+		}
+	}
+
+	return o, nil
+}
+
+// Count returns the count of all SegmenterState records in the query.
+func (q segmenterStateQuery) Count(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to count segmenter_states rows")
+	}
+
+	return count, nil
+}
+
+// Exists checks if the row exists in the table.
+func (q segmenterStateQuery) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return false, errors.Wrap(err, "models: failed to check if segmenter_states exists")
+	}
+
+	return count > 0, nil
+}
+
+// SegmenterStates retrieves all the records using an executor.
+func SegmenterStates(mods ...qm.QueryMod) segmenterStateQuery {
+	mods = append(mods, qm.From("\"trips_api\".\"segmenter_states\""))
+	q := NewQuery(mods...)
+	if len(queries.GetSelect(q)) == 0 {
+		queries.SetSelect(q, []string{"\"trips_api\".\"segmenter_states\".*"})
+	}
+
+	return segmenterStateQuery{q}
+}
+
+// FindSegmenterState retrieves a single record by ID with an executor.
+// If selectCols is empty Find will return all columns.
+func FindSegmenterState(ctx context.Context, exec boil.ContextExecutor, userDeviceID string, selectCols ...string) (*SegmenterState, error) {
+	segmenterStateObj := &SegmenterState{}
+
+	sel := "*"
+	if len(selectCols) > 0 {
+		sel = strings.Join(strmangle.IdentQuoteSlice(dialect.LQ, dialect.RQ, selectCols), ",")
+	}
+	query := fmt.Sprintf(
+		"select %s from \"trips_api\".\"segmenter_states\" where \"user_device_id\"=$1", sel,
+	)
+
+	q := queries.Raw(query, userDeviceID)
+
+	err := q.Bind(ctx, exec, segmenterStateObj)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "models: unable to select from segmenter_states")
+	}
+
+	if err = segmenterStateObj.doAfterSelectHooks(ctx, exec); err != nil {
+		return segmenterStateObj, err
+	}
+
+	return segmenterStateObj, nil
+}
+
+// Insert a single record using an executor.
+// See boil.Columns.InsertColumnSet documentation to understand column list inference for inserts.
+func (o *SegmenterState) Insert(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) error {
+	if o == nil {
+		return errors.New("models: no segmenter_states provided for insertion")
+	}
+
+	var err error
+	if !boil.TimestampsAreSkipped(ctx) {
+		currTime := time.Now().In(boil.GetLocation())
+
+		if o.UpdatedAt.IsZero() {
+			o.UpdatedAt = currTime
+		}
+	}
+
+	if err := o.doBeforeInsertHooks(ctx, exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(segmenterStateColumnsWithDefault, o)
+
+	key := makeCacheKey(columns, nzDefaults)
+	segmenterStateInsertCacheMut.RLock()
+	cache, cached := segmenterStateInsertCache[key]
+	segmenterStateInsertCacheMut.RUnlock()
+
+	if !cached {
+		wl, returnColumns := columns.InsertColumnSet(
+			segmenterStateAllColumns,
+			segmenterStateColumnsWithDefault,
+			segmenterStateColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		cache.valueMapping, err = queries.BindMapping(segmenterStateType, segmenterStateMapping, wl)
+		if err != nil {
+			return err
+		}
+		cache.retMapping, err = queries.BindMapping(segmenterStateType, segmenterStateMapping, returnColumns)
+		if err != nil {
+			return err
+		}
+		if len(wl) != 0 {
+			cache.query = fmt.Sprintf("INSERT INTO \"trips_api\".\"segmenter_states\" (\"%s\") %%sVALUES (%s)%%s", strings.Join(wl, "\",\""), strmangle.Placeholders(dialect.UseIndexPlaceholders, len(wl), 1, 1))
+		} else {
+			cache.query = "INSERT INTO \"trips_api\".\"segmenter_states\" %sDEFAULT VALUES%s"
+		}
+
+		var queryOutput, queryReturning string
+
+		if len(cache.retMapping) != 0 {
+			queryReturning = fmt.Sprintf(" RETURNING \"%s\"", strings.Join(returnColumns, "\",\""))
+		}
+
+		cache.query = fmt.Sprintf(cache.query, queryOutput, queryReturning)
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRowContext(ctx, cache.query, vals...).Scan(queries.PtrsFromMapping(value, cache.retMapping)...)
+	} else {
+		_, err = exec.ExecContext(ctx, cache.query, vals...)
+	}
+
+	if err != nil {
+		return errors.Wrap(err, "models: unable to insert into segmenter_states")
+	}
+
+	if !cached {
+		segmenterStateInsertCacheMut.Lock()
+		segmenterStateInsertCache[key] = cache
+		segmenterStateInsertCacheMut.Unlock()
+	}
+
+	return o.doAfterInsertHooks(ctx, exec)
+}
+
+// Update uses an executor to update the SegmenterState.
+// See boil.Columns.UpdateColumnSet documentation to understand column list inference for updates.
+// Update does not automatically update the record in case of default values. Use .Reload() to refresh the records.
+func (o *SegmenterState) Update(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) (int64, error) {
+	if !boil.TimestampsAreSkipped(ctx) {
+		currTime := time.Now().In(boil.GetLocation())
+
+		o.UpdatedAt = currTime
+	}
+
+	var err error
+	if err = o.doBeforeUpdateHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+	key := makeCacheKey(columns, nil)
+	segmenterStateUpdateCacheMut.RLock()
+	cache, cached := segmenterStateUpdateCache[key]
+	segmenterStateUpdateCacheMut.RUnlock()
+
+	if !cached {
+		wl := columns.UpdateColumnSet(
+			segmenterStateAllColumns,
+			segmenterStatePrimaryKeyColumns,
+		)
+
+		if !columns.IsWhitelist() {
+			wl = strmangle.SetComplement(wl, []string{"created_at"})
+		}
+		if len(wl) == 0 {
+			return 0, errors.New("models: unable to update segmenter_states, could not build whitelist")
+		}
+
+		cache.query = fmt.Sprintf("UPDATE \"trips_api\".\"segmenter_states\" SET %s WHERE %s",
+			strmangle.SetParamNames("\"", "\"", 1, wl),
+			strmangle.WhereClause("\"", "\"", len(wl)+1, segmenterStatePrimaryKeyColumns),
+		)
+		cache.valueMapping, err = queries.BindMapping(segmenterStateType, segmenterStateMapping, append(wl, segmenterStatePrimaryKeyColumns...))
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	values := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, values)
+	}
+	var result sql.Result
+	result, err = exec.ExecContext(ctx, cache.query, values...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update segmenter_states row")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by update for segmenter_states")
+	}
+
+	if !cached {
+		segmenterStateUpdateCacheMut.Lock()
+		segmenterStateUpdateCache[key] = cache
+		segmenterStateUpdateCacheMut.Unlock()
+	}
+
+	return rowsAff, o.doAfterUpdateHooks(ctx, exec)
+}
+
+// UpdateAll updates all rows with the specified column values.
+func (q segmenterStateQuery) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	queries.SetUpdate(q.Query, cols)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update all for segmenter_states")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to retrieve rows affected for segmenter_states")
+	}
+
+	return rowsAff, nil
+}
+
+// UpdateAll updates all rows with the specified column values, using an executor.
+func (o SegmenterStateSlice) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	ln := int64(len(o))
+	if ln == 0 {
+		return 0, nil
+	}
+
+	if len(cols) == 0 {
+		return 0, errors.New("models: update all requires at least one column argument")
+	}
+
+	colNames := make([]string, len(cols))
+	args := make([]interface{}, len(cols))
+
+	i := 0
+	for name, value := range cols {
+		colNames[i] = name
+		args[i] = value
+		i++
+	}
+
+	// Append all of the primary key values for each column
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), segmenterStatePrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := fmt.Sprintf("UPDATE \"trips_api\".\"segmenter_states\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, colNames),
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), len(colNames)+1, segmenterStatePrimaryKeyColumns, len(o)))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update all in segmenterState slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to retrieve rows affected all in update all segmenterState")
+	}
+	return rowsAff, nil
+}
+
+// Upsert attempts an insert using an executor, and does an update or ignore on conflict.
+// See boil.Columns documentation for how to properly use updateColumns and insertColumns.
+func (o *SegmenterState) Upsert(ctx context.Context, exec boil.ContextExecutor, updateOnConflict bool, conflictColumns []string, updateColumns, insertColumns boil.Columns, opts ...UpsertOptionFunc) error {
+	if o == nil {
+		return errors.New("models: no segmenter_states provided for upsert")
+	}
+	if !boil.TimestampsAreSkipped(ctx) {
+		currTime := time.Now().In(boil.GetLocation())
+
+		o.UpdatedAt = currTime
+	}
+
+	if err := o.doBeforeUpsertHooks(ctx, exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(segmenterStateColumnsWithDefault, o)
+
+	// Build cache key in-line uglily - mysql vs psql problems
+	buf := strmangle.GetBuffer()
+	if updateOnConflict {
+		buf.WriteByte('t')
+	} else {
+		buf.WriteByte('f')
+	}
+	buf.WriteByte('.')
+	for _, c := range conflictColumns {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(updateColumns.Kind))
+	for _, c := range updateColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(insertColumns.Kind))
+	for _, c := range insertColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	for _, c := range nzDefaults {
+		buf.WriteString(c)
+	}
+	key := buf.String()
+	strmangle.PutBuffer(buf)
+
+	segmenterStateUpsertCacheMut.RLock()
+	cache, cached := segmenterStateUpsertCache[key]
+	segmenterStateUpsertCacheMut.RUnlock()
+
+	var err error
+
+	if !cached {
+		insert, _ := insertColumns.InsertColumnSet(
+			segmenterStateAllColumns,
+			segmenterStateColumnsWithDefault,
+			segmenterStateColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		update := updateColumns.UpdateColumnSet(
+			segmenterStateAllColumns,
+			segmenterStatePrimaryKeyColumns,
+		)
+
+		if updateOnConflict && len(update) == 0 {
+			return errors.New("models: unable to upsert segmenter_states, could not build update column list")
+		}
+
+		ret := strmangle.SetComplement(segmenterStateAllColumns, strmangle.SetIntersect(insert, update))
+
+		conflict := conflictColumns
+		if len(conflict) == 0 && updateOnConflict && len(update) != 0 {
+			if len(segmenterStatePrimaryKeyColumns) == 0 {
+				return errors.New("models: unable to upsert segmenter_states, could not build conflict column list")
+			}
+
+			conflict = make([]string, len(segmenterStatePrimaryKeyColumns))
+			copy(conflict, segmenterStatePrimaryKeyColumns)
+		}
+		cache.query = buildUpsertQueryPostgres(dialect, "\"trips_api\".\"segmenter_states\"", updateOnConflict, ret, update, conflict, insert, opts...)
+
+		cache.valueMapping, err = queries.BindMapping(segmenterStateType, segmenterStateMapping, insert)
+		if err != nil {
+			return err
+		}
+		if len(ret) != 0 {
+			cache.retMapping, err = queries.BindMapping(segmenterStateType, segmenterStateMapping, ret)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+	var returns []interface{}
+	if len(cache.retMapping) != 0 {
+		returns = queries.PtrsFromMapping(value, cache.retMapping)
+	}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRowContext(ctx, cache.query, vals...).Scan(returns...)
+		if errors.Is(err, sql.ErrNoRows) {
+			err = nil // Postgres doesn't return anything when there's no update
+		}
+	} else {
+		_, err = exec.ExecContext(ctx, cache.query, vals...)
+	}
+	if err != nil {
+		return errors.Wrap(err, "models: unable to upsert segmenter_states")
+	}
+
+	if !cached {
+		segmenterStateUpsertCacheMut.Lock()
+		segmenterStateUpsertCache[key] = cache
+		segmenterStateUpsertCacheMut.Unlock()
+	}
+
+	return o.doAfterUpsertHooks(ctx, exec)
+}
+
+// Delete deletes a single SegmenterState record with an executor.
+// Delete will match against the primary key column to find the record to delete.
+func (o *SegmenterState) Delete(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if o == nil {
+		return 0, errors.New("models: no SegmenterState provided for delete")
+	}
+
+	if err := o.doBeforeDeleteHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+
+	args := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), segmenterStatePrimaryKeyMapping)
+	sql := "DELETE FROM \"trips_api\".\"segmenter_states\" WHERE \"user_device_id\"=$1"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete from segmenter_states")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by delete for segmenter_states")
+	}
+
+	if err := o.doAfterDeleteHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all matching rows.
+func (q segmenterStateQuery) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if q.Query == nil {
+		return 0, errors.New("models: no segmenterStateQuery provided for delete all")
+	}
+
+	queries.SetDelete(q.Query)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete all from segmenter_states")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by deleteall for segmenter_states")
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all rows in the slice, using an executor.
+func (o SegmenterStateSlice) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if len(o) == 0 {
+		return 0, nil
+	}
+
+	if len(segmenterStateBeforeDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doBeforeDeleteHooks(ctx, exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	var args []interface{}
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), segmenterStatePrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "DELETE FROM \"trips_api\".\"segmenter_states\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, segmenterStatePrimaryKeyColumns, len(o))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete all from segmenterState slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by deleteall for segmenter_states")
+	}
+
+	if len(segmenterStateAfterDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterDeleteHooks(ctx, exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	return rowsAff, nil
+}
+
+// Reload refetches the object from the database
+// using the primary keys with an executor.
+func (o *SegmenterState) Reload(ctx context.Context, exec boil.ContextExecutor) error {
+	ret, err := FindSegmenterState(ctx, exec, o.UserDeviceID)
+	if err != nil {
+		return err
+	}
+
+	*o = *ret
+	return nil
+}
+
+// ReloadAll refetches every row with matching primary key column values
+// and overwrites the original object slice with the newly updated slice.
+func (o *SegmenterStateSlice) ReloadAll(ctx context.Context, exec boil.ContextExecutor) error {
+	if o == nil || len(*o) == 0 {
+		return nil
+	}
+
+	slice := SegmenterStateSlice{}
+	var args []interface{}
+	for _, obj := range *o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), segmenterStatePrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "SELECT \"trips_api\".\"segmenter_states\".* FROM \"trips_api\".\"segmenter_states\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, segmenterStatePrimaryKeyColumns, len(*o))
+
+	q := queries.Raw(sql, args...)
+
+	err := q.Bind(ctx, exec, &slice)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to reload all in SegmenterStateSlice")
+	}
+
+	*o = slice
+
+	return nil
+}
+
+// SegmenterStateExists checks if the SegmenterState row exists.
+func SegmenterStateExists(ctx context.Context, exec boil.ContextExecutor, userDeviceID string) (bool, error) {
+	var exists bool
+	sql := "select exists(select 1 from \"trips_api\".\"segmenter_states\" where \"user_device_id\"=$1 limit 1)"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, userDeviceID)
+	}
+	row := exec.QueryRowContext(ctx, sql, userDeviceID)
+
+	err := row.Scan(&exists)
+	if err != nil {
+		return false, errors.Wrap(err, "models: unable to check if segmenter_states exists")
+	}
+
+	return exists, nil
+}
+
+// Exists checks if the SegmenterState row exists.
+func (o *SegmenterState) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	return SegmenterStateExists(ctx, exec, o.UserDeviceID)
+}
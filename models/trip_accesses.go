@@ -0,0 +1,1053 @@
+// Code generated by SQLBoiler 4.16.2 (https://github.com/volatiletech/sqlboiler). DO NOT EDIT.
+// This file is meant to be re-generated in place and/or deleted at any time.
+
+package models
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/friendsofgo/errors"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"github.com/volatiletech/sqlboiler/v4/queries/qmhelper"
+	"github.com/volatiletech/strmangle"
+)
+
+// TripAccess is an object representing the database table.
+type TripAccess struct {
+	ID             int64       `boil:"id" json:"id" toml:"id" yaml:"id"`
+	VehicleTokenID int         `boil:"vehicle_token_id" json:"vehicle_token_id" toml:"vehicle_token_id" yaml:"vehicle_token_id"`
+	Subject        string      `boil:"subject" json:"subject" toml:"subject" yaml:"subject"`
+	ClientID       null.String `boil:"client_id" json:"client_id,omitempty" toml:"client_id" yaml:"client_id,omitempty"`
+	Endpoint       string      `boil:"endpoint" json:"endpoint" toml:"endpoint" yaml:"endpoint"`
+	RangeStart     null.Time   `boil:"range_start" json:"range_start,omitempty" toml:"range_start" yaml:"range_start,omitempty"`
+	RangeEnd       null.Time   `boil:"range_end" json:"range_end,omitempty" toml:"range_end" yaml:"range_end,omitempty"`
+	TripCount      null.Int    `boil:"trip_count" json:"trip_count,omitempty" toml:"trip_count" yaml:"trip_count,omitempty"`
+	CreatedAt      time.Time   `boil:"created_at" json:"created_at" toml:"created_at" yaml:"created_at"`
+
+	R *tripAccessR `boil:"-" json:"-" toml:"-" yaml:"-"`
+	L tripAccessL  `boil:"-" json:"-" toml:"-" yaml:"-"`
+}
+
+var TripAccessColumns = struct {
+	ID             string
+	VehicleTokenID string
+	Subject        string
+	ClientID       string
+	Endpoint       string
+	RangeStart     string
+	RangeEnd       string
+	TripCount      string
+	CreatedAt      string
+}{
+	ID:             "id",
+	VehicleTokenID: "vehicle_token_id",
+	Subject:        "subject",
+	ClientID:       "client_id",
+	Endpoint:       "endpoint",
+	RangeStart:     "range_start",
+	RangeEnd:       "range_end",
+	TripCount:      "trip_count",
+	CreatedAt:      "created_at",
+}
+
+var TripAccessTableColumns = struct {
+	ID             string
+	VehicleTokenID string
+	Subject        string
+	ClientID       string
+	Endpoint       string
+	RangeStart     string
+	RangeEnd       string
+	TripCount      string
+	CreatedAt      string
+}{
+	ID:             "trip_accesses.id",
+	VehicleTokenID: "trip_accesses.vehicle_token_id",
+	Subject:        "trip_accesses.subject",
+	ClientID:       "trip_accesses.client_id",
+	Endpoint:       "trip_accesses.endpoint",
+	RangeStart:     "trip_accesses.range_start",
+	RangeEnd:       "trip_accesses.range_end",
+	TripCount:      "trip_accesses.trip_count",
+	CreatedAt:      "trip_accesses.created_at",
+}
+
+// Generated where
+
+type whereHelperint64 struct{ field string }
+
+func (w whereHelperint64) EQ(x int64) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.EQ, x) }
+func (w whereHelperint64) NEQ(x int64) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.NEQ, x) }
+func (w whereHelperint64) LT(x int64) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.LT, x) }
+func (w whereHelperint64) LTE(x int64) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.LTE, x) }
+func (w whereHelperint64) GT(x int64) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.GT, x) }
+func (w whereHelperint64) GTE(x int64) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.GTE, x) }
+func (w whereHelperint64) IN(slice []int64) qm.QueryMod {
+	values := make([]interface{}, 0, len(slice))
+	for _, value := range slice {
+		values = append(values, value)
+	}
+	return qm.WhereIn(fmt.Sprintf("%s IN ?", w.field), values...)
+}
+func (w whereHelperint64) NIN(slice []int64) qm.QueryMod {
+	values := make([]interface{}, 0, len(slice))
+	for _, value := range slice {
+		values = append(values, value)
+	}
+	return qm.WhereNotIn(fmt.Sprintf("%s NOT IN ?", w.field), values...)
+}
+
+type whereHelpernull_Int struct{ field string }
+
+func (w whereHelpernull_Int) EQ(x null.Int) qm.QueryMod {
+	return qmhelper.WhereNullEQ(w.field, false, x)
+}
+func (w whereHelpernull_Int) NEQ(x null.Int) qm.QueryMod {
+	return qmhelper.WhereNullEQ(w.field, true, x)
+}
+func (w whereHelpernull_Int) LT(x null.Int) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.LT, x)
+}
+func (w whereHelpernull_Int) LTE(x null.Int) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.LTE, x)
+}
+func (w whereHelpernull_Int) GT(x null.Int) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.GT, x)
+}
+func (w whereHelpernull_Int) GTE(x null.Int) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.GTE, x)
+}
+func (w whereHelpernull_Int) IN(slice []int) qm.QueryMod {
+	values := make([]interface{}, 0, len(slice))
+	for _, value := range slice {
+		values = append(values, value)
+	}
+	return qm.WhereIn(fmt.Sprintf("%s IN ?", w.field), values...)
+}
+func (w whereHelpernull_Int) NIN(slice []int) qm.QueryMod {
+	values := make([]interface{}, 0, len(slice))
+	for _, value := range slice {
+		values = append(values, value)
+	}
+	return qm.WhereNotIn(fmt.Sprintf("%s NOT IN ?", w.field), values...)
+}
+
+func (w whereHelpernull_Int) IsNull() qm.QueryMod    { return qmhelper.WhereIsNull(w.field) }
+func (w whereHelpernull_Int) IsNotNull() qm.QueryMod { return qmhelper.WhereIsNotNull(w.field) }
+
+var TripAccessWhere = struct {
+	ID             whereHelperint64
+	VehicleTokenID whereHelperint
+	Subject        whereHelperstring
+	ClientID       whereHelpernull_String
+	Endpoint       whereHelperstring
+	RangeStart     whereHelpernull_Time
+	RangeEnd       whereHelpernull_Time
+	TripCount      whereHelpernull_Int
+	CreatedAt      whereHelpertime_Time
+}{
+	ID:             whereHelperint64{field: "\"trips_api\".\"trip_accesses\".\"id\""},
+	VehicleTokenID: whereHelperint{field: "\"trips_api\".\"trip_accesses\".\"vehicle_token_id\""},
+	Subject:        whereHelperstring{field: "\"trips_api\".\"trip_accesses\".\"subject\""},
+	ClientID:       whereHelpernull_String{field: "\"trips_api\".\"trip_accesses\".\"client_id\""},
+	Endpoint:       whereHelperstring{field: "\"trips_api\".\"trip_accesses\".\"endpoint\""},
+	RangeStart:     whereHelpernull_Time{field: "\"trips_api\".\"trip_accesses\".\"range_start\""},
+	RangeEnd:       whereHelpernull_Time{field: "\"trips_api\".\"trip_accesses\".\"range_end\""},
+	TripCount:      whereHelpernull_Int{field: "\"trips_api\".\"trip_accesses\".\"trip_count\""},
+	CreatedAt:      whereHelpertime_Time{field: "\"trips_api\".\"trip_accesses\".\"created_at\""},
+}
+
+// TripAccessRels is where relationship names are stored.
+var TripAccessRels = struct {
+}{}
+
+// tripAccessR is where relationships are stored.
+type tripAccessR struct {
+}
+
+// NewStruct creates a new relationship struct
+func (*tripAccessR) NewStruct() *tripAccessR {
+	return &tripAccessR{}
+}
+
+// tripAccessL is where Load methods for each relationship are stored.
+type tripAccessL struct{}
+
+var (
+	tripAccessAllColumns            = []string{"id", "vehicle_token_id", "subject", "client_id", "endpoint", "range_start", "range_end", "trip_count", "created_at"}
+	tripAccessColumnsWithoutDefault = []string{"vehicle_token_id", "subject", "endpoint"}
+	tripAccessColumnsWithDefault    = []string{"id", "client_id", "range_start", "range_end", "trip_count", "created_at"}
+	tripAccessPrimaryKeyColumns     = []string{"id"}
+	tripAccessGeneratedColumns      = []string{}
+)
+
+type (
+	// TripAccessSlice is an alias for a slice of pointers to TripAccess.
+	// This should almost always be used instead of []TripAccess.
+	TripAccessSlice []*TripAccess
+	// TripAccessHook is the signature for custom TripAccess hook methods
+	TripAccessHook func(context.Context, boil.ContextExecutor, *TripAccess) error
+
+	tripAccessQuery struct {
+		*queries.Query
+	}
+)
+
+// Cache for insert, update and upsert
+var (
+	tripAccessType                 = reflect.TypeOf(&TripAccess{})
+	tripAccessMapping              = queries.MakeStructMapping(tripAccessType)
+	tripAccessPrimaryKeyMapping, _ = queries.BindMapping(tripAccessType, tripAccessMapping, tripAccessPrimaryKeyColumns)
+	tripAccessInsertCacheMut       sync.RWMutex
+	tripAccessInsertCache          = make(map[string]insertCache)
+	tripAccessUpdateCacheMut       sync.RWMutex
+	tripAccessUpdateCache          = make(map[string]updateCache)
+	tripAccessUpsertCacheMut       sync.RWMutex
+	tripAccessUpsertCache          = make(map[string]insertCache)
+)
+
+var (
+	// Force time package dependency for automated UpdatedAt/CreatedAt.
+	_ = time.Second
+	// Force qmhelper dependency for where clause generation (which doesn't
+	// always happen)
+	_ = qmhelper.Where
+)
+
+var tripAccessAfterSelectMu sync.Mutex
+var tripAccessAfterSelectHooks []TripAccessHook
+
+var tripAccessBeforeInsertMu sync.Mutex
+var tripAccessBeforeInsertHooks []TripAccessHook
+var tripAccessAfterInsertMu sync.Mutex
+var tripAccessAfterInsertHooks []TripAccessHook
+
+var tripAccessBeforeUpdateMu sync.Mutex
+var tripAccessBeforeUpdateHooks []TripAccessHook
+var tripAccessAfterUpdateMu sync.Mutex
+var tripAccessAfterUpdateHooks []TripAccessHook
+
+var tripAccessBeforeDeleteMu sync.Mutex
+var tripAccessBeforeDeleteHooks []TripAccessHook
+var tripAccessAfterDeleteMu sync.Mutex
+var tripAccessAfterDeleteHooks []TripAccessHook
+
+var tripAccessBeforeUpsertMu sync.Mutex
+var tripAccessBeforeUpsertHooks []TripAccessHook
+var tripAccessAfterUpsertMu sync.Mutex
+var tripAccessAfterUpsertHooks []TripAccessHook
+
+// doAfterSelectHooks executes all "after Select" hooks.
+func (o *TripAccess) doAfterSelectHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range tripAccessAfterSelectHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeInsertHooks executes all "before insert" hooks.
+func (o *TripAccess) doBeforeInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range tripAccessBeforeInsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterInsertHooks executes all "after Insert" hooks.
+func (o *TripAccess) doAfterInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range tripAccessAfterInsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpdateHooks executes all "before Update" hooks.
+func (o *TripAccess) doBeforeUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range tripAccessBeforeUpdateHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpdateHooks executes all "after Update" hooks.
+func (o *TripAccess) doAfterUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range tripAccessAfterUpdateHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeDeleteHooks executes all "before Delete" hooks.
+func (o *TripAccess) doBeforeDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range tripAccessBeforeDeleteHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterDeleteHooks executes all "after Delete" hooks.
+func (o *TripAccess) doAfterDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range tripAccessAfterDeleteHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpsertHooks executes all "before Upsert" hooks.
+func (o *TripAccess) doBeforeUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range tripAccessBeforeUpsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpsertHooks executes all "after Upsert" hooks.
+func (o *TripAccess) doAfterUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range tripAccessAfterUpsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// AddTripAccessHook registers your hook function for all future operations.
+func AddTripAccessHook(hookPoint boil.HookPoint, tripAccessHook TripAccessHook) {
+	switch hookPoint {
+	case boil.AfterSelectHook:
+		tripAccessAfterSelectMu.Lock()
+		tripAccessAfterSelectHooks = append(tripAccessAfterSelectHooks, tripAccessHook)
+		tripAccessAfterSelectMu.Unlock()
+	case boil.BeforeInsertHook:
+		tripAccessBeforeInsertMu.Lock()
+		tripAccessBeforeInsertHooks = append(tripAccessBeforeInsertHooks, tripAccessHook)
+		tripAccessBeforeInsertMu.Unlock()
+	case boil.AfterInsertHook:
+		tripAccessAfterInsertMu.Lock()
+		tripAccessAfterInsertHooks = append(tripAccessAfterInsertHooks, tripAccessHook)
+		tripAccessAfterInsertMu.Unlock()
+	case boil.BeforeUpdateHook:
+		tripAccessBeforeUpdateMu.Lock()
+		tripAccessBeforeUpdateHooks = append(tripAccessBeforeUpdateHooks, tripAccessHook)
+		tripAccessBeforeUpdateMu.Unlock()
+	case boil.AfterUpdateHook:
+		tripAccessAfterUpdateMu.Lock()
+		tripAccessAfterUpdateHooks = append(tripAccessAfterUpdateHooks, tripAccessHook)
+		tripAccessAfterUpdateMu.Unlock()
+	case boil.BeforeDeleteHook:
+		tripAccessBeforeDeleteMu.Lock()
+		tripAccessBeforeDeleteHooks = append(tripAccessBeforeDeleteHooks, tripAccessHook)
+		tripAccessBeforeDeleteMu.Unlock()
+	case boil.AfterDeleteHook:
+		tripAccessAfterDeleteMu.Lock()
+		tripAccessAfterDeleteHooks = append(tripAccessAfterDeleteHooks, tripAccessHook)
+		tripAccessAfterDeleteMu.Unlock()
+	case boil.BeforeUpsertHook:
+		tripAccessBeforeUpsertMu.Lock()
+		tripAccessBeforeUpsertHooks = append(tripAccessBeforeUpsertHooks, tripAccessHook)
+		tripAccessBeforeUpsertMu.Unlock()
+	case boil.AfterUpsertHook:
+		tripAccessAfterUpsertMu.Lock()
+		tripAccessAfterUpsertHooks = append(tripAccessAfterUpsertHooks, tripAccessHook)
+		tripAccessAfterUpsertMu.Unlock()
+	}
+}
+
+// One returns a single tripAccess record from the query.
+func (q tripAccessQuery) One(ctx context.Context, exec boil.ContextExecutor) (*TripAccess, error) {
+	o := &TripAccess{}
+
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Bind(ctx, exec, o)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "models: failed to execute a one query for trip_accesses")
+	}
+
+	if err := o.doAfterSelectHooks(ctx, exec); err != nil {
+		return o, err
+	}
+
+	return o, nil
+}
+
+// All returns all TripAccess records from the query.
+func (q tripAccessQuery) All(ctx context.Context, exec boil.ContextExecutor) (TripAccessSlice, error) {
+	var o []*TripAccess
+
+	err := q.Bind(ctx, exec, &o)
+	if err != nil {
+		return nil, errors.Wrap(err, "models: failed to assign all query results to TripAccess slice")
+	}
+
+	if len(tripAccessAfterSelectHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterSelectHooks(ctx, exec); err != nil {
+				return o, err
+			}
+		}
+	}
+
+	return o, nil
+}
+
+// Count returns the count of all TripAccess records in the query.
+func (q tripAccessQuery) Count(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to count trip_accesses rows")
+	}
+
+	return count, nil
+}
+
+// Exists checks if the row exists in the table.
+func (q tripAccessQuery) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return false, errors.Wrap(err, "models: failed to check if trip_accesses exists")
+	}
+
+	return count > 0, nil
+}
+
+// TripAccesses retrieves all the records using an executor.
+func TripAccesses(mods ...qm.QueryMod) tripAccessQuery {
+	mods = append(mods, qm.From("\"trips_api\".\"trip_accesses\""))
+	q := NewQuery(mods...)
+	if len(queries.GetSelect(q)) == 0 {
+		queries.SetSelect(q, []string{"\"trips_api\".\"trip_accesses\".*"})
+	}
+
+	return tripAccessQuery{q}
+}
+
+// FindTripAccess retrieves a single record by ID with an executor.
+// If selectCols is empty Find will return all columns.
+func FindTripAccess(ctx context.Context, exec boil.ContextExecutor, iD int64, selectCols ...string) (*TripAccess, error) {
+	tripAccessObj := &TripAccess{}
+
+	sel := "*"
+	if len(selectCols) > 0 {
+		sel = strings.Join(strmangle.IdentQuoteSlice(dialect.LQ, dialect.RQ, selectCols), ",")
+	}
+	query := fmt.Sprintf(
+		"select %s from \"trips_api\".\"trip_accesses\" where \"id\"=$1", sel,
+	)
+
+	q := queries.Raw(query, iD)
+
+	err := q.Bind(ctx, exec, tripAccessObj)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "models: unable to select from trip_accesses")
+	}
+
+	if err = tripAccessObj.doAfterSelectHooks(ctx, exec); err != nil {
+		return tripAccessObj, err
+	}
+
+	return tripAccessObj, nil
+}
+
+// Insert a single record using an executor.
+// See boil.Columns.InsertColumnSet documentation to understand column list inference for inserts.
+func (o *TripAccess) Insert(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) error {
+	if o == nil {
+		return errors.New("models: no trip_accesses provided for insertion")
+	}
+
+	var err error
+	if !boil.TimestampsAreSkipped(ctx) {
+		currTime := time.Now().In(boil.GetLocation())
+
+		if o.CreatedAt.IsZero() {
+			o.CreatedAt = currTime
+		}
+	}
+
+	if err := o.doBeforeInsertHooks(ctx, exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(tripAccessColumnsWithDefault, o)
+
+	key := makeCacheKey(columns, nzDefaults)
+	tripAccessInsertCacheMut.RLock()
+	cache, cached := tripAccessInsertCache[key]
+	tripAccessInsertCacheMut.RUnlock()
+
+	if !cached {
+		wl, returnColumns := columns.InsertColumnSet(
+			tripAccessAllColumns,
+			tripAccessColumnsWithDefault,
+			tripAccessColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		cache.valueMapping, err = queries.BindMapping(tripAccessType, tripAccessMapping, wl)
+		if err != nil {
+			return err
+		}
+		cache.retMapping, err = queries.BindMapping(tripAccessType, tripAccessMapping, returnColumns)
+		if err != nil {
+			return err
+		}
+		if len(wl) != 0 {
+			cache.query = fmt.Sprintf("INSERT INTO \"trips_api\".\"trip_accesses\" (\"%s\") %%sVALUES (%s)%%s", strings.Join(wl, "\",\""), strmangle.Placeholders(dialect.UseIndexPlaceholders, len(wl), 1, 1))
+		} else {
+			cache.query = "INSERT INTO \"trips_api\".\"trip_accesses\" %sDEFAULT VALUES%s"
+		}
+
+		var queryOutput, queryReturning string
+
+		if len(cache.retMapping) != 0 {
+			queryReturning = fmt.Sprintf(" RETURNING \"%s\"", strings.Join(returnColumns, "\",\""))
+		}
+
+		cache.query = fmt.Sprintf(cache.query, queryOutput, queryReturning)
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRowContext(ctx, cache.query, vals...).Scan(queries.PtrsFromMapping(value, cache.retMapping)...)
+	} else {
+		_, err = exec.ExecContext(ctx, cache.query, vals...)
+	}
+
+	if err != nil {
+		return errors.Wrap(err, "models: unable to insert into trip_accesses")
+	}
+
+	if !cached {
+		tripAccessInsertCacheMut.Lock()
+		tripAccessInsertCache[key] = cache
+		tripAccessInsertCacheMut.Unlock()
+	}
+
+	return o.doAfterInsertHooks(ctx, exec)
+}
+
+// Update uses an executor to update the TripAccess.
+// See boil.Columns.UpdateColumnSet documentation to understand column list inference for updates.
+// Update does not automatically update the record in case of default values. Use .Reload() to refresh the records.
+func (o *TripAccess) Update(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) (int64, error) {
+	var err error
+	if err = o.doBeforeUpdateHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+	key := makeCacheKey(columns, nil)
+	tripAccessUpdateCacheMut.RLock()
+	cache, cached := tripAccessUpdateCache[key]
+	tripAccessUpdateCacheMut.RUnlock()
+
+	if !cached {
+		wl := columns.UpdateColumnSet(
+			tripAccessAllColumns,
+			tripAccessPrimaryKeyColumns,
+		)
+
+		if !columns.IsWhitelist() {
+			wl = strmangle.SetComplement(wl, []string{"created_at"})
+		}
+		if len(wl) == 0 {
+			return 0, errors.New("models: unable to update trip_accesses, could not build whitelist")
+		}
+
+		cache.query = fmt.Sprintf("UPDATE \"trips_api\".\"trip_accesses\" SET %s WHERE %s",
+			strmangle.SetParamNames("\"", "\"", 1, wl),
+			strmangle.WhereClause("\"", "\"", len(wl)+1, tripAccessPrimaryKeyColumns),
+		)
+		cache.valueMapping, err = queries.BindMapping(tripAccessType, tripAccessMapping, append(wl, tripAccessPrimaryKeyColumns...))
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	values := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, values)
+	}
+	var result sql.Result
+	result, err = exec.ExecContext(ctx, cache.query, values...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update trip_accesses row")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by update for trip_accesses")
+	}
+
+	if !cached {
+		tripAccessUpdateCacheMut.Lock()
+		tripAccessUpdateCache[key] = cache
+		tripAccessUpdateCacheMut.Unlock()
+	}
+
+	return rowsAff, o.doAfterUpdateHooks(ctx, exec)
+}
+
+// UpdateAll updates all rows with the specified column values.
+func (q tripAccessQuery) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	queries.SetUpdate(q.Query, cols)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update all for trip_accesses")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to retrieve rows affected for trip_accesses")
+	}
+
+	return rowsAff, nil
+}
+
+// UpdateAll updates all rows with the specified column values, using an executor.
+func (o TripAccessSlice) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	ln := int64(len(o))
+	if ln == 0 {
+		return 0, nil
+	}
+
+	if len(cols) == 0 {
+		return 0, errors.New("models: update all requires at least one column argument")
+	}
+
+	colNames := make([]string, len(cols))
+	args := make([]interface{}, len(cols))
+
+	i := 0
+	for name, value := range cols {
+		colNames[i] = name
+		args[i] = value
+		i++
+	}
+
+	// Append all of the primary key values for each column
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), tripAccessPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := fmt.Sprintf("UPDATE \"trips_api\".\"trip_accesses\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, colNames),
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), len(colNames)+1, tripAccessPrimaryKeyColumns, len(o)))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update all in tripAccess slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to retrieve rows affected all in update all tripAccess")
+	}
+	return rowsAff, nil
+}
+
+// Upsert attempts an insert using an executor, and does an update or ignore on conflict.
+// See boil.Columns documentation for how to properly use updateColumns and insertColumns.
+func (o *TripAccess) Upsert(ctx context.Context, exec boil.ContextExecutor, updateOnConflict bool, conflictColumns []string, updateColumns, insertColumns boil.Columns, opts ...UpsertOptionFunc) error {
+	if o == nil {
+		return errors.New("models: no trip_accesses provided for upsert")
+	}
+	if !boil.TimestampsAreSkipped(ctx) {
+		currTime := time.Now().In(boil.GetLocation())
+
+		if o.CreatedAt.IsZero() {
+			o.CreatedAt = currTime
+		}
+	}
+
+	if err := o.doBeforeUpsertHooks(ctx, exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(tripAccessColumnsWithDefault, o)
+
+	// Build cache key in-line uglily - mysql vs psql problems
+	buf := strmangle.GetBuffer()
+	if updateOnConflict {
+		buf.WriteByte('t')
+	} else {
+		buf.WriteByte('f')
+	}
+	buf.WriteByte('.')
+	for _, c := range conflictColumns {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(updateColumns.Kind))
+	for _, c := range updateColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(insertColumns.Kind))
+	for _, c := range insertColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	for _, c := range nzDefaults {
+		buf.WriteString(c)
+	}
+	key := buf.String()
+	strmangle.PutBuffer(buf)
+
+	tripAccessUpsertCacheMut.RLock()
+	cache, cached := tripAccessUpsertCache[key]
+	tripAccessUpsertCacheMut.RUnlock()
+
+	var err error
+
+	if !cached {
+		insert, _ := insertColumns.InsertColumnSet(
+			tripAccessAllColumns,
+			tripAccessColumnsWithDefault,
+			tripAccessColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		update := updateColumns.UpdateColumnSet(
+			tripAccessAllColumns,
+			tripAccessPrimaryKeyColumns,
+		)
+
+		if updateOnConflict && len(update) == 0 {
+			return errors.New("models: unable to upsert trip_accesses, could not build update column list")
+		}
+
+		ret := strmangle.SetComplement(tripAccessAllColumns, strmangle.SetIntersect(insert, update))
+
+		conflict := conflictColumns
+		if len(conflict) == 0 && updateOnConflict && len(update) != 0 {
+			if len(tripAccessPrimaryKeyColumns) == 0 {
+				return errors.New("models: unable to upsert trip_accesses, could not build conflict column list")
+			}
+
+			conflict = make([]string, len(tripAccessPrimaryKeyColumns))
+			copy(conflict, tripAccessPrimaryKeyColumns)
+		}
+		cache.query = buildUpsertQueryPostgres(dialect, "\"trips_api\".\"trip_accesses\"", updateOnConflict, ret, update, conflict, insert, opts...)
+
+		cache.valueMapping, err = queries.BindMapping(tripAccessType, tripAccessMapping, insert)
+		if err != nil {
+			return err
+		}
+		if len(ret) != 0 {
+			cache.retMapping, err = queries.BindMapping(tripAccessType, tripAccessMapping, ret)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+	var returns []interface{}
+	if len(cache.retMapping) != 0 {
+		returns = queries.PtrsFromMapping(value, cache.retMapping)
+	}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRowContext(ctx, cache.query, vals...).Scan(returns...)
+		if errors.Is(err, sql.ErrNoRows) {
+			err = nil // Postgres doesn't return anything when there's no update
+		}
+	} else {
+		_, err = exec.ExecContext(ctx, cache.query, vals...)
+	}
+	if err != nil {
+		return errors.Wrap(err, "models: unable to upsert trip_accesses")
+	}
+
+	if !cached {
+		tripAccessUpsertCacheMut.Lock()
+		tripAccessUpsertCache[key] = cache
+		tripAccessUpsertCacheMut.Unlock()
+	}
+
+	return o.doAfterUpsertHooks(ctx, exec)
+}
+
+// Delete deletes a single TripAccess record with an executor.
+// Delete will match against the primary key column to find the record to delete.
+func (o *TripAccess) Delete(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if o == nil {
+		return 0, errors.New("models: no TripAccess provided for delete")
+	}
+
+	if err := o.doBeforeDeleteHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+
+	args := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), tripAccessPrimaryKeyMapping)
+	sql := "DELETE FROM \"trips_api\".\"trip_accesses\" WHERE \"id\"=$1"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete from trip_accesses")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by delete for trip_accesses")
+	}
+
+	if err := o.doAfterDeleteHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all matching rows.
+func (q tripAccessQuery) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if q.Query == nil {
+		return 0, errors.New("models: no tripAccessQuery provided for delete all")
+	}
+
+	queries.SetDelete(q.Query)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete all from trip_accesses")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by deleteall for trip_accesses")
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all rows in the slice, using an executor.
+func (o TripAccessSlice) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if len(o) == 0 {
+		return 0, nil
+	}
+
+	if len(tripAccessBeforeDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doBeforeDeleteHooks(ctx, exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	var args []interface{}
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), tripAccessPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "DELETE FROM \"trips_api\".\"trip_accesses\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, tripAccessPrimaryKeyColumns, len(o))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete all from tripAccess slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by deleteall for trip_accesses")
+	}
+
+	if len(tripAccessAfterDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterDeleteHooks(ctx, exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	return rowsAff, nil
+}
+
+// Reload refetches the object from the database
+// using the primary keys with an executor.
+func (o *TripAccess) Reload(ctx context.Context, exec boil.ContextExecutor) error {
+	ret, err := FindTripAccess(ctx, exec, o.ID)
+	if err != nil {
+		return err
+	}
+
+	*o = *ret
+	return nil
+}
+
+// ReloadAll refetches every row with matching primary key column values
+// and overwrites the original object slice with the newly updated slice.
+func (o *TripAccessSlice) ReloadAll(ctx context.Context, exec boil.ContextExecutor) error {
+	if o == nil || len(*o) == 0 {
+		return nil
+	}
+
+	slice := TripAccessSlice{}
+	var args []interface{}
+	for _, obj := range *o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), tripAccessPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "SELECT \"trips_api\".\"trip_accesses\".* FROM \"trips_api\".\"trip_accesses\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, tripAccessPrimaryKeyColumns, len(*o))
+
+	q := queries.Raw(sql, args...)
+
+	err := q.Bind(ctx, exec, &slice)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to reload all in TripAccessSlice")
+	}
+
+	*o = slice
+
+	return nil
+}
+
+// TripAccessExists checks if the TripAccess row exists.
+func TripAccessExists(ctx context.Context, exec boil.ContextExecutor, iD int64) (bool, error) {
+	var exists bool
+	sql := "select exists(select 1 from \"trips_api\".\"trip_accesses\" where \"id\"=$1 limit 1)"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, iD)
+	}
+	row := exec.QueryRowContext(ctx, sql, iD)
+
+	err := row.Scan(&exists)
+	if err != nil {
+		return false, errors.Wrap(err, "models: unable to check if trip_accesses exists")
+	}
+
+	return exists, nil
+}
+
+// Exists checks if the TripAccess row exists.
+func (o *TripAccess) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	return TripAccessExists(ctx, exec, o.ID)
+}
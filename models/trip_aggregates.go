@@ -0,0 +1,1003 @@
+// Code generated by SQLBoiler 4.16.2 (https://github.com/volatiletech/sqlboiler). DO NOT EDIT.
+// This file is meant to be re-generated in place and/or deleted at any time.
+
+package models
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/friendsofgo/errors"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"github.com/volatiletech/sqlboiler/v4/queries/qmhelper"
+	"github.com/volatiletech/strmangle"
+)
+
+// TripAggregate is an object representing the database table.
+type TripAggregate struct {
+	VehicleTokenID  int       `boil:"vehicle_token_id" json:"vehicle_token_id" toml:"vehicle_token_id" yaml:"vehicle_token_id"`
+	Month           time.Time `boil:"month" json:"month" toml:"month" yaml:"month"`
+	TripCount       int       `boil:"trip_count" json:"trip_count" toml:"trip_count" yaml:"trip_count"`
+	DurationSeconds float64   `boil:"duration_seconds" json:"duration_seconds" toml:"duration_seconds" yaml:"duration_seconds"`
+	DistanceKM      float64   `boil:"distance_km" json:"distance_km" toml:"distance_km" yaml:"distance_km"`
+	UpdatedAt       time.Time `boil:"updated_at" json:"updated_at" toml:"updated_at" yaml:"updated_at"`
+
+	R *tripAggregateR `boil:"-" json:"-" toml:"-" yaml:"-"`
+	L tripAggregateL  `boil:"-" json:"-" toml:"-" yaml:"-"`
+}
+
+var TripAggregateColumns = struct {
+	VehicleTokenID  string
+	Month           string
+	TripCount       string
+	DurationSeconds string
+	DistanceKM      string
+	UpdatedAt       string
+}{
+	VehicleTokenID:  "vehicle_token_id",
+	Month:           "month",
+	TripCount:       "trip_count",
+	DurationSeconds: "duration_seconds",
+	DistanceKM:      "distance_km",
+	UpdatedAt:       "updated_at",
+}
+
+var TripAggregateTableColumns = struct {
+	VehicleTokenID  string
+	Month           string
+	TripCount       string
+	DurationSeconds string
+	DistanceKM      string
+	UpdatedAt       string
+}{
+	VehicleTokenID:  "trip_aggregates.vehicle_token_id",
+	Month:           "trip_aggregates.month",
+	TripCount:       "trip_aggregates.trip_count",
+	DurationSeconds: "trip_aggregates.duration_seconds",
+	DistanceKM:      "trip_aggregates.distance_km",
+	UpdatedAt:       "trip_aggregates.updated_at",
+}
+
+// Generated where
+
+type whereHelperfloat64 struct{ field string }
+
+func (w whereHelperfloat64) EQ(x float64) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.EQ, x) }
+func (w whereHelperfloat64) NEQ(x float64) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.NEQ, x)
+}
+func (w whereHelperfloat64) LT(x float64) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.LT, x) }
+func (w whereHelperfloat64) LTE(x float64) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.LTE, x)
+}
+func (w whereHelperfloat64) GT(x float64) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.GT, x) }
+func (w whereHelperfloat64) GTE(x float64) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.GTE, x)
+}
+func (w whereHelperfloat64) IN(slice []float64) qm.QueryMod {
+	values := make([]interface{}, 0, len(slice))
+	for _, value := range slice {
+		values = append(values, value)
+	}
+	return qm.WhereIn(fmt.Sprintf("%s IN ?", w.field), values...)
+}
+func (w whereHelperfloat64) NIN(slice []float64) qm.QueryMod {
+	values := make([]interface{}, 0, len(slice))
+	for _, value := range slice {
+		values = append(values, value)
+	}
+	return qm.WhereNotIn(fmt.Sprintf("%s NOT IN ?", w.field), values...)
+}
+
+var TripAggregateWhere = struct {
+	VehicleTokenID  whereHelperint
+	Month           whereHelpertime_Time
+	TripCount       whereHelperint
+	DurationSeconds whereHelperfloat64
+	DistanceKM      whereHelperfloat64
+	UpdatedAt       whereHelpertime_Time
+}{
+	VehicleTokenID:  whereHelperint{field: "\"trips_api\".\"trip_aggregates\".\"vehicle_token_id\""},
+	Month:           whereHelpertime_Time{field: "\"trips_api\".\"trip_aggregates\".\"month\""},
+	TripCount:       whereHelperint{field: "\"trips_api\".\"trip_aggregates\".\"trip_count\""},
+	DurationSeconds: whereHelperfloat64{field: "\"trips_api\".\"trip_aggregates\".\"duration_seconds\""},
+	DistanceKM:      whereHelperfloat64{field: "\"trips_api\".\"trip_aggregates\".\"distance_km\""},
+	UpdatedAt:       whereHelpertime_Time{field: "\"trips_api\".\"trip_aggregates\".\"updated_at\""},
+}
+
+// TripAggregateRels is where relationship names are stored.
+var TripAggregateRels = struct {
+}{}
+
+// tripAggregateR is where relationships are stored.
+type tripAggregateR struct {
+}
+
+// NewStruct creates a new relationship struct
+func (*tripAggregateR) NewStruct() *tripAggregateR {
+	return &tripAggregateR{}
+}
+
+// tripAggregateL is where Load methods for each relationship are stored.
+type tripAggregateL struct{}
+
+var (
+	tripAggregateAllColumns            = []string{"vehicle_token_id", "month", "trip_count", "duration_seconds", "distance_km", "updated_at"}
+	tripAggregateColumnsWithoutDefault = []string{"vehicle_token_id", "month", "trip_count", "duration_seconds", "distance_km"}
+	tripAggregateColumnsWithDefault    = []string{"updated_at"}
+	tripAggregatePrimaryKeyColumns     = []string{"vehicle_token_id", "month"}
+	tripAggregateGeneratedColumns      = []string{}
+)
+
+type (
+	// TripAggregateSlice is an alias for a slice of pointers to TripAggregate.
+	// This should almost always be used instead of []TripAggregate.
+	TripAggregateSlice []*TripAggregate
+	// TripAggregateHook is the signature for custom TripAggregate hook methods
+	TripAggregateHook func(context.Context, boil.ContextExecutor, *TripAggregate) error
+
+	tripAggregateQuery struct {
+		*queries.Query
+	}
+)
+
+// Cache for insert, update and upsert
+var (
+	tripAggregateType                 = reflect.TypeOf(&TripAggregate{})
+	tripAggregateMapping              = queries.MakeStructMapping(tripAggregateType)
+	tripAggregatePrimaryKeyMapping, _ = queries.BindMapping(tripAggregateType, tripAggregateMapping, tripAggregatePrimaryKeyColumns)
+	tripAggregateInsertCacheMut       sync.RWMutex
+	tripAggregateInsertCache          = make(map[string]insertCache)
+	tripAggregateUpdateCacheMut       sync.RWMutex
+	tripAggregateUpdateCache          = make(map[string]updateCache)
+	tripAggregateUpsertCacheMut       sync.RWMutex
+	tripAggregateUpsertCache          = make(map[string]insertCache)
+)
+
+var (
+	// Force time package dependency for automated UpdatedAt/CreatedAt.
+	_ = time.Second
+	// Force qmhelper dependency for where clause generation (which doesn't
+	// always happen)
+	_ = qmhelper.Where
+)
+
+var tripAggregateAfterSelectMu sync.Mutex
+var tripAggregateAfterSelectHooks []TripAggregateHook
+
+var tripAggregateBeforeInsertMu sync.Mutex
+var tripAggregateBeforeInsertHooks []TripAggregateHook
+var tripAggregateAfterInsertMu sync.Mutex
+var tripAggregateAfterInsertHooks []TripAggregateHook
+
+var tripAggregateBeforeUpdateMu sync.Mutex
+var tripAggregateBeforeUpdateHooks []TripAggregateHook
+var tripAggregateAfterUpdateMu sync.Mutex
+var tripAggregateAfterUpdateHooks []TripAggregateHook
+
+var tripAggregateBeforeDeleteMu sync.Mutex
+var tripAggregateBeforeDeleteHooks []TripAggregateHook
+var tripAggregateAfterDeleteMu sync.Mutex
+var tripAggregateAfterDeleteHooks []TripAggregateHook
+
+var tripAggregateBeforeUpsertMu sync.Mutex
+var tripAggregateBeforeUpsertHooks []TripAggregateHook
+var tripAggregateAfterUpsertMu sync.Mutex
+var tripAggregateAfterUpsertHooks []TripAggregateHook
+
+// doAfterSelectHooks executes all "after Select" hooks.
+func (o *TripAggregate) doAfterSelectHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range tripAggregateAfterSelectHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeInsertHooks executes all "before insert" hooks.
+func (o *TripAggregate) doBeforeInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range tripAggregateBeforeInsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterInsertHooks executes all "after Insert" hooks.
+func (o *TripAggregate) doAfterInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range tripAggregateAfterInsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpdateHooks executes all "before Update" hooks.
+func (o *TripAggregate) doBeforeUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range tripAggregateBeforeUpdateHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpdateHooks executes all "after Update" hooks.
+func (o *TripAggregate) doAfterUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range tripAggregateAfterUpdateHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeDeleteHooks executes all "before Delete" hooks.
+func (o *TripAggregate) doBeforeDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range tripAggregateBeforeDeleteHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterDeleteHooks executes all "after Delete" hooks.
+func (o *TripAggregate) doAfterDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range tripAggregateAfterDeleteHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpsertHooks executes all "before Upsert" hooks.
+func (o *TripAggregate) doBeforeUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range tripAggregateBeforeUpsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpsertHooks executes all "after Upsert" hooks.
+func (o *TripAggregate) doAfterUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range tripAggregateAfterUpsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// AddTripAggregateHook registers your hook function for all future operations.
+func AddTripAggregateHook(hookPoint boil.HookPoint, tripAggregateHook TripAggregateHook) {
+	switch hookPoint {
+	case boil.AfterSelectHook:
+		tripAggregateAfterSelectMu.Lock()
+		tripAggregateAfterSelectHooks = append(tripAggregateAfterSelectHooks, tripAggregateHook)
+		tripAggregateAfterSelectMu.Unlock()
+	case boil.BeforeInsertHook:
+		tripAggregateBeforeInsertMu.Lock()
+		tripAggregateBeforeInsertHooks = append(tripAggregateBeforeInsertHooks, tripAggregateHook)
+		tripAggregateBeforeInsertMu.Unlock()
+	case boil.AfterInsertHook:
+		tripAggregateAfterInsertMu.Lock()
+		tripAggregateAfterInsertHooks = append(tripAggregateAfterInsertHooks, tripAggregateHook)
+		tripAggregateAfterInsertMu.Unlock()
+	case boil.BeforeUpdateHook:
+		tripAggregateBeforeUpdateMu.Lock()
+		tripAggregateBeforeUpdateHooks = append(tripAggregateBeforeUpdateHooks, tripAggregateHook)
+		tripAggregateBeforeUpdateMu.Unlock()
+	case boil.AfterUpdateHook:
+		tripAggregateAfterUpdateMu.Lock()
+		tripAggregateAfterUpdateHooks = append(tripAggregateAfterUpdateHooks, tripAggregateHook)
+		tripAggregateAfterUpdateMu.Unlock()
+	case boil.BeforeDeleteHook:
+		tripAggregateBeforeDeleteMu.Lock()
+		tripAggregateBeforeDeleteHooks = append(tripAggregateBeforeDeleteHooks, tripAggregateHook)
+		tripAggregateBeforeDeleteMu.Unlock()
+	case boil.AfterDeleteHook:
+		tripAggregateAfterDeleteMu.Lock()
+		tripAggregateAfterDeleteHooks = append(tripAggregateAfterDeleteHooks, tripAggregateHook)
+		tripAggregateAfterDeleteMu.Unlock()
+	case boil.BeforeUpsertHook:
+		tripAggregateBeforeUpsertMu.Lock()
+		tripAggregateBeforeUpsertHooks = append(tripAggregateBeforeUpsertHooks, tripAggregateHook)
+		tripAggregateBeforeUpsertMu.Unlock()
+	case boil.AfterUpsertHook:
+		tripAggregateAfterUpsertMu.Lock()
+		tripAggregateAfterUpsertHooks = append(tripAggregateAfterUpsertHooks, tripAggregateHook)
+		tripAggregateAfterUpsertMu.Unlock()
+	}
+}
+
+// One returns a single tripAggregate record from the query.
+func (q tripAggregateQuery) One(ctx context.Context, exec boil.ContextExecutor) (*TripAggregate, error) {
+	o := &TripAggregate{}
+
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Bind(ctx, exec, o)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "models: failed to execute a one query for trip_aggregates")
+	}
+
+	if err := o.doAfterSelectHooks(ctx, exec); err != nil {
+		return o, err
+	}
+
+	return o, nil
+}
+
+// All returns all TripAggregate records from the query.
+func (q tripAggregateQuery) All(ctx context.Context, exec boil.ContextExecutor) (TripAggregateSlice, error) {
+	var o []*TripAggregate
+
+	err := q.Bind(ctx, exec, &o)
+	if err != nil {
+		return nil, errors.Wrap(err, "models: failed to assign all query results to TripAggregate slice")
+	}
+
+	if len(tripAggregateAfterSelectHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterSelectHooks(ctx, exec); err != nil {
+				return o, err
+			}
+		}
+	}
+
+	return o, nil
+}
+
+// Count returns the count of all TripAggregate records in the query.
+func (q tripAggregateQuery) Count(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to count trip_aggregates rows")
+	}
+
+	return count, nil
+}
+
+// Exists checks if the row exists in the table.
+func (q tripAggregateQuery) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return false, errors.Wrap(err, "models: failed to check if trip_aggregates exists")
+	}
+
+	return count > 0, nil
+}
+
+// TripAggregates retrieves all the records using an executor.
+func TripAggregates(mods ...qm.QueryMod) tripAggregateQuery {
+	mods = append(mods, qm.From("\"trips_api\".\"trip_aggregates\""))
+	q := NewQuery(mods...)
+	if len(queries.GetSelect(q)) == 0 {
+		queries.SetSelect(q, []string{"\"trips_api\".\"trip_aggregates\".*"})
+	}
+
+	return tripAggregateQuery{q}
+}
+
+// FindTripAggregate retrieves a single record by ID with an executor.
+// If selectCols is empty Find will return all columns.
+func FindTripAggregate(ctx context.Context, exec boil.ContextExecutor, vehicleTokenID int, month time.Time, selectCols ...string) (*TripAggregate, error) {
+	tripAggregateObj := &TripAggregate{}
+
+	sel := "*"
+	if len(selectCols) > 0 {
+		sel = strings.Join(strmangle.IdentQuoteSlice(dialect.LQ, dialect.RQ, selectCols), ",")
+	}
+	query := fmt.Sprintf(
+		"select %s from \"trips_api\".\"trip_aggregates\" where \"vehicle_token_id\"=$1 AND \"month\"=$2", sel,
+	)
+
+	q := queries.Raw(query, vehicleTokenID, month)
+
+	err := q.Bind(ctx, exec, tripAggregateObj)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "models: unable to select from trip_aggregates")
+	}
+
+	if err = tripAggregateObj.doAfterSelectHooks(ctx, exec); err != nil {
+		return tripAggregateObj, err
+	}
+
+	return tripAggregateObj, nil
+}
+
+// Insert a single record using an executor.
+// See boil.Columns.InsertColumnSet documentation to understand column list inference for inserts.
+func (o *TripAggregate) Insert(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) error {
+	if o == nil {
+		return errors.New("models: no trip_aggregates provided for insertion")
+	}
+
+	var err error
+	if !boil.TimestampsAreSkipped(ctx) {
+		currTime := time.Now().In(boil.GetLocation())
+
+		if o.UpdatedAt.IsZero() {
+			o.UpdatedAt = currTime
+		}
+	}
+
+	if err := o.doBeforeInsertHooks(ctx, exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(tripAggregateColumnsWithDefault, o)
+
+	key := makeCacheKey(columns, nzDefaults)
+	tripAggregateInsertCacheMut.RLock()
+	cache, cached := tripAggregateInsertCache[key]
+	tripAggregateInsertCacheMut.RUnlock()
+
+	if !cached {
+		wl, returnColumns := columns.InsertColumnSet(
+			tripAggregateAllColumns,
+			tripAggregateColumnsWithDefault,
+			tripAggregateColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		cache.valueMapping, err = queries.BindMapping(tripAggregateType, tripAggregateMapping, wl)
+		if err != nil {
+			return err
+		}
+		cache.retMapping, err = queries.BindMapping(tripAggregateType, tripAggregateMapping, returnColumns)
+		if err != nil {
+			return err
+		}
+		if len(wl) != 0 {
+			cache.query = fmt.Sprintf("INSERT INTO \"trips_api\".\"trip_aggregates\" (\"%s\") %%sVALUES (%s)%%s", strings.Join(wl, "\",\""), strmangle.Placeholders(dialect.UseIndexPlaceholders, len(wl), 1, 1))
+		} else {
+			cache.query = "INSERT INTO \"trips_api\".\"trip_aggregates\" %sDEFAULT VALUES%s"
+		}
+
+		var queryOutput, queryReturning string
+
+		if len(cache.retMapping) != 0 {
+			queryReturning = fmt.Sprintf(" RETURNING \"%s\"", strings.Join(returnColumns, "\",\""))
+		}
+
+		cache.query = fmt.Sprintf(cache.query, queryOutput, queryReturning)
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRowContext(ctx, cache.query, vals...).Scan(queries.PtrsFromMapping(value, cache.retMapping)...)
+	} else {
+		_, err = exec.ExecContext(ctx, cache.query, vals...)
+	}
+
+	if err != nil {
+		return errors.Wrap(err, "models: unable to insert into trip_aggregates")
+	}
+
+	if !cached {
+		tripAggregateInsertCacheMut.Lock()
+		tripAggregateInsertCache[key] = cache
+		tripAggregateInsertCacheMut.Unlock()
+	}
+
+	return o.doAfterInsertHooks(ctx, exec)
+}
+
+// Update uses an executor to update the TripAggregate.
+// See boil.Columns.UpdateColumnSet documentation to understand column list inference for updates.
+// Update does not automatically update the record in case of default values. Use .Reload() to refresh the records.
+func (o *TripAggregate) Update(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) (int64, error) {
+	if !boil.TimestampsAreSkipped(ctx) {
+		currTime := time.Now().In(boil.GetLocation())
+
+		o.UpdatedAt = currTime
+	}
+
+	var err error
+	if err = o.doBeforeUpdateHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+	key := makeCacheKey(columns, nil)
+	tripAggregateUpdateCacheMut.RLock()
+	cache, cached := tripAggregateUpdateCache[key]
+	tripAggregateUpdateCacheMut.RUnlock()
+
+	if !cached {
+		wl := columns.UpdateColumnSet(
+			tripAggregateAllColumns,
+			tripAggregatePrimaryKeyColumns,
+		)
+
+		if !columns.IsWhitelist() {
+			wl = strmangle.SetComplement(wl, []string{"created_at"})
+		}
+		if len(wl) == 0 {
+			return 0, errors.New("models: unable to update trip_aggregates, could not build whitelist")
+		}
+
+		cache.query = fmt.Sprintf("UPDATE \"trips_api\".\"trip_aggregates\" SET %s WHERE %s",
+			strmangle.SetParamNames("\"", "\"", 1, wl),
+			strmangle.WhereClause("\"", "\"", len(wl)+1, tripAggregatePrimaryKeyColumns),
+		)
+		cache.valueMapping, err = queries.BindMapping(tripAggregateType, tripAggregateMapping, append(wl, tripAggregatePrimaryKeyColumns...))
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	values := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, values)
+	}
+	var result sql.Result
+	result, err = exec.ExecContext(ctx, cache.query, values...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update trip_aggregates row")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by update for trip_aggregates")
+	}
+
+	if !cached {
+		tripAggregateUpdateCacheMut.Lock()
+		tripAggregateUpdateCache[key] = cache
+		tripAggregateUpdateCacheMut.Unlock()
+	}
+
+	return rowsAff, o.doAfterUpdateHooks(ctx, exec)
+}
+
+// UpdateAll updates all rows with the specified column values.
+func (q tripAggregateQuery) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	queries.SetUpdate(q.Query, cols)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update all for trip_aggregates")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to retrieve rows affected for trip_aggregates")
+	}
+
+	return rowsAff, nil
+}
+
+// UpdateAll updates all rows with the specified column values, using an executor.
+func (o TripAggregateSlice) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	ln := int64(len(o))
+	if ln == 0 {
+		return 0, nil
+	}
+
+	if len(cols) == 0 {
+		return 0, errors.New("models: update all requires at least one column argument")
+	}
+
+	colNames := make([]string, len(cols))
+	args := make([]interface{}, len(cols))
+
+	i := 0
+	for name, value := range cols {
+		colNames[i] = name
+		args[i] = value
+		i++
+	}
+
+	// Append all of the primary key values for each column
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), tripAggregatePrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := fmt.Sprintf("UPDATE \"trips_api\".\"trip_aggregates\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, colNames),
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), len(colNames)+1, tripAggregatePrimaryKeyColumns, len(o)))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update all in tripAggregate slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to retrieve rows affected all in update all tripAggregate")
+	}
+	return rowsAff, nil
+}
+
+// Upsert attempts an insert using an executor, and does an update or ignore on conflict.
+// See boil.Columns documentation for how to properly use updateColumns and insertColumns.
+func (o *TripAggregate) Upsert(ctx context.Context, exec boil.ContextExecutor, updateOnConflict bool, conflictColumns []string, updateColumns, insertColumns boil.Columns, opts ...UpsertOptionFunc) error {
+	if o == nil {
+		return errors.New("models: no trip_aggregates provided for upsert")
+	}
+	if !boil.TimestampsAreSkipped(ctx) {
+		currTime := time.Now().In(boil.GetLocation())
+
+		o.UpdatedAt = currTime
+	}
+
+	if err := o.doBeforeUpsertHooks(ctx, exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(tripAggregateColumnsWithDefault, o)
+
+	// Build cache key in-line uglily - mysql vs psql problems
+	buf := strmangle.GetBuffer()
+	if updateOnConflict {
+		buf.WriteByte('t')
+	} else {
+		buf.WriteByte('f')
+	}
+	buf.WriteByte('.')
+	for _, c := range conflictColumns {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(updateColumns.Kind))
+	for _, c := range updateColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(insertColumns.Kind))
+	for _, c := range insertColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	for _, c := range nzDefaults {
+		buf.WriteString(c)
+	}
+	key := buf.String()
+	strmangle.PutBuffer(buf)
+
+	tripAggregateUpsertCacheMut.RLock()
+	cache, cached := tripAggregateUpsertCache[key]
+	tripAggregateUpsertCacheMut.RUnlock()
+
+	var err error
+
+	if !cached {
+		insert, _ := insertColumns.InsertColumnSet(
+			tripAggregateAllColumns,
+			tripAggregateColumnsWithDefault,
+			tripAggregateColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		update := updateColumns.UpdateColumnSet(
+			tripAggregateAllColumns,
+			tripAggregatePrimaryKeyColumns,
+		)
+
+		if updateOnConflict && len(update) == 0 {
+			return errors.New("models: unable to upsert trip_aggregates, could not build update column list")
+		}
+
+		ret := strmangle.SetComplement(tripAggregateAllColumns, strmangle.SetIntersect(insert, update))
+
+		conflict := conflictColumns
+		if len(conflict) == 0 && updateOnConflict && len(update) != 0 {
+			if len(tripAggregatePrimaryKeyColumns) == 0 {
+				return errors.New("models: unable to upsert trip_aggregates, could not build conflict column list")
+			}
+
+			conflict = make([]string, len(tripAggregatePrimaryKeyColumns))
+			copy(conflict, tripAggregatePrimaryKeyColumns)
+		}
+		cache.query = buildUpsertQueryPostgres(dialect, "\"trips_api\".\"trip_aggregates\"", updateOnConflict, ret, update, conflict, insert, opts...)
+
+		cache.valueMapping, err = queries.BindMapping(tripAggregateType, tripAggregateMapping, insert)
+		if err != nil {
+			return err
+		}
+		if len(ret) != 0 {
+			cache.retMapping, err = queries.BindMapping(tripAggregateType, tripAggregateMapping, ret)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+	var returns []interface{}
+	if len(cache.retMapping) != 0 {
+		returns = queries.PtrsFromMapping(value, cache.retMapping)
+	}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRowContext(ctx, cache.query, vals...).Scan(returns...)
+		if errors.Is(err, sql.ErrNoRows) {
+			err = nil // Postgres doesn't return anything when there's no update
+		}
+	} else {
+		_, err = exec.ExecContext(ctx, cache.query, vals...)
+	}
+	if err != nil {
+		return errors.Wrap(err, "models: unable to upsert trip_aggregates")
+	}
+
+	if !cached {
+		tripAggregateUpsertCacheMut.Lock()
+		tripAggregateUpsertCache[key] = cache
+		tripAggregateUpsertCacheMut.Unlock()
+	}
+
+	return o.doAfterUpsertHooks(ctx, exec)
+}
+
+// Delete deletes a single TripAggregate record with an executor.
+// Delete will match against the primary key column to find the record to delete.
+func (o *TripAggregate) Delete(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if o == nil {
+		return 0, errors.New("models: no TripAggregate provided for delete")
+	}
+
+	if err := o.doBeforeDeleteHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+
+	args := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), tripAggregatePrimaryKeyMapping)
+	sql := "DELETE FROM \"trips_api\".\"trip_aggregates\" WHERE \"vehicle_token_id\"=$1 AND \"month\"=$2"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete from trip_aggregates")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by delete for trip_aggregates")
+	}
+
+	if err := o.doAfterDeleteHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all matching rows.
+func (q tripAggregateQuery) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if q.Query == nil {
+		return 0, errors.New("models: no tripAggregateQuery provided for delete all")
+	}
+
+	queries.SetDelete(q.Query)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete all from trip_aggregates")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by deleteall for trip_aggregates")
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all rows in the slice, using an executor.
+func (o TripAggregateSlice) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if len(o) == 0 {
+		return 0, nil
+	}
+
+	if len(tripAggregateBeforeDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doBeforeDeleteHooks(ctx, exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	var args []interface{}
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), tripAggregatePrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "DELETE FROM \"trips_api\".\"trip_aggregates\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, tripAggregatePrimaryKeyColumns, len(o))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete all from tripAggregate slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by deleteall for trip_aggregates")
+	}
+
+	if len(tripAggregateAfterDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterDeleteHooks(ctx, exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	return rowsAff, nil
+}
+
+// Reload refetches the object from the database
+// using the primary keys with an executor.
+func (o *TripAggregate) Reload(ctx context.Context, exec boil.ContextExecutor) error {
+	ret, err := FindTripAggregate(ctx, exec, o.VehicleTokenID, o.Month)
+	if err != nil {
+		return err
+	}
+
+	*o = *ret
+	return nil
+}
+
+// ReloadAll refetches every row with matching primary key column values
+// and overwrites the original object slice with the newly updated slice.
+func (o *TripAggregateSlice) ReloadAll(ctx context.Context, exec boil.ContextExecutor) error {
+	if o == nil || len(*o) == 0 {
+		return nil
+	}
+
+	slice := TripAggregateSlice{}
+	var args []interface{}
+	for _, obj := range *o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), tripAggregatePrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "SELECT \"trips_api\".\"trip_aggregates\".* FROM \"trips_api\".\"trip_aggregates\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, tripAggregatePrimaryKeyColumns, len(*o))
+
+	q := queries.Raw(sql, args...)
+
+	err := q.Bind(ctx, exec, &slice)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to reload all in TripAggregateSlice")
+	}
+
+	*o = slice
+
+	return nil
+}
+
+// TripAggregateExists checks if the TripAggregate row exists.
+func TripAggregateExists(ctx context.Context, exec boil.ContextExecutor, vehicleTokenID int, month time.Time) (bool, error) {
+	var exists bool
+	sql := "select exists(select 1 from \"trips_api\".\"trip_aggregates\" where \"vehicle_token_id\"=$1 AND \"month\"=$2 limit 1)"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, vehicleTokenID, month)
+	}
+	row := exec.QueryRowContext(ctx, sql, vehicleTokenID, month)
+
+	err := row.Scan(&exists)
+	if err != nil {
+		return false, errors.Wrap(err, "models: unable to check if trip_aggregates exists")
+	}
+
+	return exists, nil
+}
+
+// Exists checks if the TripAggregate row exists.
+func (o *TripAggregate) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	return TripAggregateExists(ctx, exec, o.VehicleTokenID, o.Month)
+}
@@ -0,0 +1,1188 @@
+// Code generated by SQLBoiler 4.16.2 (https://github.com/volatiletech/sqlboiler). DO NOT EDIT.
+// This file is meant to be re-generated in place and/or deleted at any time.
+
+package models
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/friendsofgo/errors"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"github.com/volatiletech/sqlboiler/v4/queries/qmhelper"
+	"github.com/volatiletech/strmangle"
+)
+
+// TripAnnotationChange is an object representing the database table.
+type TripAnnotationChange struct {
+	ID             int64       `boil:"id" json:"id" toml:"id" yaml:"id"`
+	TripID         string      `boil:"trip_id" json:"trip_id" toml:"trip_id" yaml:"trip_id"`
+	VehicleTokenID int         `boil:"vehicle_token_id" json:"vehicle_token_id" toml:"vehicle_token_id" yaml:"vehicle_token_id"`
+	Subject        string      `boil:"subject" json:"subject" toml:"subject" yaml:"subject"`
+	ClientID       null.String `boil:"client_id" json:"client_id,omitempty" toml:"client_id" yaml:"client_id,omitempty"`
+	Field          string      `boil:"field" json:"field" toml:"field" yaml:"field"`
+	OldValue       null.String `boil:"old_value" json:"old_value,omitempty" toml:"old_value" yaml:"old_value,omitempty"`
+	NewValue       null.String `boil:"new_value" json:"new_value,omitempty" toml:"new_value" yaml:"new_value,omitempty"`
+	CreatedAt      time.Time   `boil:"created_at" json:"created_at" toml:"created_at" yaml:"created_at"`
+	UserAddress    null.String `boil:"user_address" json:"user_address,omitempty" toml:"user_address" yaml:"user_address,omitempty"`
+
+	R *tripAnnotationChangeR `boil:"-" json:"-" toml:"-" yaml:"-"`
+	L tripAnnotationChangeL  `boil:"-" json:"-" toml:"-" yaml:"-"`
+}
+
+var TripAnnotationChangeColumns = struct {
+	ID             string
+	TripID         string
+	VehicleTokenID string
+	Subject        string
+	ClientID       string
+	Field          string
+	OldValue       string
+	NewValue       string
+	CreatedAt      string
+	UserAddress    string
+}{
+	ID:             "id",
+	TripID:         "trip_id",
+	VehicleTokenID: "vehicle_token_id",
+	Subject:        "subject",
+	ClientID:       "client_id",
+	Field:          "field",
+	OldValue:       "old_value",
+	NewValue:       "new_value",
+	CreatedAt:      "created_at",
+	UserAddress:    "user_address",
+}
+
+var TripAnnotationChangeTableColumns = struct {
+	ID             string
+	TripID         string
+	VehicleTokenID string
+	Subject        string
+	ClientID       string
+	Field          string
+	OldValue       string
+	NewValue       string
+	CreatedAt      string
+	UserAddress    string
+}{
+	ID:             "trip_annotation_changes.id",
+	TripID:         "trip_annotation_changes.trip_id",
+	VehicleTokenID: "trip_annotation_changes.vehicle_token_id",
+	Subject:        "trip_annotation_changes.subject",
+	ClientID:       "trip_annotation_changes.client_id",
+	Field:          "trip_annotation_changes.field",
+	OldValue:       "trip_annotation_changes.old_value",
+	NewValue:       "trip_annotation_changes.new_value",
+	CreatedAt:      "trip_annotation_changes.created_at",
+	UserAddress:    "trip_annotation_changes.user_address",
+}
+
+// Generated where
+
+var TripAnnotationChangeWhere = struct {
+	ID             whereHelperint64
+	TripID         whereHelperstring
+	VehicleTokenID whereHelperint
+	Subject        whereHelperstring
+	ClientID       whereHelpernull_String
+	Field          whereHelperstring
+	OldValue       whereHelpernull_String
+	NewValue       whereHelpernull_String
+	CreatedAt      whereHelpertime_Time
+	UserAddress    whereHelpernull_String
+}{
+	ID:             whereHelperint64{field: "\"trips_api\".\"trip_annotation_changes\".\"id\""},
+	TripID:         whereHelperstring{field: "\"trips_api\".\"trip_annotation_changes\".\"trip_id\""},
+	VehicleTokenID: whereHelperint{field: "\"trips_api\".\"trip_annotation_changes\".\"vehicle_token_id\""},
+	Subject:        whereHelperstring{field: "\"trips_api\".\"trip_annotation_changes\".\"subject\""},
+	ClientID:       whereHelpernull_String{field: "\"trips_api\".\"trip_annotation_changes\".\"client_id\""},
+	Field:          whereHelperstring{field: "\"trips_api\".\"trip_annotation_changes\".\"field\""},
+	OldValue:       whereHelpernull_String{field: "\"trips_api\".\"trip_annotation_changes\".\"old_value\""},
+	NewValue:       whereHelpernull_String{field: "\"trips_api\".\"trip_annotation_changes\".\"new_value\""},
+	CreatedAt:      whereHelpertime_Time{field: "\"trips_api\".\"trip_annotation_changes\".\"created_at\""},
+	UserAddress:    whereHelpernull_String{field: "\"trips_api\".\"trip_annotation_changes\".\"user_address\""},
+}
+
+// TripAnnotationChangeRels is where relationship names are stored.
+var TripAnnotationChangeRels = struct {
+	Trip string
+}{
+	Trip: "Trip",
+}
+
+// tripAnnotationChangeR is where relationships are stored.
+type tripAnnotationChangeR struct {
+	Trip *Trip `boil:"Trip" json:"Trip" toml:"Trip" yaml:"Trip"`
+}
+
+// NewStruct creates a new relationship struct
+func (*tripAnnotationChangeR) NewStruct() *tripAnnotationChangeR {
+	return &tripAnnotationChangeR{}
+}
+
+func (r *tripAnnotationChangeR) GetTrip() *Trip {
+	if r == nil {
+		return nil
+	}
+	return r.Trip
+}
+
+// tripAnnotationChangeL is where Load methods for each relationship are stored.
+type tripAnnotationChangeL struct{}
+
+var (
+	tripAnnotationChangeAllColumns            = []string{"id", "trip_id", "vehicle_token_id", "subject", "client_id", "field", "old_value", "new_value", "created_at", "user_address"}
+	tripAnnotationChangeColumnsWithoutDefault = []string{"trip_id", "vehicle_token_id", "subject", "field"}
+	tripAnnotationChangeColumnsWithDefault    = []string{"id", "client_id", "old_value", "new_value", "created_at", "user_address"}
+	tripAnnotationChangePrimaryKeyColumns     = []string{"id"}
+	tripAnnotationChangeGeneratedColumns      = []string{}
+)
+
+type (
+	// TripAnnotationChangeSlice is an alias for a slice of pointers to TripAnnotationChange.
+	// This should almost always be used instead of []TripAnnotationChange.
+	TripAnnotationChangeSlice []*TripAnnotationChange
+	// TripAnnotationChangeHook is the signature for custom TripAnnotationChange hook methods
+	TripAnnotationChangeHook func(context.Context, boil.ContextExecutor, *TripAnnotationChange) error
+
+	tripAnnotationChangeQuery struct {
+		*queries.Query
+	}
+)
+
+// Cache for insert, update and upsert
+var (
+	tripAnnotationChangeType                 = reflect.TypeOf(&TripAnnotationChange{})
+	tripAnnotationChangeMapping              = queries.MakeStructMapping(tripAnnotationChangeType)
+	tripAnnotationChangePrimaryKeyMapping, _ = queries.BindMapping(tripAnnotationChangeType, tripAnnotationChangeMapping, tripAnnotationChangePrimaryKeyColumns)
+	tripAnnotationChangeInsertCacheMut       sync.RWMutex
+	tripAnnotationChangeInsertCache          = make(map[string]insertCache)
+	tripAnnotationChangeUpdateCacheMut       sync.RWMutex
+	tripAnnotationChangeUpdateCache          = make(map[string]updateCache)
+	tripAnnotationChangeUpsertCacheMut       sync.RWMutex
+	tripAnnotationChangeUpsertCache          = make(map[string]insertCache)
+)
+
+var (
+	// Force time package dependency for automated UpdatedAt/CreatedAt.
+	_ = time.Second
+	// Force qmhelper dependency for where clause generation (which doesn't
+	// always happen)
+	_ = qmhelper.Where
+)
+
+var tripAnnotationChangeAfterSelectMu sync.Mutex
+var tripAnnotationChangeAfterSelectHooks []TripAnnotationChangeHook
+
+var tripAnnotationChangeBeforeInsertMu sync.Mutex
+var tripAnnotationChangeBeforeInsertHooks []TripAnnotationChangeHook
+var tripAnnotationChangeAfterInsertMu sync.Mutex
+var tripAnnotationChangeAfterInsertHooks []TripAnnotationChangeHook
+
+var tripAnnotationChangeBeforeUpdateMu sync.Mutex
+var tripAnnotationChangeBeforeUpdateHooks []TripAnnotationChangeHook
+var tripAnnotationChangeAfterUpdateMu sync.Mutex
+var tripAnnotationChangeAfterUpdateHooks []TripAnnotationChangeHook
+
+var tripAnnotationChangeBeforeDeleteMu sync.Mutex
+var tripAnnotationChangeBeforeDeleteHooks []TripAnnotationChangeHook
+var tripAnnotationChangeAfterDeleteMu sync.Mutex
+var tripAnnotationChangeAfterDeleteHooks []TripAnnotationChangeHook
+
+var tripAnnotationChangeBeforeUpsertMu sync.Mutex
+var tripAnnotationChangeBeforeUpsertHooks []TripAnnotationChangeHook
+var tripAnnotationChangeAfterUpsertMu sync.Mutex
+var tripAnnotationChangeAfterUpsertHooks []TripAnnotationChangeHook
+
+// doAfterSelectHooks executes all "after Select" hooks.
+func (o *TripAnnotationChange) doAfterSelectHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range tripAnnotationChangeAfterSelectHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeInsertHooks executes all "before insert" hooks.
+func (o *TripAnnotationChange) doBeforeInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range tripAnnotationChangeBeforeInsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterInsertHooks executes all "after Insert" hooks.
+func (o *TripAnnotationChange) doAfterInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range tripAnnotationChangeAfterInsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpdateHooks executes all "before Update" hooks.
+func (o *TripAnnotationChange) doBeforeUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range tripAnnotationChangeBeforeUpdateHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpdateHooks executes all "after Update" hooks.
+func (o *TripAnnotationChange) doAfterUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range tripAnnotationChangeAfterUpdateHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeDeleteHooks executes all "before Delete" hooks.
+func (o *TripAnnotationChange) doBeforeDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range tripAnnotationChangeBeforeDeleteHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterDeleteHooks executes all "after Delete" hooks.
+func (o *TripAnnotationChange) doAfterDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range tripAnnotationChangeAfterDeleteHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpsertHooks executes all "before Upsert" hooks.
+func (o *TripAnnotationChange) doBeforeUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range tripAnnotationChangeBeforeUpsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpsertHooks executes all "after Upsert" hooks.
+func (o *TripAnnotationChange) doAfterUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range tripAnnotationChangeAfterUpsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// AddTripAnnotationChangeHook registers your hook function for all future operations.
+func AddTripAnnotationChangeHook(hookPoint boil.HookPoint, tripAnnotationChangeHook TripAnnotationChangeHook) {
+	switch hookPoint {
+	case boil.AfterSelectHook:
+		tripAnnotationChangeAfterSelectMu.Lock()
+		tripAnnotationChangeAfterSelectHooks = append(tripAnnotationChangeAfterSelectHooks, tripAnnotationChangeHook)
+		tripAnnotationChangeAfterSelectMu.Unlock()
+	case boil.BeforeInsertHook:
+		tripAnnotationChangeBeforeInsertMu.Lock()
+		tripAnnotationChangeBeforeInsertHooks = append(tripAnnotationChangeBeforeInsertHooks, tripAnnotationChangeHook)
+		tripAnnotationChangeBeforeInsertMu.Unlock()
+	case boil.AfterInsertHook:
+		tripAnnotationChangeAfterInsertMu.Lock()
+		tripAnnotationChangeAfterInsertHooks = append(tripAnnotationChangeAfterInsertHooks, tripAnnotationChangeHook)
+		tripAnnotationChangeAfterInsertMu.Unlock()
+	case boil.BeforeUpdateHook:
+		tripAnnotationChangeBeforeUpdateMu.Lock()
+		tripAnnotationChangeBeforeUpdateHooks = append(tripAnnotationChangeBeforeUpdateHooks, tripAnnotationChangeHook)
+		tripAnnotationChangeBeforeUpdateMu.Unlock()
+	case boil.AfterUpdateHook:
+		tripAnnotationChangeAfterUpdateMu.Lock()
+		tripAnnotationChangeAfterUpdateHooks = append(tripAnnotationChangeAfterUpdateHooks, tripAnnotationChangeHook)
+		tripAnnotationChangeAfterUpdateMu.Unlock()
+	case boil.BeforeDeleteHook:
+		tripAnnotationChangeBeforeDeleteMu.Lock()
+		tripAnnotationChangeBeforeDeleteHooks = append(tripAnnotationChangeBeforeDeleteHooks, tripAnnotationChangeHook)
+		tripAnnotationChangeBeforeDeleteMu.Unlock()
+	case boil.AfterDeleteHook:
+		tripAnnotationChangeAfterDeleteMu.Lock()
+		tripAnnotationChangeAfterDeleteHooks = append(tripAnnotationChangeAfterDeleteHooks, tripAnnotationChangeHook)
+		tripAnnotationChangeAfterDeleteMu.Unlock()
+	case boil.BeforeUpsertHook:
+		tripAnnotationChangeBeforeUpsertMu.Lock()
+		tripAnnotationChangeBeforeUpsertHooks = append(tripAnnotationChangeBeforeUpsertHooks, tripAnnotationChangeHook)
+		tripAnnotationChangeBeforeUpsertMu.Unlock()
+	case boil.AfterUpsertHook:
+		tripAnnotationChangeAfterUpsertMu.Lock()
+		tripAnnotationChangeAfterUpsertHooks = append(tripAnnotationChangeAfterUpsertHooks, tripAnnotationChangeHook)
+		tripAnnotationChangeAfterUpsertMu.Unlock()
+	}
+}
+
+// One returns a single tripAnnotationChange record from the query.
+func (q tripAnnotationChangeQuery) One(ctx context.Context, exec boil.ContextExecutor) (*TripAnnotationChange, error) {
+	o := &TripAnnotationChange{}
+
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Bind(ctx, exec, o)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "models: failed to execute a one query for trip_annotation_changes")
+	}
+
+	if err := o.doAfterSelectHooks(ctx, exec); err != nil {
+		return o, err
+	}
+
+	return o, nil
+}
+
+// All returns all TripAnnotationChange records from the query.
+func (q tripAnnotationChangeQuery) All(ctx context.Context, exec boil.ContextExecutor) (TripAnnotationChangeSlice, error) {
+	var o []*TripAnnotationChange
+
+	err := q.Bind(ctx, exec, &o)
+	if err != nil {
+		return nil, errors.Wrap(err, "models: failed to assign all query results to TripAnnotationChange slice")
+	}
+
+	if len(tripAnnotationChangeAfterSelectHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterSelectHooks(ctx, exec); err != nil {
+				return o, err
+			}
+		}
+	}
+
+	return o, nil
+}
+
+// Count returns the count of all TripAnnotationChange records in the query.
+func (q tripAnnotationChangeQuery) Count(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to count trip_annotation_changes rows")
+	}
+
+	return count, nil
+}
+
+// Exists checks if the row exists in the table.
+func (q tripAnnotationChangeQuery) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return false, errors.Wrap(err, "models: failed to check if trip_annotation_changes exists")
+	}
+
+	return count > 0, nil
+}
+
+// Trip pointed to by the foreign key.
+func (o *TripAnnotationChange) Trip(mods ...qm.QueryMod) tripQuery {
+	queryMods := []qm.QueryMod{
+		qm.Where("\"id\" = ?", o.TripID),
+	}
+
+	queryMods = append(queryMods, mods...)
+
+	return Trips(queryMods...)
+}
+
+// LoadTrip allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for an N-1 relationship.
+func (tripAnnotationChangeL) LoadTrip(ctx context.Context, e boil.ContextExecutor, singular bool, maybeTripAnnotationChange interface{}, mods queries.Applicator) error {
+	var slice []*TripAnnotationChange
+	var object *TripAnnotationChange
+
+	if singular {
+		var ok bool
+		object, ok = maybeTripAnnotationChange.(*TripAnnotationChange)
+		if !ok {
+			object = new(TripAnnotationChange)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeTripAnnotationChange)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeTripAnnotationChange))
+			}
+		}
+	} else {
+		s, ok := maybeTripAnnotationChange.(*[]*TripAnnotationChange)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeTripAnnotationChange)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeTripAnnotationChange))
+			}
+		}
+	}
+
+	args := make(map[interface{}]struct{})
+	if singular {
+		if object.R == nil {
+			object.R = &tripAnnotationChangeR{}
+		}
+		args[object.TripID] = struct{}{}
+
+	} else {
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &tripAnnotationChangeR{}
+			}
+
+			args[obj.TripID] = struct{}{}
+
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	argsSlice := make([]interface{}, len(args))
+	i := 0
+	for arg := range args {
+		argsSlice[i] = arg
+		i++
+	}
+
+	query := NewQuery(
+		qm.From(`trips_api.trips`),
+		qm.WhereIn(`trips_api.trips.id in ?`, argsSlice...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load Trip")
+	}
+
+	var resultSlice []*Trip
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice Trip")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results of eager load for trips")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for trips")
+	}
+
+	if len(tripAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+
+	if len(resultSlice) == 0 {
+		return nil
+	}
+
+	if singular {
+		foreign := resultSlice[0]
+		object.R.Trip = foreign
+		if foreign.R == nil {
+			foreign.R = &tripR{}
+		}
+		foreign.R.TripAnnotationChanges = append(foreign.R.TripAnnotationChanges, object)
+		return nil
+	}
+
+	for _, local := range slice {
+		for _, foreign := range resultSlice {
+			if local.TripID == foreign.ID {
+				local.R.Trip = foreign
+				if foreign.R == nil {
+					foreign.R = &tripR{}
+				}
+				foreign.R.TripAnnotationChanges = append(foreign.R.TripAnnotationChanges, local)
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// SetTrip of the tripAnnotationChange to the related item.
+// Sets o.R.Trip to related.
+// Adds o to related.R.TripAnnotationChanges.
+func (o *TripAnnotationChange) SetTrip(ctx context.Context, exec boil.ContextExecutor, insert bool, related *Trip) error {
+	var err error
+	if insert {
+		if err = related.Insert(ctx, exec, boil.Infer()); err != nil {
+			return errors.Wrap(err, "failed to insert into foreign table")
+		}
+	}
+
+	updateQuery := fmt.Sprintf(
+		"UPDATE \"trips_api\".\"trip_annotation_changes\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, []string{"trip_id"}),
+		strmangle.WhereClause("\"", "\"", 2, tripAnnotationChangePrimaryKeyColumns),
+	)
+	values := []interface{}{related.ID, o.ID}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, updateQuery)
+		fmt.Fprintln(writer, values)
+	}
+	if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+		return errors.Wrap(err, "failed to update local table")
+	}
+
+	o.TripID = related.ID
+	if o.R == nil {
+		o.R = &tripAnnotationChangeR{
+			Trip: related,
+		}
+	} else {
+		o.R.Trip = related
+	}
+
+	if related.R == nil {
+		related.R = &tripR{
+			TripAnnotationChanges: TripAnnotationChangeSlice{o},
+		}
+	} else {
+		related.R.TripAnnotationChanges = append(related.R.TripAnnotationChanges, o)
+	}
+
+	return nil
+}
+
+// TripAnnotationChanges retrieves all the records using an executor.
+func TripAnnotationChanges(mods ...qm.QueryMod) tripAnnotationChangeQuery {
+	mods = append(mods, qm.From("\"trips_api\".\"trip_annotation_changes\""))
+	q := NewQuery(mods...)
+	if len(queries.GetSelect(q)) == 0 {
+		queries.SetSelect(q, []string{"\"trips_api\".\"trip_annotation_changes\".*"})
+	}
+
+	return tripAnnotationChangeQuery{q}
+}
+
+// FindTripAnnotationChange retrieves a single record by ID with an executor.
+// If selectCols is empty Find will return all columns.
+func FindTripAnnotationChange(ctx context.Context, exec boil.ContextExecutor, iD int64, selectCols ...string) (*TripAnnotationChange, error) {
+	tripAnnotationChangeObj := &TripAnnotationChange{}
+
+	sel := "*"
+	if len(selectCols) > 0 {
+		sel = strings.Join(strmangle.IdentQuoteSlice(dialect.LQ, dialect.RQ, selectCols), ",")
+	}
+	query := fmt.Sprintf(
+		"select %s from \"trips_api\".\"trip_annotation_changes\" where \"id\"=$1", sel,
+	)
+
+	q := queries.Raw(query, iD)
+
+	err := q.Bind(ctx, exec, tripAnnotationChangeObj)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "models: unable to select from trip_annotation_changes")
+	}
+
+	if err = tripAnnotationChangeObj.doAfterSelectHooks(ctx, exec); err != nil {
+		return tripAnnotationChangeObj, err
+	}
+
+	return tripAnnotationChangeObj, nil
+}
+
+// Insert a single record using an executor.
+// See boil.Columns.InsertColumnSet documentation to understand column list inference for inserts.
+func (o *TripAnnotationChange) Insert(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) error {
+	if o == nil {
+		return errors.New("models: no trip_annotation_changes provided for insertion")
+	}
+
+	var err error
+	if !boil.TimestampsAreSkipped(ctx) {
+		currTime := time.Now().In(boil.GetLocation())
+
+		if o.CreatedAt.IsZero() {
+			o.CreatedAt = currTime
+		}
+	}
+
+	if err := o.doBeforeInsertHooks(ctx, exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(tripAnnotationChangeColumnsWithDefault, o)
+
+	key := makeCacheKey(columns, nzDefaults)
+	tripAnnotationChangeInsertCacheMut.RLock()
+	cache, cached := tripAnnotationChangeInsertCache[key]
+	tripAnnotationChangeInsertCacheMut.RUnlock()
+
+	if !cached {
+		wl, returnColumns := columns.InsertColumnSet(
+			tripAnnotationChangeAllColumns,
+			tripAnnotationChangeColumnsWithDefault,
+			tripAnnotationChangeColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		cache.valueMapping, err = queries.BindMapping(tripAnnotationChangeType, tripAnnotationChangeMapping, wl)
+		if err != nil {
+			return err
+		}
+		cache.retMapping, err = queries.BindMapping(tripAnnotationChangeType, tripAnnotationChangeMapping, returnColumns)
+		if err != nil {
+			return err
+		}
+		if len(wl) != 0 {
+			cache.query = fmt.Sprintf("INSERT INTO \"trips_api\".\"trip_annotation_changes\" (\"%s\") %%sVALUES (%s)%%s", strings.Join(wl, "\",\""), strmangle.Placeholders(dialect.UseIndexPlaceholders, len(wl), 1, 1))
+		} else {
+			cache.query = "INSERT INTO \"trips_api\".\"trip_annotation_changes\" %sDEFAULT VALUES%s"
+		}
+
+		var queryOutput, queryReturning string
+
+		if len(cache.retMapping) != 0 {
+			queryReturning = fmt.Sprintf(" RETURNING \"%s\"", strings.Join(returnColumns, "\",\""))
+		}
+
+		cache.query = fmt.Sprintf(cache.query, queryOutput, queryReturning)
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRowContext(ctx, cache.query, vals...).Scan(queries.PtrsFromMapping(value, cache.retMapping)...)
+	} else {
+		_, err = exec.ExecContext(ctx, cache.query, vals...)
+	}
+
+	if err != nil {
+		return errors.Wrap(err, "models: unable to insert into trip_annotation_changes")
+	}
+
+	if !cached {
+		tripAnnotationChangeInsertCacheMut.Lock()
+		tripAnnotationChangeInsertCache[key] = cache
+		tripAnnotationChangeInsertCacheMut.Unlock()
+	}
+
+	return o.doAfterInsertHooks(ctx, exec)
+}
+
+// Update uses an executor to update the TripAnnotationChange.
+// See boil.Columns.UpdateColumnSet documentation to understand column list inference for updates.
+// Update does not automatically update the record in case of default values. Use .Reload() to refresh the records.
+func (o *TripAnnotationChange) Update(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) (int64, error) {
+	var err error
+	if err = o.doBeforeUpdateHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+	key := makeCacheKey(columns, nil)
+	tripAnnotationChangeUpdateCacheMut.RLock()
+	cache, cached := tripAnnotationChangeUpdateCache[key]
+	tripAnnotationChangeUpdateCacheMut.RUnlock()
+
+	if !cached {
+		wl := columns.UpdateColumnSet(
+			tripAnnotationChangeAllColumns,
+			tripAnnotationChangePrimaryKeyColumns,
+		)
+
+		if !columns.IsWhitelist() {
+			wl = strmangle.SetComplement(wl, []string{"created_at"})
+		}
+		if len(wl) == 0 {
+			return 0, errors.New("models: unable to update trip_annotation_changes, could not build whitelist")
+		}
+
+		cache.query = fmt.Sprintf("UPDATE \"trips_api\".\"trip_annotation_changes\" SET %s WHERE %s",
+			strmangle.SetParamNames("\"", "\"", 1, wl),
+			strmangle.WhereClause("\"", "\"", len(wl)+1, tripAnnotationChangePrimaryKeyColumns),
+		)
+		cache.valueMapping, err = queries.BindMapping(tripAnnotationChangeType, tripAnnotationChangeMapping, append(wl, tripAnnotationChangePrimaryKeyColumns...))
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	values := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, values)
+	}
+	var result sql.Result
+	result, err = exec.ExecContext(ctx, cache.query, values...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update trip_annotation_changes row")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by update for trip_annotation_changes")
+	}
+
+	if !cached {
+		tripAnnotationChangeUpdateCacheMut.Lock()
+		tripAnnotationChangeUpdateCache[key] = cache
+		tripAnnotationChangeUpdateCacheMut.Unlock()
+	}
+
+	return rowsAff, o.doAfterUpdateHooks(ctx, exec)
+}
+
+// UpdateAll updates all rows with the specified column values.
+func (q tripAnnotationChangeQuery) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	queries.SetUpdate(q.Query, cols)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update all for trip_annotation_changes")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to retrieve rows affected for trip_annotation_changes")
+	}
+
+	return rowsAff, nil
+}
+
+// UpdateAll updates all rows with the specified column values, using an executor.
+func (o TripAnnotationChangeSlice) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	ln := int64(len(o))
+	if ln == 0 {
+		return 0, nil
+	}
+
+	if len(cols) == 0 {
+		return 0, errors.New("models: update all requires at least one column argument")
+	}
+
+	colNames := make([]string, len(cols))
+	args := make([]interface{}, len(cols))
+
+	i := 0
+	for name, value := range cols {
+		colNames[i] = name
+		args[i] = value
+		i++
+	}
+
+	// Append all of the primary key values for each column
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), tripAnnotationChangePrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := fmt.Sprintf("UPDATE \"trips_api\".\"trip_annotation_changes\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, colNames),
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), len(colNames)+1, tripAnnotationChangePrimaryKeyColumns, len(o)))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update all in tripAnnotationChange slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to retrieve rows affected all in update all tripAnnotationChange")
+	}
+	return rowsAff, nil
+}
+
+// Upsert attempts an insert using an executor, and does an update or ignore on conflict.
+// See boil.Columns documentation for how to properly use updateColumns and insertColumns.
+func (o *TripAnnotationChange) Upsert(ctx context.Context, exec boil.ContextExecutor, updateOnConflict bool, conflictColumns []string, updateColumns, insertColumns boil.Columns, opts ...UpsertOptionFunc) error {
+	if o == nil {
+		return errors.New("models: no trip_annotation_changes provided for upsert")
+	}
+	if !boil.TimestampsAreSkipped(ctx) {
+		currTime := time.Now().In(boil.GetLocation())
+
+		if o.CreatedAt.IsZero() {
+			o.CreatedAt = currTime
+		}
+	}
+
+	if err := o.doBeforeUpsertHooks(ctx, exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(tripAnnotationChangeColumnsWithDefault, o)
+
+	// Build cache key in-line uglily - mysql vs psql problems
+	buf := strmangle.GetBuffer()
+	if updateOnConflict {
+		buf.WriteByte('t')
+	} else {
+		buf.WriteByte('f')
+	}
+	buf.WriteByte('.')
+	for _, c := range conflictColumns {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(updateColumns.Kind))
+	for _, c := range updateColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(insertColumns.Kind))
+	for _, c := range insertColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	for _, c := range nzDefaults {
+		buf.WriteString(c)
+	}
+	key := buf.String()
+	strmangle.PutBuffer(buf)
+
+	tripAnnotationChangeUpsertCacheMut.RLock()
+	cache, cached := tripAnnotationChangeUpsertCache[key]
+	tripAnnotationChangeUpsertCacheMut.RUnlock()
+
+	var err error
+
+	if !cached {
+		insert, _ := insertColumns.InsertColumnSet(
+			tripAnnotationChangeAllColumns,
+			tripAnnotationChangeColumnsWithDefault,
+			tripAnnotationChangeColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		update := updateColumns.UpdateColumnSet(
+			tripAnnotationChangeAllColumns,
+			tripAnnotationChangePrimaryKeyColumns,
+		)
+
+		if updateOnConflict && len(update) == 0 {
+			return errors.New("models: unable to upsert trip_annotation_changes, could not build update column list")
+		}
+
+		ret := strmangle.SetComplement(tripAnnotationChangeAllColumns, strmangle.SetIntersect(insert, update))
+
+		conflict := conflictColumns
+		if len(conflict) == 0 && updateOnConflict && len(update) != 0 {
+			if len(tripAnnotationChangePrimaryKeyColumns) == 0 {
+				return errors.New("models: unable to upsert trip_annotation_changes, could not build conflict column list")
+			}
+
+			conflict = make([]string, len(tripAnnotationChangePrimaryKeyColumns))
+			copy(conflict, tripAnnotationChangePrimaryKeyColumns)
+		}
+		cache.query = buildUpsertQueryPostgres(dialect, "\"trips_api\".\"trip_annotation_changes\"", updateOnConflict, ret, update, conflict, insert, opts...)
+
+		cache.valueMapping, err = queries.BindMapping(tripAnnotationChangeType, tripAnnotationChangeMapping, insert)
+		if err != nil {
+			return err
+		}
+		if len(ret) != 0 {
+			cache.retMapping, err = queries.BindMapping(tripAnnotationChangeType, tripAnnotationChangeMapping, ret)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+	var returns []interface{}
+	if len(cache.retMapping) != 0 {
+		returns = queries.PtrsFromMapping(value, cache.retMapping)
+	}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRowContext(ctx, cache.query, vals...).Scan(returns...)
+		if errors.Is(err, sql.ErrNoRows) {
+			err = nil // Postgres doesn't return anything when there's no update
+		}
+	} else {
+		_, err = exec.ExecContext(ctx, cache.query, vals...)
+	}
+	if err != nil {
+		return errors.Wrap(err, "models: unable to upsert trip_annotation_changes")
+	}
+
+	if !cached {
+		tripAnnotationChangeUpsertCacheMut.Lock()
+		tripAnnotationChangeUpsertCache[key] = cache
+		tripAnnotationChangeUpsertCacheMut.Unlock()
+	}
+
+	return o.doAfterUpsertHooks(ctx, exec)
+}
+
+// Delete deletes a single TripAnnotationChange record with an executor.
+// Delete will match against the primary key column to find the record to delete.
+func (o *TripAnnotationChange) Delete(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if o == nil {
+		return 0, errors.New("models: no TripAnnotationChange provided for delete")
+	}
+
+	if err := o.doBeforeDeleteHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+
+	args := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), tripAnnotationChangePrimaryKeyMapping)
+	sql := "DELETE FROM \"trips_api\".\"trip_annotation_changes\" WHERE \"id\"=$1"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete from trip_annotation_changes")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by delete for trip_annotation_changes")
+	}
+
+	if err := o.doAfterDeleteHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all matching rows.
+func (q tripAnnotationChangeQuery) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if q.Query == nil {
+		return 0, errors.New("models: no tripAnnotationChangeQuery provided for delete all")
+	}
+
+	queries.SetDelete(q.Query)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete all from trip_annotation_changes")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by deleteall for trip_annotation_changes")
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all rows in the slice, using an executor.
+func (o TripAnnotationChangeSlice) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if len(o) == 0 {
+		return 0, nil
+	}
+
+	if len(tripAnnotationChangeBeforeDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doBeforeDeleteHooks(ctx, exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	var args []interface{}
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), tripAnnotationChangePrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "DELETE FROM \"trips_api\".\"trip_annotation_changes\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, tripAnnotationChangePrimaryKeyColumns, len(o))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete all from tripAnnotationChange slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by deleteall for trip_annotation_changes")
+	}
+
+	if len(tripAnnotationChangeAfterDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterDeleteHooks(ctx, exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	return rowsAff, nil
+}
+
+// Reload refetches the object from the database
+// using the primary keys with an executor.
+func (o *TripAnnotationChange) Reload(ctx context.Context, exec boil.ContextExecutor) error {
+	ret, err := FindTripAnnotationChange(ctx, exec, o.ID)
+	if err != nil {
+		return err
+	}
+
+	*o = *ret
+	return nil
+}
+
+// ReloadAll refetches every row with matching primary key column values
+// and overwrites the original object slice with the newly updated slice.
+func (o *TripAnnotationChangeSlice) ReloadAll(ctx context.Context, exec boil.ContextExecutor) error {
+	if o == nil || len(*o) == 0 {
+		return nil
+	}
+
+	slice := TripAnnotationChangeSlice{}
+	var args []interface{}
+	for _, obj := range *o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), tripAnnotationChangePrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "SELECT \"trips_api\".\"trip_annotation_changes\".* FROM \"trips_api\".\"trip_annotation_changes\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, tripAnnotationChangePrimaryKeyColumns, len(*o))
+
+	q := queries.Raw(sql, args...)
+
+	err := q.Bind(ctx, exec, &slice)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to reload all in TripAnnotationChangeSlice")
+	}
+
+	*o = slice
+
+	return nil
+}
+
+// TripAnnotationChangeExists checks if the TripAnnotationChange row exists.
+func TripAnnotationChangeExists(ctx context.Context, exec boil.ContextExecutor, iD int64) (bool, error) {
+	var exists bool
+	sql := "select exists(select 1 from \"trips_api\".\"trip_annotation_changes\" where \"id\"=$1 limit 1)"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, iD)
+	}
+	row := exec.QueryRowContext(ctx, sql, iD)
+
+	err := row.Scan(&exists)
+	if err != nil {
+		return false, errors.Wrap(err, "models: unable to check if trip_annotation_changes exists")
+	}
+
+	return exists, nil
+}
+
+// Exists checks if the TripAnnotationChange row exists.
+func (o *TripAnnotationChange) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	return TripAnnotationChangeExists(ctx, exec, o.ID)
+}
@@ -0,0 +1,1159 @@
+// Code generated by SQLBoiler 4.16.2 (https://github.com/volatiletech/sqlboiler). DO NOT EDIT.
+// This file is meant to be re-generated in place and/or deleted at any time.
+
+package models
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/friendsofgo/errors"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"github.com/volatiletech/sqlboiler/v4/queries/qmhelper"
+	"github.com/volatiletech/strmangle"
+)
+
+// TripUpdate is an object representing the database table.
+type TripUpdate struct {
+	ID             int64     `boil:"id" json:"id" toml:"id" yaml:"id"`
+	TripID         string    `boil:"trip_id" json:"trip_id" toml:"trip_id" yaml:"trip_id"`
+	VehicleTokenID int       `boil:"vehicle_token_id" json:"vehicle_token_id" toml:"vehicle_token_id" yaml:"vehicle_token_id"`
+	Kind           string    `boil:"kind" json:"kind" toml:"kind" yaml:"kind"`
+	CreatedAt      time.Time `boil:"created_at" json:"created_at" toml:"created_at" yaml:"created_at"`
+	Txid           int64     `boil:"txid" json:"txid" toml:"txid" yaml:"txid"`
+
+	R *tripUpdateR `boil:"-" json:"-" toml:"-" yaml:"-"`
+	L tripUpdateL  `boil:"-" json:"-" toml:"-" yaml:"-"`
+}
+
+var TripUpdateColumns = struct {
+	ID             string
+	TripID         string
+	VehicleTokenID string
+	Kind           string
+	CreatedAt      string
+	Txid           string
+}{
+	ID:             "id",
+	TripID:         "trip_id",
+	VehicleTokenID: "vehicle_token_id",
+	Kind:           "kind",
+	CreatedAt:      "created_at",
+	Txid:           "txid",
+}
+
+var TripUpdateTableColumns = struct {
+	ID             string
+	TripID         string
+	VehicleTokenID string
+	Kind           string
+	CreatedAt      string
+	Txid           string
+}{
+	ID:             "trip_updates.id",
+	TripID:         "trip_updates.trip_id",
+	VehicleTokenID: "trip_updates.vehicle_token_id",
+	Kind:           "trip_updates.kind",
+	CreatedAt:      "trip_updates.created_at",
+	Txid:           "trip_updates.txid",
+}
+
+// Generated where
+
+var TripUpdateWhere = struct {
+	ID             whereHelperint64
+	TripID         whereHelperstring
+	VehicleTokenID whereHelperint
+	Kind           whereHelperstring
+	CreatedAt      whereHelpertime_Time
+	Txid           whereHelperint64
+}{
+	ID:             whereHelperint64{field: "\"trips_api\".\"trip_updates\".\"id\""},
+	TripID:         whereHelperstring{field: "\"trips_api\".\"trip_updates\".\"trip_id\""},
+	VehicleTokenID: whereHelperint{field: "\"trips_api\".\"trip_updates\".\"vehicle_token_id\""},
+	Kind:           whereHelperstring{field: "\"trips_api\".\"trip_updates\".\"kind\""},
+	CreatedAt:      whereHelpertime_Time{field: "\"trips_api\".\"trip_updates\".\"created_at\""},
+	Txid:           whereHelperint64{field: "\"trips_api\".\"trip_updates\".\"txid\""},
+}
+
+// TripUpdateRels is where relationship names are stored.
+var TripUpdateRels = struct {
+	Trip string
+}{
+	Trip: "Trip",
+}
+
+// tripUpdateR is where relationships are stored.
+type tripUpdateR struct {
+	Trip *Trip `boil:"Trip" json:"Trip" toml:"Trip" yaml:"Trip"`
+}
+
+// NewStruct creates a new relationship struct
+func (*tripUpdateR) NewStruct() *tripUpdateR {
+	return &tripUpdateR{}
+}
+
+func (r *tripUpdateR) GetTrip() *Trip {
+	if r == nil {
+		return nil
+	}
+	return r.Trip
+}
+
+// tripUpdateL is where Load methods for each relationship are stored.
+type tripUpdateL struct{}
+
+var (
+	tripUpdateAllColumns            = []string{"id", "trip_id", "vehicle_token_id", "kind", "created_at", "txid"}
+	tripUpdateColumnsWithoutDefault = []string{"trip_id", "vehicle_token_id", "kind"}
+	tripUpdateColumnsWithDefault    = []string{"id", "created_at", "txid"}
+	tripUpdatePrimaryKeyColumns     = []string{"id"}
+	tripUpdateGeneratedColumns      = []string{}
+)
+
+type (
+	// TripUpdateSlice is an alias for a slice of pointers to TripUpdate.
+	// This should almost always be used instead of []TripUpdate.
+	TripUpdateSlice []*TripUpdate
+	// TripUpdateHook is the signature for custom TripUpdate hook methods
+	TripUpdateHook func(context.Context, boil.ContextExecutor, *TripUpdate) error
+
+	tripUpdateQuery struct {
+		*queries.Query
+	}
+)
+
+// Cache for insert, update and upsert
+var (
+	tripUpdateType                 = reflect.TypeOf(&TripUpdate{})
+	tripUpdateMapping              = queries.MakeStructMapping(tripUpdateType)
+	tripUpdatePrimaryKeyMapping, _ = queries.BindMapping(tripUpdateType, tripUpdateMapping, tripUpdatePrimaryKeyColumns)
+	tripUpdateInsertCacheMut       sync.RWMutex
+	tripUpdateInsertCache          = make(map[string]insertCache)
+	tripUpdateUpdateCacheMut       sync.RWMutex
+	tripUpdateUpdateCache          = make(map[string]updateCache)
+	tripUpdateUpsertCacheMut       sync.RWMutex
+	tripUpdateUpsertCache          = make(map[string]insertCache)
+)
+
+var (
+	// Force time package dependency for automated UpdatedAt/CreatedAt.
+	_ = time.Second
+	// Force qmhelper dependency for where clause generation (which doesn't
+	// always happen)
+	_ = qmhelper.Where
+)
+
+var tripUpdateAfterSelectMu sync.Mutex
+var tripUpdateAfterSelectHooks []TripUpdateHook
+
+var tripUpdateBeforeInsertMu sync.Mutex
+var tripUpdateBeforeInsertHooks []TripUpdateHook
+var tripUpdateAfterInsertMu sync.Mutex
+var tripUpdateAfterInsertHooks []TripUpdateHook
+
+var tripUpdateBeforeUpdateMu sync.Mutex
+var tripUpdateBeforeUpdateHooks []TripUpdateHook
+var tripUpdateAfterUpdateMu sync.Mutex
+var tripUpdateAfterUpdateHooks []TripUpdateHook
+
+var tripUpdateBeforeDeleteMu sync.Mutex
+var tripUpdateBeforeDeleteHooks []TripUpdateHook
+var tripUpdateAfterDeleteMu sync.Mutex
+var tripUpdateAfterDeleteHooks []TripUpdateHook
+
+var tripUpdateBeforeUpsertMu sync.Mutex
+var tripUpdateBeforeUpsertHooks []TripUpdateHook
+var tripUpdateAfterUpsertMu sync.Mutex
+var tripUpdateAfterUpsertHooks []TripUpdateHook
+
+// doAfterSelectHooks executes all "after Select" hooks.
+func (o *TripUpdate) doAfterSelectHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range tripUpdateAfterSelectHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeInsertHooks executes all "before insert" hooks.
+func (o *TripUpdate) doBeforeInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range tripUpdateBeforeInsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterInsertHooks executes all "after Insert" hooks.
+func (o *TripUpdate) doAfterInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range tripUpdateAfterInsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpdateHooks executes all "before Update" hooks.
+func (o *TripUpdate) doBeforeUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range tripUpdateBeforeUpdateHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpdateHooks executes all "after Update" hooks.
+func (o *TripUpdate) doAfterUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range tripUpdateAfterUpdateHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeDeleteHooks executes all "before Delete" hooks.
+func (o *TripUpdate) doBeforeDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range tripUpdateBeforeDeleteHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterDeleteHooks executes all "after Delete" hooks.
+func (o *TripUpdate) doAfterDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range tripUpdateAfterDeleteHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpsertHooks executes all "before Upsert" hooks.
+func (o *TripUpdate) doBeforeUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range tripUpdateBeforeUpsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpsertHooks executes all "after Upsert" hooks.
+func (o *TripUpdate) doAfterUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range tripUpdateAfterUpsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// AddTripUpdateHook registers your hook function for all future operations.
+func AddTripUpdateHook(hookPoint boil.HookPoint, tripUpdateHook TripUpdateHook) {
+	switch hookPoint {
+	case boil.AfterSelectHook:
+		tripUpdateAfterSelectMu.Lock()
+		tripUpdateAfterSelectHooks = append(tripUpdateAfterSelectHooks, tripUpdateHook)
+		tripUpdateAfterSelectMu.Unlock()
+	case boil.BeforeInsertHook:
+		tripUpdateBeforeInsertMu.Lock()
+		tripUpdateBeforeInsertHooks = append(tripUpdateBeforeInsertHooks, tripUpdateHook)
+		tripUpdateBeforeInsertMu.Unlock()
+	case boil.AfterInsertHook:
+		tripUpdateAfterInsertMu.Lock()
+		tripUpdateAfterInsertHooks = append(tripUpdateAfterInsertHooks, tripUpdateHook)
+		tripUpdateAfterInsertMu.Unlock()
+	case boil.BeforeUpdateHook:
+		tripUpdateBeforeUpdateMu.Lock()
+		tripUpdateBeforeUpdateHooks = append(tripUpdateBeforeUpdateHooks, tripUpdateHook)
+		tripUpdateBeforeUpdateMu.Unlock()
+	case boil.AfterUpdateHook:
+		tripUpdateAfterUpdateMu.Lock()
+		tripUpdateAfterUpdateHooks = append(tripUpdateAfterUpdateHooks, tripUpdateHook)
+		tripUpdateAfterUpdateMu.Unlock()
+	case boil.BeforeDeleteHook:
+		tripUpdateBeforeDeleteMu.Lock()
+		tripUpdateBeforeDeleteHooks = append(tripUpdateBeforeDeleteHooks, tripUpdateHook)
+		tripUpdateBeforeDeleteMu.Unlock()
+	case boil.AfterDeleteHook:
+		tripUpdateAfterDeleteMu.Lock()
+		tripUpdateAfterDeleteHooks = append(tripUpdateAfterDeleteHooks, tripUpdateHook)
+		tripUpdateAfterDeleteMu.Unlock()
+	case boil.BeforeUpsertHook:
+		tripUpdateBeforeUpsertMu.Lock()
+		tripUpdateBeforeUpsertHooks = append(tripUpdateBeforeUpsertHooks, tripUpdateHook)
+		tripUpdateBeforeUpsertMu.Unlock()
+	case boil.AfterUpsertHook:
+		tripUpdateAfterUpsertMu.Lock()
+		tripUpdateAfterUpsertHooks = append(tripUpdateAfterUpsertHooks, tripUpdateHook)
+		tripUpdateAfterUpsertMu.Unlock()
+	}
+}
+
+// One returns a single tripUpdate record from the query.
+func (q tripUpdateQuery) One(ctx context.Context, exec boil.ContextExecutor) (*TripUpdate, error) {
+	o := &TripUpdate{}
+
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Bind(ctx, exec, o)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "models: failed to execute a one query for trip_updates")
+	}
+
+	if err := o.doAfterSelectHooks(ctx, exec); err != nil {
+		return o, err
+	}
+
+	return o, nil
+}
+
+// All returns all TripUpdate records from the query.
+func (q tripUpdateQuery) All(ctx context.Context, exec boil.ContextExecutor) (TripUpdateSlice, error) {
+	var o []*TripUpdate
+
+	err := q.Bind(ctx, exec, &o)
+	if err != nil {
+		return nil, errors.Wrap(err, "models: failed to assign all query results to TripUpdate slice")
+	}
+
+	if len(tripUpdateAfterSelectHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterSelectHooks(ctx, exec); err != nil {
+				return o, err
+			}
+		}
+	}
+
+	return o, nil
+}
+
+// Count returns the count of all TripUpdate records in the query.
+func (q tripUpdateQuery) Count(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to count trip_updates rows")
+	}
+
+	return count, nil
+}
+
+// Exists checks if the row exists in the table.
+func (q tripUpdateQuery) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return false, errors.Wrap(err, "models: failed to check if trip_updates exists")
+	}
+
+	return count > 0, nil
+}
+
+// Trip pointed to by the foreign key.
+func (o *TripUpdate) Trip(mods ...qm.QueryMod) tripQuery {
+	queryMods := []qm.QueryMod{
+		qm.Where("\"id\" = ?", o.TripID),
+	}
+
+	queryMods = append(queryMods, mods...)
+
+	return Trips(queryMods...)
+}
+
+// LoadTrip allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for an N-1 relationship.
+func (tripUpdateL) LoadTrip(ctx context.Context, e boil.ContextExecutor, singular bool, maybeTripUpdate interface{}, mods queries.Applicator) error {
+	var slice []*TripUpdate
+	var object *TripUpdate
+
+	if singular {
+		var ok bool
+		object, ok = maybeTripUpdate.(*TripUpdate)
+		if !ok {
+			object = new(TripUpdate)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeTripUpdate)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeTripUpdate))
+			}
+		}
+	} else {
+		s, ok := maybeTripUpdate.(*[]*TripUpdate)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeTripUpdate)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeTripUpdate))
+			}
+		}
+	}
+
+	args := make(map[interface{}]struct{})
+	if singular {
+		if object.R == nil {
+			object.R = &tripUpdateR{}
+		}
+		args[object.TripID] = struct{}{}
+
+	} else {
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &tripUpdateR{}
+			}
+
+			args[obj.TripID] = struct{}{}
+
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	argsSlice := make([]interface{}, len(args))
+	i := 0
+	for arg := range args {
+		argsSlice[i] = arg
+		i++
+	}
+
+	query := NewQuery(
+		qm.From(`trips_api.trips`),
+		qm.WhereIn(`trips_api.trips.id in ?`, argsSlice...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load Trip")
+	}
+
+	var resultSlice []*Trip
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice Trip")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results of eager load for trips")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for trips")
+	}
+
+	if len(tripAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+
+	if len(resultSlice) == 0 {
+		return nil
+	}
+
+	if singular {
+		foreign := resultSlice[0]
+		object.R.Trip = foreign
+		if foreign.R == nil {
+			foreign.R = &tripR{}
+		}
+		foreign.R.TripUpdates = append(foreign.R.TripUpdates, object)
+		return nil
+	}
+
+	for _, local := range slice {
+		for _, foreign := range resultSlice {
+			if local.TripID == foreign.ID {
+				local.R.Trip = foreign
+				if foreign.R == nil {
+					foreign.R = &tripR{}
+				}
+				foreign.R.TripUpdates = append(foreign.R.TripUpdates, local)
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// SetTrip of the tripUpdate to the related item.
+// Sets o.R.Trip to related.
+// Adds o to related.R.TripUpdates.
+func (o *TripUpdate) SetTrip(ctx context.Context, exec boil.ContextExecutor, insert bool, related *Trip) error {
+	var err error
+	if insert {
+		if err = related.Insert(ctx, exec, boil.Infer()); err != nil {
+			return errors.Wrap(err, "failed to insert into foreign table")
+		}
+	}
+
+	updateQuery := fmt.Sprintf(
+		"UPDATE \"trips_api\".\"trip_updates\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, []string{"trip_id"}),
+		strmangle.WhereClause("\"", "\"", 2, tripUpdatePrimaryKeyColumns),
+	)
+	values := []interface{}{related.ID, o.ID}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, updateQuery)
+		fmt.Fprintln(writer, values)
+	}
+	if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+		return errors.Wrap(err, "failed to update local table")
+	}
+
+	o.TripID = related.ID
+	if o.R == nil {
+		o.R = &tripUpdateR{
+			Trip: related,
+		}
+	} else {
+		o.R.Trip = related
+	}
+
+	if related.R == nil {
+		related.R = &tripR{
+			TripUpdates: TripUpdateSlice{o},
+		}
+	} else {
+		related.R.TripUpdates = append(related.R.TripUpdates, o)
+	}
+
+	return nil
+}
+
+// TripUpdates retrieves all the records using an executor.
+func TripUpdates(mods ...qm.QueryMod) tripUpdateQuery {
+	mods = append(mods, qm.From("\"trips_api\".\"trip_updates\""))
+	q := NewQuery(mods...)
+	if len(queries.GetSelect(q)) == 0 {
+		queries.SetSelect(q, []string{"\"trips_api\".\"trip_updates\".*"})
+	}
+
+	return tripUpdateQuery{q}
+}
+
+// FindTripUpdate retrieves a single record by ID with an executor.
+// If selectCols is empty Find will return all columns.
+func FindTripUpdate(ctx context.Context, exec boil.ContextExecutor, iD int64, selectCols ...string) (*TripUpdate, error) {
+	tripUpdateObj := &TripUpdate{}
+
+	sel := "*"
+	if len(selectCols) > 0 {
+		sel = strings.Join(strmangle.IdentQuoteSlice(dialect.LQ, dialect.RQ, selectCols), ",")
+	}
+	query := fmt.Sprintf(
+		"select %s from \"trips_api\".\"trip_updates\" where \"id\"=$1", sel,
+	)
+
+	q := queries.Raw(query, iD)
+
+	err := q.Bind(ctx, exec, tripUpdateObj)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "models: unable to select from trip_updates")
+	}
+
+	if err = tripUpdateObj.doAfterSelectHooks(ctx, exec); err != nil {
+		return tripUpdateObj, err
+	}
+
+	return tripUpdateObj, nil
+}
+
+// Insert a single record using an executor.
+// See boil.Columns.InsertColumnSet documentation to understand column list inference for inserts.
+func (o *TripUpdate) Insert(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) error {
+	if o == nil {
+		return errors.New("models: no trip_updates provided for insertion")
+	}
+
+	var err error
+	if !boil.TimestampsAreSkipped(ctx) {
+		currTime := time.Now().In(boil.GetLocation())
+
+		if o.CreatedAt.IsZero() {
+			o.CreatedAt = currTime
+		}
+	}
+
+	if err := o.doBeforeInsertHooks(ctx, exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(tripUpdateColumnsWithDefault, o)
+
+	key := makeCacheKey(columns, nzDefaults)
+	tripUpdateInsertCacheMut.RLock()
+	cache, cached := tripUpdateInsertCache[key]
+	tripUpdateInsertCacheMut.RUnlock()
+
+	if !cached {
+		wl, returnColumns := columns.InsertColumnSet(
+			tripUpdateAllColumns,
+			tripUpdateColumnsWithDefault,
+			tripUpdateColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		cache.valueMapping, err = queries.BindMapping(tripUpdateType, tripUpdateMapping, wl)
+		if err != nil {
+			return err
+		}
+		cache.retMapping, err = queries.BindMapping(tripUpdateType, tripUpdateMapping, returnColumns)
+		if err != nil {
+			return err
+		}
+		if len(wl) != 0 {
+			cache.query = fmt.Sprintf("INSERT INTO \"trips_api\".\"trip_updates\" (\"%s\") %%sVALUES (%s)%%s", strings.Join(wl, "\",\""), strmangle.Placeholders(dialect.UseIndexPlaceholders, len(wl), 1, 1))
+		} else {
+			cache.query = "INSERT INTO \"trips_api\".\"trip_updates\" %sDEFAULT VALUES%s"
+		}
+
+		var queryOutput, queryReturning string
+
+		if len(cache.retMapping) != 0 {
+			queryReturning = fmt.Sprintf(" RETURNING \"%s\"", strings.Join(returnColumns, "\",\""))
+		}
+
+		cache.query = fmt.Sprintf(cache.query, queryOutput, queryReturning)
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRowContext(ctx, cache.query, vals...).Scan(queries.PtrsFromMapping(value, cache.retMapping)...)
+	} else {
+		_, err = exec.ExecContext(ctx, cache.query, vals...)
+	}
+
+	if err != nil {
+		return errors.Wrap(err, "models: unable to insert into trip_updates")
+	}
+
+	if !cached {
+		tripUpdateInsertCacheMut.Lock()
+		tripUpdateInsertCache[key] = cache
+		tripUpdateInsertCacheMut.Unlock()
+	}
+
+	return o.doAfterInsertHooks(ctx, exec)
+}
+
+// Update uses an executor to update the TripUpdate.
+// See boil.Columns.UpdateColumnSet documentation to understand column list inference for updates.
+// Update does not automatically update the record in case of default values. Use .Reload() to refresh the records.
+func (o *TripUpdate) Update(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) (int64, error) {
+	var err error
+	if err = o.doBeforeUpdateHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+	key := makeCacheKey(columns, nil)
+	tripUpdateUpdateCacheMut.RLock()
+	cache, cached := tripUpdateUpdateCache[key]
+	tripUpdateUpdateCacheMut.RUnlock()
+
+	if !cached {
+		wl := columns.UpdateColumnSet(
+			tripUpdateAllColumns,
+			tripUpdatePrimaryKeyColumns,
+		)
+
+		if !columns.IsWhitelist() {
+			wl = strmangle.SetComplement(wl, []string{"created_at"})
+		}
+		if len(wl) == 0 {
+			return 0, errors.New("models: unable to update trip_updates, could not build whitelist")
+		}
+
+		cache.query = fmt.Sprintf("UPDATE \"trips_api\".\"trip_updates\" SET %s WHERE %s",
+			strmangle.SetParamNames("\"", "\"", 1, wl),
+			strmangle.WhereClause("\"", "\"", len(wl)+1, tripUpdatePrimaryKeyColumns),
+		)
+		cache.valueMapping, err = queries.BindMapping(tripUpdateType, tripUpdateMapping, append(wl, tripUpdatePrimaryKeyColumns...))
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	values := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, values)
+	}
+	var result sql.Result
+	result, err = exec.ExecContext(ctx, cache.query, values...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update trip_updates row")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by update for trip_updates")
+	}
+
+	if !cached {
+		tripUpdateUpdateCacheMut.Lock()
+		tripUpdateUpdateCache[key] = cache
+		tripUpdateUpdateCacheMut.Unlock()
+	}
+
+	return rowsAff, o.doAfterUpdateHooks(ctx, exec)
+}
+
+// UpdateAll updates all rows with the specified column values.
+func (q tripUpdateQuery) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	queries.SetUpdate(q.Query, cols)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update all for trip_updates")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to retrieve rows affected for trip_updates")
+	}
+
+	return rowsAff, nil
+}
+
+// UpdateAll updates all rows with the specified column values, using an executor.
+func (o TripUpdateSlice) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	ln := int64(len(o))
+	if ln == 0 {
+		return 0, nil
+	}
+
+	if len(cols) == 0 {
+		return 0, errors.New("models: update all requires at least one column argument")
+	}
+
+	colNames := make([]string, len(cols))
+	args := make([]interface{}, len(cols))
+
+	i := 0
+	for name, value := range cols {
+		colNames[i] = name
+		args[i] = value
+		i++
+	}
+
+	// Append all of the primary key values for each column
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), tripUpdatePrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := fmt.Sprintf("UPDATE \"trips_api\".\"trip_updates\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, colNames),
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), len(colNames)+1, tripUpdatePrimaryKeyColumns, len(o)))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update all in tripUpdate slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to retrieve rows affected all in update all tripUpdate")
+	}
+	return rowsAff, nil
+}
+
+// Upsert attempts an insert using an executor, and does an update or ignore on conflict.
+// See boil.Columns documentation for how to properly use updateColumns and insertColumns.
+func (o *TripUpdate) Upsert(ctx context.Context, exec boil.ContextExecutor, updateOnConflict bool, conflictColumns []string, updateColumns, insertColumns boil.Columns, opts ...UpsertOptionFunc) error {
+	if o == nil {
+		return errors.New("models: no trip_updates provided for upsert")
+	}
+	if !boil.TimestampsAreSkipped(ctx) {
+		currTime := time.Now().In(boil.GetLocation())
+
+		if o.CreatedAt.IsZero() {
+			o.CreatedAt = currTime
+		}
+	}
+
+	if err := o.doBeforeUpsertHooks(ctx, exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(tripUpdateColumnsWithDefault, o)
+
+	// Build cache key in-line uglily - mysql vs psql problems
+	buf := strmangle.GetBuffer()
+	if updateOnConflict {
+		buf.WriteByte('t')
+	} else {
+		buf.WriteByte('f')
+	}
+	buf.WriteByte('.')
+	for _, c := range conflictColumns {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(updateColumns.Kind))
+	for _, c := range updateColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(insertColumns.Kind))
+	for _, c := range insertColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	for _, c := range nzDefaults {
+		buf.WriteString(c)
+	}
+	key := buf.String()
+	strmangle.PutBuffer(buf)
+
+	tripUpdateUpsertCacheMut.RLock()
+	cache, cached := tripUpdateUpsertCache[key]
+	tripUpdateUpsertCacheMut.RUnlock()
+
+	var err error
+
+	if !cached {
+		insert, _ := insertColumns.InsertColumnSet(
+			tripUpdateAllColumns,
+			tripUpdateColumnsWithDefault,
+			tripUpdateColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		update := updateColumns.UpdateColumnSet(
+			tripUpdateAllColumns,
+			tripUpdatePrimaryKeyColumns,
+		)
+
+		if updateOnConflict && len(update) == 0 {
+			return errors.New("models: unable to upsert trip_updates, could not build update column list")
+		}
+
+		ret := strmangle.SetComplement(tripUpdateAllColumns, strmangle.SetIntersect(insert, update))
+
+		conflict := conflictColumns
+		if len(conflict) == 0 && updateOnConflict && len(update) != 0 {
+			if len(tripUpdatePrimaryKeyColumns) == 0 {
+				return errors.New("models: unable to upsert trip_updates, could not build conflict column list")
+			}
+
+			conflict = make([]string, len(tripUpdatePrimaryKeyColumns))
+			copy(conflict, tripUpdatePrimaryKeyColumns)
+		}
+		cache.query = buildUpsertQueryPostgres(dialect, "\"trips_api\".\"trip_updates\"", updateOnConflict, ret, update, conflict, insert, opts...)
+
+		cache.valueMapping, err = queries.BindMapping(tripUpdateType, tripUpdateMapping, insert)
+		if err != nil {
+			return err
+		}
+		if len(ret) != 0 {
+			cache.retMapping, err = queries.BindMapping(tripUpdateType, tripUpdateMapping, ret)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+	var returns []interface{}
+	if len(cache.retMapping) != 0 {
+		returns = queries.PtrsFromMapping(value, cache.retMapping)
+	}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRowContext(ctx, cache.query, vals...).Scan(returns...)
+		if errors.Is(err, sql.ErrNoRows) {
+			err = nil // Postgres doesn't return anything when there's no update
+		}
+	} else {
+		_, err = exec.ExecContext(ctx, cache.query, vals...)
+	}
+	if err != nil {
+		return errors.Wrap(err, "models: unable to upsert trip_updates")
+	}
+
+	if !cached {
+		tripUpdateUpsertCacheMut.Lock()
+		tripUpdateUpsertCache[key] = cache
+		tripUpdateUpsertCacheMut.Unlock()
+	}
+
+	return o.doAfterUpsertHooks(ctx, exec)
+}
+
+// Delete deletes a single TripUpdate record with an executor.
+// Delete will match against the primary key column to find the record to delete.
+func (o *TripUpdate) Delete(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if o == nil {
+		return 0, errors.New("models: no TripUpdate provided for delete")
+	}
+
+	if err := o.doBeforeDeleteHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+
+	args := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), tripUpdatePrimaryKeyMapping)
+	sql := "DELETE FROM \"trips_api\".\"trip_updates\" WHERE \"id\"=$1"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete from trip_updates")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by delete for trip_updates")
+	}
+
+	if err := o.doAfterDeleteHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all matching rows.
+func (q tripUpdateQuery) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if q.Query == nil {
+		return 0, errors.New("models: no tripUpdateQuery provided for delete all")
+	}
+
+	queries.SetDelete(q.Query)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete all from trip_updates")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by deleteall for trip_updates")
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all rows in the slice, using an executor.
+func (o TripUpdateSlice) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if len(o) == 0 {
+		return 0, nil
+	}
+
+	if len(tripUpdateBeforeDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doBeforeDeleteHooks(ctx, exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	var args []interface{}
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), tripUpdatePrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "DELETE FROM \"trips_api\".\"trip_updates\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, tripUpdatePrimaryKeyColumns, len(o))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete all from tripUpdate slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by deleteall for trip_updates")
+	}
+
+	if len(tripUpdateAfterDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterDeleteHooks(ctx, exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	return rowsAff, nil
+}
+
+// Reload refetches the object from the database
+// using the primary keys with an executor.
+func (o *TripUpdate) Reload(ctx context.Context, exec boil.ContextExecutor) error {
+	ret, err := FindTripUpdate(ctx, exec, o.ID)
+	if err != nil {
+		return err
+	}
+
+	*o = *ret
+	return nil
+}
+
+// ReloadAll refetches every row with matching primary key column values
+// and overwrites the original object slice with the newly updated slice.
+func (o *TripUpdateSlice) ReloadAll(ctx context.Context, exec boil.ContextExecutor) error {
+	if o == nil || len(*o) == 0 {
+		return nil
+	}
+
+	slice := TripUpdateSlice{}
+	var args []interface{}
+	for _, obj := range *o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), tripUpdatePrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "SELECT \"trips_api\".\"trip_updates\".* FROM \"trips_api\".\"trip_updates\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, tripUpdatePrimaryKeyColumns, len(*o))
+
+	q := queries.Raw(sql, args...)
+
+	err := q.Bind(ctx, exec, &slice)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to reload all in TripUpdateSlice")
+	}
+
+	*o = slice
+
+	return nil
+}
+
+// TripUpdateExists checks if the TripUpdate row exists.
+func TripUpdateExists(ctx context.Context, exec boil.ContextExecutor, iD int64) (bool, error) {
+	var exists bool
+	sql := "select exists(select 1 from \"trips_api\".\"trip_updates\" where \"id\"=$1 limit 1)"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, iD)
+	}
+	row := exec.QueryRowContext(ctx, sql, iD)
+
+	err := row.Scan(&exists)
+	if err != nil {
+		return false, errors.Wrap(err, "models: unable to check if trip_updates exists")
+	}
+
+	return exists, nil
+}
+
+// Exists checks if the TripUpdate row exists.
+func (o *TripUpdate) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	return TripUpdateExists(ctx, exec, o.ID)
+}
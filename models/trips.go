@@ -19,22 +19,31 @@ import (
 	"github.com/volatiletech/sqlboiler/v4/queries"
 	"github.com/volatiletech/sqlboiler/v4/queries/qm"
 	"github.com/volatiletech/sqlboiler/v4/queries/qmhelper"
+	"github.com/volatiletech/sqlboiler/v4/types"
 	"github.com/volatiletech/sqlboiler/v4/types/pgeo"
 	"github.com/volatiletech/strmangle"
 )
 
 // Trip is an object representing the database table.
 type Trip struct {
-	ID                    string         `boil:"id" json:"id" toml:"id" yaml:"id"`
-	StartTime             time.Time      `boil:"start_time" json:"start_time" toml:"start_time" yaml:"start_time"`
-	EndTime               null.Time      `boil:"end_time" json:"end_time,omitempty" toml:"end_time" yaml:"end_time,omitempty"`
-	VehicleTokenID        int            `boil:"vehicle_token_id" json:"vehicle_token_id" toml:"vehicle_token_id" yaml:"vehicle_token_id"`
-	EncryptionKey         null.Bytes     `boil:"encryption_key" json:"encryption_key,omitempty" toml:"encryption_key" yaml:"encryption_key,omitempty"`
-	BundlrID              null.String    `boil:"bundlr_id" json:"bundlr_id,omitempty" toml:"bundlr_id" yaml:"bundlr_id,omitempty"`
-	StartPosition         pgeo.NullPoint `boil:"start_position" json:"start_position,omitempty" toml:"start_position" yaml:"start_position,omitempty"`
-	StartPositionEstimate pgeo.NullPoint `boil:"start_position_estimate" json:"start_position_estimate,omitempty" toml:"start_position_estimate" yaml:"start_position_estimate,omitempty"`
-	EndPosition           pgeo.NullPoint `boil:"end_position" json:"end_position,omitempty" toml:"end_position" yaml:"end_position,omitempty"`
-	DroppedData           bool           `boil:"dropped_data" json:"dropped_data" toml:"dropped_data" yaml:"dropped_data"`
+	ID                    string            `boil:"id" json:"id" toml:"id" yaml:"id"`
+	StartTime             time.Time         `boil:"start_time" json:"start_time" toml:"start_time" yaml:"start_time"`
+	EndTime               null.Time         `boil:"end_time" json:"end_time,omitempty" toml:"end_time" yaml:"end_time,omitempty"`
+	VehicleTokenID        int               `boil:"vehicle_token_id" json:"vehicle_token_id" toml:"vehicle_token_id" yaml:"vehicle_token_id"`
+	EncryptionKey         null.Bytes        `boil:"encryption_key" json:"encryption_key,omitempty" toml:"encryption_key" yaml:"encryption_key,omitempty"`
+	BundlrID              null.String       `boil:"bundlr_id" json:"bundlr_id,omitempty" toml:"bundlr_id" yaml:"bundlr_id,omitempty"`
+	StartPosition         pgeo.NullPoint    `boil:"start_position" json:"start_position,omitempty" toml:"start_position" yaml:"start_position,omitempty"`
+	StartPositionEstimate pgeo.NullPoint    `boil:"start_position_estimate" json:"start_position_estimate,omitempty" toml:"start_position_estimate" yaml:"start_position_estimate,omitempty"`
+	EndPosition           pgeo.NullPoint    `boil:"end_position" json:"end_position,omitempty" toml:"end_position" yaml:"end_position,omitempty"`
+	DroppedData           bool              `boil:"dropped_data" json:"dropped_data" toml:"dropped_data" yaml:"dropped_data"`
+	DistanceKM            null.Float64      `boil:"distance_km" json:"distance_km,omitempty" toml:"distance_km" yaml:"distance_km,omitempty"`
+	Purpose               null.String       `boil:"purpose" json:"purpose,omitempty" toml:"purpose" yaml:"purpose,omitempty"`
+	CustomPurpose         null.String       `boil:"custom_purpose" json:"custom_purpose,omitempty" toml:"custom_purpose" yaml:"custom_purpose,omitempty"`
+	Note                  null.String       `boil:"note" json:"note,omitempty" toml:"note" yaml:"note,omitempty"`
+	SegmentIds            types.StringArray `boil:"segment_ids" json:"segment_ids" toml:"segment_ids" yaml:"segment_ids"`
+	DataSha256            null.Bytes        `boil:"data_sha256" json:"data_sha256,omitempty" toml:"data_sha256" yaml:"data_sha256,omitempty"`
+	CiphertextSha256      null.Bytes        `boil:"ciphertext_sha256" json:"ciphertext_sha256,omitempty" toml:"ciphertext_sha256" yaml:"ciphertext_sha256,omitempty"`
+	Compression           null.String       `boil:"compression" json:"compression,omitempty" toml:"compression" yaml:"compression,omitempty"`
 
 	R *tripR `boil:"-" json:"-" toml:"-" yaml:"-"`
 	L tripL  `boil:"-" json:"-" toml:"-" yaml:"-"`
@@ -51,6 +60,14 @@ var TripColumns = struct {
 	StartPositionEstimate string
 	EndPosition           string
 	DroppedData           string
+	DistanceKM            string
+	Purpose               string
+	CustomPurpose         string
+	Note                  string
+	SegmentIds            string
+	DataSha256            string
+	CiphertextSha256      string
+	Compression           string
 }{
 	ID:                    "id",
 	StartTime:             "start_time",
@@ -62,6 +79,14 @@ var TripColumns = struct {
 	StartPositionEstimate: "start_position_estimate",
 	EndPosition:           "end_position",
 	DroppedData:           "dropped_data",
+	DistanceKM:            "distance_km",
+	Purpose:               "purpose",
+	CustomPurpose:         "custom_purpose",
+	Note:                  "note",
+	SegmentIds:            "segment_ids",
+	DataSha256:            "data_sha256",
+	CiphertextSha256:      "ciphertext_sha256",
+	Compression:           "compression",
 }
 
 var TripTableColumns = struct {
@@ -75,6 +100,14 @@ var TripTableColumns = struct {
 	StartPositionEstimate string
 	EndPosition           string
 	DroppedData           string
+	DistanceKM            string
+	Purpose               string
+	CustomPurpose         string
+	Note                  string
+	SegmentIds            string
+	DataSha256            string
+	CiphertextSha256      string
+	Compression           string
 }{
 	ID:                    "trips.id",
 	StartTime:             "trips.start_time",
@@ -86,105 +119,18 @@ var TripTableColumns = struct {
 	StartPositionEstimate: "trips.start_position_estimate",
 	EndPosition:           "trips.end_position",
 	DroppedData:           "trips.dropped_data",
+	DistanceKM:            "trips.distance_km",
+	Purpose:               "trips.purpose",
+	CustomPurpose:         "trips.custom_purpose",
+	Note:                  "trips.note",
+	SegmentIds:            "trips.segment_ids",
+	DataSha256:            "trips.data_sha256",
+	CiphertextSha256:      "trips.ciphertext_sha256",
+	Compression:           "trips.compression",
 }
 
 // Generated where
 
-type whereHelperstring struct{ field string }
-
-func (w whereHelperstring) EQ(x string) qm.QueryMod     { return qmhelper.Where(w.field, qmhelper.EQ, x) }
-func (w whereHelperstring) NEQ(x string) qm.QueryMod    { return qmhelper.Where(w.field, qmhelper.NEQ, x) }
-func (w whereHelperstring) LT(x string) qm.QueryMod     { return qmhelper.Where(w.field, qmhelper.LT, x) }
-func (w whereHelperstring) LTE(x string) qm.QueryMod    { return qmhelper.Where(w.field, qmhelper.LTE, x) }
-func (w whereHelperstring) GT(x string) qm.QueryMod     { return qmhelper.Where(w.field, qmhelper.GT, x) }
-func (w whereHelperstring) GTE(x string) qm.QueryMod    { return qmhelper.Where(w.field, qmhelper.GTE, x) }
-func (w whereHelperstring) LIKE(x string) qm.QueryMod   { return qm.Where(w.field+" LIKE ?", x) }
-func (w whereHelperstring) NLIKE(x string) qm.QueryMod  { return qm.Where(w.field+" NOT LIKE ?", x) }
-func (w whereHelperstring) ILIKE(x string) qm.QueryMod  { return qm.Where(w.field+" ILIKE ?", x) }
-func (w whereHelperstring) NILIKE(x string) qm.QueryMod { return qm.Where(w.field+" NOT ILIKE ?", x) }
-func (w whereHelperstring) IN(slice []string) qm.QueryMod {
-	values := make([]interface{}, 0, len(slice))
-	for _, value := range slice {
-		values = append(values, value)
-	}
-	return qm.WhereIn(fmt.Sprintf("%s IN ?", w.field), values...)
-}
-func (w whereHelperstring) NIN(slice []string) qm.QueryMod {
-	values := make([]interface{}, 0, len(slice))
-	for _, value := range slice {
-		values = append(values, value)
-	}
-	return qm.WhereNotIn(fmt.Sprintf("%s NOT IN ?", w.field), values...)
-}
-
-type whereHelpertime_Time struct{ field string }
-
-func (w whereHelpertime_Time) EQ(x time.Time) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.EQ, x)
-}
-func (w whereHelpertime_Time) NEQ(x time.Time) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.NEQ, x)
-}
-func (w whereHelpertime_Time) LT(x time.Time) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.LT, x)
-}
-func (w whereHelpertime_Time) LTE(x time.Time) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.LTE, x)
-}
-func (w whereHelpertime_Time) GT(x time.Time) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.GT, x)
-}
-func (w whereHelpertime_Time) GTE(x time.Time) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.GTE, x)
-}
-
-type whereHelpernull_Time struct{ field string }
-
-func (w whereHelpernull_Time) EQ(x null.Time) qm.QueryMod {
-	return qmhelper.WhereNullEQ(w.field, false, x)
-}
-func (w whereHelpernull_Time) NEQ(x null.Time) qm.QueryMod {
-	return qmhelper.WhereNullEQ(w.field, true, x)
-}
-func (w whereHelpernull_Time) LT(x null.Time) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.LT, x)
-}
-func (w whereHelpernull_Time) LTE(x null.Time) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.LTE, x)
-}
-func (w whereHelpernull_Time) GT(x null.Time) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.GT, x)
-}
-func (w whereHelpernull_Time) GTE(x null.Time) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.GTE, x)
-}
-
-func (w whereHelpernull_Time) IsNull() qm.QueryMod    { return qmhelper.WhereIsNull(w.field) }
-func (w whereHelpernull_Time) IsNotNull() qm.QueryMod { return qmhelper.WhereIsNotNull(w.field) }
-
-type whereHelperint struct{ field string }
-
-func (w whereHelperint) EQ(x int) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.EQ, x) }
-func (w whereHelperint) NEQ(x int) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.NEQ, x) }
-func (w whereHelperint) LT(x int) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.LT, x) }
-func (w whereHelperint) LTE(x int) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.LTE, x) }
-func (w whereHelperint) GT(x int) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.GT, x) }
-func (w whereHelperint) GTE(x int) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.GTE, x) }
-func (w whereHelperint) IN(slice []int) qm.QueryMod {
-	values := make([]interface{}, 0, len(slice))
-	for _, value := range slice {
-		values = append(values, value)
-	}
-	return qm.WhereIn(fmt.Sprintf("%s IN ?", w.field), values...)
-}
-func (w whereHelperint) NIN(slice []int) qm.QueryMod {
-	values := make([]interface{}, 0, len(slice))
-	for _, value := range slice {
-		values = append(values, value)
-	}
-	return qm.WhereNotIn(fmt.Sprintf("%s NOT IN ?", w.field), values...)
-}
-
 type whereHelpernull_Bytes struct{ field string }
 
 func (w whereHelpernull_Bytes) EQ(x null.Bytes) qm.QueryMod {
@@ -209,56 +155,6 @@ func (w whereHelpernull_Bytes) GTE(x null.Bytes) qm.QueryMod {
 func (w whereHelpernull_Bytes) IsNull() qm.QueryMod    { return qmhelper.WhereIsNull(w.field) }
 func (w whereHelpernull_Bytes) IsNotNull() qm.QueryMod { return qmhelper.WhereIsNotNull(w.field) }
 
-type whereHelpernull_String struct{ field string }
-
-func (w whereHelpernull_String) EQ(x null.String) qm.QueryMod {
-	return qmhelper.WhereNullEQ(w.field, false, x)
-}
-func (w whereHelpernull_String) NEQ(x null.String) qm.QueryMod {
-	return qmhelper.WhereNullEQ(w.field, true, x)
-}
-func (w whereHelpernull_String) LT(x null.String) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.LT, x)
-}
-func (w whereHelpernull_String) LTE(x null.String) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.LTE, x)
-}
-func (w whereHelpernull_String) GT(x null.String) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.GT, x)
-}
-func (w whereHelpernull_String) GTE(x null.String) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.GTE, x)
-}
-func (w whereHelpernull_String) LIKE(x null.String) qm.QueryMod {
-	return qm.Where(w.field+" LIKE ?", x)
-}
-func (w whereHelpernull_String) NLIKE(x null.String) qm.QueryMod {
-	return qm.Where(w.field+" NOT LIKE ?", x)
-}
-func (w whereHelpernull_String) ILIKE(x null.String) qm.QueryMod {
-	return qm.Where(w.field+" ILIKE ?", x)
-}
-func (w whereHelpernull_String) NILIKE(x null.String) qm.QueryMod {
-	return qm.Where(w.field+" NOT ILIKE ?", x)
-}
-func (w whereHelpernull_String) IN(slice []string) qm.QueryMod {
-	values := make([]interface{}, 0, len(slice))
-	for _, value := range slice {
-		values = append(values, value)
-	}
-	return qm.WhereIn(fmt.Sprintf("%s IN ?", w.field), values...)
-}
-func (w whereHelpernull_String) NIN(slice []string) qm.QueryMod {
-	values := make([]interface{}, 0, len(slice))
-	for _, value := range slice {
-		values = append(values, value)
-	}
-	return qm.WhereNotIn(fmt.Sprintf("%s NOT IN ?", w.field), values...)
-}
-
-func (w whereHelpernull_String) IsNull() qm.QueryMod    { return qmhelper.WhereIsNull(w.field) }
-func (w whereHelpernull_String) IsNotNull() qm.QueryMod { return qmhelper.WhereIsNotNull(w.field) }
-
 type whereHelperpgeo_NullPoint struct{ field string }
 
 func (w whereHelperpgeo_NullPoint) EQ(x pgeo.NullPoint) qm.QueryMod {
@@ -292,6 +188,44 @@ func (w whereHelperbool) LTE(x bool) qm.QueryMod { return qmhelper.Where(w.field
 func (w whereHelperbool) GT(x bool) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.GT, x) }
 func (w whereHelperbool) GTE(x bool) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.GTE, x) }
 
+type whereHelpernull_Float64 struct{ field string }
+
+func (w whereHelpernull_Float64) EQ(x null.Float64) qm.QueryMod {
+	return qmhelper.WhereNullEQ(w.field, false, x)
+}
+func (w whereHelpernull_Float64) NEQ(x null.Float64) qm.QueryMod {
+	return qmhelper.WhereNullEQ(w.field, true, x)
+}
+func (w whereHelpernull_Float64) LT(x null.Float64) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.LT, x)
+}
+func (w whereHelpernull_Float64) LTE(x null.Float64) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.LTE, x)
+}
+func (w whereHelpernull_Float64) GT(x null.Float64) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.GT, x)
+}
+func (w whereHelpernull_Float64) GTE(x null.Float64) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.GTE, x)
+}
+func (w whereHelpernull_Float64) IN(slice []float64) qm.QueryMod {
+	values := make([]interface{}, 0, len(slice))
+	for _, value := range slice {
+		values = append(values, value)
+	}
+	return qm.WhereIn(fmt.Sprintf("%s IN ?", w.field), values...)
+}
+func (w whereHelpernull_Float64) NIN(slice []float64) qm.QueryMod {
+	values := make([]interface{}, 0, len(slice))
+	for _, value := range slice {
+		values = append(values, value)
+	}
+	return qm.WhereNotIn(fmt.Sprintf("%s NOT IN ?", w.field), values...)
+}
+
+func (w whereHelpernull_Float64) IsNull() qm.QueryMod    { return qmhelper.WhereIsNull(w.field) }
+func (w whereHelpernull_Float64) IsNotNull() qm.QueryMod { return qmhelper.WhereIsNotNull(w.field) }
+
 var TripWhere = struct {
 	ID                    whereHelperstring
 	StartTime             whereHelpertime_Time
@@ -303,6 +237,14 @@ var TripWhere = struct {
 	StartPositionEstimate whereHelperpgeo_NullPoint
 	EndPosition           whereHelperpgeo_NullPoint
 	DroppedData           whereHelperbool
+	DistanceKM            whereHelpernull_Float64
+	Purpose               whereHelpernull_String
+	CustomPurpose         whereHelpernull_String
+	Note                  whereHelpernull_String
+	SegmentIds            whereHelpertypes_StringArray
+	DataSha256            whereHelpernull_Bytes
+	CiphertextSha256      whereHelpernull_Bytes
+	Compression           whereHelpernull_String
 }{
 	ID:                    whereHelperstring{field: "\"trips_api\".\"trips\".\"id\""},
 	StartTime:             whereHelpertime_Time{field: "\"trips_api\".\"trips\".\"start_time\""},
@@ -314,18 +256,32 @@ var TripWhere = struct {
 	StartPositionEstimate: whereHelperpgeo_NullPoint{field: "\"trips_api\".\"trips\".\"start_position_estimate\""},
 	EndPosition:           whereHelperpgeo_NullPoint{field: "\"trips_api\".\"trips\".\"end_position\""},
 	DroppedData:           whereHelperbool{field: "\"trips_api\".\"trips\".\"dropped_data\""},
+	DistanceKM:            whereHelpernull_Float64{field: "\"trips_api\".\"trips\".\"distance_km\""},
+	Purpose:               whereHelpernull_String{field: "\"trips_api\".\"trips\".\"purpose\""},
+	CustomPurpose:         whereHelpernull_String{field: "\"trips_api\".\"trips\".\"custom_purpose\""},
+	Note:                  whereHelpernull_String{field: "\"trips_api\".\"trips\".\"note\""},
+	SegmentIds:            whereHelpertypes_StringArray{field: "\"trips_api\".\"trips\".\"segment_ids\""},
+	DataSha256:            whereHelpernull_Bytes{field: "\"trips_api\".\"trips\".\"data_sha256\""},
+	CiphertextSha256:      whereHelpernull_Bytes{field: "\"trips_api\".\"trips\".\"ciphertext_sha256\""},
+	Compression:           whereHelpernull_String{field: "\"trips_api\".\"trips\".\"compression\""},
 }
 
 // TripRels is where relationship names are stored.
 var TripRels = struct {
-	VehicleToken string
+	VehicleToken          string
+	TripAnnotationChanges string
+	TripUpdates           string
 }{
-	VehicleToken: "VehicleToken",
+	VehicleToken:          "VehicleToken",
+	TripAnnotationChanges: "TripAnnotationChanges",
+	TripUpdates:           "TripUpdates",
 }
 
 // tripR is where relationships are stored.
 type tripR struct {
-	VehicleToken *Vehicle `boil:"VehicleToken" json:"VehicleToken" toml:"VehicleToken" yaml:"VehicleToken"`
+	VehicleToken          *Vehicle                  `boil:"VehicleToken" json:"VehicleToken" toml:"VehicleToken" yaml:"VehicleToken"`
+	TripAnnotationChanges TripAnnotationChangeSlice `boil:"TripAnnotationChanges" json:"TripAnnotationChanges" toml:"TripAnnotationChanges" yaml:"TripAnnotationChanges"`
+	TripUpdates           TripUpdateSlice           `boil:"TripUpdates" json:"TripUpdates" toml:"TripUpdates" yaml:"TripUpdates"`
 }
 
 // NewStruct creates a new relationship struct
@@ -340,13 +296,27 @@ func (r *tripR) GetVehicleToken() *Vehicle {
 	return r.VehicleToken
 }
 
+func (r *tripR) GetTripAnnotationChanges() TripAnnotationChangeSlice {
+	if r == nil {
+		return nil
+	}
+	return r.TripAnnotationChanges
+}
+
+func (r *tripR) GetTripUpdates() TripUpdateSlice {
+	if r == nil {
+		return nil
+	}
+	return r.TripUpdates
+}
+
 // tripL is where Load methods for each relationship are stored.
 type tripL struct{}
 
 var (
-	tripAllColumns            = []string{"id", "start_time", "end_time", "vehicle_token_id", "encryption_key", "bundlr_id", "start_position", "start_position_estimate", "end_position", "dropped_data"}
+	tripAllColumns            = []string{"id", "start_time", "end_time", "vehicle_token_id", "encryption_key", "bundlr_id", "start_position", "start_position_estimate", "end_position", "dropped_data", "distance_km", "purpose", "custom_purpose", "note", "segment_ids", "data_sha256", "ciphertext_sha256", "compression"}
 	tripColumnsWithoutDefault = []string{"id", "start_time", "vehicle_token_id"}
-	tripColumnsWithDefault    = []string{"end_time", "encryption_key", "bundlr_id", "start_position", "start_position_estimate", "end_position", "dropped_data"}
+	tripColumnsWithDefault    = []string{"end_time", "encryption_key", "bundlr_id", "start_position", "start_position_estimate", "end_position", "dropped_data", "distance_km", "purpose", "custom_purpose", "note", "segment_ids", "data_sha256", "ciphertext_sha256", "compression"}
 	tripPrimaryKeyColumns     = []string{"id"}
 	tripGeneratedColumns      = []string{}
 )
@@ -667,6 +637,34 @@ func (o *Trip) VehicleToken(mods ...qm.QueryMod) vehicleQuery {
 	return Vehicles(queryMods...)
 }
 
+// TripAnnotationChanges retrieves all the trip_annotation_change's TripAnnotationChanges with an executor.
+func (o *Trip) TripAnnotationChanges(mods ...qm.QueryMod) tripAnnotationChangeQuery {
+	var queryMods []qm.QueryMod
+	if len(mods) != 0 {
+		queryMods = append(queryMods, mods...)
+	}
+
+	queryMods = append(queryMods,
+		qm.Where("\"trips_api\".\"trip_annotation_changes\".\"trip_id\"=?", o.ID),
+	)
+
+	return TripAnnotationChanges(queryMods...)
+}
+
+// TripUpdates retrieves all the trip_update's TripUpdates with an executor.
+func (o *Trip) TripUpdates(mods ...qm.QueryMod) tripUpdateQuery {
+	var queryMods []qm.QueryMod
+	if len(mods) != 0 {
+		queryMods = append(queryMods, mods...)
+	}
+
+	queryMods = append(queryMods,
+		qm.Where("\"trips_api\".\"trip_updates\".\"trip_id\"=?", o.ID),
+	)
+
+	return TripUpdates(queryMods...)
+}
+
 // LoadVehicleToken allows an eager lookup of values, cached into the
 // loaded structs of the objects. This is for an N-1 relationship.
 func (tripL) LoadVehicleToken(ctx context.Context, e boil.ContextExecutor, singular bool, maybeTrip interface{}, mods queries.Applicator) error {
@@ -787,6 +785,232 @@ func (tripL) LoadVehicleToken(ctx context.Context, e boil.ContextExecutor, singu
 	return nil
 }
 
+// LoadTripAnnotationChanges allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-M or N-M relationship.
+func (tripL) LoadTripAnnotationChanges(ctx context.Context, e boil.ContextExecutor, singular bool, maybeTrip interface{}, mods queries.Applicator) error {
+	var slice []*Trip
+	var object *Trip
+
+	if singular {
+		var ok bool
+		object, ok = maybeTrip.(*Trip)
+		if !ok {
+			object = new(Trip)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeTrip)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeTrip))
+			}
+		}
+	} else {
+		s, ok := maybeTrip.(*[]*Trip)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeTrip)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeTrip))
+			}
+		}
+	}
+
+	args := make(map[interface{}]struct{})
+	if singular {
+		if object.R == nil {
+			object.R = &tripR{}
+		}
+		args[object.ID] = struct{}{}
+	} else {
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &tripR{}
+			}
+			args[obj.ID] = struct{}{}
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	argsSlice := make([]interface{}, len(args))
+	i := 0
+	for arg := range args {
+		argsSlice[i] = arg
+		i++
+	}
+
+	query := NewQuery(
+		qm.From(`trips_api.trip_annotation_changes`),
+		qm.WhereIn(`trips_api.trip_annotation_changes.trip_id in ?`, argsSlice...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load trip_annotation_changes")
+	}
+
+	var resultSlice []*TripAnnotationChange
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice trip_annotation_changes")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on trip_annotation_changes")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for trip_annotation_changes")
+	}
+
+	if len(tripAnnotationChangeAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+	if singular {
+		object.R.TripAnnotationChanges = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &tripAnnotationChangeR{}
+			}
+			foreign.R.Trip = object
+		}
+		return nil
+	}
+
+	for _, foreign := range resultSlice {
+		for _, local := range slice {
+			if local.ID == foreign.TripID {
+				local.R.TripAnnotationChanges = append(local.R.TripAnnotationChanges, foreign)
+				if foreign.R == nil {
+					foreign.R = &tripAnnotationChangeR{}
+				}
+				foreign.R.Trip = local
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// LoadTripUpdates allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-M or N-M relationship.
+func (tripL) LoadTripUpdates(ctx context.Context, e boil.ContextExecutor, singular bool, maybeTrip interface{}, mods queries.Applicator) error {
+	var slice []*Trip
+	var object *Trip
+
+	if singular {
+		var ok bool
+		object, ok = maybeTrip.(*Trip)
+		if !ok {
+			object = new(Trip)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeTrip)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeTrip))
+			}
+		}
+	} else {
+		s, ok := maybeTrip.(*[]*Trip)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeTrip)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeTrip))
+			}
+		}
+	}
+
+	args := make(map[interface{}]struct{})
+	if singular {
+		if object.R == nil {
+			object.R = &tripR{}
+		}
+		args[object.ID] = struct{}{}
+	} else {
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &tripR{}
+			}
+			args[obj.ID] = struct{}{}
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	argsSlice := make([]interface{}, len(args))
+	i := 0
+	for arg := range args {
+		argsSlice[i] = arg
+		i++
+	}
+
+	query := NewQuery(
+		qm.From(`trips_api.trip_updates`),
+		qm.WhereIn(`trips_api.trip_updates.trip_id in ?`, argsSlice...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load trip_updates")
+	}
+
+	var resultSlice []*TripUpdate
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice trip_updates")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on trip_updates")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for trip_updates")
+	}
+
+	if len(tripUpdateAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+	if singular {
+		object.R.TripUpdates = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &tripUpdateR{}
+			}
+			foreign.R.Trip = object
+		}
+		return nil
+	}
+
+	for _, foreign := range resultSlice {
+		for _, local := range slice {
+			if local.ID == foreign.TripID {
+				local.R.TripUpdates = append(local.R.TripUpdates, foreign)
+				if foreign.R == nil {
+					foreign.R = &tripUpdateR{}
+				}
+				foreign.R.Trip = local
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
 // SetVehicleToken of the trip to the related item.
 // Sets o.R.VehicleToken to related.
 // Adds o to related.R.VehicleTokenTrips.
@@ -834,6 +1058,112 @@ func (o *Trip) SetVehicleToken(ctx context.Context, exec boil.ContextExecutor, i
 	return nil
 }
 
+// AddTripAnnotationChanges adds the given related objects to the existing relationships
+// of the trip, optionally inserting them as new records.
+// Appends related to o.R.TripAnnotationChanges.
+// Sets related.R.Trip appropriately.
+func (o *Trip) AddTripAnnotationChanges(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*TripAnnotationChange) error {
+	var err error
+	for _, rel := range related {
+		if insert {
+			rel.TripID = o.ID
+			if err = rel.Insert(ctx, exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		} else {
+			updateQuery := fmt.Sprintf(
+				"UPDATE \"trips_api\".\"trip_annotation_changes\" SET %s WHERE %s",
+				strmangle.SetParamNames("\"", "\"", 1, []string{"trip_id"}),
+				strmangle.WhereClause("\"", "\"", 2, tripAnnotationChangePrimaryKeyColumns),
+			)
+			values := []interface{}{o.ID, rel.ID}
+
+			if boil.IsDebug(ctx) {
+				writer := boil.DebugWriterFrom(ctx)
+				fmt.Fprintln(writer, updateQuery)
+				fmt.Fprintln(writer, values)
+			}
+			if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+				return errors.Wrap(err, "failed to update foreign table")
+			}
+
+			rel.TripID = o.ID
+		}
+	}
+
+	if o.R == nil {
+		o.R = &tripR{
+			TripAnnotationChanges: related,
+		}
+	} else {
+		o.R.TripAnnotationChanges = append(o.R.TripAnnotationChanges, related...)
+	}
+
+	for _, rel := range related {
+		if rel.R == nil {
+			rel.R = &tripAnnotationChangeR{
+				Trip: o,
+			}
+		} else {
+			rel.R.Trip = o
+		}
+	}
+	return nil
+}
+
+// AddTripUpdates adds the given related objects to the existing relationships
+// of the trip, optionally inserting them as new records.
+// Appends related to o.R.TripUpdates.
+// Sets related.R.Trip appropriately.
+func (o *Trip) AddTripUpdates(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*TripUpdate) error {
+	var err error
+	for _, rel := range related {
+		if insert {
+			rel.TripID = o.ID
+			if err = rel.Insert(ctx, exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		} else {
+			updateQuery := fmt.Sprintf(
+				"UPDATE \"trips_api\".\"trip_updates\" SET %s WHERE %s",
+				strmangle.SetParamNames("\"", "\"", 1, []string{"trip_id"}),
+				strmangle.WhereClause("\"", "\"", 2, tripUpdatePrimaryKeyColumns),
+			)
+			values := []interface{}{o.ID, rel.ID}
+
+			if boil.IsDebug(ctx) {
+				writer := boil.DebugWriterFrom(ctx)
+				fmt.Fprintln(writer, updateQuery)
+				fmt.Fprintln(writer, values)
+			}
+			if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+				return errors.Wrap(err, "failed to update foreign table")
+			}
+
+			rel.TripID = o.ID
+		}
+	}
+
+	if o.R == nil {
+		o.R = &tripR{
+			TripUpdates: related,
+		}
+	} else {
+		o.R.TripUpdates = append(o.R.TripUpdates, related...)
+	}
+
+	for _, rel := range related {
+		if rel.R == nil {
+			rel.R = &tripUpdateR{
+				Trip: o,
+			}
+		} else {
+			rel.R.Trip = o
+		}
+	}
+	return nil
+}
+
 // Trips retrieves all the records using an executor.
 func Trips(mods ...qm.QueryMod) tripQuery {
 	mods = append(mods, qm.From("\"trips_api\".\"trips\""))
@@ -0,0 +1,1139 @@
+// Code generated by SQLBoiler 4.16.2 (https://github.com/volatiletech/sqlboiler). DO NOT EDIT.
+// This file is meant to be re-generated in place and/or deleted at any time.
+
+package models
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/friendsofgo/errors"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"github.com/volatiletech/sqlboiler/v4/queries/qmhelper"
+	"github.com/volatiletech/strmangle"
+)
+
+// VehicleMapping is an object representing the database table.
+type VehicleMapping struct {
+	ID           int64     `boil:"id" json:"id" toml:"id" yaml:"id"`
+	UserDeviceID string    `boil:"user_device_id" json:"user_device_id" toml:"user_device_id" yaml:"user_device_id"`
+	TokenID      int       `boil:"token_id" json:"token_id" toml:"token_id" yaml:"token_id"`
+	ValidFrom    time.Time `boil:"valid_from" json:"valid_from" toml:"valid_from" yaml:"valid_from"`
+	ValidTo      null.Time `boil:"valid_to" json:"valid_to,omitempty" toml:"valid_to" yaml:"valid_to,omitempty"`
+
+	R *vehicleMappingR `boil:"-" json:"-" toml:"-" yaml:"-"`
+	L vehicleMappingL  `boil:"-" json:"-" toml:"-" yaml:"-"`
+}
+
+var VehicleMappingColumns = struct {
+	ID           string
+	UserDeviceID string
+	TokenID      string
+	ValidFrom    string
+	ValidTo      string
+}{
+	ID:           "id",
+	UserDeviceID: "user_device_id",
+	TokenID:      "token_id",
+	ValidFrom:    "valid_from",
+	ValidTo:      "valid_to",
+}
+
+var VehicleMappingTableColumns = struct {
+	ID           string
+	UserDeviceID string
+	TokenID      string
+	ValidFrom    string
+	ValidTo      string
+}{
+	ID:           "vehicle_mappings.id",
+	UserDeviceID: "vehicle_mappings.user_device_id",
+	TokenID:      "vehicle_mappings.token_id",
+	ValidFrom:    "vehicle_mappings.valid_from",
+	ValidTo:      "vehicle_mappings.valid_to",
+}
+
+// Generated where
+
+var VehicleMappingWhere = struct {
+	ID           whereHelperint64
+	UserDeviceID whereHelperstring
+	TokenID      whereHelperint
+	ValidFrom    whereHelpertime_Time
+	ValidTo      whereHelpernull_Time
+}{
+	ID:           whereHelperint64{field: "\"trips_api\".\"vehicle_mappings\".\"id\""},
+	UserDeviceID: whereHelperstring{field: "\"trips_api\".\"vehicle_mappings\".\"user_device_id\""},
+	TokenID:      whereHelperint{field: "\"trips_api\".\"vehicle_mappings\".\"token_id\""},
+	ValidFrom:    whereHelpertime_Time{field: "\"trips_api\".\"vehicle_mappings\".\"valid_from\""},
+	ValidTo:      whereHelpernull_Time{field: "\"trips_api\".\"vehicle_mappings\".\"valid_to\""},
+}
+
+// VehicleMappingRels is where relationship names are stored.
+var VehicleMappingRels = struct {
+	Token string
+}{
+	Token: "Token",
+}
+
+// vehicleMappingR is where relationships are stored.
+type vehicleMappingR struct {
+	Token *Vehicle `boil:"Token" json:"Token" toml:"Token" yaml:"Token"`
+}
+
+// NewStruct creates a new relationship struct
+func (*vehicleMappingR) NewStruct() *vehicleMappingR {
+	return &vehicleMappingR{}
+}
+
+func (r *vehicleMappingR) GetToken() *Vehicle {
+	if r == nil {
+		return nil
+	}
+	return r.Token
+}
+
+// vehicleMappingL is where Load methods for each relationship are stored.
+type vehicleMappingL struct{}
+
+var (
+	vehicleMappingAllColumns            = []string{"id", "user_device_id", "token_id", "valid_from", "valid_to"}
+	vehicleMappingColumnsWithoutDefault = []string{"user_device_id", "token_id", "valid_from"}
+	vehicleMappingColumnsWithDefault    = []string{"id", "valid_to"}
+	vehicleMappingPrimaryKeyColumns     = []string{"id"}
+	vehicleMappingGeneratedColumns      = []string{}
+)
+
+type (
+	// VehicleMappingSlice is an alias for a slice of pointers to VehicleMapping.
+	// This should almost always be used instead of []VehicleMapping.
+	VehicleMappingSlice []*VehicleMapping
+	// VehicleMappingHook is the signature for custom VehicleMapping hook methods
+	VehicleMappingHook func(context.Context, boil.ContextExecutor, *VehicleMapping) error
+
+	vehicleMappingQuery struct {
+		*queries.Query
+	}
+)
+
+// Cache for insert, update and upsert
+var (
+	vehicleMappingType                 = reflect.TypeOf(&VehicleMapping{})
+	vehicleMappingMapping              = queries.MakeStructMapping(vehicleMappingType)
+	vehicleMappingPrimaryKeyMapping, _ = queries.BindMapping(vehicleMappingType, vehicleMappingMapping, vehicleMappingPrimaryKeyColumns)
+	vehicleMappingInsertCacheMut       sync.RWMutex
+	vehicleMappingInsertCache          = make(map[string]insertCache)
+	vehicleMappingUpdateCacheMut       sync.RWMutex
+	vehicleMappingUpdateCache          = make(map[string]updateCache)
+	vehicleMappingUpsertCacheMut       sync.RWMutex
+	vehicleMappingUpsertCache          = make(map[string]insertCache)
+)
+
+var (
+	// Force time package dependency for automated UpdatedAt/CreatedAt.
+	_ = time.Second
+	// Force qmhelper dependency for where clause generation (which doesn't
+	// always happen)
+	_ = qmhelper.Where
+)
+
+var vehicleMappingAfterSelectMu sync.Mutex
+var vehicleMappingAfterSelectHooks []VehicleMappingHook
+
+var vehicleMappingBeforeInsertMu sync.Mutex
+var vehicleMappingBeforeInsertHooks []VehicleMappingHook
+var vehicleMappingAfterInsertMu sync.Mutex
+var vehicleMappingAfterInsertHooks []VehicleMappingHook
+
+var vehicleMappingBeforeUpdateMu sync.Mutex
+var vehicleMappingBeforeUpdateHooks []VehicleMappingHook
+var vehicleMappingAfterUpdateMu sync.Mutex
+var vehicleMappingAfterUpdateHooks []VehicleMappingHook
+
+var vehicleMappingBeforeDeleteMu sync.Mutex
+var vehicleMappingBeforeDeleteHooks []VehicleMappingHook
+var vehicleMappingAfterDeleteMu sync.Mutex
+var vehicleMappingAfterDeleteHooks []VehicleMappingHook
+
+var vehicleMappingBeforeUpsertMu sync.Mutex
+var vehicleMappingBeforeUpsertHooks []VehicleMappingHook
+var vehicleMappingAfterUpsertMu sync.Mutex
+var vehicleMappingAfterUpsertHooks []VehicleMappingHook
+
+// doAfterSelectHooks executes all "after Select" hooks.
+func (o *VehicleMapping) doAfterSelectHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range vehicleMappingAfterSelectHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeInsertHooks executes all "before insert" hooks.
+func (o *VehicleMapping) doBeforeInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range vehicleMappingBeforeInsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterInsertHooks executes all "after Insert" hooks.
+func (o *VehicleMapping) doAfterInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range vehicleMappingAfterInsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpdateHooks executes all "before Update" hooks.
+func (o *VehicleMapping) doBeforeUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range vehicleMappingBeforeUpdateHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpdateHooks executes all "after Update" hooks.
+func (o *VehicleMapping) doAfterUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range vehicleMappingAfterUpdateHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeDeleteHooks executes all "before Delete" hooks.
+func (o *VehicleMapping) doBeforeDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range vehicleMappingBeforeDeleteHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterDeleteHooks executes all "after Delete" hooks.
+func (o *VehicleMapping) doAfterDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range vehicleMappingAfterDeleteHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpsertHooks executes all "before Upsert" hooks.
+func (o *VehicleMapping) doBeforeUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range vehicleMappingBeforeUpsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpsertHooks executes all "after Upsert" hooks.
+func (o *VehicleMapping) doAfterUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range vehicleMappingAfterUpsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// AddVehicleMappingHook registers your hook function for all future operations.
+func AddVehicleMappingHook(hookPoint boil.HookPoint, vehicleMappingHook VehicleMappingHook) {
+	switch hookPoint {
+	case boil.AfterSelectHook:
+		vehicleMappingAfterSelectMu.Lock()
+		vehicleMappingAfterSelectHooks = append(vehicleMappingAfterSelectHooks, vehicleMappingHook)
+		vehicleMappingAfterSelectMu.Unlock()
+	case boil.BeforeInsertHook:
+		vehicleMappingBeforeInsertMu.Lock()
+		vehicleMappingBeforeInsertHooks = append(vehicleMappingBeforeInsertHooks, vehicleMappingHook)
+		vehicleMappingBeforeInsertMu.Unlock()
+	case boil.AfterInsertHook:
+		vehicleMappingAfterInsertMu.Lock()
+		vehicleMappingAfterInsertHooks = append(vehicleMappingAfterInsertHooks, vehicleMappingHook)
+		vehicleMappingAfterInsertMu.Unlock()
+	case boil.BeforeUpdateHook:
+		vehicleMappingBeforeUpdateMu.Lock()
+		vehicleMappingBeforeUpdateHooks = append(vehicleMappingBeforeUpdateHooks, vehicleMappingHook)
+		vehicleMappingBeforeUpdateMu.Unlock()
+	case boil.AfterUpdateHook:
+		vehicleMappingAfterUpdateMu.Lock()
+		vehicleMappingAfterUpdateHooks = append(vehicleMappingAfterUpdateHooks, vehicleMappingHook)
+		vehicleMappingAfterUpdateMu.Unlock()
+	case boil.BeforeDeleteHook:
+		vehicleMappingBeforeDeleteMu.Lock()
+		vehicleMappingBeforeDeleteHooks = append(vehicleMappingBeforeDeleteHooks, vehicleMappingHook)
+		vehicleMappingBeforeDeleteMu.Unlock()
+	case boil.AfterDeleteHook:
+		vehicleMappingAfterDeleteMu.Lock()
+		vehicleMappingAfterDeleteHooks = append(vehicleMappingAfterDeleteHooks, vehicleMappingHook)
+		vehicleMappingAfterDeleteMu.Unlock()
+	case boil.BeforeUpsertHook:
+		vehicleMappingBeforeUpsertMu.Lock()
+		vehicleMappingBeforeUpsertHooks = append(vehicleMappingBeforeUpsertHooks, vehicleMappingHook)
+		vehicleMappingBeforeUpsertMu.Unlock()
+	case boil.AfterUpsertHook:
+		vehicleMappingAfterUpsertMu.Lock()
+		vehicleMappingAfterUpsertHooks = append(vehicleMappingAfterUpsertHooks, vehicleMappingHook)
+		vehicleMappingAfterUpsertMu.Unlock()
+	}
+}
+
+// One returns a single vehicleMapping record from the query.
+func (q vehicleMappingQuery) One(ctx context.Context, exec boil.ContextExecutor) (*VehicleMapping, error) {
+	o := &VehicleMapping{}
+
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Bind(ctx, exec, o)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "models: failed to execute a one query for vehicle_mappings")
+	}
+
+	if err := o.doAfterSelectHooks(ctx, exec); err != nil {
+		return o, err
+	}
+
+	return o, nil
+}
+
+// All returns all VehicleMapping records from the query.
+func (q vehicleMappingQuery) All(ctx context.Context, exec boil.ContextExecutor) (VehicleMappingSlice, error) {
+	var o []*VehicleMapping
+
+	err := q.Bind(ctx, exec, &o)
+	if err != nil {
+		return nil, errors.Wrap(err, "models: failed to assign all query results to VehicleMapping slice")
+	}
+
+	if len(vehicleMappingAfterSelectHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterSelectHooks(ctx, exec); err != nil {
+				return o, err
+			}
+		}
+	}
+
+	return o, nil
+}
+
+// Count returns the count of all VehicleMapping records in the query.
+func (q vehicleMappingQuery) Count(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to count vehicle_mappings rows")
+	}
+
+	return count, nil
+}
+
+// Exists checks if the row exists in the table.
+func (q vehicleMappingQuery) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return false, errors.Wrap(err, "models: failed to check if vehicle_mappings exists")
+	}
+
+	return count > 0, nil
+}
+
+// Token pointed to by the foreign key.
+func (o *VehicleMapping) Token(mods ...qm.QueryMod) vehicleQuery {
+	queryMods := []qm.QueryMod{
+		qm.Where("\"token_id\" = ?", o.TokenID),
+	}
+
+	queryMods = append(queryMods, mods...)
+
+	return Vehicles(queryMods...)
+}
+
+// LoadToken allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for an N-1 relationship.
+func (vehicleMappingL) LoadToken(ctx context.Context, e boil.ContextExecutor, singular bool, maybeVehicleMapping interface{}, mods queries.Applicator) error {
+	var slice []*VehicleMapping
+	var object *VehicleMapping
+
+	if singular {
+		var ok bool
+		object, ok = maybeVehicleMapping.(*VehicleMapping)
+		if !ok {
+			object = new(VehicleMapping)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeVehicleMapping)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeVehicleMapping))
+			}
+		}
+	} else {
+		s, ok := maybeVehicleMapping.(*[]*VehicleMapping)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeVehicleMapping)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeVehicleMapping))
+			}
+		}
+	}
+
+	args := make(map[interface{}]struct{})
+	if singular {
+		if object.R == nil {
+			object.R = &vehicleMappingR{}
+		}
+		args[object.TokenID] = struct{}{}
+
+	} else {
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &vehicleMappingR{}
+			}
+
+			args[obj.TokenID] = struct{}{}
+
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	argsSlice := make([]interface{}, len(args))
+	i := 0
+	for arg := range args {
+		argsSlice[i] = arg
+		i++
+	}
+
+	query := NewQuery(
+		qm.From(`trips_api.vehicles`),
+		qm.WhereIn(`trips_api.vehicles.token_id in ?`, argsSlice...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load Vehicle")
+	}
+
+	var resultSlice []*Vehicle
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice Vehicle")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results of eager load for vehicles")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for vehicles")
+	}
+
+	if len(vehicleAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+
+	if len(resultSlice) == 0 {
+		return nil
+	}
+
+	if singular {
+		foreign := resultSlice[0]
+		object.R.Token = foreign
+		if foreign.R == nil {
+			foreign.R = &vehicleR{}
+		}
+		foreign.R.TokenVehicleMappings = append(foreign.R.TokenVehicleMappings, object)
+		return nil
+	}
+
+	for _, local := range slice {
+		for _, foreign := range resultSlice {
+			if local.TokenID == foreign.TokenID {
+				local.R.Token = foreign
+				if foreign.R == nil {
+					foreign.R = &vehicleR{}
+				}
+				foreign.R.TokenVehicleMappings = append(foreign.R.TokenVehicleMappings, local)
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// SetToken of the vehicleMapping to the related item.
+// Sets o.R.Token to related.
+// Adds o to related.R.TokenVehicleMappings.
+func (o *VehicleMapping) SetToken(ctx context.Context, exec boil.ContextExecutor, insert bool, related *Vehicle) error {
+	var err error
+	if insert {
+		if err = related.Insert(ctx, exec, boil.Infer()); err != nil {
+			return errors.Wrap(err, "failed to insert into foreign table")
+		}
+	}
+
+	updateQuery := fmt.Sprintf(
+		"UPDATE \"trips_api\".\"vehicle_mappings\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, []string{"token_id"}),
+		strmangle.WhereClause("\"", "\"", 2, vehicleMappingPrimaryKeyColumns),
+	)
+	values := []interface{}{related.TokenID, o.ID}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, updateQuery)
+		fmt.Fprintln(writer, values)
+	}
+	if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+		return errors.Wrap(err, "failed to update local table")
+	}
+
+	o.TokenID = related.TokenID
+	if o.R == nil {
+		o.R = &vehicleMappingR{
+			Token: related,
+		}
+	} else {
+		o.R.Token = related
+	}
+
+	if related.R == nil {
+		related.R = &vehicleR{
+			TokenVehicleMappings: VehicleMappingSlice{o},
+		}
+	} else {
+		related.R.TokenVehicleMappings = append(related.R.TokenVehicleMappings, o)
+	}
+
+	return nil
+}
+
+// VehicleMappings retrieves all the records using an executor.
+func VehicleMappings(mods ...qm.QueryMod) vehicleMappingQuery {
+	mods = append(mods, qm.From("\"trips_api\".\"vehicle_mappings\""))
+	q := NewQuery(mods...)
+	if len(queries.GetSelect(q)) == 0 {
+		queries.SetSelect(q, []string{"\"trips_api\".\"vehicle_mappings\".*"})
+	}
+
+	return vehicleMappingQuery{q}
+}
+
+// FindVehicleMapping retrieves a single record by ID with an executor.
+// If selectCols is empty Find will return all columns.
+func FindVehicleMapping(ctx context.Context, exec boil.ContextExecutor, iD int64, selectCols ...string) (*VehicleMapping, error) {
+	vehicleMappingObj := &VehicleMapping{}
+
+	sel := "*"
+	if len(selectCols) > 0 {
+		sel = strings.Join(strmangle.IdentQuoteSlice(dialect.LQ, dialect.RQ, selectCols), ",")
+	}
+	query := fmt.Sprintf(
+		"select %s from \"trips_api\".\"vehicle_mappings\" where \"id\"=$1", sel,
+	)
+
+	q := queries.Raw(query, iD)
+
+	err := q.Bind(ctx, exec, vehicleMappingObj)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "models: unable to select from vehicle_mappings")
+	}
+
+	if err = vehicleMappingObj.doAfterSelectHooks(ctx, exec); err != nil {
+		return vehicleMappingObj, err
+	}
+
+	return vehicleMappingObj, nil
+}
+
+// Insert a single record using an executor.
+// See boil.Columns.InsertColumnSet documentation to understand column list inference for inserts.
+func (o *VehicleMapping) Insert(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) error {
+	if o == nil {
+		return errors.New("models: no vehicle_mappings provided for insertion")
+	}
+
+	var err error
+
+	if err := o.doBeforeInsertHooks(ctx, exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(vehicleMappingColumnsWithDefault, o)
+
+	key := makeCacheKey(columns, nzDefaults)
+	vehicleMappingInsertCacheMut.RLock()
+	cache, cached := vehicleMappingInsertCache[key]
+	vehicleMappingInsertCacheMut.RUnlock()
+
+	if !cached {
+		wl, returnColumns := columns.InsertColumnSet(
+			vehicleMappingAllColumns,
+			vehicleMappingColumnsWithDefault,
+			vehicleMappingColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		cache.valueMapping, err = queries.BindMapping(vehicleMappingType, vehicleMappingMapping, wl)
+		if err != nil {
+			return err
+		}
+		cache.retMapping, err = queries.BindMapping(vehicleMappingType, vehicleMappingMapping, returnColumns)
+		if err != nil {
+			return err
+		}
+		if len(wl) != 0 {
+			cache.query = fmt.Sprintf("INSERT INTO \"trips_api\".\"vehicle_mappings\" (\"%s\") %%sVALUES (%s)%%s", strings.Join(wl, "\",\""), strmangle.Placeholders(dialect.UseIndexPlaceholders, len(wl), 1, 1))
+		} else {
+			cache.query = "INSERT INTO \"trips_api\".\"vehicle_mappings\" %sDEFAULT VALUES%s"
+		}
+
+		var queryOutput, queryReturning string
+
+		if len(cache.retMapping) != 0 {
+			queryReturning = fmt.Sprintf(" RETURNING \"%s\"", strings.Join(returnColumns, "\",\""))
+		}
+
+		cache.query = fmt.Sprintf(cache.query, queryOutput, queryReturning)
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRowContext(ctx, cache.query, vals...).Scan(queries.PtrsFromMapping(value, cache.retMapping)...)
+	} else {
+		_, err = exec.ExecContext(ctx, cache.query, vals...)
+	}
+
+	if err != nil {
+		return errors.Wrap(err, "models: unable to insert into vehicle_mappings")
+	}
+
+	if !cached {
+		vehicleMappingInsertCacheMut.Lock()
+		vehicleMappingInsertCache[key] = cache
+		vehicleMappingInsertCacheMut.Unlock()
+	}
+
+	return o.doAfterInsertHooks(ctx, exec)
+}
+
+// Update uses an executor to update the VehicleMapping.
+// See boil.Columns.UpdateColumnSet documentation to understand column list inference for updates.
+// Update does not automatically update the record in case of default values. Use .Reload() to refresh the records.
+func (o *VehicleMapping) Update(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) (int64, error) {
+	var err error
+	if err = o.doBeforeUpdateHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+	key := makeCacheKey(columns, nil)
+	vehicleMappingUpdateCacheMut.RLock()
+	cache, cached := vehicleMappingUpdateCache[key]
+	vehicleMappingUpdateCacheMut.RUnlock()
+
+	if !cached {
+		wl := columns.UpdateColumnSet(
+			vehicleMappingAllColumns,
+			vehicleMappingPrimaryKeyColumns,
+		)
+
+		if !columns.IsWhitelist() {
+			wl = strmangle.SetComplement(wl, []string{"created_at"})
+		}
+		if len(wl) == 0 {
+			return 0, errors.New("models: unable to update vehicle_mappings, could not build whitelist")
+		}
+
+		cache.query = fmt.Sprintf("UPDATE \"trips_api\".\"vehicle_mappings\" SET %s WHERE %s",
+			strmangle.SetParamNames("\"", "\"", 1, wl),
+			strmangle.WhereClause("\"", "\"", len(wl)+1, vehicleMappingPrimaryKeyColumns),
+		)
+		cache.valueMapping, err = queries.BindMapping(vehicleMappingType, vehicleMappingMapping, append(wl, vehicleMappingPrimaryKeyColumns...))
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	values := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, values)
+	}
+	var result sql.Result
+	result, err = exec.ExecContext(ctx, cache.query, values...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update vehicle_mappings row")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by update for vehicle_mappings")
+	}
+
+	if !cached {
+		vehicleMappingUpdateCacheMut.Lock()
+		vehicleMappingUpdateCache[key] = cache
+		vehicleMappingUpdateCacheMut.Unlock()
+	}
+
+	return rowsAff, o.doAfterUpdateHooks(ctx, exec)
+}
+
+// UpdateAll updates all rows with the specified column values.
+func (q vehicleMappingQuery) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	queries.SetUpdate(q.Query, cols)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update all for vehicle_mappings")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to retrieve rows affected for vehicle_mappings")
+	}
+
+	return rowsAff, nil
+}
+
+// UpdateAll updates all rows with the specified column values, using an executor.
+func (o VehicleMappingSlice) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	ln := int64(len(o))
+	if ln == 0 {
+		return 0, nil
+	}
+
+	if len(cols) == 0 {
+		return 0, errors.New("models: update all requires at least one column argument")
+	}
+
+	colNames := make([]string, len(cols))
+	args := make([]interface{}, len(cols))
+
+	i := 0
+	for name, value := range cols {
+		colNames[i] = name
+		args[i] = value
+		i++
+	}
+
+	// Append all of the primary key values for each column
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), vehicleMappingPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := fmt.Sprintf("UPDATE \"trips_api\".\"vehicle_mappings\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, colNames),
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), len(colNames)+1, vehicleMappingPrimaryKeyColumns, len(o)))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update all in vehicleMapping slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to retrieve rows affected all in update all vehicleMapping")
+	}
+	return rowsAff, nil
+}
+
+// Upsert attempts an insert using an executor, and does an update or ignore on conflict.
+// See boil.Columns documentation for how to properly use updateColumns and insertColumns.
+func (o *VehicleMapping) Upsert(ctx context.Context, exec boil.ContextExecutor, updateOnConflict bool, conflictColumns []string, updateColumns, insertColumns boil.Columns, opts ...UpsertOptionFunc) error {
+	if o == nil {
+		return errors.New("models: no vehicle_mappings provided for upsert")
+	}
+
+	if err := o.doBeforeUpsertHooks(ctx, exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(vehicleMappingColumnsWithDefault, o)
+
+	// Build cache key in-line uglily - mysql vs psql problems
+	buf := strmangle.GetBuffer()
+	if updateOnConflict {
+		buf.WriteByte('t')
+	} else {
+		buf.WriteByte('f')
+	}
+	buf.WriteByte('.')
+	for _, c := range conflictColumns {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(updateColumns.Kind))
+	for _, c := range updateColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(insertColumns.Kind))
+	for _, c := range insertColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	for _, c := range nzDefaults {
+		buf.WriteString(c)
+	}
+	key := buf.String()
+	strmangle.PutBuffer(buf)
+
+	vehicleMappingUpsertCacheMut.RLock()
+	cache, cached := vehicleMappingUpsertCache[key]
+	vehicleMappingUpsertCacheMut.RUnlock()
+
+	var err error
+
+	if !cached {
+		insert, _ := insertColumns.InsertColumnSet(
+			vehicleMappingAllColumns,
+			vehicleMappingColumnsWithDefault,
+			vehicleMappingColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		update := updateColumns.UpdateColumnSet(
+			vehicleMappingAllColumns,
+			vehicleMappingPrimaryKeyColumns,
+		)
+
+		if updateOnConflict && len(update) == 0 {
+			return errors.New("models: unable to upsert vehicle_mappings, could not build update column list")
+		}
+
+		ret := strmangle.SetComplement(vehicleMappingAllColumns, strmangle.SetIntersect(insert, update))
+
+		conflict := conflictColumns
+		if len(conflict) == 0 && updateOnConflict && len(update) != 0 {
+			if len(vehicleMappingPrimaryKeyColumns) == 0 {
+				return errors.New("models: unable to upsert vehicle_mappings, could not build conflict column list")
+			}
+
+			conflict = make([]string, len(vehicleMappingPrimaryKeyColumns))
+			copy(conflict, vehicleMappingPrimaryKeyColumns)
+		}
+		cache.query = buildUpsertQueryPostgres(dialect, "\"trips_api\".\"vehicle_mappings\"", updateOnConflict, ret, update, conflict, insert, opts...)
+
+		cache.valueMapping, err = queries.BindMapping(vehicleMappingType, vehicleMappingMapping, insert)
+		if err != nil {
+			return err
+		}
+		if len(ret) != 0 {
+			cache.retMapping, err = queries.BindMapping(vehicleMappingType, vehicleMappingMapping, ret)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+	var returns []interface{}
+	if len(cache.retMapping) != 0 {
+		returns = queries.PtrsFromMapping(value, cache.retMapping)
+	}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRowContext(ctx, cache.query, vals...).Scan(returns...)
+		if errors.Is(err, sql.ErrNoRows) {
+			err = nil // Postgres doesn't return anything when there's no update
+		}
+	} else {
+		_, err = exec.ExecContext(ctx, cache.query, vals...)
+	}
+	if err != nil {
+		return errors.Wrap(err, "models: unable to upsert vehicle_mappings")
+	}
+
+	if !cached {
+		vehicleMappingUpsertCacheMut.Lock()
+		vehicleMappingUpsertCache[key] = cache
+		vehicleMappingUpsertCacheMut.Unlock()
+	}
+
+	return o.doAfterUpsertHooks(ctx, exec)
+}
+
+// Delete deletes a single VehicleMapping record with an executor.
+// Delete will match against the primary key column to find the record to delete.
+func (o *VehicleMapping) Delete(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if o == nil {
+		return 0, errors.New("models: no VehicleMapping provided for delete")
+	}
+
+	if err := o.doBeforeDeleteHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+
+	args := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), vehicleMappingPrimaryKeyMapping)
+	sql := "DELETE FROM \"trips_api\".\"vehicle_mappings\" WHERE \"id\"=$1"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete from vehicle_mappings")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by delete for vehicle_mappings")
+	}
+
+	if err := o.doAfterDeleteHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all matching rows.
+func (q vehicleMappingQuery) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if q.Query == nil {
+		return 0, errors.New("models: no vehicleMappingQuery provided for delete all")
+	}
+
+	queries.SetDelete(q.Query)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete all from vehicle_mappings")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by deleteall for vehicle_mappings")
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all rows in the slice, using an executor.
+func (o VehicleMappingSlice) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if len(o) == 0 {
+		return 0, nil
+	}
+
+	if len(vehicleMappingBeforeDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doBeforeDeleteHooks(ctx, exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	var args []interface{}
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), vehicleMappingPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "DELETE FROM \"trips_api\".\"vehicle_mappings\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, vehicleMappingPrimaryKeyColumns, len(o))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete all from vehicleMapping slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by deleteall for vehicle_mappings")
+	}
+
+	if len(vehicleMappingAfterDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterDeleteHooks(ctx, exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	return rowsAff, nil
+}
+
+// Reload refetches the object from the database
+// using the primary keys with an executor.
+func (o *VehicleMapping) Reload(ctx context.Context, exec boil.ContextExecutor) error {
+	ret, err := FindVehicleMapping(ctx, exec, o.ID)
+	if err != nil {
+		return err
+	}
+
+	*o = *ret
+	return nil
+}
+
+// ReloadAll refetches every row with matching primary key column values
+// and overwrites the original object slice with the newly updated slice.
+func (o *VehicleMappingSlice) ReloadAll(ctx context.Context, exec boil.ContextExecutor) error {
+	if o == nil || len(*o) == 0 {
+		return nil
+	}
+
+	slice := VehicleMappingSlice{}
+	var args []interface{}
+	for _, obj := range *o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), vehicleMappingPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "SELECT \"trips_api\".\"vehicle_mappings\".* FROM \"trips_api\".\"vehicle_mappings\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, vehicleMappingPrimaryKeyColumns, len(*o))
+
+	q := queries.Raw(sql, args...)
+
+	err := q.Bind(ctx, exec, &slice)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to reload all in VehicleMappingSlice")
+	}
+
+	*o = slice
+
+	return nil
+}
+
+// VehicleMappingExists checks if the VehicleMapping row exists.
+func VehicleMappingExists(ctx context.Context, exec boil.ContextExecutor, iD int64) (bool, error) {
+	var exists bool
+	sql := "select exists(select 1 from \"trips_api\".\"vehicle_mappings\" where \"id\"=$1 limit 1)"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, iD)
+	}
+	row := exec.QueryRowContext(ctx, sql, iD)
+
+	err := row.Scan(&exists)
+	if err != nil {
+		return false, errors.Wrap(err, "models: unable to check if vehicle_mappings exists")
+	}
+
+	return exists, nil
+}
+
+// Exists checks if the VehicleMapping row exists.
+func (o *VehicleMapping) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	return VehicleMappingExists(ctx, exec, o.ID)
+}
@@ -14,6 +14,7 @@ import (
 	"time"
 
 	"github.com/friendsofgo/errors"
+	"github.com/volatiletech/null/v8"
 	"github.com/volatiletech/sqlboiler/v4/boil"
 	"github.com/volatiletech/sqlboiler/v4/queries"
 	"github.com/volatiletech/sqlboiler/v4/queries/qm"
@@ -23,8 +24,9 @@ import (
 
 // Vehicle is an object representing the database table.
 type Vehicle struct {
-	TokenID      int    `boil:"token_id" json:"token_id" toml:"token_id" yaml:"token_id"`
-	UserDeviceID string `boil:"user_device_id" json:"user_device_id" toml:"user_device_id" yaml:"user_device_id"`
+	TokenID      int       `boil:"token_id" json:"token_id" toml:"token_id" yaml:"token_id"`
+	UserDeviceID string    `boil:"user_device_id" json:"user_device_id" toml:"user_device_id" yaml:"user_device_id"`
+	DeletedAt    null.Time `boil:"deleted_at" json:"deleted_at,omitempty" toml:"deleted_at" yaml:"deleted_at,omitempty"`
 
 	R *vehicleR `boil:"-" json:"-" toml:"-" yaml:"-"`
 	L vehicleL  `boil:"-" json:"-" toml:"-" yaml:"-"`
@@ -33,17 +35,21 @@ type Vehicle struct {
 var VehicleColumns = struct {
 	TokenID      string
 	UserDeviceID string
+	DeletedAt    string
 }{
 	TokenID:      "token_id",
 	UserDeviceID: "user_device_id",
+	DeletedAt:    "deleted_at",
 }
 
 var VehicleTableColumns = struct {
 	TokenID      string
 	UserDeviceID string
+	DeletedAt    string
 }{
 	TokenID:      "vehicles.token_id",
 	UserDeviceID: "vehicles.user_device_id",
+	DeletedAt:    "vehicles.deleted_at",
 }
 
 // Generated where
@@ -51,21 +57,26 @@ var VehicleTableColumns = struct {
 var VehicleWhere = struct {
 	TokenID      whereHelperint
 	UserDeviceID whereHelperstring
+	DeletedAt    whereHelpernull_Time
 }{
 	TokenID:      whereHelperint{field: "\"trips_api\".\"vehicles\".\"token_id\""},
 	UserDeviceID: whereHelperstring{field: "\"trips_api\".\"vehicles\".\"user_device_id\""},
+	DeletedAt:    whereHelpernull_Time{field: "\"trips_api\".\"vehicles\".\"deleted_at\""},
 }
 
 // VehicleRels is where relationship names are stored.
 var VehicleRels = struct {
-	VehicleTokenTrips string
+	VehicleTokenTrips    string
+	TokenVehicleMappings string
 }{
-	VehicleTokenTrips: "VehicleTokenTrips",
+	VehicleTokenTrips:    "VehicleTokenTrips",
+	TokenVehicleMappings: "TokenVehicleMappings",
 }
 
 // vehicleR is where relationships are stored.
 type vehicleR struct {
-	VehicleTokenTrips TripSlice `boil:"VehicleTokenTrips" json:"VehicleTokenTrips" toml:"VehicleTokenTrips" yaml:"VehicleTokenTrips"`
+	VehicleTokenTrips    TripSlice           `boil:"VehicleTokenTrips" json:"VehicleTokenTrips" toml:"VehicleTokenTrips" yaml:"VehicleTokenTrips"`
+	TokenVehicleMappings VehicleMappingSlice `boil:"TokenVehicleMappings" json:"TokenVehicleMappings" toml:"TokenVehicleMappings" yaml:"TokenVehicleMappings"`
 }
 
 // NewStruct creates a new relationship struct
@@ -80,13 +91,20 @@ func (r *vehicleR) GetVehicleTokenTrips() TripSlice {
 	return r.VehicleTokenTrips
 }
 
+func (r *vehicleR) GetTokenVehicleMappings() VehicleMappingSlice {
+	if r == nil {
+		return nil
+	}
+	return r.TokenVehicleMappings
+}
+
 // vehicleL is where Load methods for each relationship are stored.
 type vehicleL struct{}
 
 var (
-	vehicleAllColumns            = []string{"token_id", "user_device_id"}
+	vehicleAllColumns            = []string{"token_id", "user_device_id", "deleted_at"}
 	vehicleColumnsWithoutDefault = []string{"token_id", "user_device_id"}
-	vehicleColumnsWithDefault    = []string{}
+	vehicleColumnsWithDefault    = []string{"deleted_at"}
 	vehiclePrimaryKeyColumns     = []string{"token_id"}
 	vehicleGeneratedColumns      = []string{}
 )
@@ -410,6 +428,20 @@ func (o *Vehicle) VehicleTokenTrips(mods ...qm.QueryMod) tripQuery {
 	return Trips(queryMods...)
 }
 
+// TokenVehicleMappings retrieves all the vehicle_mapping's VehicleMappings with an executor via token_id column.
+func (o *Vehicle) TokenVehicleMappings(mods ...qm.QueryMod) vehicleMappingQuery {
+	var queryMods []qm.QueryMod
+	if len(mods) != 0 {
+		queryMods = append(queryMods, mods...)
+	}
+
+	queryMods = append(queryMods,
+		qm.Where("\"trips_api\".\"vehicle_mappings\".\"token_id\"=?", o.TokenID),
+	)
+
+	return VehicleMappings(queryMods...)
+}
+
 // LoadVehicleTokenTrips allows an eager lookup of values, cached into the
 // loaded structs of the objects. This is for a 1-M or N-M relationship.
 func (vehicleL) LoadVehicleTokenTrips(ctx context.Context, e boil.ContextExecutor, singular bool, maybeVehicle interface{}, mods queries.Applicator) error {
@@ -523,6 +555,119 @@ func (vehicleL) LoadVehicleTokenTrips(ctx context.Context, e boil.ContextExecuto
 	return nil
 }
 
+// LoadTokenVehicleMappings allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-M or N-M relationship.
+func (vehicleL) LoadTokenVehicleMappings(ctx context.Context, e boil.ContextExecutor, singular bool, maybeVehicle interface{}, mods queries.Applicator) error {
+	var slice []*Vehicle
+	var object *Vehicle
+
+	if singular {
+		var ok bool
+		object, ok = maybeVehicle.(*Vehicle)
+		if !ok {
+			object = new(Vehicle)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeVehicle)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeVehicle))
+			}
+		}
+	} else {
+		s, ok := maybeVehicle.(*[]*Vehicle)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeVehicle)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeVehicle))
+			}
+		}
+	}
+
+	args := make(map[interface{}]struct{})
+	if singular {
+		if object.R == nil {
+			object.R = &vehicleR{}
+		}
+		args[object.TokenID] = struct{}{}
+	} else {
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &vehicleR{}
+			}
+			args[obj.TokenID] = struct{}{}
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	argsSlice := make([]interface{}, len(args))
+	i := 0
+	for arg := range args {
+		argsSlice[i] = arg
+		i++
+	}
+
+	query := NewQuery(
+		qm.From(`trips_api.vehicle_mappings`),
+		qm.WhereIn(`trips_api.vehicle_mappings.token_id in ?`, argsSlice...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load vehicle_mappings")
+	}
+
+	var resultSlice []*VehicleMapping
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice vehicle_mappings")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on vehicle_mappings")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for vehicle_mappings")
+	}
+
+	if len(vehicleMappingAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+	if singular {
+		object.R.TokenVehicleMappings = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &vehicleMappingR{}
+			}
+			foreign.R.Token = object
+		}
+		return nil
+	}
+
+	for _, foreign := range resultSlice {
+		for _, local := range slice {
+			if local.TokenID == foreign.TokenID {
+				local.R.TokenVehicleMappings = append(local.R.TokenVehicleMappings, foreign)
+				if foreign.R == nil {
+					foreign.R = &vehicleMappingR{}
+				}
+				foreign.R.Token = local
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
 // AddVehicleTokenTrips adds the given related objects to the existing relationships
 // of the vehicle, optionally inserting them as new records.
 // Appends related to o.R.VehicleTokenTrips.
@@ -576,6 +721,59 @@ func (o *Vehicle) AddVehicleTokenTrips(ctx context.Context, exec boil.ContextExe
 	return nil
 }
 
+// AddTokenVehicleMappings adds the given related objects to the existing relationships
+// of the vehicle, optionally inserting them as new records.
+// Appends related to o.R.TokenVehicleMappings.
+// Sets related.R.Token appropriately.
+func (o *Vehicle) AddTokenVehicleMappings(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*VehicleMapping) error {
+	var err error
+	for _, rel := range related {
+		if insert {
+			rel.TokenID = o.TokenID
+			if err = rel.Insert(ctx, exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		} else {
+			updateQuery := fmt.Sprintf(
+				"UPDATE \"trips_api\".\"vehicle_mappings\" SET %s WHERE %s",
+				strmangle.SetParamNames("\"", "\"", 1, []string{"token_id"}),
+				strmangle.WhereClause("\"", "\"", 2, vehicleMappingPrimaryKeyColumns),
+			)
+			values := []interface{}{o.TokenID, rel.ID}
+
+			if boil.IsDebug(ctx) {
+				writer := boil.DebugWriterFrom(ctx)
+				fmt.Fprintln(writer, updateQuery)
+				fmt.Fprintln(writer, values)
+			}
+			if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+				return errors.Wrap(err, "failed to update foreign table")
+			}
+
+			rel.TokenID = o.TokenID
+		}
+	}
+
+	if o.R == nil {
+		o.R = &vehicleR{
+			TokenVehicleMappings: related,
+		}
+	} else {
+		o.R.TokenVehicleMappings = append(o.R.TokenVehicleMappings, related...)
+	}
+
+	for _, rel := range related {
+		if rel.R == nil {
+			rel.R = &vehicleMappingR{
+				Token: o,
+			}
+		} else {
+			rel.R.Token = o
+		}
+	}
+	return nil
+}
+
 // Vehicles retrieves all the records using an executor.
 func Vehicles(mods ...qm.QueryMod) vehicleQuery {
 	mods = append(mods, qm.From("\"trips_api\".\"vehicles\""))
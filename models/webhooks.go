@@ -0,0 +1,1191 @@
+// Code generated by SQLBoiler 4.16.2 (https://github.com/volatiletech/sqlboiler). DO NOT EDIT.
+// This file is meant to be re-generated in place and/or deleted at any time.
+
+package models
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/friendsofgo/errors"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"github.com/volatiletech/sqlboiler/v4/queries/qmhelper"
+	"github.com/volatiletech/sqlboiler/v4/types"
+	"github.com/volatiletech/strmangle"
+)
+
+// Webhook is an object representing the database table.
+type Webhook struct {
+	ID             string            `boil:"id" json:"id" toml:"id" yaml:"id"`
+	VehicleTokenID int               `boil:"vehicle_token_id" json:"vehicle_token_id" toml:"vehicle_token_id" yaml:"vehicle_token_id"`
+	URL            string            `boil:"url" json:"url" toml:"url" yaml:"url"`
+	EventTypes     types.StringArray `boil:"event_types" json:"event_types" toml:"event_types" yaml:"event_types"`
+	Secret         string            `boil:"secret" json:"secret" toml:"secret" yaml:"secret"`
+	FailureCount   int               `boil:"failure_count" json:"failure_count" toml:"failure_count" yaml:"failure_count"`
+	DisabledAt     null.Time         `boil:"disabled_at" json:"disabled_at,omitempty" toml:"disabled_at" yaml:"disabled_at,omitempty"`
+	CreatedAt      time.Time         `boil:"created_at" json:"created_at" toml:"created_at" yaml:"created_at"`
+	Subject        string            `boil:"subject" json:"subject" toml:"subject" yaml:"subject"`
+	ClientID       null.String       `boil:"client_id" json:"client_id,omitempty" toml:"client_id" yaml:"client_id,omitempty"`
+
+	R *webhookR `boil:"-" json:"-" toml:"-" yaml:"-"`
+	L webhookL  `boil:"-" json:"-" toml:"-" yaml:"-"`
+}
+
+var WebhookColumns = struct {
+	ID             string
+	VehicleTokenID string
+	URL            string
+	EventTypes     string
+	Secret         string
+	FailureCount   string
+	DisabledAt     string
+	CreatedAt      string
+	Subject        string
+	ClientID       string
+}{
+	ID:             "id",
+	VehicleTokenID: "vehicle_token_id",
+	URL:            "url",
+	EventTypes:     "event_types",
+	Secret:         "secret",
+	FailureCount:   "failure_count",
+	DisabledAt:     "disabled_at",
+	CreatedAt:      "created_at",
+	Subject:        "subject",
+	ClientID:       "client_id",
+}
+
+var WebhookTableColumns = struct {
+	ID             string
+	VehicleTokenID string
+	URL            string
+	EventTypes     string
+	Secret         string
+	FailureCount   string
+	DisabledAt     string
+	CreatedAt      string
+	Subject        string
+	ClientID       string
+}{
+	ID:             "webhooks.id",
+	VehicleTokenID: "webhooks.vehicle_token_id",
+	URL:            "webhooks.url",
+	EventTypes:     "webhooks.event_types",
+	Secret:         "webhooks.secret",
+	FailureCount:   "webhooks.failure_count",
+	DisabledAt:     "webhooks.disabled_at",
+	CreatedAt:      "webhooks.created_at",
+	Subject:        "webhooks.subject",
+	ClientID:       "webhooks.client_id",
+}
+
+// Generated where
+
+var WebhookWhere = struct {
+	ID             whereHelperstring
+	VehicleTokenID whereHelperint
+	URL            whereHelperstring
+	EventTypes     whereHelpertypes_StringArray
+	Secret         whereHelperstring
+	FailureCount   whereHelperint
+	DisabledAt     whereHelpernull_Time
+	CreatedAt      whereHelpertime_Time
+	Subject        whereHelperstring
+	ClientID       whereHelpernull_String
+}{
+	ID:             whereHelperstring{field: "\"trips_api\".\"webhooks\".\"id\""},
+	VehicleTokenID: whereHelperint{field: "\"trips_api\".\"webhooks\".\"vehicle_token_id\""},
+	URL:            whereHelperstring{field: "\"trips_api\".\"webhooks\".\"url\""},
+	EventTypes:     whereHelpertypes_StringArray{field: "\"trips_api\".\"webhooks\".\"event_types\""},
+	Secret:         whereHelperstring{field: "\"trips_api\".\"webhooks\".\"secret\""},
+	FailureCount:   whereHelperint{field: "\"trips_api\".\"webhooks\".\"failure_count\""},
+	DisabledAt:     whereHelpernull_Time{field: "\"trips_api\".\"webhooks\".\"disabled_at\""},
+	CreatedAt:      whereHelpertime_Time{field: "\"trips_api\".\"webhooks\".\"created_at\""},
+	Subject:        whereHelperstring{field: "\"trips_api\".\"webhooks\".\"subject\""},
+	ClientID:       whereHelpernull_String{field: "\"trips_api\".\"webhooks\".\"client_id\""},
+}
+
+// WebhookRels is where relationship names are stored.
+var WebhookRels = struct {
+	WebhookDeliveries string
+}{
+	WebhookDeliveries: "WebhookDeliveries",
+}
+
+// webhookR is where relationships are stored.
+type webhookR struct {
+	WebhookDeliveries WebhookDeliverySlice `boil:"WebhookDeliveries" json:"WebhookDeliveries" toml:"WebhookDeliveries" yaml:"WebhookDeliveries"`
+}
+
+// NewStruct creates a new relationship struct
+func (*webhookR) NewStruct() *webhookR {
+	return &webhookR{}
+}
+
+func (r *webhookR) GetWebhookDeliveries() WebhookDeliverySlice {
+	if r == nil {
+		return nil
+	}
+	return r.WebhookDeliveries
+}
+
+// webhookL is where Load methods for each relationship are stored.
+type webhookL struct{}
+
+var (
+	webhookAllColumns            = []string{"id", "vehicle_token_id", "url", "event_types", "secret", "failure_count", "disabled_at", "created_at", "subject", "client_id"}
+	webhookColumnsWithoutDefault = []string{"id", "vehicle_token_id", "url", "event_types", "secret", "subject"}
+	webhookColumnsWithDefault    = []string{"failure_count", "disabled_at", "created_at", "client_id"}
+	webhookPrimaryKeyColumns     = []string{"id"}
+	webhookGeneratedColumns      = []string{}
+)
+
+type (
+	// WebhookSlice is an alias for a slice of pointers to Webhook.
+	// This should almost always be used instead of []Webhook.
+	WebhookSlice []*Webhook
+	// WebhookHook is the signature for custom Webhook hook methods
+	WebhookHook func(context.Context, boil.ContextExecutor, *Webhook) error
+
+	webhookQuery struct {
+		*queries.Query
+	}
+)
+
+// Cache for insert, update and upsert
+var (
+	webhookType                 = reflect.TypeOf(&Webhook{})
+	webhookMapping              = queries.MakeStructMapping(webhookType)
+	webhookPrimaryKeyMapping, _ = queries.BindMapping(webhookType, webhookMapping, webhookPrimaryKeyColumns)
+	webhookInsertCacheMut       sync.RWMutex
+	webhookInsertCache          = make(map[string]insertCache)
+	webhookUpdateCacheMut       sync.RWMutex
+	webhookUpdateCache          = make(map[string]updateCache)
+	webhookUpsertCacheMut       sync.RWMutex
+	webhookUpsertCache          = make(map[string]insertCache)
+)
+
+var (
+	// Force time package dependency for automated UpdatedAt/CreatedAt.
+	_ = time.Second
+	// Force qmhelper dependency for where clause generation (which doesn't
+	// always happen)
+	_ = qmhelper.Where
+)
+
+var webhookAfterSelectMu sync.Mutex
+var webhookAfterSelectHooks []WebhookHook
+
+var webhookBeforeInsertMu sync.Mutex
+var webhookBeforeInsertHooks []WebhookHook
+var webhookAfterInsertMu sync.Mutex
+var webhookAfterInsertHooks []WebhookHook
+
+var webhookBeforeUpdateMu sync.Mutex
+var webhookBeforeUpdateHooks []WebhookHook
+var webhookAfterUpdateMu sync.Mutex
+var webhookAfterUpdateHooks []WebhookHook
+
+var webhookBeforeDeleteMu sync.Mutex
+var webhookBeforeDeleteHooks []WebhookHook
+var webhookAfterDeleteMu sync.Mutex
+var webhookAfterDeleteHooks []WebhookHook
+
+var webhookBeforeUpsertMu sync.Mutex
+var webhookBeforeUpsertHooks []WebhookHook
+var webhookAfterUpsertMu sync.Mutex
+var webhookAfterUpsertHooks []WebhookHook
+
+// doAfterSelectHooks executes all "after Select" hooks.
+func (o *Webhook) doAfterSelectHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range webhookAfterSelectHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeInsertHooks executes all "before insert" hooks.
+func (o *Webhook) doBeforeInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range webhookBeforeInsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterInsertHooks executes all "after Insert" hooks.
+func (o *Webhook) doAfterInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range webhookAfterInsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpdateHooks executes all "before Update" hooks.
+func (o *Webhook) doBeforeUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range webhookBeforeUpdateHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpdateHooks executes all "after Update" hooks.
+func (o *Webhook) doAfterUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range webhookAfterUpdateHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeDeleteHooks executes all "before Delete" hooks.
+func (o *Webhook) doBeforeDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range webhookBeforeDeleteHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterDeleteHooks executes all "after Delete" hooks.
+func (o *Webhook) doAfterDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range webhookAfterDeleteHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpsertHooks executes all "before Upsert" hooks.
+func (o *Webhook) doBeforeUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range webhookBeforeUpsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpsertHooks executes all "after Upsert" hooks.
+func (o *Webhook) doAfterUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range webhookAfterUpsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// AddWebhookHook registers your hook function for all future operations.
+func AddWebhookHook(hookPoint boil.HookPoint, webhookHook WebhookHook) {
+	switch hookPoint {
+	case boil.AfterSelectHook:
+		webhookAfterSelectMu.Lock()
+		webhookAfterSelectHooks = append(webhookAfterSelectHooks, webhookHook)
+		webhookAfterSelectMu.Unlock()
+	case boil.BeforeInsertHook:
+		webhookBeforeInsertMu.Lock()
+		webhookBeforeInsertHooks = append(webhookBeforeInsertHooks, webhookHook)
+		webhookBeforeInsertMu.Unlock()
+	case boil.AfterInsertHook:
+		webhookAfterInsertMu.Lock()
+		webhookAfterInsertHooks = append(webhookAfterInsertHooks, webhookHook)
+		webhookAfterInsertMu.Unlock()
+	case boil.BeforeUpdateHook:
+		webhookBeforeUpdateMu.Lock()
+		webhookBeforeUpdateHooks = append(webhookBeforeUpdateHooks, webhookHook)
+		webhookBeforeUpdateMu.Unlock()
+	case boil.AfterUpdateHook:
+		webhookAfterUpdateMu.Lock()
+		webhookAfterUpdateHooks = append(webhookAfterUpdateHooks, webhookHook)
+		webhookAfterUpdateMu.Unlock()
+	case boil.BeforeDeleteHook:
+		webhookBeforeDeleteMu.Lock()
+		webhookBeforeDeleteHooks = append(webhookBeforeDeleteHooks, webhookHook)
+		webhookBeforeDeleteMu.Unlock()
+	case boil.AfterDeleteHook:
+		webhookAfterDeleteMu.Lock()
+		webhookAfterDeleteHooks = append(webhookAfterDeleteHooks, webhookHook)
+		webhookAfterDeleteMu.Unlock()
+	case boil.BeforeUpsertHook:
+		webhookBeforeUpsertMu.Lock()
+		webhookBeforeUpsertHooks = append(webhookBeforeUpsertHooks, webhookHook)
+		webhookBeforeUpsertMu.Unlock()
+	case boil.AfterUpsertHook:
+		webhookAfterUpsertMu.Lock()
+		webhookAfterUpsertHooks = append(webhookAfterUpsertHooks, webhookHook)
+		webhookAfterUpsertMu.Unlock()
+	}
+}
+
+// One returns a single webhook record from the query.
+func (q webhookQuery) One(ctx context.Context, exec boil.ContextExecutor) (*Webhook, error) {
+	o := &Webhook{}
+
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Bind(ctx, exec, o)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "models: failed to execute a one query for webhooks")
+	}
+
+	if err := o.doAfterSelectHooks(ctx, exec); err != nil {
+		return o, err
+	}
+
+	return o, nil
+}
+
+// All returns all Webhook records from the query.
+func (q webhookQuery) All(ctx context.Context, exec boil.ContextExecutor) (WebhookSlice, error) {
+	var o []*Webhook
+
+	err := q.Bind(ctx, exec, &o)
+	if err != nil {
+		return nil, errors.Wrap(err, "models: failed to assign all query results to Webhook slice")
+	}
+
+	if len(webhookAfterSelectHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterSelectHooks(ctx, exec); err != nil {
+				return o, err
+			}
+		}
+	}
+
+	return o, nil
+}
+
+// Count returns the count of all Webhook records in the query.
+func (q webhookQuery) Count(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to count webhooks rows")
+	}
+
+	return count, nil
+}
+
+// Exists checks if the row exists in the table.
+func (q webhookQuery) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return false, errors.Wrap(err, "models: failed to check if webhooks exists")
+	}
+
+	return count > 0, nil
+}
+
+// WebhookDeliveries retrieves all the webhook_delivery's WebhookDeliveries with an executor.
+func (o *Webhook) WebhookDeliveries(mods ...qm.QueryMod) webhookDeliveryQuery {
+	var queryMods []qm.QueryMod
+	if len(mods) != 0 {
+		queryMods = append(queryMods, mods...)
+	}
+
+	queryMods = append(queryMods,
+		qm.Where("\"trips_api\".\"webhook_deliveries\".\"webhook_id\"=?", o.ID),
+	)
+
+	return WebhookDeliveries(queryMods...)
+}
+
+// LoadWebhookDeliveries allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-M or N-M relationship.
+func (webhookL) LoadWebhookDeliveries(ctx context.Context, e boil.ContextExecutor, singular bool, maybeWebhook interface{}, mods queries.Applicator) error {
+	var slice []*Webhook
+	var object *Webhook
+
+	if singular {
+		var ok bool
+		object, ok = maybeWebhook.(*Webhook)
+		if !ok {
+			object = new(Webhook)
+			ok = queries.SetFromEmbeddedStruct(&object, &maybeWebhook)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", object, maybeWebhook))
+			}
+		}
+	} else {
+		s, ok := maybeWebhook.(*[]*Webhook)
+		if ok {
+			slice = *s
+		} else {
+			ok = queries.SetFromEmbeddedStruct(&slice, maybeWebhook)
+			if !ok {
+				return errors.New(fmt.Sprintf("failed to set %T from embedded struct %T", slice, maybeWebhook))
+			}
+		}
+	}
+
+	args := make(map[interface{}]struct{})
+	if singular {
+		if object.R == nil {
+			object.R = &webhookR{}
+		}
+		args[object.ID] = struct{}{}
+	} else {
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &webhookR{}
+			}
+			args[obj.ID] = struct{}{}
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	argsSlice := make([]interface{}, len(args))
+	i := 0
+	for arg := range args {
+		argsSlice[i] = arg
+		i++
+	}
+
+	query := NewQuery(
+		qm.From(`trips_api.webhook_deliveries`),
+		qm.WhereIn(`trips_api.webhook_deliveries.webhook_id in ?`, argsSlice...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load webhook_deliveries")
+	}
+
+	var resultSlice []*WebhookDelivery
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice webhook_deliveries")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on webhook_deliveries")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for webhook_deliveries")
+	}
+
+	if len(webhookDeliveryAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+	if singular {
+		object.R.WebhookDeliveries = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &webhookDeliveryR{}
+			}
+			foreign.R.Webhook = object
+		}
+		return nil
+	}
+
+	for _, foreign := range resultSlice {
+		for _, local := range slice {
+			if local.ID == foreign.WebhookID {
+				local.R.WebhookDeliveries = append(local.R.WebhookDeliveries, foreign)
+				if foreign.R == nil {
+					foreign.R = &webhookDeliveryR{}
+				}
+				foreign.R.Webhook = local
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// AddWebhookDeliveries adds the given related objects to the existing relationships
+// of the webhook, optionally inserting them as new records.
+// Appends related to o.R.WebhookDeliveries.
+// Sets related.R.Webhook appropriately.
+func (o *Webhook) AddWebhookDeliveries(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*WebhookDelivery) error {
+	var err error
+	for _, rel := range related {
+		if insert {
+			rel.WebhookID = o.ID
+			if err = rel.Insert(ctx, exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		} else {
+			updateQuery := fmt.Sprintf(
+				"UPDATE \"trips_api\".\"webhook_deliveries\" SET %s WHERE %s",
+				strmangle.SetParamNames("\"", "\"", 1, []string{"webhook_id"}),
+				strmangle.WhereClause("\"", "\"", 2, webhookDeliveryPrimaryKeyColumns),
+			)
+			values := []interface{}{o.ID, rel.ID}
+
+			if boil.IsDebug(ctx) {
+				writer := boil.DebugWriterFrom(ctx)
+				fmt.Fprintln(writer, updateQuery)
+				fmt.Fprintln(writer, values)
+			}
+			if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+				return errors.Wrap(err, "failed to update foreign table")
+			}
+
+			rel.WebhookID = o.ID
+		}
+	}
+
+	if o.R == nil {
+		o.R = &webhookR{
+			WebhookDeliveries: related,
+		}
+	} else {
+		o.R.WebhookDeliveries = append(o.R.WebhookDeliveries, related...)
+	}
+
+	for _, rel := range related {
+		if rel.R == nil {
+			rel.R = &webhookDeliveryR{
+				Webhook: o,
+			}
+		} else {
+			rel.R.Webhook = o
+		}
+	}
+	return nil
+}
+
+// Webhooks retrieves all the records using an executor.
+func Webhooks(mods ...qm.QueryMod) webhookQuery {
+	mods = append(mods, qm.From("\"trips_api\".\"webhooks\""))
+	q := NewQuery(mods...)
+	if len(queries.GetSelect(q)) == 0 {
+		queries.SetSelect(q, []string{"\"trips_api\".\"webhooks\".*"})
+	}
+
+	return webhookQuery{q}
+}
+
+// FindWebhook retrieves a single record by ID with an executor.
+// If selectCols is empty Find will return all columns.
+func FindWebhook(ctx context.Context, exec boil.ContextExecutor, iD string, selectCols ...string) (*Webhook, error) {
+	webhookObj := &Webhook{}
+
+	sel := "*"
+	if len(selectCols) > 0 {
+		sel = strings.Join(strmangle.IdentQuoteSlice(dialect.LQ, dialect.RQ, selectCols), ",")
+	}
+	query := fmt.Sprintf(
+		"select %s from \"trips_api\".\"webhooks\" where \"id\"=$1", sel,
+	)
+
+	q := queries.Raw(query, iD)
+
+	err := q.Bind(ctx, exec, webhookObj)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "models: unable to select from webhooks")
+	}
+
+	if err = webhookObj.doAfterSelectHooks(ctx, exec); err != nil {
+		return webhookObj, err
+	}
+
+	return webhookObj, nil
+}
+
+// Insert a single record using an executor.
+// See boil.Columns.InsertColumnSet documentation to understand column list inference for inserts.
+func (o *Webhook) Insert(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) error {
+	if o == nil {
+		return errors.New("models: no webhooks provided for insertion")
+	}
+
+	var err error
+	if !boil.TimestampsAreSkipped(ctx) {
+		currTime := time.Now().In(boil.GetLocation())
+
+		if o.CreatedAt.IsZero() {
+			o.CreatedAt = currTime
+		}
+	}
+
+	if err := o.doBeforeInsertHooks(ctx, exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(webhookColumnsWithDefault, o)
+
+	key := makeCacheKey(columns, nzDefaults)
+	webhookInsertCacheMut.RLock()
+	cache, cached := webhookInsertCache[key]
+	webhookInsertCacheMut.RUnlock()
+
+	if !cached {
+		wl, returnColumns := columns.InsertColumnSet(
+			webhookAllColumns,
+			webhookColumnsWithDefault,
+			webhookColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		cache.valueMapping, err = queries.BindMapping(webhookType, webhookMapping, wl)
+		if err != nil {
+			return err
+		}
+		cache.retMapping, err = queries.BindMapping(webhookType, webhookMapping, returnColumns)
+		if err != nil {
+			return err
+		}
+		if len(wl) != 0 {
+			cache.query = fmt.Sprintf("INSERT INTO \"trips_api\".\"webhooks\" (\"%s\") %%sVALUES (%s)%%s", strings.Join(wl, "\",\""), strmangle.Placeholders(dialect.UseIndexPlaceholders, len(wl), 1, 1))
+		} else {
+			cache.query = "INSERT INTO \"trips_api\".\"webhooks\" %sDEFAULT VALUES%s"
+		}
+
+		var queryOutput, queryReturning string
+
+		if len(cache.retMapping) != 0 {
+			queryReturning = fmt.Sprintf(" RETURNING \"%s\"", strings.Join(returnColumns, "\",\""))
+		}
+
+		cache.query = fmt.Sprintf(cache.query, queryOutput, queryReturning)
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRowContext(ctx, cache.query, vals...).Scan(queries.PtrsFromMapping(value, cache.retMapping)...)
+	} else {
+		_, err = exec.ExecContext(ctx, cache.query, vals...)
+	}
+
+	if err != nil {
+		return errors.Wrap(err, "models: unable to insert into webhooks")
+	}
+
+	if !cached {
+		webhookInsertCacheMut.Lock()
+		webhookInsertCache[key] = cache
+		webhookInsertCacheMut.Unlock()
+	}
+
+	return o.doAfterInsertHooks(ctx, exec)
+}
+
+// Update uses an executor to update the Webhook.
+// See boil.Columns.UpdateColumnSet documentation to understand column list inference for updates.
+// Update does not automatically update the record in case of default values. Use .Reload() to refresh the records.
+func (o *Webhook) Update(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) (int64, error) {
+	var err error
+	if err = o.doBeforeUpdateHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+	key := makeCacheKey(columns, nil)
+	webhookUpdateCacheMut.RLock()
+	cache, cached := webhookUpdateCache[key]
+	webhookUpdateCacheMut.RUnlock()
+
+	if !cached {
+		wl := columns.UpdateColumnSet(
+			webhookAllColumns,
+			webhookPrimaryKeyColumns,
+		)
+
+		if !columns.IsWhitelist() {
+			wl = strmangle.SetComplement(wl, []string{"created_at"})
+		}
+		if len(wl) == 0 {
+			return 0, errors.New("models: unable to update webhooks, could not build whitelist")
+		}
+
+		cache.query = fmt.Sprintf("UPDATE \"trips_api\".\"webhooks\" SET %s WHERE %s",
+			strmangle.SetParamNames("\"", "\"", 1, wl),
+			strmangle.WhereClause("\"", "\"", len(wl)+1, webhookPrimaryKeyColumns),
+		)
+		cache.valueMapping, err = queries.BindMapping(webhookType, webhookMapping, append(wl, webhookPrimaryKeyColumns...))
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	values := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), cache.valueMapping)
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, values)
+	}
+	var result sql.Result
+	result, err = exec.ExecContext(ctx, cache.query, values...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update webhooks row")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by update for webhooks")
+	}
+
+	if !cached {
+		webhookUpdateCacheMut.Lock()
+		webhookUpdateCache[key] = cache
+		webhookUpdateCacheMut.Unlock()
+	}
+
+	return rowsAff, o.doAfterUpdateHooks(ctx, exec)
+}
+
+// UpdateAll updates all rows with the specified column values.
+func (q webhookQuery) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	queries.SetUpdate(q.Query, cols)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update all for webhooks")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to retrieve rows affected for webhooks")
+	}
+
+	return rowsAff, nil
+}
+
+// UpdateAll updates all rows with the specified column values, using an executor.
+func (o WebhookSlice) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	ln := int64(len(o))
+	if ln == 0 {
+		return 0, nil
+	}
+
+	if len(cols) == 0 {
+		return 0, errors.New("models: update all requires at least one column argument")
+	}
+
+	colNames := make([]string, len(cols))
+	args := make([]interface{}, len(cols))
+
+	i := 0
+	for name, value := range cols {
+		colNames[i] = name
+		args[i] = value
+		i++
+	}
+
+	// Append all of the primary key values for each column
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), webhookPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := fmt.Sprintf("UPDATE \"trips_api\".\"webhooks\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, colNames),
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), len(colNames)+1, webhookPrimaryKeyColumns, len(o)))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to update all in webhook slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to retrieve rows affected all in update all webhook")
+	}
+	return rowsAff, nil
+}
+
+// Upsert attempts an insert using an executor, and does an update or ignore on conflict.
+// See boil.Columns documentation for how to properly use updateColumns and insertColumns.
+func (o *Webhook) Upsert(ctx context.Context, exec boil.ContextExecutor, updateOnConflict bool, conflictColumns []string, updateColumns, insertColumns boil.Columns, opts ...UpsertOptionFunc) error {
+	if o == nil {
+		return errors.New("models: no webhooks provided for upsert")
+	}
+	if !boil.TimestampsAreSkipped(ctx) {
+		currTime := time.Now().In(boil.GetLocation())
+
+		if o.CreatedAt.IsZero() {
+			o.CreatedAt = currTime
+		}
+	}
+
+	if err := o.doBeforeUpsertHooks(ctx, exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(webhookColumnsWithDefault, o)
+
+	// Build cache key in-line uglily - mysql vs psql problems
+	buf := strmangle.GetBuffer()
+	if updateOnConflict {
+		buf.WriteByte('t')
+	} else {
+		buf.WriteByte('f')
+	}
+	buf.WriteByte('.')
+	for _, c := range conflictColumns {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(updateColumns.Kind))
+	for _, c := range updateColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(insertColumns.Kind))
+	for _, c := range insertColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	for _, c := range nzDefaults {
+		buf.WriteString(c)
+	}
+	key := buf.String()
+	strmangle.PutBuffer(buf)
+
+	webhookUpsertCacheMut.RLock()
+	cache, cached := webhookUpsertCache[key]
+	webhookUpsertCacheMut.RUnlock()
+
+	var err error
+
+	if !cached {
+		insert, _ := insertColumns.InsertColumnSet(
+			webhookAllColumns,
+			webhookColumnsWithDefault,
+			webhookColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		update := updateColumns.UpdateColumnSet(
+			webhookAllColumns,
+			webhookPrimaryKeyColumns,
+		)
+
+		if updateOnConflict && len(update) == 0 {
+			return errors.New("models: unable to upsert webhooks, could not build update column list")
+		}
+
+		ret := strmangle.SetComplement(webhookAllColumns, strmangle.SetIntersect(insert, update))
+
+		conflict := conflictColumns
+		if len(conflict) == 0 && updateOnConflict && len(update) != 0 {
+			if len(webhookPrimaryKeyColumns) == 0 {
+				return errors.New("models: unable to upsert webhooks, could not build conflict column list")
+			}
+
+			conflict = make([]string, len(webhookPrimaryKeyColumns))
+			copy(conflict, webhookPrimaryKeyColumns)
+		}
+		cache.query = buildUpsertQueryPostgres(dialect, "\"trips_api\".\"webhooks\"", updateOnConflict, ret, update, conflict, insert, opts...)
+
+		cache.valueMapping, err = queries.BindMapping(webhookType, webhookMapping, insert)
+		if err != nil {
+			return err
+		}
+		if len(ret) != 0 {
+			cache.retMapping, err = queries.BindMapping(webhookType, webhookMapping, ret)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+	var returns []interface{}
+	if len(cache.retMapping) != 0 {
+		returns = queries.PtrsFromMapping(value, cache.retMapping)
+	}
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, cache.query)
+		fmt.Fprintln(writer, vals)
+	}
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRowContext(ctx, cache.query, vals...).Scan(returns...)
+		if errors.Is(err, sql.ErrNoRows) {
+			err = nil // Postgres doesn't return anything when there's no update
+		}
+	} else {
+		_, err = exec.ExecContext(ctx, cache.query, vals...)
+	}
+	if err != nil {
+		return errors.Wrap(err, "models: unable to upsert webhooks")
+	}
+
+	if !cached {
+		webhookUpsertCacheMut.Lock()
+		webhookUpsertCache[key] = cache
+		webhookUpsertCacheMut.Unlock()
+	}
+
+	return o.doAfterUpsertHooks(ctx, exec)
+}
+
+// Delete deletes a single Webhook record with an executor.
+// Delete will match against the primary key column to find the record to delete.
+func (o *Webhook) Delete(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if o == nil {
+		return 0, errors.New("models: no Webhook provided for delete")
+	}
+
+	if err := o.doBeforeDeleteHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+
+	args := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), webhookPrimaryKeyMapping)
+	sql := "DELETE FROM \"trips_api\".\"webhooks\" WHERE \"id\"=$1"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args...)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete from webhooks")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by delete for webhooks")
+	}
+
+	if err := o.doAfterDeleteHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all matching rows.
+func (q webhookQuery) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if q.Query == nil {
+		return 0, errors.New("models: no webhookQuery provided for delete all")
+	}
+
+	queries.SetDelete(q.Query)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete all from webhooks")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by deleteall for webhooks")
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all rows in the slice, using an executor.
+func (o WebhookSlice) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if len(o) == 0 {
+		return 0, nil
+	}
+
+	if len(webhookBeforeDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doBeforeDeleteHooks(ctx, exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	var args []interface{}
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), webhookPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "DELETE FROM \"trips_api\".\"webhooks\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, webhookPrimaryKeyColumns, len(o))
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, args)
+	}
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "models: unable to delete all from webhook slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "models: failed to get rows affected by deleteall for webhooks")
+	}
+
+	if len(webhookAfterDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterDeleteHooks(ctx, exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	return rowsAff, nil
+}
+
+// Reload refetches the object from the database
+// using the primary keys with an executor.
+func (o *Webhook) Reload(ctx context.Context, exec boil.ContextExecutor) error {
+	ret, err := FindWebhook(ctx, exec, o.ID)
+	if err != nil {
+		return err
+	}
+
+	*o = *ret
+	return nil
+}
+
+// ReloadAll refetches every row with matching primary key column values
+// and overwrites the original object slice with the newly updated slice.
+func (o *WebhookSlice) ReloadAll(ctx context.Context, exec boil.ContextExecutor) error {
+	if o == nil || len(*o) == 0 {
+		return nil
+	}
+
+	slice := WebhookSlice{}
+	var args []interface{}
+	for _, obj := range *o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), webhookPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "SELECT \"trips_api\".\"webhooks\".* FROM \"trips_api\".\"webhooks\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, webhookPrimaryKeyColumns, len(*o))
+
+	q := queries.Raw(sql, args...)
+
+	err := q.Bind(ctx, exec, &slice)
+	if err != nil {
+		return errors.Wrap(err, "models: unable to reload all in WebhookSlice")
+	}
+
+	*o = slice
+
+	return nil
+}
+
+// WebhookExists checks if the Webhook row exists.
+func WebhookExists(ctx context.Context, exec boil.ContextExecutor, iD string) (bool, error) {
+	var exists bool
+	sql := "select exists(select 1 from \"trips_api\".\"webhooks\" where \"id\"=$1 limit 1)"
+
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, sql)
+		fmt.Fprintln(writer, iD)
+	}
+	row := exec.QueryRowContext(ctx, sql, iD)
+
+	err := row.Scan(&exists)
+	if err != nil {
+		return false, errors.Wrap(err, "models: unable to check if webhooks exists")
+	}
+
+	return exists, nil
+}
+
+// Exists checks if the Webhook row exists.
+func (o *Webhook) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	return WebhookExists(ctx, exec, o.ID)
+}